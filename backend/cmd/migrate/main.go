@@ -1,24 +1,19 @@
 package main
 
 import (
+	"context"
 	"log/slog"
 	"os"
 
-	"github.com/golang-migrate/migrate/v4"
-	_ "github.com/golang-migrate/migrate/v4/database/sqlite"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"backend/internal/infra/sqlite"
 )
 
 func main() {
 	dbPath := getEnv("DB_FILE_PATH", "./devshare.db")
 	migrationsPath := getEnv("MIGRATIONS_PATH", "internal/infra/migrations/sqlite")
 
-	m, err := migrate.New("file://"+migrationsPath, "sqlite://"+dbPath)
-	if err != nil {
-		slog.Error("migration init failed", "error", err)
-		os.Exit(1)
-	}
-	if err := m.Up(); err != nil && err != migrate.ErrNoChange {
+	// Concurrent instances wait on a lock row, so only one applies migrations.
+	if err := sqlite.MigrateUp(context.Background(), dbPath, migrationsPath); err != nil {
 		slog.Error("migration failed", "error", err)
 		os.Exit(1)
 	}
@@ -2,8 +2,12 @@ package main
 
 import (
 	"context"
+	"database/sql"
 	"log/slog"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"backend/internal/application"
 	handlererrors "backend/internal/application/errors"
@@ -11,28 +15,38 @@ import (
 	"backend/internal/infra/filestorage"
 	"backend/internal/infra/http/handlers"
 	"backend/internal/infra/http/middleware"
+	"backend/internal/infra/migrations"
+	"backend/internal/infra/pathcheck"
 	"backend/internal/infra/sqlite"
 	"backend/internal/infra/terraform"
 	"backend/internal/infra/tfparser"
+	"backend/pkg/buildinfo"
 	"backend/pkg/config"
+	"backend/pkg/contracts"
 	"backend/pkg/crypto"
+	"backend/pkg/jsonutil"
 	"backend/pkg/jwt"
 	"backend/pkg/validation"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/logger"
-	"github.com/gofiber/fiber/v2/middleware/recover"
 )
 
+// shutdownTimeout bounds how long in-flight requests get to finish after a
+// shutdown signal.
+const shutdownTimeout = 10 * time.Second
+
 func main() {
+	startedAt := time.Now()
+
 	// Initialize structured logging
 	slogger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
 		Level: slog.LevelInfo,
 	}))
 	slog.SetDefault(slogger)
 
-	slog.Info("starting dev-share backend")
+	slog.Info("starting dev-share backend", "version", buildinfo.Version, "commit", buildinfo.Commit)
 
 	// Load configuration
 	cfg, err := config.Load()
@@ -40,7 +54,7 @@ func main() {
 		slog.Error("failed to load configuration", "error", err)
 		os.Exit(1)
 	}
-	slog.Info("configuration loaded")
+	slog.Info("configuration loaded", "config", cfg)
 
 	// Database configuration
 	dbConfig := sqlite.Config{
@@ -57,12 +71,55 @@ func main() {
 
 	slog.Info("successfully connected to database")
 
+	// Refuse to serve against a schema that is dirty or at another version,
+	// e.g. while another instance is still migrating.
+	schemaVersion, err := sqlite.LatestMigrationVersion(migrations.SQLite())
+	if err != nil {
+		slog.Error("failed to read embedded migrations", "error", err)
+		os.Exit(1)
+	}
+	if err := sqlite.CheckSchemaVersion(context.Background(), db, schemaVersion); err != nil {
+		slog.Error("database schema is not ready", "error", err)
+		os.Exit(1)
+	}
+	slog.Info("database schema verified", "version", schemaVersion)
+
+	// Optional read replica for list/get queries
+	var replicaDB *sql.DB
+	if cfg.DBReplicaURL != "" {
+		replicaDB, err = sqlite.NewReplicaDB(cfg.DBReplicaURL)
+		if err != nil {
+			slog.Error("failed to connect to database replica", "error", err)
+			os.Exit(1)
+		}
+		defer replicaDB.Close()
+		slog.Info("successfully connected to database replica")
+	}
+
+	// Entity ID generation
+	idGenerator, err := domain.NewIDGenerator(cfg.IDStrategy)
+	if err != nil {
+		slog.Error("failed to configure ID generation", "error", err)
+		os.Exit(1)
+	}
+	slog.Info("ID generation configured", "strategy", cfg.IDStrategy)
+
 	// Initialize validation service
-	validator := validation.New()
+	contentPolicy := cfg.ContentPolicy()
+	if err := contentPolicy.Validate(); err != nil {
+		slog.Error("invalid content policy", "error", err)
+		os.Exit(1)
+	}
+	if err := validation.SetPasswordPolicy(cfg.PasswordPolicy()); err != nil {
+		slog.Error("invalid password policy", "error", err)
+		os.Exit(1)
+	}
+	validator := validation.New(validation.WithContentPolicy(contentPolicy))
 	if err := validator.RegisterDefaultCustomValidations(); err != nil {
 		slog.Error("failed to register custom validations", "error", err)
 		os.Exit(1)
 	}
+	contracts.RegisterValidations(validator)
 	slog.Info("validation service initialized")
 
 	// Initialize JWT service
@@ -71,7 +128,8 @@ func main() {
 		slog.Error("failed to initialize JWT service", "error", err)
 		os.Exit(1)
 	}
-	slog.Info("JWT service initialized")
+	jwtService.WithClockSkew(cfg.JWTClockSkew)
+	slog.Info("JWT service initialized", "clock_skew", cfg.JWTClockSkew)
 
 	// File storage
 	fileStorage := filestorage.NewLocalFileStorage(cfg.TemplateStoragePath)
@@ -91,40 +149,82 @@ func main() {
 	executionStorage := filestorage.NewLocalExecutionStorage(cfg.EnvExecutionPath, cfg.TemplateStoragePath)
 	slog.Info("execution storage initialized", "path", cfg.EnvExecutionPath)
 
+	// Template path reachability checks
+	pathChecker := pathcheck.NewChecker(cfg.TemplateStoragePath, cfg.TemplatePathFileAllowlist, pathcheck.DefaultTimeout).
+		WithHostAllowlist(cfg.TemplatePathHostAllowlist)
+
 	// Terraform executor
 	tfExecutor := terraform.NewExecutor(cfg.EnvExecutionPath, cfg.TFPluginCacheDir)
 	slog.Info("terraform executor initialized")
 
 	// Infrastructure factories
-	uowFactory := sqlite.NewUnitOfWorkFactory(db)
-	repoFactory := sqlite.NewRepositoryFactory()
+	txTimeout := sqlite.WithTransactionTimeout(time.Duration(cfg.TxTimeoutSeconds) * time.Second)
+	uowFactory := sqlite.NewUnitOfWorkFactory(db, txTimeout)
+	repoFactory := sqlite.NewRepositoryFactory(sqlite.WithIDGenerator(idGenerator))
+	// Request handling may read from the replica; background checks such as
+	// token revocation keep using the primary so replica lag cannot hide a write.
+	requestUOWFactory, requestRepoFactory := uowFactory, repoFactory
+	if replicaDB != nil {
+		requestUOWFactory = sqlite.NewUnitOfWorkFactoryWithReplica(db, replicaDB, txTimeout)
+		requestRepoFactory = sqlite.NewRepositoryFactory(sqlite.WithReplicaReads(), sqlite.WithIDGenerator(idGenerator))
+	}
 
 	// Application-layer service factory
-	serviceFactory := application.NewServiceFactory(uowFactory, repoFactory, validator, fileStorage, encryptor, tfParser, executionStorage, tfExecutor)
+	crossTenantDenial := application.CrossTenantDenial(cfg.CrossTenantDenial)
+	serviceFactory := application.NewServiceFactory(requestUOWFactory, requestRepoFactory, validator, fileStorage, encryptor, tfParser, executionStorage, tfExecutor, pathChecker).
+		WithCrossTenantDenial(crossTenantDenial).
+		WithIDGenerator(idGenerator)
+
+	cookieCfg := jwt.DefaultCookieConfig()
+	cookieCfg.Name = cfg.CookieName
+	if cfg.Production() {
+		cookieCfg.Secure = true
+	}
 
 	// Initialize handlers
-	userHandler := handlers.NewUserHandler(serviceFactory.NewUserService, jwtService)
+	userHandler := handlers.NewUserHandler(serviceFactory.NewUserService, jwtService).WithCookieConfig(cookieCfg)
 	workspaceHandler := handlers.NewWorkspaceHandler(serviceFactory.NewWorkspaceService)
-	templateHandler := handlers.NewTemplateHandler(serviceFactory.NewTemplateService)
+	templateHandler := handlers.NewTemplateHandler(serviceFactory.NewTemplateService).WithCrossTenantDenial(crossTenantDenial)
 	templateVariableHandler := handlers.NewTemplateVariableHandler(serviceFactory.NewTemplateVariableService)
 	envVarValueHandler := handlers.NewEnvironmentVariableValueHandler(serviceFactory.NewEnvironmentVariableValueService)
-	environmentHandler := handlers.NewEnvironmentHandler(serviceFactory.NewEnvironmentService)
+	environmentHandler := handlers.NewEnvironmentHandler(serviceFactory.NewEnvironmentService).WithCrossTenantDenial(crossTenantDenial)
 	groupHandler := handlers.NewGroupHandler(serviceFactory.NewGroupService)
-	adminHandler := handlers.NewAdminHandler(serviceFactory.NewAdminService, jwtService, cfg.AdminInitToken)
+	apiKeyHandler := handlers.NewAPIKeyHandler(serviceFactory.NewAPIKeyService)
+	adminHandler := handlers.NewAdminHandler(serviceFactory.NewAdminService, jwtService, cfg.AdminInitToken).WithCookieConfig(cookieCfg)
+	debugHandler := handlers.NewDebugHandler(startedAt)
+
+	// The purger runs in the background when enabled; its dry-run report is always available to admins.
+	purger := application.NewSoftDeletePurger(uowFactory, repoFactory, fileStorage, executionStorage, validator,
+		time.Duration(cfg.PurgeIntervalSeconds)*time.Second,
+		time.Duration(cfg.PurgeRetentionDays)*24*time.Hour)
+	adminHandler.WithPurger(purger)
+	limitsHandler := handlers.NewLimitsHandler(contentPolicy)
 
 	app := fiber.New(fiber.Config{
 		AppName:      "Dev-Share Backend",
 		ErrorHandler: handlererrors.ErrorHandler(),
 		BodyLimit:    cfg.BodyLimitBytes,
+		JSONDecoder:  jsonutil.Unmarshal,
+		// Forwarded headers, such as the scheme RequireTLS checks, are only
+		// read from these proxies
+		EnableTrustedProxyCheck: true,
+		TrustedProxies:          cfg.TrustedProxies,
 	})
 
 	// Middleware
 	app.Use(logger.New())
-	app.Use(recover.New())
+	app.Use(middleware.Recover())
+	// The container healthcheck probes /health over plain HTTP from inside
+	app.Use(middleware.RequireTLS(cfg.Production(), "/health"))
+	app.Use(middleware.AuditTransactions())
+	app.Use(middleware.RejectUnexpectedBody(cfg.UnexpectedBodyPolicy == "warn"))
 	app.Use(cors.New(cors.Config{
 		AllowOrigins:     cfg.CORSAllowOrigins,
 		AllowCredentials: true,
 	}))
+	if cfg.ResponseEnvelope {
+		app.Use(middleware.EnvelopeResponses())
+	}
 
 	// Health check endpoint
 	app.Get("/health", func(c *fiber.Ctx) error {
@@ -137,7 +237,8 @@ func main() {
 
 	// Admin endpoints (unprotected, first-time only)
 	app.Get("/admin/status", adminHandler.GetSystemStatus)
-	app.Post("/admin/init", adminHandler.InitializeSystem)
+	initBackoff := middleware.NewFailureBackoff(cfg.AdminInitMaxAttempts, time.Duration(cfg.AdminInitBackoffSeconds)*time.Second)
+	app.Post("/admin/init", initBackoff.Handler(), adminHandler.InitializeSystem)
 
 	// API routes
 	api := app.Group("/api/v1")
@@ -150,33 +251,62 @@ func main() {
 
 	// Public: user registration does not require authentication
 	userHandler.RegisterRoutes(api)
+	limitsHandler.RegisterRoutes(api)
+	tokenEpochChecker := application.NewTokenEpochChecker(uowFactory, repoFactory)
+	handlers.NewAuthHandler(jwtService, tokenEpochChecker).WithRateLimit(cfg.IntrospectRateLimit).RegisterRoutes(api)
 
-	// Protected routes — all authenticated users
-	protected := api.Group("", middleware.RequireAuth(jwtService, jwt.DefaultCookieConfig()))
+	// Protected routes — authorization for every route is declared in middleware.RoutePolicies
+	policies := middleware.NewPolicyMatrix("/api/v1", middleware.RoutePolicies()).WithCrossTenantDenial(crossTenantDenial)
+	activityTracker := application.NewActivityTracker(uowFactory, repoFactory, time.Duration(cfg.ActivityIntervalSeconds)*time.Second)
+	protected := api.Group("",
+		middleware.AuthenticateAPIKey(serviceFactory.NewAPIKeyService()),
+		middleware.RequireAuth(jwtService, cookieCfg),
+		middleware.RejectRevokedTokens(tokenEpochChecker),
+		middleware.ScopeToWorkspace(),
+		middleware.RequireScope(jwt.ScopeWrite),
+		middleware.Authorize(policies),
+		middleware.TrackActivity(activityTracker),
+	)
 	userHandler.RegisterProtectedRoutes(protected)
-
-	// Environment routes — all roles can read and write
 	environmentHandler.RegisterRoutes(protected)
 	envVarValueHandler.RegisterRoutes(protected)
+	workspaceHandler.RegisterRoutes(protected)
+	templateHandler.RegisterRoutes(protected)
+	templateVariableHandler.RegisterRoutes(protected)
+	adminHandler.RegisterAdminRoutes(protected)
+	groupHandler.RegisterRoutes(protected)
+	apiKeyHandler.RegisterRoutes(protected)
+	debugHandler.RegisterRoutes(protected)
 
-	// Editor-level routes — editor and admin can write, all can read (GET passes through)
-	editorProtected := protected.Group("", middleware.RequireRoleForWrite(domain.RoleEditor))
-	workspaceHandler.RegisterRoutes(editorProtected)
-	templateHandler.RegisterRoutes(editorProtected)
-	templateVariableHandler.RegisterRoutes(editorProtected)
-
-	// Admin-level routes — only admin can access (all methods including GET)
-	adminProtected := protected.Group("", middleware.RequireRole(domain.RoleAdmin))
-	adminHandler.RegisterAdminRoutes(adminProtected)
-	groupHandler.RegisterRoutes(adminProtected)
+	// SIGINT/SIGTERM cancel this context, stopping the background workers and
+	// shutting the server down.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
 	// Environment reaper — auto-destroys environments with expired TTLs.
 	reaper := application.NewEnvironmentReaper(uowFactory, repoFactory, executionStorage, tfExecutor, encryptor, validator)
-	reaperCtx, reaperCancel := context.WithCancel(context.Background())
-	defer reaperCancel()
-	go reaper.Start(reaperCtx)
+	go reaper.Start(ctx)
 	slog.Info("environment reaper started")
 
+	// Outbox dispatcher — delivers domain events such as user.created to their handlers.
+	outbox := application.NewOutboxDispatcher(uowFactory, repoFactory, application.DefaultOutboxInterval)
+	go outbox.Start(ctx)
+	slog.Info("outbox dispatcher started")
+
+	// Purger — hard-deletes workspaces soft-deleted longer than the retention window.
+	if cfg.PurgeIntervalSeconds > 0 {
+		go purger.Start(ctx)
+		slog.Info("soft-delete purger started", "interval_seconds", cfg.PurgeIntervalSeconds, "retention_days", cfg.PurgeRetentionDays)
+	}
+
+	go func() {
+		<-ctx.Done()
+		slog.Info("shutting down server")
+		if err := app.ShutdownWithTimeout(shutdownTimeout); err != nil {
+			slog.Error("server shutdown failed", "error", err)
+		}
+	}()
+
 	// Get port from environment or default to 8080
 	slog.Info("starting server", "port", cfg.Port)
 	if err := app.Listen(":" + cfg.Port); err != nil {
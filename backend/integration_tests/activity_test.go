@@ -0,0 +1,116 @@
+package integration_tests
+
+import (
+	"database/sql"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func getLastActiveAtFromDB(t *testing.T, userID uuid.UUID) sql.NullString {
+	t.Helper()
+	var lastActive sql.NullString
+	if err := DbConnection.QueryRow("SELECT last_active_at FROM users WHERE id = ?", userID).Scan(&lastActive); err != nil {
+		t.Fatalf("getLastActiveAtFromDB: %v", err)
+	}
+	return lastActive
+}
+
+func TestLastActiveAt_UpdatedOnAuthenticatedRequest(t *testing.T) {
+	adminAuth, workspaceID := setupAdminForUserMgmt(t)
+	defer teardownAdminForUserMgmt(t)
+
+	invite, status := AdminInviteUser(t, adminAuth, "Active User", "active-user@example.com", "user")
+	if status != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", status)
+	}
+
+	if lastActive := getLastActiveAtFromDB(t, invite.UserID); lastActive.Valid {
+		t.Fatalf("expected last_active_at to be NULL before any request, got %q", lastActive.String)
+	}
+
+	userAuth := AuthContext{
+		UserID:      invite.UserID,
+		UserName:    "Active User",
+		Role:        "user",
+		WorkspaceID: workspaceID,
+	}
+	if _, status := ListWorkspaces(t, userAuth, 10, 0, "", ""); status != http.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+
+	if lastActive := getLastActiveAtFromDB(t, invite.UserID); !lastActive.Valid {
+		t.Fatal("expected last_active_at to be set after an authenticated request")
+	}
+
+	users, status := AdminListUsers(t, adminAuth)
+	if status != http.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+	for _, u := range users {
+		if u.ID == invite.UserID && u.LastActiveAt == nil {
+			t.Error("expected last_active_at in admin user list")
+		}
+	}
+}
+
+func TestLastActiveAt_ThrottledWithinWindow(t *testing.T) {
+	adminAuth, workspaceID := setupAdminForUserMgmt(t)
+	defer teardownAdminForUserMgmt(t)
+
+	invite, status := AdminInviteUser(t, adminAuth, "Throttled User", "throttled-user@example.com", "user")
+	if status != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", status)
+	}
+
+	userAuth := AuthContext{
+		UserID:      invite.UserID,
+		UserName:    "Throttled User",
+		Role:        "user",
+		WorkspaceID: workspaceID,
+	}
+	if _, status := ListWorkspaces(t, userAuth, 10, 0, "", ""); status != http.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+
+	// Backdate the stored value: a re-write would replace it with the current time.
+	const sentinel = "2000-01-01 00:00:00"
+	if _, err := DbConnection.Exec("UPDATE users SET last_active_at = ? WHERE id = ?", sentinel, invite.UserID); err != nil {
+		t.Fatalf("failed to backdate last_active_at: %v", err)
+	}
+
+	for range 3 {
+		if _, status := ListWorkspaces(t, userAuth, 10, 0, "", ""); status != http.StatusOK {
+			t.Fatalf("expected 200, got %d", status)
+		}
+	}
+
+	if lastActive := getLastActiveAtFromDB(t, invite.UserID); lastActive.String != sentinel {
+		t.Errorf("expected last_active_at to stay %q within the throttle window, got %q", sentinel, lastActive.String)
+	}
+}
+
+func TestLastActiveAt_NotUpdatedOnDeniedRequest(t *testing.T) {
+	adminAuth, workspaceID := setupAdminForUserMgmt(t)
+	defer teardownAdminForUserMgmt(t)
+
+	invite, status := AdminInviteUser(t, adminAuth, "Denied User", "denied-user@example.com", "user")
+	if status != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", status)
+	}
+
+	userAuth := AuthContext{
+		UserID:      invite.UserID,
+		UserName:    "Denied User",
+		Role:        "user",
+		WorkspaceID: workspaceID,
+	}
+	if _, status := AdminListUsers(t, userAuth); status != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d", status)
+	}
+
+	if lastActive := getLastActiveAtFromDB(t, invite.UserID); lastActive.Valid {
+		t.Errorf("expected a denied request to leave last_active_at NULL, got %q", lastActive.String)
+	}
+}
@@ -0,0 +1,138 @@
+package integration_tests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"backend/internal/domain"
+	"backend/internal/infra/sqlite"
+
+	"github.com/google/uuid"
+)
+
+type AdminInitAttemptResponse struct {
+	ID          uuid.UUID `json:"id"`
+	Outcome     string    `json:"outcome"`
+	IP          string    `json:"ip"`
+	AttemptedAt time.Time `json:"attempted_at"`
+}
+
+type AdminInitAttemptsPage struct {
+	Items []AdminInitAttemptResponse `json:"items"`
+	Total int                        `json:"total"`
+}
+
+func ListAdminInitAttempts(t *testing.T, auth AuthContext, query string) (*AdminInitAttemptsPage, int) {
+	t.Helper()
+
+	status, body := getRawList(t, auth, "/api/v1/admin/init-attempts?"+query)
+	if status != http.StatusOK {
+		return nil, status
+	}
+	var page AdminInitAttemptsPage
+	if err := json.Unmarshal([]byte(body), &page); err != nil {
+		t.Fatalf("failed to decode init attempts: %v", err)
+	}
+	return &page, status
+}
+
+func TestAdminInit_ConflictAttemptIsLogged(t *testing.T) {
+	// Earlier tests' conflicts from this IP would swallow this one
+	if _, err := DbConnection.Exec("DELETE FROM admin_init_attempts WHERE outcome = 'conflict'"); err != nil {
+		t.Fatalf("failed to clear attempts: %v", err)
+	}
+	auth, _ := setupAdminForUserMgmt(t)
+	defer teardownAdminForUserMgmt(t)
+
+	page, status := ListAdminInitAttempts(t, auth, "limit=1")
+	if status != http.StatusOK {
+		t.Fatalf("list attempts: expected 200, got %d", status)
+	}
+	if len(page.Items) != 1 || page.Items[0].Outcome != "success" {
+		t.Fatalf("expected the setup's initialization as the latest attempt, got %+v", page.Items)
+	}
+	before := page.Total
+
+	if _, status := InitializeAdmin(t, "Takeover", "takeover@example.com", "StrongP@ssw0rd123", "Takeover Workspace", "", ""); status != http.StatusConflict {
+		t.Fatalf("second init: expected 409, got %d", status)
+	}
+
+	page, status = ListAdminInitAttempts(t, auth, "limit=1")
+	if status != http.StatusOK {
+		t.Fatalf("list attempts: expected 200, got %d", status)
+	}
+	if page.Total != before+1 {
+		t.Errorf("expected one more attempt, got %d after %d", page.Total, before)
+	}
+	latest := page.Items[0]
+	if latest.Outcome != "conflict" {
+		t.Errorf("expected the latest attempt to be a conflict, got %q", latest.Outcome)
+	}
+	if latest.IP == "" {
+		t.Error("expected the attempt's client IP to be recorded")
+	}
+	if time.Since(latest.AttemptedAt) > time.Minute {
+		t.Errorf("expected a recent attempt time, got %s", latest.AttemptedAt)
+	}
+
+	userAuth := auth
+	userAuth.Role = "editor"
+	if _, status := ListAdminInitAttempts(t, userAuth, ""); status != http.StatusForbidden {
+		t.Errorf("list attempts as editor: expected 403, got %d", status)
+	}
+}
+
+func TestAdminInit_RepeatedAttemptsAreRecordedOncePerWindow(t *testing.T) {
+	if _, err := DbConnection.Exec("DELETE FROM admin_init_attempts WHERE outcome = 'conflict'"); err != nil {
+		t.Fatalf("failed to clear attempts: %v", err)
+	}
+	_, _ = setupAdminForUserMgmt(t)
+	defer teardownAdminForUserMgmt(t)
+
+	for i := 0; i < 5; i++ {
+		if _, status := InitializeAdmin(t, "Takeover", "takeover@example.com", "StrongP@ssw0rd123", "Takeover Workspace", "", ""); status != http.StatusConflict {
+			t.Fatalf("init %d: expected 409, got %d", i+1, status)
+		}
+	}
+
+	if n := countRows(t, "SELECT COUNT(*) FROM admin_init_attempts WHERE outcome = 'conflict'"); n != 1 {
+		t.Errorf("expected repeated conflicts from one IP to be recorded once, got %d rows", n)
+	}
+}
+
+func TestSystemInitRepository_PruneAttemptsKeepsNewest(t *testing.T) {
+	if _, err := DbConnection.Exec("DELETE FROM admin_init_attempts"); err != nil {
+		t.Fatalf("failed to clear attempts: %v", err)
+	}
+	repo := sqlite.NewRepositoryFactory().CreateSystemInitRepository(sqlite.NewUnitOfWork(DbConnection))
+	ctx := context.Background()
+
+	start := time.Now().Add(-time.Hour)
+	for i := 0; i < 5; i++ {
+		attempt := domain.NewAdminInitAttempt(domain.RandomIDGenerator{}, domain.AdminInitInvalid, fmt.Sprintf("203.0.113.%d", i))
+		attempt.AttemptedAt = start.Add(time.Duration(i) * time.Minute)
+		if recorded, err := repo.RecordAttempt(ctx, attempt, attempt.AttemptedAt.Add(-domain.AdminInitAttemptWindow)); err != nil || !recorded {
+			t.Fatalf("failed to record attempt %d: recorded=%v err=%v", i, recorded, err)
+		}
+	}
+
+	if err := repo.PruneAttempts(ctx, 3); err != nil {
+		t.Fatalf("PruneAttempts failed: %v", err)
+	}
+	attempts, err := repo.ListAttempts(ctx, 10, 0)
+	if err != nil {
+		t.Fatalf("ListAttempts failed: %v", err)
+	}
+	if len(attempts) != 3 {
+		t.Fatalf("expected 3 attempts kept, got %d", len(attempts))
+	}
+	for i, attempt := range attempts {
+		if want := fmt.Sprintf("203.0.113.%d", 4-i); attempt.IP != want {
+			t.Errorf("attempt %d: expected the newest attempts to be kept, got %s want %s", i, attempt.IP, want)
+		}
+	}
+}
@@ -1,9 +1,22 @@
 package integration_tests
 
 import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 	"net/http"
+	"strings"
+	"sync"
 	"testing"
 
+	apphandlers "backend/internal/application/handlers"
+	domainerrors "backend/internal/domain/errors"
+	"backend/internal/infra/sqlite"
+	"backend/pkg/contracts"
+	pkgerrors "backend/pkg/errors"
+	"backend/pkg/validation"
+
 	"github.com/google/uuid"
 )
 
@@ -69,6 +82,83 @@ func TestAdminInit_SuccessAndConflict(t *testing.T) {
 	TearDownWorkspace(t, "My Workspace")
 }
 
+func TestAdminInit_ConcurrentRequestsInitializeOnce(t *testing.T) {
+	const attempts = 8
+
+	statuses := make([]int, attempts)
+	responses := make([]*AdminInitResponse, attempts)
+	var wg sync.WaitGroup
+	for i := range attempts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			responses[i], statuses[i] = InitializeAdmin(
+				t,
+				fmt.Sprintf("Racing Admin %d", i),
+				fmt.Sprintf("racing-admin-%d@example.com", i),
+				"StrongP@ssw0rd123",
+				fmt.Sprintf("Racing Workspace %d", i),
+				"Concurrent init",
+				"",
+			)
+		}()
+	}
+	wg.Wait()
+
+	created := 0
+	for i, status := range statuses {
+		switch status {
+		case http.StatusCreated:
+			created++
+			defer TearDownWorkspace(t, fmt.Sprintf("Racing Workspace %d", i))
+		case http.StatusConflict:
+		default:
+			t.Errorf("attempt %d: expected 201 or 409, got %d", i, status)
+		}
+	}
+	if created != 1 {
+		t.Fatalf("expected exactly one 201, got %d", created)
+	}
+
+	var rows int
+	if err := DbConnection.QueryRow("SELECT COUNT(*) FROM system_init").Scan(&rows); err != nil {
+		t.Fatalf("failed to count system_init rows: %v", err)
+	}
+	if rows != 1 {
+		t.Errorf("expected exactly one system_init row, got %d", rows)
+	}
+
+	var workspaces int
+	if err := DbConnection.QueryRow("SELECT COUNT(*) FROM workspaces WHERE name LIKE 'Racing Workspace %'").Scan(&workspaces); err != nil {
+		t.Fatalf("failed to count workspaces: %v", err)
+	}
+	if workspaces != 1 {
+		t.Errorf("expected the losing inits to roll back, found %d workspaces", workspaces)
+	}
+}
+
+func TestSystemInit_SecondMarkIsAlreadyInitialized(t *testing.T) {
+	auth := AuthContext{UserID: uuid.New(), UserName: "System Init User", Role: "admin", WorkspaceID: uuid.New()}
+	workspace, status := CreateWorkspace(t, auth, "System Init WS", "Workspace for system_init test", uuid.New())
+	if status != http.StatusCreated {
+		t.Fatalf("failed to create workspace: status %d", status)
+	}
+	defer TearDownWorkspace(t, workspace.Name)
+
+	repo := sqlite.NewRepositoryFactory().CreateSystemInitRepository(sqlite.NewUnitOfWork(DbConnection))
+	if err := repo.MarkInitialized(context.Background(), workspace.ID); err != nil {
+		t.Fatalf("first mark: unexpected error: %v", err)
+	}
+
+	err := repo.MarkInitialized(context.Background(), workspace.ID)
+	if !errors.Is(err, domainerrors.ErrSystemAlreadyInitialized) {
+		t.Fatalf("second mark: expected ErrSystemAlreadyInitialized, got %v", err)
+	}
+	if err.HTTPStatus() != http.StatusConflict {
+		t.Errorf("expected HTTP status 409, got %d", err.HTTPStatus())
+	}
+}
+
 func TestAdminInit_InvalidPassword(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -127,6 +217,30 @@ func TestAdminInit_InvalidEmail(t *testing.T) {
 	}
 }
 
+func TestAdminInit_DescriptionTooLong(t *testing.T) {
+	_, status := InitializeAdmin(
+		t,
+		"Admin User",
+		"admin@example.com",
+		"StrongP@ssw0rd123",
+		"Long Description Workspace",
+		strings.Repeat("d", validation.MaxDescriptionLength+1),
+		"",
+	)
+
+	if status != http.StatusBadRequest {
+		t.Errorf("expected status 400 Bad Request, got %d", status)
+	}
+
+	var count int
+	if err := DbConnection.QueryRow("SELECT COUNT(*) FROM workspaces WHERE name = ?", "Long Description Workspace").Scan(&count); err != nil {
+		t.Fatalf("failed to count workspaces: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("expected no workspace to be created, found %d", count)
+	}
+}
+
 func TestAdminInit_MissingFields(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -273,3 +387,38 @@ func TestAdminInit_WorkspaceNameValidation(t *testing.T) {
 		})
 	}
 }
+
+// isolationRecorder records the options of every Begin before delegating to
+// the real unit of work.
+type isolationRecorder struct {
+	apphandlers.UnitOfWork
+	begun []apphandlers.TxOptions
+}
+
+func (r *isolationRecorder) Begin(ctx context.Context, opts ...apphandlers.TxOption) *pkgerrors.Error {
+	r.begun = append(r.begun, apphandlers.NewTxOptions(opts...))
+	return r.UnitOfWork.Begin(ctx, opts...)
+}
+
+func TestAdminInit_RequestsSerializableTransaction(t *testing.T) {
+	service, uow := testServiceFactory.NewAdminService()
+	recorder := &isolationRecorder{UnitOfWork: uow}
+
+	_, err := service.InitializeSystem(context.Background(), recorder, contracts.AdminInit{
+		AdminName:     "Isolated Admin",
+		AdminEmail:    "isolated-admin@example.com",
+		AdminPassword: "StrongP@ssw0rd123",
+		WorkspaceName: "Isolation Workspace",
+	})
+	if err != nil {
+		t.Fatalf("InitializeSystem failed: %v", err)
+	}
+	defer TearDownWorkspace(t, "Isolation Workspace")
+
+	if len(recorder.begun) == 0 {
+		t.Fatal("expected admin init to begin a transaction")
+	}
+	if got := recorder.begun[0].Isolation; got != sql.LevelSerializable {
+		t.Errorf("expected the outermost transaction to request %s, got %s", sql.LevelSerializable, got)
+	}
+}
@@ -1,6 +1,7 @@
 package integration_tests
 
 import (
+	"fmt"
 	"net/http"
 	"testing"
 
@@ -133,6 +134,100 @@ func TestAdminInviteUser_InvalidRole(t *testing.T) {
 	}
 }
 
+// --- Batch Invite ---
+
+func TestAdminInviteUsers_AllSucceed(t *testing.T) {
+	auth, _ := setupAdminForUserMgmt(t)
+	defer teardownAdminForUserMgmt(t)
+
+	batch, status := AdminInviteUsers(t, auth, []map[string]string{
+		{"name": "Batch One", "email": "batch-one@example.com", "role": "user"},
+		{"name": "Batch Two", "email": "batch-two@example.com", "role": "editor"},
+	})
+	if status != http.StatusCreated {
+		t.Fatalf("expected 201 for a uniform batch, got %d", status)
+	}
+	if batch.Succeeded != 2 || batch.Failed != 0 {
+		t.Errorf("expected 2 succeeded and 0 failed, got %d and %d", batch.Succeeded, batch.Failed)
+	}
+	for i, result := range batch.Results {
+		if result.Index != i || result.Status != http.StatusCreated {
+			t.Errorf("result %d: expected index %d with status 201, got index %d with status %d", i, i, result.Index, result.Status)
+		}
+		if result.Data == nil || result.Data.Password == "" {
+			t.Errorf("result %d: expected the invited user with a generated password", i)
+		}
+		if result.Error != nil {
+			t.Errorf("result %d: expected no error, got %+v", i, result.Error)
+		}
+	}
+}
+
+func TestAdminInviteUsers_MixedOutcomes(t *testing.T) {
+	auth, _ := setupAdminForUserMgmt(t)
+	defer teardownAdminForUserMgmt(t)
+
+	if _, status := AdminInviteUser(t, auth, "Existing", "batch-existing@example.com", "user"); status != http.StatusCreated {
+		t.Fatalf("setup invite: expected 201, got %d", status)
+	}
+
+	batch, status := AdminInviteUsers(t, auth, []map[string]string{
+		{"name": "Batch Fresh", "email": "batch-fresh@example.com", "role": "user"},
+		{"name": "Batch Dup", "email": "batch-existing@example.com", "role": "user"},
+		{"name": "Batch Invalid", "email": "not-an-email", "role": "user"},
+	})
+	if status != http.StatusMultiStatus {
+		t.Fatalf("expected 207 for a mixed batch, got %d", status)
+	}
+	if batch.Succeeded != 1 || batch.Failed != 2 {
+		t.Errorf("expected 1 succeeded and 2 failed, got %d and %d", batch.Succeeded, batch.Failed)
+	}
+
+	expected := []struct {
+		status int
+		code   string
+	}{
+		{http.StatusCreated, ""},
+		{http.StatusConflict, "CONFLICT"},
+		{http.StatusBadRequest, "VALIDATION_ERROR"},
+	}
+	if len(batch.Results) != len(expected) {
+		t.Fatalf("expected %d results, got %d", len(expected), len(batch.Results))
+	}
+	for i, want := range expected {
+		result := batch.Results[i]
+		if result.Index != i || result.Status != want.status {
+			t.Errorf("result %d: expected index %d with status %d, got index %d with status %d", i, i, want.status, result.Index, result.Status)
+		}
+		if want.code == "" {
+			if result.Data == nil || result.Error != nil {
+				t.Errorf("result %d: expected data and no error", i)
+			}
+			continue
+		}
+		if result.Data != nil || result.Error == nil {
+			t.Fatalf("result %d: expected an error and no data", i)
+		}
+		if result.Error.Code != want.code {
+			t.Errorf("result %d: expected error code %s, got %s", i, want.code, result.Error.Code)
+		}
+	}
+
+	// Item errors are formatted like the error of a failed request
+	invalid := batch.Results[2].Error
+	if fields, ok := invalid.Metadata["fields"].(map[string]interface{}); !ok || fields["email"] == nil {
+		t.Errorf("expected a localized email field message, got metadata %v", invalid.Metadata)
+	}
+	if _, leaked := invalid.Metadata["violations"]; leaked {
+		t.Error("expected raw violations to be stripped from the item error")
+	}
+
+	// The failures did not roll back the successful invite
+	if _, status := AdminInviteUser(t, auth, "Batch Fresh Again", "batch-fresh@example.com", "user"); status != http.StatusConflict {
+		t.Errorf("expected the batch-invited user to exist (409), got %d", status)
+	}
+}
+
 // --- Reset Password ---
 
 func TestAdminResetPassword_Success(t *testing.T) {
@@ -259,6 +354,109 @@ func TestAdminListUsers_NonAdminForbidden(t *testing.T) {
 	}
 }
 
+func TestAdminListUsers_InactiveSince(t *testing.T) {
+	auth, _ := setupAdminForUserMgmt(t)
+	defer teardownAdminForUserMgmt(t)
+
+	invite := func(name, email string) uuid.UUID {
+		t.Helper()
+		resp, s := AdminInviteUser(t, auth, name, email, "user")
+		if s != http.StatusCreated {
+			t.Fatalf("setup invite %s: expected 201, got %d", email, s)
+		}
+		return resp.UserID
+	}
+	setLastActive := func(id uuid.UUID, at any) {
+		t.Helper()
+		if _, err := DbConnection.Exec("UPDATE users SET last_active_at = ? WHERE id = ?", at, id); err != nil {
+			t.Fatalf("set last_active_at: %v", err)
+		}
+	}
+
+	never := invite("Never Active", "never-active@example.com")
+	older := invite("Older Active", "older-active@example.com")
+	old := invite("Old Active", "old-active@example.com")
+	recent := invite("Recent Active", "recent-active@example.com")
+	setLastActive(never, nil)
+	setLastActive(older, "2019-06-01 00:00:00")
+	setLastActive(old, "2023-03-15 12:00:00")
+	setLastActive(recent, "2025-01-01 00:00:00")
+
+	page, status := AdminListInactiveUsers(t, auth, "2024-01-01T00:00:00Z", 0, 0)
+	if status != http.StatusOK {
+		t.Fatalf("list inactive users: expected 200, got %d", status)
+	}
+
+	var order []uuid.UUID
+	for _, u := range page.Items {
+		switch u.ID {
+		case recent:
+			t.Error("user active after the cutoff should not be listed")
+		case never, older, old:
+			order = append(order, u.ID)
+		}
+	}
+	want := []uuid.UUID{never, older, old}
+	if len(order) != len(want) {
+		t.Fatalf("expected %d inactive users, got %d", len(want), len(order))
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Errorf("position %d: expected %s, got %s (never-active first, then oldest)", i, want[i], order[i])
+		}
+	}
+}
+
+func TestAdminListUsers_InactiveSinceInvalid(t *testing.T) {
+	auth, _ := setupAdminForUserMgmt(t)
+	defer teardownAdminForUserMgmt(t)
+
+	_, status := AdminListInactiveUsers(t, auth, "last tuesday", 0, 0)
+	if status != http.StatusBadRequest {
+		t.Errorf("invalid inactive_since: expected 400, got %d", status)
+	}
+	_, status = AdminListInactiveUsers(t, auth, "2024-01-01T00:00:00Z", 101, 0)
+	if status != http.StatusBadRequest {
+		t.Errorf("limit above 100: expected 400, got %d", status)
+	}
+}
+
+func TestAdminListUsers_InactiveSincePaged(t *testing.T) {
+	auth, _ := setupAdminForUserMgmt(t)
+	defer teardownAdminForUserMgmt(t)
+
+	var want []uuid.UUID
+	for i, at := range []string{"2019-06-01 00:00:00", "2020-06-01 00:00:00", "2021-06-01 00:00:00"} {
+		resp, s := AdminInviteUser(t, auth, fmt.Sprintf("Paged Inactive %d", i), fmt.Sprintf("paged-inactive-%d@example.com", i), "user")
+		if s != http.StatusCreated {
+			t.Fatalf("setup invite %d: expected 201, got %d", i, s)
+		}
+		if _, err := DbConnection.Exec("UPDATE users SET last_active_at = ? WHERE id = ?", at, resp.UserID); err != nil {
+			t.Fatalf("set last_active_at: %v", err)
+		}
+		want = append(want, resp.UserID)
+	}
+
+	first, status := AdminListInactiveUsers(t, auth, "2024-01-01T00:00:00Z", 2, 0)
+	if status != http.StatusOK {
+		t.Fatalf("first page: expected 200, got %d", status)
+	}
+	if first.Total != 3 || first.Limit != 2 || first.Offset != 0 {
+		t.Errorf("expected total 3, limit 2, offset 0, got %d, %d, %d", first.Total, first.Limit, first.Offset)
+	}
+	if len(first.Items) != 2 || first.Items[0].ID != want[0] || first.Items[1].ID != want[1] {
+		t.Fatalf("expected the two oldest users on the first page, got %+v", first.Items)
+	}
+
+	second, status := AdminListInactiveUsers(t, auth, "2024-01-01T00:00:00Z", 2, 2)
+	if status != http.StatusOK {
+		t.Fatalf("second page: expected 200, got %d", status)
+	}
+	if second.Total != 3 || len(second.Items) != 1 || second.Items[0].ID != want[2] {
+		t.Errorf("expected only the newest inactive user on the second page, got total %d, items %+v", second.Total, second.Items)
+	}
+}
+
 // --- Delete User ---
 
 func TestAdminDeleteUser_Success(t *testing.T) {
@@ -323,3 +521,221 @@ func TestAdminDeleteUser_NotFound(t *testing.T) {
 		t.Errorf("not found delete: expected 404, got %d", status)
 	}
 }
+
+// --- Get User By Email ---
+
+func TestAdminGetUserByEmail_Found(t *testing.T) {
+	auth, workspaceID := setupAdminForUserMgmt(t)
+	defer teardownAdminForUserMgmt(t)
+
+	invite, status := AdminInviteUser(t, auth, "Lookup User", "lookup@example.com", "user")
+	if status != http.StatusCreated {
+		t.Fatalf("failed to invite user: status %d", status)
+	}
+
+	user, status := AdminGetUserByEmail(t, auth, "lookup@example.com")
+	if status != http.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+	if user.ID != invite.UserID {
+		t.Errorf("expected user ID %s, got %s", invite.UserID, user.ID)
+	}
+	if user.Email != "lookup@example.com" {
+		t.Errorf("expected email 'lookup@example.com', got '%s'", user.Email)
+	}
+	if user.WorkspaceID != workspaceID {
+		t.Errorf("expected workspace ID %s, got %s", workspaceID, user.WorkspaceID)
+	}
+}
+
+func TestAdminGetUserByEmail_NotFound(t *testing.T) {
+	auth, _ := setupAdminForUserMgmt(t)
+	defer teardownAdminForUserMgmt(t)
+
+	_, status := AdminGetUserByEmail(t, auth, "nobody@example.com")
+	if status != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", status)
+	}
+}
+
+func TestAdminGetUserByEmail_InvalidEmail(t *testing.T) {
+	auth, _ := setupAdminForUserMgmt(t)
+	defer teardownAdminForUserMgmt(t)
+
+	_, status := AdminGetUserByEmail(t, auth, "not-an-email")
+	if status != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", status)
+	}
+}
+
+func TestAdminGetUserByEmail_OtherWorkspace(t *testing.T) {
+	auth, _ := setupAdminForUserMgmt(t)
+	defer teardownAdminForUserMgmt(t)
+
+	if _, status := AdminInviteUser(t, auth, "Hidden User", "hidden@example.com", "user"); status != http.StatusCreated {
+		t.Fatalf("failed to invite user: status %d", status)
+	}
+
+	// An admin of another workspace cannot see the user at all
+	otherAdmin, _ := setupWorkspaceForTemplates(t)
+	_, status := AdminGetUserByEmail(t, otherAdmin, "hidden@example.com")
+	if status != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", status)
+	}
+}
+
+func TestAdminGetUserByEmail_NonAdmin(t *testing.T) {
+	auth, _ := setupAdminForUserMgmt(t)
+	defer teardownAdminForUserMgmt(t)
+
+	userAuth := auth
+	userAuth.Role = "user"
+	_, status := AdminGetUserByEmail(t, userAuth, "mgmt-admin@example.com")
+	if status != http.StatusForbidden {
+		t.Errorf("expected 403, got %d", status)
+	}
+}
+
+// --- Move User ---
+
+// createAdministeredWorkspace creates a workspace administered by auth's user.
+func createAdministeredWorkspace(t *testing.T, auth AuthContext) *WorkspaceResponse {
+	t.Helper()
+	workspace, status := CreateWorkspace(t, auth, "Move Target "+uuid.New().String()[:8], "", auth.UserID)
+	if status != http.StatusCreated {
+		t.Fatalf("failed to create target workspace: status %d", status)
+	}
+	return workspace
+}
+
+func TestAdminMoveUser_Success(t *testing.T) {
+	auth, _ := setupAdminForUserMgmt(t)
+	defer teardownAdminForUserMgmt(t)
+	target := createAdministeredWorkspace(t, auth)
+	defer TearDownWorkspace(t, target.Name)
+
+	invite, status := AdminInviteUser(t, auth, "Moving User", "moving@example.com", "editor")
+	if status != http.StatusCreated {
+		t.Fatalf("failed to invite user: status %d", status)
+	}
+	loginResp, _, status := LoginUser(t, "moving@example.com", invite.Password)
+	if status != http.StatusOK {
+		t.Fatalf("failed to log in: status %d", status)
+	}
+	oldCookies := loginResp.Cookies()
+	if status := GetWithCookies(t, "/api/v1/templates", oldCookies); status != http.StatusOK {
+		t.Fatalf("expected 200 before the move, got %d", status)
+	}
+
+	moved, status := AdminMoveUser(t, auth, invite.UserID, target.ID)
+	if status != http.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+	if moved.WorkspaceID != target.ID {
+		t.Errorf("expected workspace %s, got %s", target.ID, moved.WorkspaceID)
+	}
+	if moved.Role != "user" {
+		t.Errorf("expected the moved editor to arrive as a user, got %q", moved.Role)
+	}
+
+	// The token issued before the move is revoked
+	if status := GetWithCookies(t, "/api/v1/templates", oldCookies); status != http.StatusUnauthorized {
+		t.Errorf("expected 401 with the pre-move token, got %d", status)
+	}
+
+	// Logging in again yields a working token for the new workspace
+	loginResp, login, status := LoginUser(t, "moving@example.com", invite.Password)
+	if status != http.StatusOK {
+		t.Fatalf("failed to log in after the move: status %d", status)
+	}
+	if login.WorkspaceID != target.ID {
+		t.Errorf("expected login workspace %s, got %s", target.ID, login.WorkspaceID)
+	}
+	if status := GetWithCookies(t, "/api/v1/templates", loginResp.Cookies()); status != http.StatusOK {
+		t.Errorf("expected 200 with a fresh token, got %d", status)
+	}
+}
+
+func TestAdminMoveUser_NonAdmin(t *testing.T) {
+	auth, _ := setupAdminForUserMgmt(t)
+	defer teardownAdminForUserMgmt(t)
+	target := createAdministeredWorkspace(t, auth)
+	defer TearDownWorkspace(t, target.Name)
+
+	invite, status := AdminInviteUser(t, auth, "Stay Put", "stayput@example.com", "user")
+	if status != http.StatusCreated {
+		t.Fatalf("failed to invite user: status %d", status)
+	}
+
+	editorAuth := auth
+	editorAuth.Role = "editor"
+	if _, status := AdminMoveUser(t, editorAuth, invite.UserID, target.ID); status != http.StatusForbidden {
+		t.Errorf("expected 403, got %d", status)
+	}
+}
+
+func TestAdminMoveUser_TargetWorkspaceNotFound(t *testing.T) {
+	auth, _ := setupAdminForUserMgmt(t)
+	defer teardownAdminForUserMgmt(t)
+
+	invite, status := AdminInviteUser(t, auth, "Nowhere User", "nowhere@example.com", "user")
+	if status != http.StatusCreated {
+		t.Fatalf("failed to invite user: status %d", status)
+	}
+
+	if _, status := AdminMoveUser(t, auth, invite.UserID, uuid.New()); status != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", status)
+	}
+
+	users, _ := AdminListUsers(t, auth)
+	found := false
+	for _, u := range users {
+		if u.ID == invite.UserID {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected the user to remain in the source workspace")
+	}
+}
+
+func TestAdminMoveUser_OtherWorkspaceUser(t *testing.T) {
+	auth, _ := setupAdminForUserMgmt(t)
+	defer teardownAdminForUserMgmt(t)
+
+	invite, status := AdminInviteUser(t, auth, "Not Yours", "notyours@example.com", "user")
+	if status != http.StatusCreated {
+		t.Fatalf("failed to invite user: status %d", status)
+	}
+
+	// An admin of another workspace does not administer the user's workspace
+	otherAdmin, otherWorkspace := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, otherWorkspace.Name)
+	if _, status := AdminMoveUser(t, otherAdmin, invite.UserID, otherWorkspace.ID); status != http.StatusForbidden {
+		t.Errorf("expected 403, got %d", status)
+	}
+}
+
+func TestAdminMoveUser_TargetNotAdministeredByCaller(t *testing.T) {
+	auth, source := setupAdminForUserMgmt(t)
+	defer teardownAdminForUserMgmt(t)
+	_, foreign := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, foreign.Name)
+
+	invite, status := AdminInviteUser(t, auth, "Pushed Admin", "pushed@example.com", "admin")
+	if status != http.StatusCreated {
+		t.Fatalf("failed to invite user: status %d", status)
+	}
+
+	if _, status := AdminMoveUser(t, auth, invite.UserID, foreign.ID); status != http.StatusForbidden {
+		t.Errorf("expected 403, got %d", status)
+	}
+
+	var workspaceID uuid.UUID
+	if err := DbConnection.QueryRow("SELECT workspace_id FROM users WHERE id = ?", invite.UserID).Scan(&workspaceID); err != nil {
+		t.Fatalf("failed to read user: %v", err)
+	}
+	if workspaceID != source {
+		t.Errorf("expected the user to stay in %s, got %s", source, workspaceID)
+	}
+}
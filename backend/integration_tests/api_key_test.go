@@ -0,0 +1,170 @@
+package integration_tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestAPIKey_CreateAndAuthenticate(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, workspace.Name)
+
+	key, status := CreateAPIKey(t, auth, workspace.ID, "CI Pipeline", "editor")
+	if status != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", status)
+	}
+	if !strings.HasPrefix(key.Key, "dsk_") {
+		t.Errorf("expected key to start with 'dsk_', got %q", key.Key)
+	}
+	if !strings.HasPrefix(key.Key, key.KeyPrefix) {
+		t.Errorf("expected key prefix %q to be the start of the key", key.KeyPrefix)
+	}
+	if key.Role != "editor" || key.WorkspaceID != workspace.ID {
+		t.Errorf("unexpected key metadata: %+v", key)
+	}
+
+	if status := GetWithAPIKey(t, "/api/v1/templates", key.Key); status != http.StatusOK {
+		t.Errorf("expected 200 with a valid API key, got %d", status)
+	}
+	if status := GetWithAPIKey(t, "/api/v1/templates", "dsk_not-a-real-key"); status != http.StatusUnauthorized {
+		t.Errorf("expected 401 with an unknown API key, got %d", status)
+	}
+}
+
+func TestAPIKey_ListDoesNotLeakSecrets(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, workspace.Name)
+
+	key, status := CreateAPIKey(t, auth, workspace.ID, "Listed Key", "user")
+	if status != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", status)
+	}
+
+	body, status := ListAPIKeysRaw(t, auth, workspace.ID)
+	if status != http.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+	if strings.Contains(body, key.Key) {
+		t.Error("list response contains the plaintext key")
+	}
+	if strings.Contains(body, `"key"`) || strings.Contains(body, "hash") {
+		t.Errorf("list response exposes secret fields: %s", body)
+	}
+
+	var keys []APIKeyResponse
+	if err := json.Unmarshal([]byte(body), &keys); err != nil {
+		t.Fatalf("failed to decode list: %v", err)
+	}
+	if len(keys) != 1 || keys[0].ID != key.ID {
+		t.Fatalf("expected the created key to be listed, got %+v", keys)
+	}
+	if keys[0].KeyPrefix != key.KeyPrefix {
+		t.Errorf("expected key prefix %q, got %q", key.KeyPrefix, keys[0].KeyPrefix)
+	}
+}
+
+func TestAPIKey_RevokedKeyFailsAuth(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, workspace.Name)
+
+	key, status := CreateAPIKey(t, auth, workspace.ID, "Short Lived", "user")
+	if status != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", status)
+	}
+	if status := GetWithAPIKey(t, "/api/v1/templates", key.Key); status != http.StatusOK {
+		t.Fatalf("expected 200 before revocation, got %d", status)
+	}
+
+	if status := RevokeAPIKey(t, auth, workspace.ID, key.ID); status != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", status)
+	}
+
+	if status := GetWithAPIKey(t, "/api/v1/templates", key.Key); status != http.StatusUnauthorized {
+		t.Errorf("expected 401 after revocation, got %d", status)
+	}
+
+	body, _ := ListAPIKeysRaw(t, auth, workspace.ID)
+	if strings.Contains(body, key.ID.String()) {
+		t.Error("expected revoked key to be absent from the list")
+	}
+
+	if status := RevokeAPIKey(t, auth, workspace.ID, key.ID); status != http.StatusNotFound {
+		t.Errorf("expected 404 revoking an already revoked key, got %d", status)
+	}
+}
+
+func TestAPIKey_OtherWorkspace(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, workspace.Name)
+	otherAuth, otherWorkspace := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, otherWorkspace.Name)
+
+	key, status := CreateAPIKey(t, auth, workspace.ID, "Scoped Key", "user")
+	if status != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", status)
+	}
+
+	// A path naming another workspace is answered like a missing one
+	if _, status := ListAPIKeysRaw(t, otherAuth, workspace.ID); status != http.StatusNotFound {
+		t.Errorf("expected 404 listing another workspace's keys, got %d", status)
+	}
+	if status := RevokeAPIKey(t, otherAuth, workspace.ID, key.ID); status != http.StatusNotFound {
+		t.Errorf("expected 404 revoking another workspace's key, got %d", status)
+	}
+	// Even through its own workspace path, another admin cannot reach the key
+	if status := RevokeAPIKey(t, otherAuth, otherWorkspace.ID, key.ID); status != http.StatusNotFound {
+		t.Errorf("expected 404 revoking a key from another workspace, got %d", status)
+	}
+	if _, status := CreateAPIKey(t, otherAuth, workspace.ID, "Intruder Key", "admin"); status != http.StatusNotFound {
+		t.Errorf("expected 404 creating a key in another workspace, got %d", status)
+	}
+
+	if status := GetWithAPIKey(t, "/api/v1/templates", key.Key); status != http.StatusOK {
+		t.Errorf("expected the key to survive the rejected revocations, got %d", status)
+	}
+}
+
+func TestAPIKey_DeletedWorkspaceFailsAuth(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, workspace.Name)
+
+	key, status := CreateAPIKey(t, auth, workspace.ID, "Orphaned Key", "user")
+	if status != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", status)
+	}
+
+	if _, err := DbConnection.Exec("UPDATE workspaces SET deleted_at = CURRENT_TIMESTAMP WHERE id = ?", workspace.ID); err != nil {
+		t.Fatalf("failed to soft-delete workspace: %v", err)
+	}
+
+	if status := GetWithAPIKey(t, "/api/v1/templates", key.Key); status != http.StatusUnauthorized {
+		t.Errorf("expected 401 for a key of a deleted workspace, got %d", status)
+	}
+}
+
+func TestAPIKey_CannotCreateEnvironment(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, workspace.Name)
+
+	template, status := CreateTemplate(t, auth, "API Key Template", workspace.ID, defaultFiles())
+	if status != http.StatusCreated {
+		t.Fatalf("failed to create template: status %d", status)
+	}
+	key, status := CreateAPIKey(t, auth, workspace.ID, "Env Creator", "admin")
+	if status != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", status)
+	}
+
+	status = SendWithAPIKey(t, http.MethodPost, "/api/v1/environments", key.Key, map[string]any{
+		"name":        "key-env",
+		"template_id": template.ID,
+	})
+	if status != http.StatusForbidden {
+		t.Errorf("expected 403 creating an environment with an API key, got %d", status)
+	}
+	if n := countRows(t, "SELECT COUNT(*) FROM environments WHERE workspace_id = ?", workspace.ID); n != 0 {
+		t.Errorf("expected no environment to be created, found %d", n)
+	}
+}
@@ -0,0 +1,73 @@
+package integration_tests
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"testing"
+)
+
+func TestCrossWorkspaceTemplateAccess_LogsDenial(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, workspace.Name)
+	otherAuth, other := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, other.Name)
+
+	created, status := CreateTemplate(t, auth, "Denied Template", workspace.ID, defaultFiles())
+	if status != http.StatusCreated {
+		t.Fatalf("failed to create template: status %d", status)
+	}
+
+	tests := []struct {
+		name         string
+		path         string
+		resourceType string
+		resourceID   string
+	}{
+		{"template by ID", "/api/v1/templates/" + created.ID.String(), "Template", created.ID.String()},
+		{"workspace listing", "/api/v1/templates/workspace/" + workspace.ID.String(), "Workspace", workspace.ID.String()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logs bytes.Buffer
+			previous := slog.Default()
+			slog.SetDefault(slog.New(slog.NewJSONHandler(&logs, nil)))
+			t.Cleanup(func() { slog.SetDefault(previous) })
+
+			if status, _ := getRawList(t, otherAuth, tt.path); status != http.StatusNotFound {
+				t.Fatalf("expected 404, got %d", status)
+			}
+			slog.SetDefault(previous)
+
+			var denial map[string]any
+			scanner := bufio.NewScanner(&logs)
+			for scanner.Scan() {
+				var entry map[string]any
+				if json.Unmarshal(scanner.Bytes(), &entry) == nil && entry["msg"] == "authorization denied" {
+					denial = entry
+					break
+				}
+			}
+			if denial == nil {
+				t.Fatalf("expected an authorization denied log, got %q", logs.String())
+			}
+
+			want := map[string]any{
+				"level":                 "WARN",
+				"actor_id":              otherAuth.UserID.String(),
+				"actor_workspace_id":    other.ID.String(),
+				"resource_type":         tt.resourceType,
+				"resource_id":           tt.resourceID,
+				"resource_workspace_id": workspace.ID.String(),
+			}
+			for key, value := range want {
+				if denial[key] != value {
+					t.Errorf("%s: expected %v, got %v", key, value, denial[key])
+				}
+			}
+		})
+	}
+}
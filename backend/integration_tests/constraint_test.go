@@ -0,0 +1,115 @@
+package integration_tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	handlererrors "backend/internal/application/errors"
+	"backend/internal/domain"
+	"backend/internal/infra/sqlite"
+	"backend/pkg/contracts"
+	pkgerrors "backend/pkg/errors"
+	"backend/pkg/validation"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+func TestCheckConstraintViolation_MapsToValidationError(t *testing.T) {
+	auth := AuthContext{
+		UserID:      uuid.New(),
+		UserName:    "Check Constraint User",
+		Role:        "admin",
+		WorkspaceID: uuid.New(),
+	}
+	workspace, status := CreateWorkspace(t, auth, "Check Constraint WS", "Workspace for CHECK constraint test", uuid.New())
+	if status != http.StatusCreated {
+		t.Fatalf("failed to create workspace: status %d", status)
+	}
+	defer TearDownWorkspace(t, workspace.Name)
+
+	// A user with neither a password nor an OAuth identity violates the
+	// users.check_auth_method constraint.
+	repo := sqlite.NewRepositoryFactory().CreateUserRepository(sqlite.NewUnitOfWork(DbConnection))
+	err := repo.Create(context.Background(), domain.UserAggregate{
+		BaseUser: domain.NewBaseUser(domain.RandomIDGenerator{}, "No Auth", "no-auth@example.com", domain.RoleUser, workspace.ID),
+	})
+	if err == nil {
+		t.Fatal("expected CHECK constraint violation, got nil")
+	}
+
+	if err.Code() != pkgerrors.CodeValidation {
+		t.Errorf("expected code %s, got %s", pkgerrors.CodeValidation, err.Code())
+	}
+	if err.HTTPStatus() != http.StatusBadRequest {
+		t.Errorf("expected HTTP status 400, got %d", err.HTTPStatus())
+	}
+	if got := err.GetMetadata()["constraint"]; got != "check_auth_method" {
+		t.Errorf("expected constraint 'check_auth_method', got %v", got)
+	}
+}
+
+func TestErrorMetadataKeys_FollowConvention(t *testing.T) {
+	auth := AuthContext{
+		UserID:      uuid.New(),
+		UserName:    "Metadata Keys User",
+		Role:        "admin",
+		WorkspaceID: uuid.New(),
+	}
+	workspace, status := CreateWorkspace(t, auth, "Metadata Keys WS", "Workspace for metadata key test", uuid.New())
+	if status != http.StatusCreated {
+		t.Fatalf("failed to create workspace: status %d", status)
+	}
+	defer TearDownWorkspace(t, workspace.Name)
+
+	repo := sqlite.NewRepositoryFactory().CreateUserRepository(sqlite.NewUnitOfWork(DbConnection))
+	dbErr := repo.Create(context.Background(), domain.UserAggregate{
+		BaseUser: domain.NewBaseUser(domain.RandomIDGenerator{}, "No Auth", "metadata-keys@example.com", domain.RoleUser, workspace.ID),
+	})
+	if dbErr == nil {
+		t.Fatal("expected a database error, got nil")
+	}
+
+	validator := validation.New()
+	if err := validator.RegisterDefaultCustomValidations(); err != nil {
+		t.Fatalf("failed to register validations: %v", err)
+	}
+	contracts.RegisterValidations(validator)
+	validationErr := validator.Validate(contracts.CreateLocalUser{Email: "not-an-email"})
+	if validationErr == nil {
+		t.Fatal("expected a validation error, got nil")
+	}
+
+	pattern := regexp.MustCompile(`^[a-z0-9]+(_[a-z0-9]+)*$`)
+	if pkgerrors.MetadataKeyCase == pkgerrors.CamelCase {
+		pattern = regexp.MustCompile(`^[a-z][a-zA-Z0-9]*$`)
+	}
+
+	for name, err := range map[string]*pkgerrors.Error{"database": dbErr, "validation": validationErr} {
+		t.Run(name, func(t *testing.T) {
+			app := fiber.New(fiber.Config{ErrorHandler: handlererrors.ErrorHandler()})
+			app.Get("/", func(c *fiber.Ctx) error { return err })
+
+			resp, testErr := app.Test(httptest.NewRequest(http.MethodGet, "/", nil), -1)
+			if testErr != nil {
+				t.Fatalf("request failed: %v", testErr)
+			}
+			var body handlererrors.ErrorResponse
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if len(body.Error.Metadata) == 0 {
+				t.Fatal("expected metadata in the response")
+			}
+			for key := range body.Error.Metadata {
+				if !pattern.MatchString(key) {
+					t.Errorf("metadata key %q does not follow the configured convention", key)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package integration_tests
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"backend/internal/domain"
+	"backend/pkg/contracts"
+
+	"github.com/google/uuid"
+)
+
+// assertJSONRoundTrip marshals server, the value a handler responds with,
+// decodes it into client, the struct tests decode responses into, and
+// re-encodes client. Every key client carries must arrive from server with
+// the same value; keys only the server sends are allowed. server should have
+// every field set so omitempty cannot hide a mismatch.
+func assertJSONRoundTrip(t *testing.T, server any, client any) {
+	t.Helper()
+
+	serverJSON, err := json.Marshal(server)
+	if err != nil {
+		t.Fatalf("marshal %T: %v", server, err)
+	}
+	if err := json.Unmarshal(serverJSON, client); err != nil {
+		t.Fatalf("unmarshal %T into %T: %v", server, client, err)
+	}
+	clientJSON, err := json.Marshal(client)
+	if err != nil {
+		t.Fatalf("marshal %T: %v", client, err)
+	}
+
+	var sent, received map[string]any
+	if err := json.Unmarshal(serverJSON, &sent); err != nil {
+		t.Fatalf("decode %T: %v", server, err)
+	}
+	if err := json.Unmarshal(clientJSON, &received); err != nil {
+		t.Fatalf("decode %T: %v", client, err)
+	}
+
+	for key, value := range received {
+		got, ok := sent[key]
+		if !ok {
+			t.Errorf("%T expects key %q that %T does not send (sent keys: %v)", client, key, server, keysOf(sent))
+			continue
+		}
+		if !reflect.DeepEqual(got, value) {
+			t.Errorf("%T.%s: sent %v, decoded as %v", client, key, got, value)
+		}
+	}
+}
+
+func keysOf(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func TestContracts_JSONRoundTrip(t *testing.T) {
+	at := time.Date(2024, 5, 17, 9, 30, 0, 0, time.UTC)
+	id, other, third := uuid.New(), uuid.New(), uuid.New()
+
+	tests := []struct {
+		name   string
+		server any
+		client any
+	}{
+		{
+			name: "workspace",
+			server: &domain.Workspace{
+				ID: id, Name: "Workspace", Description: "Described", AdminID: &other,
+				CreatedAt: at, UpdatedAt: at, Version: 3,
+			},
+			client: &WorkspaceResponse{},
+		},
+		{
+			name: "deleted workspace",
+			server: &domain.Workspace{
+				ID: id, Name: "Workspace", Description: "Described", AdminID: &other,
+				CreatedAt: at, UpdatedAt: at, Version: 3, DeletedAt: &at, DeletedBy: &third,
+			},
+			client: &DeletedWorkspaceResponse{},
+		},
+		{
+			name: "template",
+			server: &domain.Template{
+				ID: id, Name: "Template", WorkspaceID: other, Path: "modules/app",
+				RepoURL: "https://example.com/repo.git", CreatedAt: at, UpdatedAt: at,
+			},
+			client: &TemplateResponse{},
+		},
+		{
+			name: "group",
+			server: &domain.Group{
+				ID: id, Name: "Group", Description: "Described", WorkspaceID: other,
+				AccessAllTemplates: true, CreatedAt: at, UpdatedAt: at,
+			},
+			client: &GroupResponse{},
+		},
+		{
+			name: "login",
+			server: contracts.LoginResponse{
+				UserID: id, Name: "User", Role: "editor", WorkspaceID: other, Scope: "read", TokenEpoch: 2,
+			},
+			client: &LoginResponse{},
+		},
+		{
+			name: "admin init",
+			server: contracts.AdminInitResponse{
+				Message: "initialized", WorkspaceID: id, AdminUserID: other, UserName: "Admin",
+			},
+			client: &AdminInitResponse{},
+		},
+		{
+			name: "invite user",
+			server: contracts.InviteUserResponse{
+				UserID: id, Name: "Invited", Email: "invited@example.com", Role: "user", Password: "Temp0rary!",
+			},
+			client: &InviteUserResponse{},
+		},
+		{
+			name:   "reset password",
+			server: contracts.ResetPasswordResponse{UserID: id, Password: "Temp0rary!"},
+			client: &ResetPasswordResponse{},
+		},
+		{
+			name: "admin user list",
+			server: contracts.AdminUserResponse{
+				ID: id, Name: "User", Email: "user@example.com", Role: "user", WorkspaceID: other,
+				CreatedAt: at, UpdatedAt: at, LastActiveAt: &at,
+			},
+			client: &AdminUserListResponse{},
+		},
+		{
+			name:   "workspace secret",
+			server: contracts.WorkspaceSecretResponse{WorkspaceID: id, Secret: "s3cret", RotatedAt: at},
+			client: &WorkspaceSecretResponse{},
+		},
+		{
+			name: "api key",
+			server: contracts.CreateAPIKeyResponse{
+				APIKeyResponse: contracts.APIKeyResponse{
+					ID: id, WorkspaceID: other, Name: "CI", Role: "editor", Scope: "write",
+					KeyPrefix: "dsk_abc", CreatedBy: &third, CreatedAt: at,
+				},
+				Key: "dsk_abc.secret",
+			},
+			client: &APIKeyResponse{},
+		},
+		{
+			name: "debug info",
+			server: contracts.DebugInfoResponse{
+				Version: "1.2.3", Commit: "abc123", GoVersion: "go1.24", UptimeSeconds: 12.5, Goroutines: 7,
+			},
+			client: &DebugInfoResponse{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertJSONRoundTrip(t, tt.server, tt.client)
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package integration_tests
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"backend/internal/application"
+	handlererrors "backend/internal/application/errors"
+	"backend/internal/infra/http/middleware"
+	"backend/internal/infra/sqlite"
+	"backend/pkg/contracts"
+	"backend/pkg/jwt"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+func TestCrossTenantDenial_Modes(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, workspace.Name)
+	otherAuth, otherWorkspace := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, otherWorkspace.Name)
+
+	created, status := CreateTemplate(t, auth, "Cross Tenant Template", workspace.ID, defaultFiles())
+	if status != http.StatusCreated {
+		t.Fatalf("failed to create template: status %d", status)
+	}
+
+	ctx := jwt.WithClaims(context.Background(), &jwt.Claims{
+		ID:          otherAuth.UserID.String(),
+		Name:        otherAuth.UserName,
+		Role:        otherAuth.Role,
+		WorkspaceID: otherAuth.WorkspaceID.String(),
+	})
+
+	tests := []struct {
+		mode           application.CrossTenantDenial
+		expectedStatus int
+	}{
+		{application.CrossTenantNotFound, http.StatusNotFound},
+		{application.CrossTenantForbidden, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			factory := testServiceFactory.WithCrossTenantDenial(tt.mode)
+			templates := factory.NewTemplateService()
+			workspaces, uow := factory.NewWorkspaceService()
+
+			if _, err := templates.GetTemplate(ctx, contracts.GetTemplate{ID: created.ID}); err == nil || err.HTTPStatus() != tt.expectedStatus {
+				t.Errorf("GetTemplate: expected %d, got %v", tt.expectedStatus, err)
+			}
+			if err := templates.DeleteTemplate(ctx, contracts.DeleteTemplate{ID: created.ID}); err == nil || err.HTTPStatus() != tt.expectedStatus {
+				t.Errorf("DeleteTemplate: expected %d, got %v", tt.expectedStatus, err)
+			}
+			if _, err := templates.GetTemplatesByWorkspace(ctx, contracts.GetTemplatesByWorkspace{WorkspaceID: workspace.ID}); err == nil || err.HTTPStatus() != tt.expectedStatus {
+				t.Errorf("GetTemplatesByWorkspace: expected %d, got %v", tt.expectedStatus, err)
+			}
+			if _, err := workspaces.RotateSecret(ctx, uow, contracts.RotateWorkspaceSecret{ID: workspace.ID}); err == nil || err.HTTPStatus() != tt.expectedStatus {
+				t.Errorf("RotateSecret: expected %d, got %v", tt.expectedStatus, err)
+			}
+		})
+	}
+
+	// Neither mode lets the other workspace touch the template
+	if _, status := GetTemplate(t, auth, created.ID); status != http.StatusOK {
+		t.Errorf("expected the template to survive, got %d", status)
+	}
+}
+
+// TestWorkspaceScope_IsolatesHandlerWithoutCheck serves templates from a
+// handler that reads the repository directly and never compares workspaces;
+// the scope from ScopeToWorkspace must still hide other workspaces' rows.
+func TestWorkspaceScope_IsolatesHandlerWithoutCheck(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, workspace.Name)
+	otherAuth, otherWorkspace := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, otherWorkspace.Name)
+
+	created, status := CreateTemplate(t, auth, "Scoped Template", workspace.ID, defaultFiles())
+	if status != http.StatusCreated {
+		t.Fatalf("failed to create template: status %d", status)
+	}
+
+	templates := sqlite.NewRepositoryFactory().CreateTemplateRepository(sqlite.NewUnitOfWork(DbConnection))
+	app := fiber.New(fiber.Config{ErrorHandler: handlererrors.ErrorHandler()})
+	app.Use(middleware.RequireAuth(jwtSvc, jwt.DefaultCookieConfig()), middleware.ScopeToWorkspace())
+	app.Get("/templates/:id", func(c *fiber.Ctx) error {
+		template, err := templates.GetByID(middleware.ContextWithClaims(c), uuid.MustParse(c.Params("id")))
+		if err != nil {
+			return err
+		}
+		return c.JSON(template)
+	})
+	app.Get("/workspaces/:id/templates", func(c *fiber.Ctx) error {
+		list, err := templates.GetByWorkspaceID(middleware.ContextWithClaims(c), uuid.MustParse(c.Params("id")))
+		if err != nil {
+			return err
+		}
+		return c.JSON(list)
+	})
+
+	get := func(caller AuthContext, path string) (int, string) {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		addAuth(t, req, caller)
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatalf("GET %s failed: %v", path, err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(body)
+	}
+
+	if status, body := get(auth, "/templates/"+created.ID.String()); status != http.StatusOK {
+		t.Errorf("owner: expected 200, got %d: %s", status, body)
+	}
+	if status, _ := get(otherAuth, "/templates/"+created.ID.String()); status != http.StatusNotFound {
+		t.Errorf("other workspace: expected 404, got %d", status)
+	}
+
+	status, body := get(otherAuth, "/workspaces/"+workspace.ID.String()+"/templates")
+	var listed []json.RawMessage
+	if err := json.Unmarshal([]byte(body), &listed); status != http.StatusOK || err != nil || len(listed) != 0 {
+		t.Errorf("other workspace listing: expected 200 with [], got %d with %s", status, body)
+	}
+}
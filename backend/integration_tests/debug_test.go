@@ -0,0 +1,55 @@
+package integration_tests
+
+import (
+	"net/http"
+	"testing"
+
+	"backend/pkg/buildinfo"
+
+	"github.com/google/uuid"
+)
+
+func TestDebugInfo_AdminSeesBuildInfo(t *testing.T) {
+	original := buildinfo.Version
+	buildinfo.Version = "v9.9.9-test"
+	defer func() { buildinfo.Version = original }()
+
+	auth := AuthContext{
+		UserID:      uuid.New(),
+		UserName:    "Debug Admin",
+		Role:        "admin",
+		WorkspaceID: uuid.New(),
+	}
+
+	info, status := GetDebugInfo(t, auth)
+	if status != http.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+
+	if info.Version != "v9.9.9-test" {
+		t.Errorf("expected version 'v9.9.9-test', got '%s'", info.Version)
+	}
+	if info.UptimeSeconds <= 0 {
+		t.Errorf("expected positive uptime, got %f", info.UptimeSeconds)
+	}
+	if info.GoVersion == "" {
+		t.Error("expected go_version to be set")
+	}
+	if info.Goroutines <= 0 {
+		t.Errorf("expected positive goroutine count, got %d", info.Goroutines)
+	}
+}
+
+func TestDebugInfo_NonAdminForbidden(t *testing.T) {
+	auth := AuthContext{
+		UserID:      uuid.New(),
+		UserName:    "Debug User",
+		Role:        "user",
+		WorkspaceID: uuid.New(),
+	}
+
+	_, status := GetDebugInfo(t, auth)
+	if status != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d", status)
+	}
+}
@@ -0,0 +1,235 @@
+package integration_tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// setupAdministeredWorkspace creates a workspace administered by the user of
+// the returned auth context.
+func setupAdministeredWorkspace(t *testing.T) (AuthContext, *WorkspaceResponse) {
+	t.Helper()
+
+	auth := AuthContext{UserID: uuid.New(), UserName: "Deleted WS Admin", Role: "admin", WorkspaceID: uuid.New()}
+	workspace := createAdministeredWorkspace(t, auth)
+	auth.WorkspaceID = workspace.ID
+	return auth, workspace
+}
+
+// findDeleted returns the workspace with id from the first page of deleted
+// workspaces, or nil. Deleting a workspace puts it at the top of the list.
+func findDeleted(t *testing.T, auth AuthContext, id uuid.UUID) *DeletedWorkspaceResponse {
+	t.Helper()
+
+	page, status := AdminListDeletedWorkspaces(t, auth, 100, 0)
+	if status != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", status)
+	}
+	for _, w := range page.Items {
+		if w.ID == id {
+			return w
+		}
+	}
+	return nil
+}
+
+func TestListDeletedWorkspaces_OnlyDeletedAppear(t *testing.T) {
+	auth, live := setupAdministeredWorkspace(t)
+	defer TearDownWorkspace(t, live.Name)
+	deleted := createAdministeredWorkspace(t, auth)
+	defer TearDownWorkspace(t, deleted.Name)
+
+	if status := DeleteWorkspace(t, auth, deleted.ID); status != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", status)
+	}
+
+	page, status := AdminListDeletedWorkspaces(t, auth, 100, 0)
+	if status != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", status)
+	}
+	if page.Total < 1 || page.Limit != 100 || page.Offset != 0 {
+		t.Errorf("unexpected envelope: total=%d limit=%d offset=%d", page.Total, page.Limit, page.Offset)
+	}
+	for _, w := range page.Items {
+		if w.DeletedAt == nil {
+			t.Errorf("workspace %s listed without deleted_at", w.ID)
+		}
+		if w.ID == live.ID {
+			t.Errorf("live workspace %s must not be listed", live.ID)
+		}
+	}
+
+	found := findDeleted(t, auth, deleted.ID)
+	if found == nil {
+		t.Fatalf("expected deleted workspace %s in the list", deleted.ID)
+	}
+	if found.DeletedBy == nil || *found.DeletedBy != auth.UserID {
+		t.Errorf("expected deleted_by %s, got %v", auth.UserID, found.DeletedBy)
+	}
+}
+
+func TestListDeletedWorkspaces_RestoreRemovesFromList(t *testing.T) {
+	auth, workspace := setupAdministeredWorkspace(t)
+	defer TearDownWorkspace(t, workspace.Name)
+
+	if status := DeleteWorkspace(t, auth, workspace.ID); status != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", status)
+	}
+	if findDeleted(t, auth, workspace.ID) == nil {
+		t.Fatalf("expected deleted workspace %s in the list", workspace.ID)
+	}
+
+	restored, status := AdminRestoreWorkspace(t, auth, workspace.ID)
+	if status != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", status)
+	}
+	if restored.ID != workspace.ID {
+		t.Errorf("expected workspace %s, got %s", workspace.ID, restored.ID)
+	}
+
+	if findDeleted(t, auth, workspace.ID) != nil {
+		t.Errorf("restored workspace %s must not be listed", workspace.ID)
+	}
+	if _, status := GetWorkspace(t, auth, workspace.ID); status != http.StatusOK {
+		t.Errorf("expected restored workspace to be readable, got %d", status)
+	}
+
+	// Restoring a workspace that is not deleted is a miss
+	if _, status := AdminRestoreWorkspace(t, auth, workspace.ID); status != http.StatusNotFound {
+		t.Errorf("expected status 404 for a live workspace, got %d", status)
+	}
+}
+
+func TestListDeletedWorkspaces_OnlyCallersWorkspaces(t *testing.T) {
+	auth, workspace := setupAdministeredWorkspace(t)
+	defer TearDownWorkspace(t, workspace.Name)
+	otherAuth, other := setupAdministeredWorkspace(t)
+	defer TearDownWorkspace(t, other.Name)
+
+	if status := DeleteWorkspace(t, otherAuth, other.ID); status != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", status)
+	}
+
+	page, status := AdminListDeletedWorkspaces(t, auth, 100, 0)
+	if status != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", status)
+	}
+	if page.Total != 0 || len(page.Items) != 0 {
+		t.Errorf("expected no deleted workspaces for an admin who deleted none, got total %d", page.Total)
+	}
+
+	if _, status := AdminRestoreWorkspace(t, auth, other.ID); status != http.StatusNotFound {
+		t.Errorf("expected status 404 restoring another admin's workspace, got %d", status)
+	}
+	if findDeleted(t, otherAuth, other.ID) == nil {
+		t.Errorf("expected the refused restore to leave workspace %s deleted", other.ID)
+	}
+}
+
+func TestListDeletedWorkspaces_AdminOnly(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, workspace.Name)
+	auth.Role = "editor"
+
+	if _, status := AdminListDeletedWorkspaces(t, auth, 10, 0); status != http.StatusForbidden {
+		t.Errorf("expected status 403 for editor, got %d", status)
+	}
+}
+
+// listWorkspacesByID lists the workspaces at path, keyed by ID.
+func listWorkspacesByID(t *testing.T, auth AuthContext, path string) map[uuid.UUID]*DeletedWorkspaceResponse {
+	t.Helper()
+
+	status, body := getRawList(t, auth, path)
+	if status != http.StatusOK {
+		t.Fatalf("GET %s: expected status 200, got %d: %s", path, status, body)
+	}
+	var workspaces []*DeletedWorkspaceResponse
+	if err := json.Unmarshal([]byte(body), &workspaces); err != nil {
+		t.Fatalf("GET %s: decode: %v", path, err)
+	}
+	byID := make(map[uuid.UUID]*DeletedWorkspaceResponse, len(workspaces))
+	for _, w := range workspaces {
+		byID[w.ID] = w
+	}
+	return byID
+}
+
+func TestListWorkspaces_IncludeDeleted(t *testing.T) {
+	auth, live := setupAdministeredWorkspace(t)
+	defer TearDownWorkspace(t, live.Name)
+	deleted := createAdministeredWorkspace(t, auth)
+	defer TearDownWorkspace(t, deleted.Name)
+
+	if status := DeleteWorkspace(t, auth, deleted.ID); status != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", status)
+	}
+
+	normal := listWorkspacesByID(t, auth, "/api/v1/workspaces?limit=100")
+	if _, ok := normal[deleted.ID]; ok {
+		t.Errorf("deleted workspace %s must not appear in a normal list", deleted.ID)
+	}
+	if _, ok := normal[live.ID]; !ok {
+		t.Errorf("expected live workspace %s in a normal list", live.ID)
+	}
+
+	all := listWorkspacesByID(t, auth, "/api/v1/workspaces?limit=100&include_deleted=true")
+	if w, ok := all[deleted.ID]; !ok {
+		t.Errorf("expected deleted workspace %s with include_deleted", deleted.ID)
+	} else if w.DeletedAt == nil {
+		t.Errorf("expected deleted workspace %s to carry deleted_at", deleted.ID)
+	}
+	if w, ok := all[live.ID]; !ok {
+		t.Errorf("expected live workspace %s with include_deleted", live.ID)
+	} else if w.DeletedAt != nil {
+		t.Errorf("live workspace %s must not carry deleted_at", live.ID)
+	}
+}
+
+func TestListWorkspaces_IncludeDeletedOnlyOwnTenant(t *testing.T) {
+	auth, workspace := setupAdministeredWorkspace(t)
+	defer TearDownWorkspace(t, workspace.Name)
+	otherAuth, otherWorkspace := setupAdministeredWorkspace(t)
+	defer TearDownWorkspace(t, otherWorkspace.Name)
+
+	own := createAdministeredWorkspace(t, auth)
+	defer TearDownWorkspace(t, own.Name)
+	foreign := createAdministeredWorkspace(t, otherAuth)
+	defer TearDownWorkspace(t, foreign.Name)
+	if status := DeleteWorkspace(t, auth, own.ID); status != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", status)
+	}
+	if status := DeleteWorkspace(t, otherAuth, foreign.ID); status != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", status)
+	}
+
+	for _, path := range []string{
+		"/api/v1/workspaces?limit=100&include_deleted=true",
+		"/api/v1/workspaces?limit=100&include_deleted=true&stream=true",
+	} {
+		all := listWorkspacesByID(t, auth, path)
+		if _, ok := all[own.ID]; !ok {
+			t.Errorf("GET %s: expected the caller's deleted workspace %s", path, own.ID)
+		}
+		if _, ok := all[foreign.ID]; ok {
+			t.Errorf("GET %s: another admin's deleted workspace %s must not appear", path, foreign.ID)
+		}
+		if _, ok := all[otherWorkspace.ID]; !ok {
+			t.Errorf("GET %s: expected the live workspace %s", path, otherWorkspace.ID)
+		}
+	}
+}
+
+func TestListWorkspaces_IncludeDeletedRequiresAdmin(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, workspace.Name)
+
+	auth.Role = "editor"
+	status, _ := getRawList(t, auth, "/api/v1/workspaces?include_deleted=true")
+	if status != http.StatusForbidden {
+		t.Errorf("expected status 403 for a non-admin, got %d", status)
+	}
+}
@@ -0,0 +1,67 @@
+package integration_tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// postGroupRaw posts body verbatim to the group create endpoint.
+func postGroupRaw(t *testing.T, auth AuthContext, body string) *http.Response {
+	t.Helper()
+
+	req, _ := http.NewRequest(http.MethodPost, BaseURL+"/api/v1/groups", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	addAuth(t, req, auth)
+
+	resp, err := HTTPClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to post group: %v", err)
+	}
+	return resp
+}
+
+func TestRequestBody_DuplicateKeysRejected(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, workspace.Name)
+
+	resp := postGroupRaw(t, auth, `{"name":"Visible","description":"","name":"Hidden"}`)
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", resp.StatusCode)
+	}
+	var errResp struct {
+		Error ErrorResponse `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&errResp); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+	if !strings.Contains(errResp.Error.Message, `"name"`) {
+		t.Errorf("expected the message to name the duplicated key, got %q", errResp.Error.Message)
+	}
+	if errResp.Error.Metadata["key"] != "name" {
+		t.Errorf("expected metadata key %q, got %v", "name", errResp.Error.Metadata["key"])
+	}
+
+	groups, status := ListGroups(t, auth)
+	if status != http.StatusOK {
+		t.Fatalf("failed to list groups: status %d", status)
+	}
+	if len(groups) != 0 {
+		t.Errorf("expected no group to be created, got %d", len(groups))
+	}
+}
+
+func TestRequestBody_DistinctKeysAccepted(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, workspace.Name)
+
+	resp := postGroupRaw(t, auth, `{"name":"Distinct","description":"no repeats"}`)
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", resp.StatusCode)
+	}
+}
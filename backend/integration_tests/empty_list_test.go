@@ -0,0 +1,115 @@
+package integration_tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"backend/internal/domain/repository"
+	"backend/internal/infra/sqlite"
+
+	"github.com/google/uuid"
+)
+
+// getRawList issues an authenticated GET and returns the status and the
+// whitespace-trimmed response body.
+func getRawList(t *testing.T, auth AuthContext, path string) (int, string) {
+	t.Helper()
+
+	req, _ := http.NewRequest(http.MethodGet, BaseURL+path, nil)
+	addAuth(t, req, auth)
+
+	resp, err := HTTPClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET %s failed: %v", path, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("GET %s: failed to read body: %v", path, err)
+	}
+	return resp.StatusCode, string(bytes.TrimSpace(body))
+}
+
+func TestListEndpoints_EmptyResultsAreArrays(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, workspace.Name)
+
+	// Checked before any template exists in the workspace
+	status, body := getRawList(t, auth, fmt.Sprintf("/api/v1/templates/workspace/%s", workspace.ID))
+	if status != http.StatusOK || body != "[]" {
+		t.Errorf("templates by workspace: expected 200 with [], got %d with %s", status, body)
+	}
+
+	template, status := CreateTemplate(t, auth, "Empty Lists Template", workspace.ID, map[string]string{
+		"main.tf": `resource "null_resource" "noop" {}`,
+	})
+	if status != http.StatusCreated {
+		t.Fatalf("failed to create template: status %d", status)
+	}
+	group, status := CreateGroup(t, auth, "Empty Lists Group", "", false)
+	if status != http.StatusCreated {
+		t.Fatalf("failed to create group: status %d", status)
+	}
+	defer TearDownGroups(t, workspace.ID)
+
+	paths := []string{
+		"/api/v1/workspaces?offset=100000",
+		"/api/v1/workspaces?offset=100000&stream=true",
+		fmt.Sprintf("/api/v1/workspaces/admin/%s", uuid.New()),
+		"/api/v1/templates?filter.name=does-not-exist",
+		"/api/v1/templates?filter.name=does-not-exist&stream=true",
+		fmt.Sprintf("/api/v1/templates/%s/variables", template.ID),
+		"/api/v1/admin/users",
+		fmt.Sprintf("/api/v1/groups/%s/members", group.ID),
+		fmt.Sprintf("/api/v1/groups/%s/templates", group.ID),
+	}
+
+	for _, path := range paths {
+		t.Run(path, func(t *testing.T) {
+			status, body := getRawList(t, auth, path)
+			if status != http.StatusOK {
+				t.Fatalf("expected status 200, got %d: %s", status, body)
+			}
+			if body != "[]" {
+				t.Errorf("expected [], got %s", body)
+			}
+		})
+	}
+}
+
+func TestListRepositories_EmptyResultsAreNonNil(t *testing.T) {
+	ctx := context.Background()
+	uow := sqlite.NewUnitOfWork(DbConnection)
+	factory := sqlite.NewRepositoryFactory()
+	opts := repository.ListOptions{Filters: map[string]any{"id": uuid.New()}}
+
+	users, err := factory.CreateUserRepository(uow).List(ctx, opts)
+	if err != nil {
+		t.Fatalf("failed to list users: %v", err)
+	}
+	if data, _ := json.Marshal(users); string(data) != "[]" {
+		t.Errorf("expected users to marshal as [], got %s", data)
+	}
+
+	environments, err := factory.CreateEnvironmentRepository(uow).ListFiltered(ctx, repository.EnvironmentListOptions{WorkspaceID: uuid.New()})
+	if err != nil {
+		t.Fatalf("failed to list environments: %v", err)
+	}
+	if data, _ := json.Marshal(environments); string(data) != "[]" {
+		t.Errorf("expected environments to marshal as [], got %s", data)
+	}
+
+	groups, err := factory.CreateGroupRepository(uow).GetByWorkspaceID(ctx, uuid.New())
+	if err != nil {
+		t.Fatalf("failed to list groups: %v", err)
+	}
+	if data, _ := json.Marshal(groups); string(data) != "[]" {
+		t.Errorf("expected groups to marshal as [], got %s", data)
+	}
+}
@@ -0,0 +1,123 @@
+package integration_tests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"backend/internal/domain"
+	"backend/internal/infra/sqlite"
+
+	"github.com/google/uuid"
+)
+
+type EnvironmentPageResponse struct {
+	Items []struct {
+		ID          uuid.UUID `json:"id"`
+		Name        string    `json:"name"`
+		WorkspaceID uuid.UUID `json:"workspace_id"`
+	} `json:"items"`
+	Total  int `json:"total"`
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+}
+
+// setupEnvironments creates a workspace with a real user and template, and
+// count environments owned by that user. It returns the user's auth context.
+func setupEnvironments(t *testing.T, count int) (AuthContext, *WorkspaceResponse) {
+	t.Helper()
+
+	admin, workspace := setupWorkspaceForTemplates(t)
+	t.Cleanup(func() { TearDownWorkspace(t, workspace.Name) })
+
+	template, status := CreateTemplate(t, admin, "Env List Template "+uuid.New().String()[:8], workspace.ID, defaultFiles())
+	if status != http.StatusCreated {
+		t.Fatalf("failed to create template: status %d", status)
+	}
+
+	user, status := CreateUser(t, "Env Lister", "env-list-"+uuid.New().String()[:8]+"@example.com", "SecureP@ssw0rd!", workspace.ID)
+	if status != http.StatusCreated {
+		t.Fatalf("failed to create user: status %d", status)
+	}
+
+	repo := sqlite.NewRepositoryFactory().CreateEnvironmentRepository(sqlite.NewUnitOfWork(DbConnection))
+	for i := 0; i < count; i++ {
+		env := domain.NewEnvironment(domain.RandomIDGenerator{}, fmt.Sprintf("env-%02d", i), "", user.UserID, workspace.ID, template.ID, nil)
+		if err := repo.Create(context.Background(), env); err != nil {
+			t.Fatalf("failed to create environment: %v", err)
+		}
+	}
+
+	return AuthContext{UserID: user.UserID, UserName: "Env Lister", Role: "user", WorkspaceID: workspace.ID}, workspace
+}
+
+func listEnvironmentsPage(t *testing.T, auth AuthContext, query string) EnvironmentPageResponse {
+	t.Helper()
+
+	status, body := getRawList(t, auth, "/api/v1/environments?"+query)
+	if status != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", status, body)
+	}
+	var page EnvironmentPageResponse
+	if err := json.Unmarshal([]byte(body), &page); err != nil {
+		t.Fatalf("failed to decode page %s: %v", body, err)
+	}
+	return page
+}
+
+func TestListEnvironments_Paginated(t *testing.T) {
+	auth, _ := setupEnvironments(t, 5)
+
+	first := listEnvironmentsPage(t, auth, "limit=2&sort_by=name&order=ASC")
+	if first.Total != 5 || first.Limit != 2 || first.Offset != 0 {
+		t.Errorf("expected total 5, limit 2, offset 0, got %d, %d, %d", first.Total, first.Limit, first.Offset)
+	}
+	if len(first.Items) != 2 || first.Items[0].Name != "env-00" || first.Items[1].Name != "env-01" {
+		t.Fatalf("unexpected first page: %+v", first.Items)
+	}
+
+	last := listEnvironmentsPage(t, auth, "limit=2&offset=4&sort_by=name&order=ASC")
+	if last.Total != 5 || len(last.Items) != 1 || last.Items[0].Name != "env-04" {
+		t.Errorf("unexpected last page: total %d, items %+v", last.Total, last.Items)
+	}
+}
+
+func TestListEnvironments_EmptyResults(t *testing.T) {
+	auth, _ := setupEnvironments(t, 0)
+
+	status, body := getRawList(t, auth, "/api/v1/environments")
+	if status != http.StatusOK || body != "[]" {
+		t.Errorf("legacy list: expected 200 with [], got %d with %s", status, body)
+	}
+
+	status, body = getRawList(t, auth, "/api/v1/environments?limit=10")
+	if status != http.StatusOK {
+		t.Fatalf("paged list: expected 200, got %d: %s", status, body)
+	}
+	if want := `{"items":[],"total":0,"limit":10,"offset":0}`; body != want {
+		t.Errorf("paged list: expected %s, got %s", want, body)
+	}
+}
+
+func TestListEnvironments_OtherWorkspaceNeverLeaks(t *testing.T) {
+	auth, workspace := setupEnvironments(t, 2)
+	otherAuth, _ := setupEnvironments(t, 3)
+	otherAuth.Role = "admin"
+
+	page := listEnvironmentsPage(t, otherAuth, "limit=100&scope=all")
+	if page.Total != 3 || len(page.Items) != 3 {
+		t.Fatalf("expected only the 3 environments of the caller's workspace, got total %d, %d items", page.Total, len(page.Items))
+	}
+	for _, env := range page.Items {
+		if env.WorkspaceID == workspace.ID {
+			t.Errorf("environment %s from another workspace leaked into the listing", env.ID)
+		}
+	}
+
+	page = listEnvironmentsPage(t, auth, "limit=100")
+	if page.Total != 2 {
+		t.Errorf("expected 2 environments in the first workspace, got %d", page.Total)
+	}
+}
@@ -0,0 +1,163 @@
+package integration_tests
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"backend/internal/domain/repository"
+	"backend/internal/infra/sqlite"
+
+	"github.com/google/uuid"
+)
+
+// environmentRequest sends a bodyless request for an environment and returns
+// the status and body.
+func environmentRequest(t *testing.T, auth AuthContext, method, path string) (int, string) {
+	t.Helper()
+
+	req, _ := http.NewRequest(method, BaseURL+"/api/v1/environments/"+path, nil)
+	addAuth(t, req, auth)
+
+	resp, err := HTTPClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s failed: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(body)
+}
+
+func environmentRepository() repository.EnvironmentRepository {
+	return sqlite.NewRepositoryFactory().CreateEnvironmentRepository(sqlite.NewUnitOfWork(DbConnection))
+}
+
+func TestEnvironmentSoftDelete_HiddenFromReads(t *testing.T) {
+	auth, workspace := setupEnvironments(t, 2)
+	page := listEnvironmentsPage(t, auth, "limit=10&sort_by=name&order=ASC")
+	deleted, kept := page.Items[0], page.Items[1]
+
+	repo := environmentRepository()
+	ctx := context.Background()
+	if err := repo.Delete(ctx, deleted.ID); err != nil {
+		t.Fatalf("failed to delete environment: %v", err)
+	}
+
+	if status, _ := environmentRequest(t, auth, http.MethodGet, deleted.ID.String()); status != http.StatusNotFound {
+		t.Errorf("get deleted environment: expected 404, got %d", status)
+	}
+	if status, _ := environmentRequest(t, auth, http.MethodGet, kept.ID.String()); status != http.StatusOK {
+		t.Errorf("get kept environment: expected 200, got %d", status)
+	}
+
+	after := listEnvironmentsPage(t, auth, "limit=10")
+	if after.Total != 1 || len(after.Items) != 1 || after.Items[0].ID != kept.ID {
+		t.Errorf("expected only the kept environment to be listed, got %+v (total %d)", after.Items, after.Total)
+	}
+
+	byWorkspace, err := repo.GetByWorkspaceID(ctx, workspace.ID)
+	if err != nil {
+		t.Fatalf("GetByWorkspaceID failed: %v", err)
+	}
+	if len(byWorkspace) != 1 || byWorkspace[0].ID != kept.ID {
+		t.Errorf("expected GetByWorkspaceID to exclude the deleted environment, got %d", len(byWorkspace))
+	}
+	byTemplate, err := repo.GetByTemplateID(ctx, byWorkspace[0].TemplateID)
+	if err != nil {
+		t.Fatalf("GetByTemplateID failed: %v", err)
+	}
+	if len(byTemplate) != 1 || byTemplate[0].ID != kept.ID {
+		t.Errorf("expected GetByTemplateID to exclude the deleted environment, got %d", len(byTemplate))
+	}
+
+	if err := repo.Delete(ctx, deleted.ID); err == nil {
+		t.Error("expected deleting an already deleted environment to fail")
+	}
+}
+
+func TestEnvironmentRestore(t *testing.T) {
+	auth, _ := setupEnvironments(t, 1)
+	env := listEnvironmentsPage(t, auth, "limit=10").Items[0]
+
+	if status, _ := environmentRequest(t, auth, http.MethodPost, env.ID.String()+"/restore"); status != http.StatusNotFound {
+		t.Errorf("restore a live environment: expected 404, got %d", status)
+	}
+
+	if err := environmentRepository().Delete(context.Background(), env.ID); err != nil {
+		t.Fatalf("failed to delete environment: %v", err)
+	}
+
+	otherAuth, other := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, other.Name)
+	if status, _ := environmentRequest(t, otherAuth, http.MethodPost, env.ID.String()+"/restore"); status != http.StatusNotFound {
+		t.Errorf("restore from another workspace: expected 404, got %d", status)
+	}
+
+	status, body := environmentRequest(t, auth, http.MethodPost, env.ID.String()+"/restore")
+	if status != http.StatusOK {
+		t.Fatalf("restore: expected 200, got %d: %s", status, body)
+	}
+	var restored struct {
+		ID uuid.UUID `json:"id"`
+	}
+	if err := json.Unmarshal([]byte(body), &restored); err != nil || restored.ID != env.ID {
+		t.Errorf("expected the restored environment in the response, got %s", body)
+	}
+
+	if status, _ := environmentRequest(t, auth, http.MethodGet, env.ID.String()); status != http.StatusOK {
+		t.Errorf("get restored environment: expected 200, got %d", status)
+	}
+	if page := listEnvironmentsPage(t, auth, "limit=10"); page.Total != 1 {
+		t.Errorf("expected the restored environment to be listed, got total %d", page.Total)
+	}
+}
+
+func TestEnvironmentDelete_ThroughAPISoftDeletes(t *testing.T) {
+	auth, _ := setupEnvironments(t, 1)
+	env := listEnvironmentsPage(t, auth, "limit=10").Items[0]
+
+	if status, body := environmentRequest(t, auth, http.MethodDelete, env.ID.String()); status != http.StatusNoContent {
+		t.Fatalf("delete: expected 204, got %d: %s", status, body)
+	}
+
+	// Destroy runs in the background before the environment is marked deleted
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		status, _ := environmentRequest(t, auth, http.MethodGet, env.ID.String())
+		if status == http.StatusNotFound {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("environment still readable after delete: status %d", status)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	if n := countRows(t, "SELECT COUNT(*) FROM environments WHERE id = ? AND deleted_at IS NOT NULL", env.ID); n != 1 {
+		t.Fatalf("expected the environment row to be kept as deleted, found %d", n)
+	}
+
+	if status, _ := environmentRequest(t, auth, http.MethodPost, env.ID.String()+"/restore"); status != http.StatusOK {
+		t.Errorf("restore: expected 200, got %d", status)
+	}
+}
+
+func TestEnvironmentRestore_ConflictWithLiveSibling(t *testing.T) {
+	// Both environments come from the same template and user
+	auth, _ := setupEnvironments(t, 2)
+	env := listEnvironmentsPage(t, auth, "limit=10").Items[0]
+
+	if err := environmentRepository().Delete(context.Background(), env.ID); err != nil {
+		t.Fatalf("failed to delete environment: %v", err)
+	}
+
+	if status, _ := environmentRequest(t, auth, http.MethodPost, env.ID.String()+"/restore"); status != http.StatusConflict {
+		t.Fatalf("restore: expected 409, got %d", status)
+	}
+	if status, _ := environmentRequest(t, auth, http.MethodGet, env.ID.String()); status != http.StatusNotFound {
+		t.Errorf("expected the refused restore to be rolled back, got %d", status)
+	}
+}
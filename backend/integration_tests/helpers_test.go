@@ -7,6 +7,8 @@ import (
 	"io"
 	"mime/multipart"
 	"net/http"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
 
@@ -18,9 +20,10 @@ type WorkspaceResponse struct {
 	ID          uuid.UUID `json:"id"`
 	Name        string    `json:"name"`
 	Description string    `json:"description"`
-	AdminID     uuid.UUID `json:"admin"`
+	AdminID     uuid.UUID `json:"admin_id"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
+	Version     int       `json:"version"`
 }
 
 type UserResponse struct {
@@ -160,6 +163,67 @@ func GetWorkspacesByAdmin(t *testing.T, auth AuthContext, adminID uuid.UUID) ([]
 	return nil, resp.StatusCode
 }
 
+func GetWorkspacesByIDs(t *testing.T, auth AuthContext, ids ...uuid.UUID) ([]*WorkspaceResponse, int) {
+	t.Helper()
+
+	raw := make([]string, len(ids))
+	for i, id := range ids {
+		raw[i] = id.String()
+	}
+	req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/api/v1/workspaces/batch?ids=%s", BaseURL, strings.Join(raw, ",")), nil)
+	addAuth(t, req, auth)
+
+	resp, err := HTTPClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to get workspaces by IDs: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		var workspaces []*WorkspaceResponse
+		if err := json.NewDecoder(resp.Body).Decode(&workspaces); err != nil {
+			t.Fatalf("failed to decode workspaces response: %v", err)
+		}
+		return workspaces, resp.StatusCode
+	}
+
+	return nil, resp.StatusCode
+}
+
+// UpdateWorkspaceAtVersion sends a workspace update guarded by version and
+// returns the raw response; the caller closes its body.
+func UpdateWorkspaceAtVersion(t *testing.T, auth AuthContext, id uuid.UUID, name string, version int) *http.Response {
+	t.Helper()
+
+	body, _ := json.Marshal(map[string]interface{}{"name": name, "version": version})
+	req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("%s/api/v1/workspaces/%s", BaseURL, id), bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	addAuth(t, req, auth)
+
+	resp, err := HTTPClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to update workspace: %v", err)
+	}
+	return resp
+}
+
+// UpdateWorkspaceAdmin hands a workspace to adminID and returns the status.
+func UpdateWorkspaceAdmin(t *testing.T, auth AuthContext, id, adminID uuid.UUID) int {
+	t.Helper()
+
+	body, _ := json.Marshal(map[string]interface{}{"admin_id": adminID})
+	req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("%s/api/v1/workspaces/%s", BaseURL, id), bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	addAuth(t, req, auth)
+
+	resp, err := HTTPClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to update workspace: %v", err)
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode
+}
+
 func UpdateWorkspace(t *testing.T, auth AuthContext, id uuid.UUID, name, description string) (*WorkspaceResponse, int) {
 	t.Helper()
 
@@ -274,16 +338,28 @@ func CreateUser(t *testing.T, name, email, password string, workspaceID uuid.UUI
 }
 
 type LoginResponse struct {
-	UserID uuid.UUID `json:"user_id"`
+	UserID      uuid.UUID `json:"user_id"`
+	WorkspaceID uuid.UUID `json:"workspace_id"`
+	Scope       string    `json:"scope"`
 }
 
 func LoginUser(t *testing.T, email, password string) (*http.Response, *LoginResponse, int) {
 	t.Helper()
+	return LoginUserWithScope(t, email, password, "")
+}
+
+// LoginUserWithScope logs in requesting a token limited to scope; an empty
+// scope uses the server default.
+func LoginUserWithScope(t *testing.T, email, password, scope string) (*http.Response, *LoginResponse, int) {
+	t.Helper()
 
 	payload := map[string]interface{}{
 		"email":    email,
 		"password": password,
 	}
+	if scope != "" {
+		payload["scope"] = scope
+	}
 
 	body, _ := json.Marshal(payload)
 	resp, err := HTTPClient.Post(
@@ -335,20 +411,51 @@ type TemplateResponse struct {
 	Name        string    `json:"name"`
 	WorkspaceID uuid.UUID `json:"workspace_id"`
 	Path        string    `json:"path"`
+	RepoURL     string    `json:"repo_url"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 }
 
+// UpdatedTemplateResponse is the template update response, which also says
+// whether the update changed anything.
+type UpdatedTemplateResponse struct {
+	TemplateResponse
+	Modified bool `json:"modified"`
+}
+
+type PathCheckResponse struct {
+	Target     string `json:"target"`
+	Scheme     string `json:"scheme"`
+	Reachable  bool   `json:"reachable"`
+	StatusCode int    `json:"status_code"`
+	Detail     string `json:"detail"`
+}
+
+type ValidateTemplatePathResponse struct {
+	TemplateID uuid.UUID           `json:"template_id"`
+	Reachable  bool                `json:"reachable"`
+	Checks     []PathCheckResponse `json:"checks"`
+}
+
 // CreateTemplate sends a multipart form request to create a template with files.
 // The files parameter maps filename to content (e.g., {"main.tf": "resource {}"}).
 func CreateTemplate(t *testing.T, auth AuthContext, name string, workspaceID uuid.UUID, files map[string]string) (*TemplateResponse, int) {
 	t.Helper()
+	return CreateTemplateWithRepoURL(t, auth, name, workspaceID, "", files)
+}
+
+// CreateTemplateWithRepoURL is like CreateTemplate but also sends repo_url when non-empty.
+func CreateTemplateWithRepoURL(t *testing.T, auth AuthContext, name string, workspaceID uuid.UUID, repoURL string, files map[string]string) (*TemplateResponse, int) {
+	t.Helper()
 
 	var buf bytes.Buffer
 	writer := multipart.NewWriter(&buf)
 
 	writer.WriteField("name", name)
 	writer.WriteField("workspace_id", workspaceID.String())
+	if repoURL != "" {
+		writer.WriteField("repo_url", repoURL)
+	}
 
 	for filename, content := range files {
 		writer.WriteField("paths", filename)
@@ -415,6 +522,42 @@ func CreateTemplateRaw(t *testing.T, auth AuthContext, name string, workspaceID
 	return resp, resp.StatusCode
 }
 
+// CreateTemplateWithFields sends a template creation request with exactly the
+// given form fields, so tests can omit or malform individual fields.
+func CreateTemplateWithFields(t *testing.T, auth AuthContext, fields map[string]string, files map[string]string) int {
+	t.Helper()
+
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+
+	for key, value := range fields {
+		writer.WriteField(key, value)
+	}
+
+	for filename, content := range files {
+		writer.WriteField("paths", filename)
+		part, err := writer.CreateFormFile("files", filename)
+		if err != nil {
+			t.Fatalf("failed to create form file: %v", err)
+		}
+		part.Write([]byte(content))
+	}
+
+	writer.Close()
+
+	req, _ := http.NewRequest(http.MethodPost, BaseURL+"/api/v1/templates", &buf)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	addAuth(t, req, auth)
+
+	resp, err := HTTPClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to create template: %v", err)
+	}
+	defer resp.Body.Close()
+
+	return resp.StatusCode
+}
+
 func GetTemplate(t *testing.T, auth AuthContext, id uuid.UUID) (*TemplateResponse, int) {
 	t.Helper()
 
@@ -438,6 +581,29 @@ func GetTemplate(t *testing.T, auth AuthContext, id uuid.UUID) (*TemplateRespons
 	return nil, resp.StatusCode
 }
 
+func ValidateTemplatePath(t *testing.T, auth AuthContext, id uuid.UUID) (*ValidateTemplatePathResponse, int) {
+	t.Helper()
+
+	req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/api/v1/templates/%s/validate-path", BaseURL, id), nil)
+	addAuth(t, req, auth)
+
+	resp, err := HTTPClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to validate template path: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		var result ValidateTemplatePathResponse
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			t.Fatalf("failed to decode validate path response: %v", err)
+		}
+		return &result, resp.StatusCode
+	}
+
+	return nil, resp.StatusCode
+}
+
 func GetTemplatesByWorkspace(t *testing.T, auth AuthContext, workspaceID uuid.UUID) ([]*TemplateResponse, int) {
 	t.Helper()
 
@@ -461,7 +627,39 @@ func GetTemplatesByWorkspace(t *testing.T, auth AuthContext, workspaceID uuid.UU
 	return nil, resp.StatusCode
 }
 
-func UpdateTemplate(t *testing.T, auth AuthContext, id uuid.UUID, name string, files ...map[string]string) (*TemplateResponse, int) {
+type PagedTemplatesResponse struct {
+	Items  []*TemplateResponse `json:"items"`
+	Total  int                 `json:"total"`
+	Limit  int                 `json:"limit"`
+	Offset int                 `json:"offset"`
+}
+
+// GetTemplatesByWorkspacePage lists a workspace's templates with the given
+// query string, which must include a limit to get the paged envelope.
+func GetTemplatesByWorkspacePage(t *testing.T, auth AuthContext, workspaceID uuid.UUID, query string) (*PagedTemplatesResponse, int) {
+	t.Helper()
+
+	req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/api/v1/templates/workspace/%s?%s", BaseURL, workspaceID, query), nil)
+	addAuth(t, req, auth)
+
+	resp, err := HTTPClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to get templates by workspace: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		var page PagedTemplatesResponse
+		if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+			t.Fatalf("failed to decode paged templates response: %v", err)
+		}
+		return &page, resp.StatusCode
+	}
+
+	return nil, resp.StatusCode
+}
+
+func UpdateTemplate(t *testing.T, auth AuthContext, id uuid.UUID, name string, files ...map[string]string) (*UpdatedTemplateResponse, int) {
 	t.Helper()
 
 	var buf bytes.Buffer
@@ -495,7 +693,38 @@ func UpdateTemplate(t *testing.T, auth AuthContext, id uuid.UUID, name string, f
 	defer resp.Body.Close()
 
 	if resp.StatusCode == http.StatusOK {
-		var template TemplateResponse
+		var template UpdatedTemplateResponse
+		if err := json.NewDecoder(resp.Body).Decode(&template); err != nil {
+			t.Fatalf("failed to decode template response: %v", err)
+		}
+		return &template, resp.StatusCode
+	}
+
+	return nil, resp.StatusCode
+}
+
+// UpdateTemplateRepoURL sends only repo_url, even when empty, to update or
+// clear a template's repository URL.
+func UpdateTemplateRepoURL(t *testing.T, auth AuthContext, id uuid.UUID, repoURL string) (*UpdatedTemplateResponse, int) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	writer.WriteField("repo_url", repoURL)
+	writer.Close()
+
+	req, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("%s/api/v1/templates/%s", BaseURL, id), &buf)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	addAuth(t, req, auth)
+
+	resp, err := HTTPClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to update template: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		var template UpdatedTemplateResponse
 		if err := json.NewDecoder(resp.Body).Decode(&template); err != nil {
 			t.Fatalf("failed to decode template response: %v", err)
 		}
@@ -548,6 +777,12 @@ func ListTemplateFiles(t *testing.T, auth AuthContext, templateID uuid.UUID) ([]
 	return nil, resp.StatusCode
 }
 
+type TemplateFileContentResponse struct {
+	Path     string `json:"path"`
+	Language string `json:"language"`
+	Content  string `json:"content"`
+}
+
 func GetTemplateFileContent(t *testing.T, auth AuthContext, templateID uuid.UUID, path string) (string, int) {
 	t.Helper()
 
@@ -561,8 +796,17 @@ func GetTemplateFileContent(t *testing.T, auth AuthContext, templateID uuid.UUID
 	}
 	defer resp.Body.Close()
 
-	bodyBytes, _ := io.ReadAll(resp.Body)
-	return string(bodyBytes), resp.StatusCode
+	if resp.StatusCode != http.StatusOK {
+		return "", resp.StatusCode
+	}
+	var file TemplateFileContentResponse
+	if err := json.NewDecoder(resp.Body).Decode(&file); err != nil {
+		t.Fatalf("failed to decode template file content: %v", err)
+	}
+	if file.Path != path {
+		t.Fatalf("expected path %q in the response, got %q", path, file.Path)
+	}
+	return file.Content, resp.StatusCode
 }
 
 func ListTemplates(t *testing.T, auth AuthContext, limit, offset int, sortBy, order string) ([]*TemplateResponse, int) {
@@ -596,6 +840,61 @@ func ListTemplates(t *testing.T, auth AuthContext, limit, offset int, sortBy, or
 	return nil, resp.StatusCode
 }
 
+// ListTemplatesWithFilters lists templates with filter.<column>=<value> query parameters.
+func ListTemplatesWithFilters(t *testing.T, auth AuthContext, limit, offset int, filters map[string]string) ([]*TemplateResponse, int) {
+	t.Helper()
+
+	query := url.Values{}
+	query.Set("limit", fmt.Sprint(limit))
+	query.Set("offset", fmt.Sprint(offset))
+	for column, value := range filters {
+		query.Set("filter."+column, value)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, BaseURL+"/api/v1/templates?"+query.Encode(), nil)
+	addAuth(t, req, auth)
+
+	resp, err := HTTPClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to list templates: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		var templates []*TemplateResponse
+		if err := json.NewDecoder(resp.Body).Decode(&templates); err != nil {
+			t.Fatalf("failed to decode templates response: %v", err)
+		}
+		return templates, resp.StatusCode
+	}
+
+	return nil, resp.StatusCode
+}
+
+// ListTemplatesWithAccept lists templates with the given Accept header and
+// returns the raw response body and content type.
+func ListTemplatesWithAccept(t *testing.T, auth AuthContext, accept string) (string, string, int) {
+	t.Helper()
+
+	req, _ := http.NewRequest(http.MethodGet, BaseURL+"/api/v1/templates", nil)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	addAuth(t, req, auth)
+
+	resp, err := HTTPClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to list templates: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read templates response: %v", err)
+	}
+	return string(body), resp.Header.Get("Content-Type"), resp.StatusCode
+}
+
 // Admin helpers
 
 type AdminInitResponse struct {
@@ -655,11 +954,12 @@ type ResetPasswordResponse struct {
 }
 
 type AdminUserListResponse struct {
-	ID          uuid.UUID `json:"id"`
-	Name        string    `json:"name"`
-	Email       string    `json:"email"`
-	Role        string    `json:"role"`
-	WorkspaceID uuid.UUID `json:"workspace_id"`
+	ID           uuid.UUID  `json:"id"`
+	Name         string     `json:"name"`
+	Email        string     `json:"email"`
+	Role         string     `json:"role"`
+	WorkspaceID  uuid.UUID  `json:"workspace_id"`
+	LastActiveAt *time.Time `json:"last_active_at"`
 }
 
 func AdminInviteUser(t *testing.T, auth AuthContext, name, email, role string) (*InviteUserResponse, int) {
@@ -693,6 +993,48 @@ func AdminInviteUser(t *testing.T, auth AuthContext, name, email, role string) (
 	return nil, resp.StatusCode
 }
 
+type BatchItemError struct {
+	Code     string                 `json:"code"`
+	Message  string                 `json:"message"`
+	Metadata map[string]interface{} `json:"metadata"`
+}
+
+type BatchInviteResult struct {
+	Index  int                 `json:"index"`
+	Status int                 `json:"status"`
+	Data   *InviteUserResponse `json:"data"`
+	Error  *BatchItemError     `json:"error"`
+}
+
+type BatchInviteResponse struct {
+	Results   []BatchInviteResult `json:"results"`
+	Succeeded int                 `json:"succeeded"`
+	Failed    int                 `json:"failed"`
+}
+
+// AdminInviteUsers invites users through the batch endpoint. Each user is a
+// map with name, email and role keys.
+func AdminInviteUsers(t *testing.T, auth AuthContext, users []map[string]string) (*BatchInviteResponse, int) {
+	t.Helper()
+
+	body, _ := json.Marshal(map[string]interface{}{"users": users})
+	req, _ := http.NewRequest(http.MethodPost, BaseURL+"/api/v1/admin/users/invite/batch", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	addAuth(t, req, auth)
+
+	resp, err := HTTPClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to invite users: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var batch BatchInviteResponse
+	if err := json.NewDecoder(resp.Body).Decode(&batch); err != nil || batch.Results == nil {
+		return nil, resp.StatusCode
+	}
+	return &batch, resp.StatusCode
+}
+
 func AdminResetUserPassword(t *testing.T, auth AuthContext, userID uuid.UUID) (*ResetPasswordResponse, int) {
 	t.Helper()
 
@@ -739,6 +1081,313 @@ func AdminListUsers(t *testing.T, auth AuthContext) ([]*AdminUserListResponse, i
 	return nil, resp.StatusCode
 }
 
+type PagedAdminUsersResponse struct {
+	Items  []*AdminUserListResponse `json:"items"`
+	Total  int                      `json:"total"`
+	Limit  int                      `json:"limit"`
+	Offset int                      `json:"offset"`
+}
+
+// AdminListInactiveUsers lists one page of users with the inactive_since
+// filter. A zero limit uses the server's default page size.
+func AdminListInactiveUsers(t *testing.T, auth AuthContext, since string, limit, offset int) (*PagedAdminUsersResponse, int) {
+	t.Helper()
+
+	path := fmt.Sprintf("%s/api/v1/admin/users?inactive_since=%s&offset=%d", BaseURL, url.QueryEscape(since), offset)
+	if limit > 0 {
+		path += fmt.Sprintf("&limit=%d", limit)
+	}
+	req, _ := http.NewRequest(http.MethodGet, path, nil)
+	addAuth(t, req, auth)
+
+	resp, err := HTTPClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to list inactive users: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		var page PagedAdminUsersResponse
+		if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+			t.Fatalf("failed to decode inactive users response: %v", err)
+		}
+		return &page, resp.StatusCode
+	}
+
+	return nil, resp.StatusCode
+}
+
+func AdminGetUserByEmail(t *testing.T, auth AuthContext, email string) (*AdminUserListResponse, int) {
+	t.Helper()
+
+	req, _ := http.NewRequest(http.MethodGet, BaseURL+"/api/v1/admin/users/by-email?email="+url.QueryEscape(email), nil)
+	addAuth(t, req, auth)
+
+	resp, err := HTTPClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to get user by email: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		var user AdminUserListResponse
+		if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
+			t.Fatalf("failed to decode user response: %v", err)
+		}
+		return &user, resp.StatusCode
+	}
+
+	return nil, resp.StatusCode
+}
+
+type DeletedWorkspaceResponse struct {
+	WorkspaceResponse
+	DeletedAt *time.Time `json:"deleted_at"`
+	DeletedBy *uuid.UUID `json:"deleted_by"`
+}
+
+type PagedDeletedWorkspacesResponse struct {
+	Items  []*DeletedWorkspaceResponse `json:"items"`
+	Total  int                         `json:"total"`
+	Limit  int                         `json:"limit"`
+	Offset int                         `json:"offset"`
+}
+
+func AdminListDeletedWorkspaces(t *testing.T, auth AuthContext, limit, offset int) (*PagedDeletedWorkspacesResponse, int) {
+	t.Helper()
+
+	req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/api/v1/admin/workspaces/deleted?limit=%d&offset=%d", BaseURL, limit, offset), nil)
+	addAuth(t, req, auth)
+
+	resp, err := HTTPClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to list deleted workspaces: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		var page PagedDeletedWorkspacesResponse
+		if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+			t.Fatalf("failed to decode deleted workspaces response: %v", err)
+		}
+		return &page, resp.StatusCode
+	}
+
+	return nil, resp.StatusCode
+}
+
+func AdminRestoreWorkspace(t *testing.T, auth AuthContext, id uuid.UUID) (*WorkspaceResponse, int) {
+	t.Helper()
+
+	req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/api/v1/admin/workspaces/%s/restore", BaseURL, id), nil)
+	addAuth(t, req, auth)
+
+	resp, err := HTTPClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to restore workspace: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		var workspace WorkspaceResponse
+		if err := json.NewDecoder(resp.Body).Decode(&workspace); err != nil {
+			t.Fatalf("failed to decode workspace response: %v", err)
+		}
+		return &workspace, resp.StatusCode
+	}
+
+	return nil, resp.StatusCode
+}
+
+type WorkspaceSecretResponse struct {
+	WorkspaceID uuid.UUID `json:"workspace_id"`
+	Secret      string    `json:"secret"`
+	RotatedAt   time.Time `json:"rotated_at"`
+}
+
+func RotateWorkspaceSecret(t *testing.T, auth AuthContext, workspaceID uuid.UUID) (*WorkspaceSecretResponse, int) {
+	t.Helper()
+
+	req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/api/v1/workspaces/%s/rotate-secret", BaseURL, workspaceID), nil)
+	addAuth(t, req, auth)
+
+	resp, err := HTTPClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to rotate workspace secret: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		var secret WorkspaceSecretResponse
+		if err := json.NewDecoder(resp.Body).Decode(&secret); err != nil {
+			t.Fatalf("failed to decode secret response: %v", err)
+		}
+		return &secret, resp.StatusCode
+	}
+
+	return nil, resp.StatusCode
+}
+
+func AdminMoveUser(t *testing.T, auth AuthContext, userID, workspaceID uuid.UUID) (*AdminUserListResponse, int) {
+	t.Helper()
+
+	body, _ := json.Marshal(map[string]string{"workspace_id": workspaceID.String()})
+	req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/api/v1/users/%s/move", BaseURL, userID), bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	addAuth(t, req, auth)
+
+	resp, err := HTTPClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to move user: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		var user AdminUserListResponse
+		if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
+			t.Fatalf("failed to decode moved user: %v", err)
+		}
+		return &user, resp.StatusCode
+	}
+
+	return nil, resp.StatusCode
+}
+
+// GetWithCookies sends a GET carrying the given cookies, e.g. those set by LoginUser.
+func GetWithCookies(t *testing.T, path string, cookies []*http.Cookie) int {
+	t.Helper()
+	return SendWithCookies(t, http.MethodGet, path, cookies)
+}
+
+// SendWithCookies sends a bodiless request carrying the given cookies.
+func SendWithCookies(t *testing.T, method, path string, cookies []*http.Cookie) int {
+	t.Helper()
+
+	req, _ := http.NewRequest(method, BaseURL+path, nil)
+	for _, cookie := range cookies {
+		req.AddCookie(cookie)
+	}
+
+	resp, err := HTTPClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	return resp.StatusCode
+}
+
+// API key helpers
+
+type APIKeyResponse struct {
+	ID          uuid.UUID `json:"id"`
+	WorkspaceID uuid.UUID `json:"workspace_id"`
+	Name        string    `json:"name"`
+	Role        string    `json:"role"`
+	Scope       string    `json:"scope"`
+	KeyPrefix   string    `json:"key_prefix"`
+	Key         string    `json:"key"`
+}
+
+func CreateAPIKey(t *testing.T, auth AuthContext, workspaceID uuid.UUID, name, role string) (*APIKeyResponse, int) {
+	t.Helper()
+	return CreateScopedAPIKey(t, auth, workspaceID, name, role, "")
+}
+
+// CreateScopedAPIKey creates a key with the given scope; an empty scope uses the server default.
+func CreateScopedAPIKey(t *testing.T, auth AuthContext, workspaceID uuid.UUID, name, role, scope string) (*APIKeyResponse, int) {
+	t.Helper()
+
+	payload := map[string]string{"name": name, "role": role}
+	if scope != "" {
+		payload["scope"] = scope
+	}
+	body, _ := json.Marshal(payload)
+	req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/api/v1/workspaces/%s/api-keys", BaseURL, workspaceID), bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	addAuth(t, req, auth)
+
+	resp, err := HTTPClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to create API key: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusCreated {
+		var key APIKeyResponse
+		if err := json.NewDecoder(resp.Body).Decode(&key); err != nil {
+			t.Fatalf("failed to decode API key response: %v", err)
+		}
+		return &key, resp.StatusCode
+	}
+
+	return nil, resp.StatusCode
+}
+
+// ListAPIKeysRaw returns the raw response body so tests can check nothing secret leaks.
+func ListAPIKeysRaw(t *testing.T, auth AuthContext, workspaceID uuid.UUID) (string, int) {
+	t.Helper()
+
+	req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/api/v1/workspaces/%s/api-keys", BaseURL, workspaceID), nil)
+	addAuth(t, req, auth)
+
+	resp, err := HTTPClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to list API keys: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	return string(body), resp.StatusCode
+}
+
+func RevokeAPIKey(t *testing.T, auth AuthContext, workspaceID, keyID uuid.UUID) int {
+	t.Helper()
+
+	req, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/api/v1/workspaces/%s/api-keys/%s", BaseURL, workspaceID, keyID), nil)
+	addAuth(t, req, auth)
+
+	resp, err := HTTPClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to revoke API key: %v", err)
+	}
+	defer resp.Body.Close()
+
+	return resp.StatusCode
+}
+
+// GetWithAPIKey sends a GET authenticated only by an API key bearer token.
+func GetWithAPIKey(t *testing.T, path, key string) int {
+	t.Helper()
+	return SendWithAPIKey(t, http.MethodGet, path, key, nil)
+}
+
+// SendWithAPIKey sends a request with an optional JSON body, authenticated
+// only by an API key bearer token.
+func SendWithAPIKey(t *testing.T, method, path, key string, payload any) int {
+	t.Helper()
+
+	var body io.Reader
+	if payload != nil {
+		data, _ := json.Marshal(payload)
+		body = bytes.NewReader(data)
+	}
+
+	req, _ := http.NewRequest(method, BaseURL+path, body)
+	req.Header.Set("Authorization", "Bearer "+key)
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := HTTPClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to send request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	return resp.StatusCode
+}
+
 // Group helpers
 
 type GroupResponse struct {
@@ -999,3 +1648,36 @@ func AdminDeleteUser(t *testing.T, auth AuthContext, userID uuid.UUID) int {
 
 	return resp.StatusCode
 }
+
+// Debug helpers
+
+type DebugInfoResponse struct {
+	Version       string  `json:"version"`
+	Commit        string  `json:"commit"`
+	GoVersion     string  `json:"go_version"`
+	UptimeSeconds float64 `json:"uptime_seconds"`
+	Goroutines    int     `json:"goroutines"`
+}
+
+func GetDebugInfo(t *testing.T, auth AuthContext) (*DebugInfoResponse, int) {
+	t.Helper()
+
+	req, _ := http.NewRequest(http.MethodGet, BaseURL+"/api/v1/debug/info", nil)
+	addAuth(t, req, auth)
+
+	resp, err := HTTPClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to get debug info: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		var info DebugInfoResponse
+		if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+			t.Fatalf("failed to decode debug info response: %v", err)
+		}
+		return &info, resp.StatusCode
+	}
+
+	return nil, resp.StatusCode
+}
@@ -0,0 +1,71 @@
+package integration_tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestListEndpoints_RejectOutOfBoundsQuery(t *testing.T) {
+	auth, _ := setupWorkspaceForTemplates(t)
+
+	tests := []struct {
+		name      string
+		query     string
+		wantField string
+	}{
+		{"limit below minimum", "limit=0", "limit"},
+		{"limit above maximum", "limit=101", "limit"},
+		{"negative offset", "offset=-1", "offset"},
+		{"unknown sort column", "sort_by=secret_hash", "sort_by"},
+		{"unknown order", "order=sideways", "order"},
+		{"lowercase order", "order=asc", "order"},
+	}
+
+	for _, path := range []string{"/api/v1/templates", "/api/v1/workspaces"} {
+		for _, tt := range tests {
+			t.Run(path+" "+tt.name, func(t *testing.T) {
+				req, _ := http.NewRequest(http.MethodGet, BaseURL+path+"?"+tt.query, nil)
+				addAuth(t, req, auth)
+				resp, err := HTTPClient.Do(req)
+				if err != nil {
+					t.Fatalf("request failed: %v", err)
+				}
+				defer resp.Body.Close()
+
+				if resp.StatusCode != http.StatusBadRequest {
+					t.Fatalf("expected status 400, got %d", resp.StatusCode)
+				}
+				var errResp struct {
+					Error ErrorResponse `json:"error"`
+				}
+				if err := json.NewDecoder(resp.Body).Decode(&errResp); err != nil {
+					t.Fatalf("failed to decode error response: %v", err)
+				}
+				fields, _ := errResp.Error.Metadata["fields"].(map[string]interface{})
+				if _, ok := fields[tt.wantField]; !ok {
+					t.Errorf("expected a %s field error, got metadata %v", tt.wantField, errResp.Error.Metadata)
+				}
+			})
+		}
+	}
+}
+
+func TestListEndpoints_AcceptBoundaryValues(t *testing.T) {
+	auth, _ := setupWorkspaceForTemplates(t)
+
+	for _, path := range []string{"/api/v1/templates", "/api/v1/workspaces"} {
+		for _, query := range []string{"limit=1", "limit=100", "offset=0", "sort_by=updated_at&order=ASC"} {
+			req, _ := http.NewRequest(http.MethodGet, BaseURL+path+"?"+query, nil)
+			addAuth(t, req, auth)
+			resp, err := HTTPClient.Do(req)
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("%s?%s: expected status 200, got %d", path, query, resp.StatusCode)
+			}
+		}
+	}
+}
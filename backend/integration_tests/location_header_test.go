@@ -0,0 +1,117 @@
+package integration_tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// createdResource is the part of a create response the Location tests read;
+// user registration answers with user_id instead of id.
+type createdResource struct {
+	ID     uuid.UUID `json:"id"`
+	UserID uuid.UUID `json:"user_id"`
+}
+
+// postCreate sends a JSON create request, authenticated when auth is set.
+func postCreate(t *testing.T, auth *AuthContext, path string, payload any) *http.Response {
+	t.Helper()
+
+	body, _ := json.Marshal(payload)
+	req, _ := http.NewRequest(http.MethodPost, BaseURL+path, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if auth != nil {
+		addAuth(t, req, *auth)
+	}
+
+	resp, err := HTTPClient.Do(req)
+	if err != nil {
+		t.Fatalf("POST %s failed: %v", path, err)
+	}
+	return resp
+}
+
+// assertCreatedAt checks that resp is a 201 with a Location header of the
+// collection path followed by the new resource's ID, and that a GET on it
+// returns that resource. It returns the new ID.
+func assertCreatedAt(t *testing.T, auth AuthContext, resp *http.Response, collection string) uuid.UUID {
+	t.Helper()
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", resp.StatusCode)
+	}
+	var created createdResource
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		t.Fatalf("failed to decode create response: %v", err)
+	}
+	id := created.ID
+	if id == uuid.Nil {
+		id = created.UserID
+	}
+
+	location := resp.Header.Get("Location")
+	if want := collection + "/" + id.String(); location != want {
+		t.Fatalf("expected Location %q, got %q", want, location)
+	}
+
+	status, body := getRawList(t, auth, location)
+	if status != http.StatusOK {
+		t.Fatalf("GET %s: expected 200, got %d: %s", location, status, body)
+	}
+	var resolved createdResource
+	if err := json.Unmarshal([]byte(body), &resolved); err != nil {
+		t.Fatalf("GET %s: failed to decode body: %v", location, err)
+	}
+	if resolved.ID != id {
+		t.Errorf("GET %s: expected resource %s, got %s", location, id, resolved.ID)
+	}
+	return id
+}
+
+func TestCreate_LocationHeaderResolvesToResource(t *testing.T) {
+	admin, workspace := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, workspace.Name)
+
+	name := "Location WS " + uuid.New().String()[:8]
+	resp := postCreate(t, &admin, "/api/v1/workspaces", map[string]any{"name": name, "admin_id": admin.UserID})
+	defer TearDownWorkspace(t, name)
+	assertCreatedAt(t, admin, resp, "/api/v1/workspaces")
+
+	resp = postCreate(t, nil, "/api/v1/users", map[string]any{
+		"name":         "Location User",
+		"email":        "location-" + uuid.New().String()[:8] + "@example.com",
+		"password":     "SecureP@ssw0rd!",
+		"workspace_id": workspace.ID,
+	})
+	userID := assertCreatedAt(t, admin, resp, "/api/v1/users")
+	outsider := admin
+	outsider.WorkspaceID = uuid.New()
+	if status, _ := getRawList(t, outsider, "/api/v1/users/"+userID.String()); status != http.StatusNotFound {
+		t.Errorf("GET user from another workspace: expected 404, got %d", status)
+	}
+	member := AuthContext{UserID: userID, UserName: "Location User", Role: "user", WorkspaceID: workspace.ID}
+	if status, _ := getRawList(t, member, "/api/v1/users/"+userID.String()); status != http.StatusOK {
+		t.Errorf("GET own user as a member: expected 200, got %d", status)
+	}
+	resp = postCreate(t, nil, "/api/v1/users", map[string]any{
+		"name":         "Location Colleague",
+		"email":        "colleague-" + uuid.New().String()[:8] + "@example.com",
+		"password":     "SecureP@ssw0rd!",
+		"workspace_id": workspace.ID,
+	})
+	colleagueID := assertCreatedAt(t, admin, resp, "/api/v1/users")
+	if status, _ := getRawList(t, member, "/api/v1/users/"+colleagueID.String()); status != http.StatusForbidden {
+		t.Errorf("GET another user as a member: expected 403, got %d", status)
+	}
+
+	resp, _ = CreateTemplateRaw(t, admin, "Location Template", workspace.ID, defaultFiles())
+	templateID := assertCreatedAt(t, admin, resp, "/api/v1/templates")
+
+	creator := AuthContext{UserID: userID, UserName: "Location User", Role: "admin", WorkspaceID: workspace.ID}
+	resp = postCreate(t, &creator, "/api/v1/environments", map[string]any{"name": "location-env", "template_id": templateID})
+	assertCreatedAt(t, creator, resp, "/api/v1/environments")
+}
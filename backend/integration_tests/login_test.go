@@ -1,9 +1,20 @@
 package integration_tests
 
 import (
+	"bytes"
+	"encoding/json"
 	"net/http"
+	"net/http/httptest"
+	"sort"
 	"testing"
+	"time"
 
+	handlererrors "backend/internal/application/errors"
+	"backend/internal/infra/http/handlers"
+	"backend/internal/infra/http/middleware"
+	"backend/pkg/jwt"
+
+	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 )
 
@@ -13,6 +24,7 @@ func setupUserForLogin(t *testing.T, email, password string) uuid.UUID {
 	auth := AuthContext{
 		UserID:      uuid.New(),
 		UserName:    "Test User",
+		Role:        "admin",
 		WorkspaceID: uuid.New(),
 	}
 	adminID := uuid.New()
@@ -136,3 +148,98 @@ func TestLogin_SameErrorForAllFailures(t *testing.T) {
 		t.Errorf("expected status 401, got %d", wrongPassStatus)
 	}
 }
+
+func TestLogin_UnknownEmailTimingMatchesWrongPassword(t *testing.T) {
+	// An unknown email must cost an argon2 verification just like a wrong
+	// password, otherwise response time reveals which emails are registered.
+	// Compare medians with a wide band so scheduler noise does not flake.
+	email := "login-timing@example.com"
+	password := "SecureP@ssw0rd!"
+	setupUserForLogin(t, email, password)
+
+	const samples = 7
+	medianLogin := func(email, password string) time.Duration {
+		durations := make([]time.Duration, samples)
+		for i := range durations {
+			start := time.Now()
+			_, _, status := LoginUser(t, email, password)
+			durations[i] = time.Since(start)
+			if status != http.StatusUnauthorized {
+				t.Fatalf("expected status 401, got %d", status)
+			}
+		}
+		sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+		return durations[samples/2]
+	}
+
+	// Warm up so the lazily computed dummy hash is not charged to the samples
+	LoginUser(t, "login-timing-warmup@example.com", "SomeP@ssw0rd!")
+
+	wrongPassword := medianLogin(email, "WrongP@ssw0rd!")
+	unknownEmail := medianLogin("login-timing-nobody@example.com", "SomeP@ssw0rd!")
+
+	ratio := float64(unknownEmail) / float64(wrongPassword)
+	if ratio < 0.5 || ratio > 2.0 {
+		t.Errorf("expected comparable login timing, got unknown email %v vs wrong password %v (ratio %.2f)", unknownEmail, wrongPassword, ratio)
+	}
+}
+
+func TestLogin_CustomCookieNameRoundTrips(t *testing.T) {
+	email := "login-cookie-name@example.com"
+	password := "SecureP@ssw0rd!"
+	setupUserForLogin(t, email, password)
+
+	cookieCfg := jwt.DefaultCookieConfig()
+	cookieCfg.Name = "devshare_session"
+
+	app := fiber.New(fiber.Config{ErrorHandler: handlererrors.ErrorHandler()})
+	api := app.Group("/api/v1")
+	userHandler := handlers.NewUserHandler(testServiceFactory.NewUserService, jwtSvc).WithCookieConfig(cookieCfg)
+	userHandler.RegisterRoutes(api)
+	userHandler.RegisterProtectedRoutes(api.Group("", middleware.RequireAuth(jwtSvc, cookieCfg)))
+
+	body, _ := json.Marshal(map[string]string{"email": email, "password": password})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/login", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("login request failed: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected login status 200, got %d", resp.StatusCode)
+	}
+
+	var session *http.Cookie
+	for _, cookie := range resp.Cookies() {
+		if cookie.Name == jwt.DefaultCookieName {
+			t.Errorf("expected no %s cookie when a custom name is configured", jwt.DefaultCookieName)
+		}
+		if cookie.Name == cookieCfg.Name {
+			session = cookie
+		}
+	}
+	if session == nil || session.Value == "" {
+		t.Fatalf("expected a %s cookie, got %v", cookieCfg.Name, resp.Cookies())
+	}
+
+	me := httptest.NewRequest(http.MethodGet, "/api/v1/me", nil)
+	me.AddCookie(session)
+	resp, err = app.Test(me, -1)
+	if err != nil {
+		t.Fatalf("me request failed: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected /me with the custom cookie to return 200, got %d", resp.StatusCode)
+	}
+
+	// The default cookie name no longer authenticates
+	me = httptest.NewRequest(http.MethodGet, "/api/v1/me", nil)
+	me.AddCookie(&http.Cookie{Name: jwt.DefaultCookieName, Value: session.Value})
+	resp, err = app.Test(me, -1)
+	if err != nil {
+		t.Fatalf("me request failed: %v", err)
+	}
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected /me with the default cookie name to return 401, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,73 @@
+package integration_tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+
+	"backend/internal/infra/sqlite"
+	"backend/pkg/contracts"
+
+	"github.com/google/uuid"
+)
+
+func TestProtectedService_WithoutClaims(t *testing.T) {
+	var logs bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&logs, nil)))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	// Calling the service directly skips RequireAuth, as a misregistered route would
+	service := newWorkspaceServiceForTest()
+	_, err := service.RotateSecret(context.Background(), sqlite.NewUnitOfWork(DbConnection), contracts.RotateWorkspaceSecret{ID: uuid.New()})
+	if err == nil {
+		t.Fatal("expected an error without claims")
+	}
+
+	if err.HTTPStatus() != http.StatusUnauthorized {
+		t.Errorf("expected status 401, got %d", err.HTTPStatus())
+	}
+	if reason := err.GetMetadata()["reason"]; reason != "missing_claims" {
+		t.Errorf("expected reason 'missing_claims', got %v", reason)
+	}
+
+	logged := logs.String()
+	if !strings.Contains(logged, `"level":"WARN"`) || !strings.Contains(logged, "JWT claims missing from context") {
+		t.Errorf("expected a warning about missing claims, got %q", logged)
+	}
+	if !strings.Contains(logged, "WorkspaceService.RotateSecret") {
+		t.Errorf("expected the log to name the calling service method, got %q", logged)
+	}
+}
+
+func TestListTemplates_UnparseableWorkspaceClaim(t *testing.T) {
+	token, err := jwtSvc.GenerateToken(uuid.NewString(), "Broken Token", "admin", "not-a-uuid")
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, BaseURL+"/api/v1/templates", nil)
+	req.AddCookie(&http.Cookie{Name: "access_token", Value: token})
+	resp, err := HTTPClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to list templates: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected status 401, got %d", resp.StatusCode)
+	}
+	var errResp struct {
+		Error ErrorResponse `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&errResp); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+	if errResp.Error.Code != "UNAUTHORIZED" || errResp.Error.Metadata["reason"] != "invalid_claims" {
+		t.Errorf("expected UNAUTHORIZED with reason invalid_claims, got %+v", errResp.Error)
+	}
+}
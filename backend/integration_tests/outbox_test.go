@@ -0,0 +1,81 @@
+package integration_tests
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"backend/internal/application"
+	"backend/internal/domain"
+	"backend/internal/infra/sqlite"
+	"backend/pkg/contracts"
+
+	"github.com/google/uuid"
+)
+
+const countUserCreatedEvents = "SELECT COUNT(*) FROM outbox_events WHERE type = ? AND aggregate_id = ?"
+
+func TestCreateUser_WritesUserCreatedEvent(t *testing.T) {
+	_, workspace := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, workspace.Name)
+
+	user, status := CreateUser(t, "Outbox User", "outbox-"+uuid.NewString()[:8]+"@example.com", "SecureP@ssw0rd!", workspace.ID)
+	if status != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", status)
+	}
+
+	if n := countRows(t, countUserCreatedEvents, domain.EventUserCreated, user.UserID); n != 1 {
+		t.Fatalf("expected exactly one user.created event, found %d", n)
+	}
+	if lastActive := getLastActiveAtFromDB(t, user.UserID); lastActive.Valid {
+		t.Fatalf("expected last_active_at to be left to the event handler, got %q", lastActive.String)
+	}
+
+	dispatcher := application.NewOutboxDispatcher(sqlite.NewUnitOfWorkFactory(DbConnection), sqlite.NewRepositoryFactory(), time.Hour)
+	// Events from other tests may be pending ahead of this one
+	for {
+		processed, err := dispatcher.DispatchOnce(context.Background())
+		if err != nil {
+			t.Fatalf("DispatchOnce failed: %v", err)
+		}
+		if processed == 0 {
+			break
+		}
+	}
+
+	if n := countRows(t, countUserCreatedEvents+" AND processed_at IS NOT NULL", domain.EventUserCreated, user.UserID); n != 1 {
+		t.Errorf("expected the event to be marked processed, found %d", n)
+	}
+	if lastActive := getLastActiveAtFromDB(t, user.UserID); !lastActive.Valid {
+		t.Error("expected the user.created handler to set last_active_at")
+	}
+}
+
+func TestCreateUser_RolledBackWritesNoEvent(t *testing.T) {
+	_, workspace := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, workspace.Name)
+
+	ctx := context.Background()
+	service, uow := testServiceFactory.NewUserService()
+	if err := uow.Begin(ctx); err != nil {
+		t.Fatalf("failed to begin: %v", err)
+	}
+	defer uow.Rollback()
+	user, err := service.CreateLocalUser(ctx, uow, contracts.CreateLocalUser{
+		Name:        "Rolled Back User",
+		Email:       "rolled-back-" + uuid.NewString()[:8] + "@example.com",
+		Password:    "SecureP@ssw0rd!",
+		WorkspaceID: workspace.ID,
+	})
+	if err != nil {
+		t.Fatalf("CreateLocalUser failed: %v", err)
+	}
+	if err := uow.Rollback(); err != nil {
+		t.Fatalf("failed to roll back: %v", err)
+	}
+
+	if n := countRows(t, countUserCreatedEvents, domain.EventUserCreated, user.ID); n != 0 {
+		t.Errorf("expected no user.created event after rollback, found %d", n)
+	}
+}
@@ -0,0 +1,312 @@
+package integration_tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"backend/internal/application"
+	"backend/internal/infra/filestorage"
+	"backend/internal/infra/sqlite"
+	"backend/pkg/validation"
+
+	"github.com/google/uuid"
+)
+
+func newTestPurger(t *testing.T, interval time.Duration) *application.SoftDeletePurger {
+	t.Helper()
+	return newTestPurgerWithExecutions(t, interval, t.TempDir())
+}
+
+func newTestPurgerWithExecutions(t *testing.T, interval time.Duration, executionDir string) *application.SoftDeletePurger {
+	t.Helper()
+	return application.NewSoftDeletePurger(
+		sqlite.NewUnitOfWorkFactory(DbConnection),
+		sqlite.NewRepositoryFactory(),
+		filestorage.NewLocalFileStorage(t.TempDir()),
+		filestorage.NewLocalExecutionStorage(executionDir, t.TempDir()),
+		validation.New(),
+		interval,
+		application.DefaultPurgeRetention,
+	)
+}
+
+// softDeleteWorkspaceAt soft-deletes the workspace through the API, then
+// backdates its deletion.
+func softDeleteWorkspaceAt(t *testing.T, auth AuthContext, id uuid.UUID, at time.Time) {
+	t.Helper()
+	if status := DeleteWorkspace(t, auth, id); status != http.StatusNoContent && status != http.StatusOK {
+		t.Fatalf("failed to delete workspace %s: status %d", id, status)
+	}
+	if _, err := DbConnection.Exec("UPDATE workspaces SET deleted_at = ? WHERE id = ?", at.UTC().Format("2006-01-02 15:04:05"), id); err != nil {
+		t.Fatalf("failed to backdate deletion: %v", err)
+	}
+}
+
+func countRows(t *testing.T, query string, args ...any) int {
+	t.Helper()
+	var count int
+	if err := DbConnection.QueryRow(query, args...).Scan(&count); err != nil {
+		t.Fatalf("failed to count rows: %v", err)
+	}
+	return count
+}
+
+func TestSoftDeletePurger_PurgesPastRetentionAndKeepsRecent(t *testing.T) {
+	expiredAuth, expired := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, expired.Name)
+	recentAuth, recent := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, recent.Name)
+	_, live := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, live.Name)
+
+	template, status := CreateTemplate(t, expiredAuth, "Purged Template", expired.ID, defaultFiles())
+	if status != http.StatusCreated {
+		t.Fatalf("failed to create template: status %d", status)
+	}
+
+	softDeleteWorkspaceAt(t, expiredAuth, expired.ID, time.Now().Add(-31*24*time.Hour))
+	softDeleteWorkspaceAt(t, recentAuth, recent.ID, time.Now().Add(-29*24*time.Hour))
+
+	purged, err := newTestPurger(t, time.Hour).PurgeOnce(context.Background())
+	if err != nil {
+		t.Fatalf("PurgeOnce failed: %v", err)
+	}
+	if purged != 1 {
+		t.Errorf("expected 1 workspace purged, got %d", purged)
+	}
+
+	if n := countRows(t, "SELECT COUNT(*) FROM workspaces WHERE id = ?", expired.ID); n != 0 {
+		t.Errorf("expected the workspace past retention to be purged, found %d rows", n)
+	}
+	if n := countRows(t, "SELECT COUNT(*) FROM templates WHERE id = ?", template.ID); n != 0 {
+		t.Errorf("expected the purged workspace's template to go with it, found %d rows", n)
+	}
+	if n := countRows(t, "SELECT COUNT(*) FROM workspaces WHERE id = ? AND deleted_at IS NOT NULL", recent.ID); n != 1 {
+		t.Errorf("expected the recently deleted workspace to be kept, found %d rows", n)
+	}
+	if n := countRows(t, "SELECT COUNT(*) FROM workspaces WHERE id = ? AND deleted_at IS NULL", live.ID); n != 1 {
+		t.Errorf("expected the live workspace to be kept, found %d rows", n)
+	}
+}
+
+func TestSoftDeletePurger_TearsDownEnvironmentsBeforePurging(t *testing.T) {
+	_, workspace := setupEnvironments(t, 2)
+	environments, err := environmentRepository().GetByWorkspaceID(context.Background(), workspace.ID)
+	if err != nil || len(environments) != 2 {
+		t.Fatalf("failed to load environments: %v (%d found)", err, len(environments))
+	}
+	applied, untouched := environments[0], environments[1]
+	if _, err := DbConnection.Exec("UPDATE environments SET status = 'ready' WHERE id = ?", applied.ID); err != nil {
+		t.Fatalf("failed to mark environment applied: %v", err)
+	}
+	if _, err := DbConnection.Exec("UPDATE workspaces SET deleted_at = ? WHERE id = ?", time.Now().Add(-31*24*time.Hour).UTC().Format("2006-01-02 15:04:05"), workspace.ID); err != nil {
+		t.Fatalf("failed to soft-delete workspace: %v", err)
+	}
+
+	executionDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(executionDir, applied.ExecutionPath()), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	purger := newTestPurgerWithExecutions(t, time.Hour, executionDir)
+
+	if _, err := purger.PurgeOnce(context.Background()); err != nil {
+		t.Fatalf("PurgeOnce failed: %v", err)
+	}
+	if n := countRows(t, "SELECT COUNT(*) FROM workspaces WHERE id = ?", workspace.ID); n != 1 {
+		t.Fatalf("expected the workspace to be kept while an environment may hold resources, found %d rows", n)
+	}
+	if n := countRows(t, "SELECT COUNT(*) FROM teardown_queue WHERE environment_id = ? AND status = 'pending' AND teardown_at <= CURRENT_TIMESTAMP", applied.ID); n != 1 {
+		t.Errorf("expected a due teardown for the applied environment, found %d", n)
+	}
+	if n := countRows(t, "SELECT COUNT(*) FROM teardown_queue WHERE environment_id = ?", untouched.ID); n != 0 {
+		t.Errorf("expected no teardown for the never-applied environment, found %d", n)
+	}
+
+	// Once the reaper has destroyed it, the next run purges the workspace
+	if _, err := DbConnection.Exec("UPDATE environments SET status = 'destroyed' WHERE id = ?", applied.ID); err != nil {
+		t.Fatalf("failed to mark environment destroyed: %v", err)
+	}
+	if _, err := purger.PurgeOnce(context.Background()); err != nil {
+		t.Fatalf("PurgeOnce failed: %v", err)
+	}
+	if n := countRows(t, "SELECT COUNT(*) FROM workspaces WHERE id = ?", workspace.ID); n != 0 {
+		t.Errorf("expected the workspace to be purged, found %d rows", n)
+	}
+	if _, err := os.Stat(filepath.Join(executionDir, workspace.ID.String())); !os.IsNotExist(err) {
+		t.Errorf("expected the workspace's execution directory to be removed, stat returned %v", err)
+	}
+}
+
+func TestSoftDeletePurger_PurgesDeletedEnvironmentsPastRetention(t *testing.T) {
+	_, workspace := setupEnvironments(t, 3)
+	environments, err := environmentRepository().GetByWorkspaceID(context.Background(), workspace.ID)
+	if err != nil || len(environments) != 3 {
+		t.Fatalf("failed to load environments: %v (%d found)", err, len(environments))
+	}
+	expired, recent, failed := environments[0], environments[1], environments[2]
+
+	expiredAt := time.Now().Add(-31 * 24 * time.Hour).UTC().Format("2006-01-02 15:04:05")
+	recentAt := time.Now().Add(-29 * 24 * time.Hour).UTC().Format("2006-01-02 15:04:05")
+	for _, update := range []struct {
+		env       uuid.UUID
+		status    string
+		deletedAt string
+	}{
+		{expired.ID, "destroyed", expiredAt},
+		{recent.ID, "destroyed", recentAt},
+		{failed.ID, "error", expiredAt},
+	} {
+		if _, err := DbConnection.Exec("UPDATE environments SET status = ?, deleted_at = ? WHERE id = ?", update.status, update.deletedAt, update.env); err != nil {
+			t.Fatalf("failed to soft-delete environment: %v", err)
+		}
+	}
+
+	executionDir := t.TempDir()
+	for _, env := range environments {
+		if err := os.MkdirAll(filepath.Join(executionDir, env.ExecutionPath()), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	purged, purgeErr := newTestPurgerWithExecutions(t, time.Hour, executionDir).PurgeEnvironments(context.Background())
+	if purgeErr != nil {
+		t.Fatalf("PurgeEnvironments failed: %v", purgeErr)
+	}
+	if purged < 1 {
+		t.Errorf("expected at least 1 environment purged, got %d", purged)
+	}
+
+	if n := countRows(t, "SELECT COUNT(*) FROM environments WHERE id = ?", expired.ID); n != 0 {
+		t.Errorf("expected the environment past retention to be purged, found %d rows", n)
+	}
+	if _, err := os.Stat(filepath.Join(executionDir, expired.ExecutionPath())); !os.IsNotExist(err) {
+		t.Errorf("expected the purged environment's execution directory to be removed, stat returned %v", err)
+	}
+	for name, env := range map[string]uuid.UUID{"recently deleted": recent.ID, "failed destroy": failed.ID} {
+		if n := countRows(t, "SELECT COUNT(*) FROM environments WHERE id = ?", env); n != 1 {
+			t.Errorf("expected the %s environment to be kept, found %d rows", name, n)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(executionDir, failed.ExecutionPath())); err != nil {
+		t.Errorf("expected the failed environment's execution directory to be kept: %v", err)
+	}
+}
+
+func TestSoftDeletePurger_StopsWhenCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		newTestPurger(t, 10*time.Millisecond).Start(ctx)
+		close(done)
+	}()
+
+	time.Sleep(30 * time.Millisecond)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected Start to return after its context was cancelled")
+	}
+}
+
+type PurgeReportResponse struct {
+	OlderThanDays  int         `json:"older_than_days"`
+	Cutoff         time.Time   `json:"cutoff"`
+	WorkspaceCount int         `json:"workspace_count"`
+	WorkspaceIDs   []uuid.UUID `json:"workspace_ids"`
+}
+
+func GetPurgeReport(t *testing.T, auth AuthContext, query string) (*PurgeReportResponse, int) {
+	t.Helper()
+
+	status, body := getRawList(t, auth, "/api/v1/admin/workspaces/purge-report"+query)
+	if status != http.StatusOK {
+		return nil, status
+	}
+	var report PurgeReportResponse
+	if err := json.Unmarshal([]byte(body), &report); err != nil {
+		t.Fatalf("failed to decode purge report: %v", err)
+	}
+	return &report, status
+}
+
+func TestPurgeReport_MatchesSubsequentPurge(t *testing.T) {
+	auth, expired := setupAdministeredWorkspace(t)
+	defer TearDownWorkspace(t, expired.Name)
+	older := createAdministeredWorkspace(t, auth)
+	defer TearDownWorkspace(t, older.Name)
+	recent := createAdministeredWorkspace(t, auth)
+	defer TearDownWorkspace(t, recent.Name)
+	otherAuth, foreign := setupAdministeredWorkspace(t)
+	defer TearDownWorkspace(t, foreign.Name)
+
+	softDeleteWorkspaceAt(t, auth, expired.ID, time.Now().Add(-31*24*time.Hour))
+	softDeleteWorkspaceAt(t, auth, older.ID, time.Now().Add(-90*24*time.Hour))
+	softDeleteWorkspaceAt(t, auth, recent.ID, time.Now().Add(-24*time.Hour))
+	softDeleteWorkspaceAt(t, otherAuth, foreign.ID, time.Now().Add(-31*24*time.Hour))
+
+	report, status := GetPurgeReport(t, auth, "")
+	if status != http.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+	if report.OlderThanDays != 30 {
+		t.Errorf("expected the default retention of 30 days, got %d", report.OlderThanDays)
+	}
+	if report.WorkspaceCount != 2 || len(report.WorkspaceIDs) != 2 {
+		t.Fatalf("expected exactly the caller's 2 expired workspaces, got %d: %v", report.WorkspaceCount, report.WorkspaceIDs)
+	}
+	if !slices.Contains(report.WorkspaceIDs, expired.ID) || !slices.Contains(report.WorkspaceIDs, older.ID) {
+		t.Errorf("expected both expired workspaces in the report, got %v", report.WorkspaceIDs)
+	}
+	if slices.Contains(report.WorkspaceIDs, recent.ID) {
+		t.Errorf("expected the recently deleted workspace not to be reported")
+	}
+	if slices.Contains(report.WorkspaceIDs, foreign.ID) {
+		t.Errorf("expected another admin's workspace not to be reported")
+	}
+
+	narrowed, status := GetPurgeReport(t, auth, "?older_than_days=60")
+	if status != http.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+	if !slices.Contains(narrowed.WorkspaceIDs, older.ID) || slices.Contains(narrowed.WorkspaceIDs, expired.ID) {
+		t.Errorf("expected only the 90-day-old deletion with older_than_days=60, got %v", narrowed.WorkspaceIDs)
+	}
+
+	// The report deleted nothing
+	for _, id := range report.WorkspaceIDs {
+		if n := countRows(t, "SELECT COUNT(*) FROM workspaces WHERE id = ?", id); n != 1 {
+			t.Fatalf("expected reported workspace %s to still exist, found %d rows", id, n)
+		}
+	}
+
+	if _, err := newTestPurger(t, time.Hour).PurgeOnce(context.Background()); err != nil {
+		t.Fatalf("PurgeOnce failed: %v", err)
+	}
+	for _, id := range report.WorkspaceIDs {
+		if n := countRows(t, "SELECT COUNT(*) FROM workspaces WHERE id = ?", id); n != 0 {
+			t.Errorf("expected reported workspace %s to be purged, found %d rows", id, n)
+		}
+	}
+	if n := countRows(t, "SELECT COUNT(*) FROM workspaces WHERE id = ?", recent.ID); n != 1 {
+		t.Errorf("expected the unreported workspace to be kept, found %d rows", n)
+	}
+}
+
+func TestPurgeReport_InvalidAge(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, workspace.Name)
+
+	for _, query := range []string{"?older_than_days=-1", "?older_than_days=abc"} {
+		if _, status := GetPurgeReport(t, auth, query); status != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", query, status)
+		}
+	}
+}
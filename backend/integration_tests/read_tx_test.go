@@ -0,0 +1,107 @@
+package integration_tests
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"backend/internal/domain/repository"
+	"backend/internal/infra/sqlite"
+
+	"github.com/google/uuid"
+)
+
+func TestReadOnlyTx_ListAndCountAgreeUnderConcurrentInsert(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, workspace.Name)
+
+	for _, name := range []string{"Snapshot One", "Snapshot Two"} {
+		if _, status := CreateTemplate(t, auth, name, workspace.ID, defaultFiles()); status != http.StatusCreated {
+			t.Fatalf("failed to create template %q: status %d", name, status)
+		}
+	}
+
+	ctx := context.Background()
+	uow := sqlite.NewUnitOfWork(DbConnection)
+	repo := sqlite.NewRepositoryFactory().CreateTemplateRepository(uow)
+
+	if err := uow.BeginReadOnly(ctx); err != nil {
+		t.Fatalf("failed to begin read-only transaction: %v", err)
+	}
+	defer uow.Rollback()
+
+	listed, err := repo.List(ctx, repository.ListOptions{Filters: map[string]any{"workspace_id": workspace.ID}})
+	if err != nil {
+		t.Fatalf("failed to list templates: %v", err)
+	}
+
+	inserted := make(chan error, 1)
+	go func() {
+		_, err := DbConnection.Exec(
+			"INSERT INTO templates (id, name, workspace_id, path) VALUES (?, ?, ?, ?)",
+			uuid.New(), "Snapshot Intruder", workspace.ID, "intruder",
+		)
+		inserted <- err
+	}()
+	// Give the insert a chance to land if the transaction does not hold it off
+	time.Sleep(100 * time.Millisecond)
+
+	counted, err := repo.GetByWorkspaceID(ctx, workspace.ID)
+	if err != nil {
+		t.Fatalf("failed to count templates: %v", err)
+	}
+	if len(listed) != 2 || len(counted) != len(listed) {
+		t.Errorf("expected list and count to agree on 2 templates, got %d and %d", len(listed), len(counted))
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		t.Fatalf("failed to commit read-only transaction: %v", err)
+	}
+	if err := <-inserted; err != nil {
+		t.Fatalf("concurrent insert failed: %v", err)
+	}
+
+	after, err := repo.GetByWorkspaceID(ctx, workspace.ID)
+	if err != nil {
+		t.Fatalf("failed to count templates after commit: %v", err)
+	}
+	if len(after) != 3 {
+		t.Errorf("expected the insert to be visible after the transaction, got %d templates", len(after))
+	}
+}
+
+func TestReadOnlyTx_RejectsWrites(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, workspace.Name)
+
+	template, status := CreateTemplate(t, auth, "Read Only Target", workspace.ID, defaultFiles())
+	if status != http.StatusCreated {
+		t.Fatalf("failed to create template: status %d", status)
+	}
+
+	uow := sqlite.NewUnitOfWork(DbConnection)
+	repo := sqlite.NewRepositoryFactory().CreateTemplateRepository(uow)
+
+	if err := uow.BeginReadOnly(context.Background()); err != nil {
+		t.Fatalf("failed to begin read-only transaction: %v", err)
+	}
+	writeErr := repo.Delete(context.Background(), template.ID)
+	uow.Rollback()
+
+	if writeErr == nil {
+		t.Fatal("expected a write inside a read-only transaction to fail")
+	}
+
+	// query_only must not leak to later users of the connection
+	if _, status := GetTemplate(t, auth, template.ID); status != http.StatusOK {
+		t.Fatalf("expected the template to survive, got status %d", status)
+	}
+	var queryOnly int
+	if err := DbConnection.QueryRow("PRAGMA query_only").Scan(&queryOnly); err != nil {
+		t.Fatalf("failed to read query_only: %v", err)
+	}
+	if queryOnly != 0 {
+		t.Error("expected query_only to be reset after the transaction")
+	}
+}
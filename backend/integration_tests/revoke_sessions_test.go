@@ -0,0 +1,157 @@
+package integration_tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"backend/pkg/jwt"
+
+	"github.com/google/uuid"
+)
+
+type RevokeSessionsResponse struct {
+	WorkspaceID uuid.UUID `json:"workspace_id"`
+	TokenEpoch  int64     `json:"token_epoch"`
+}
+
+func RevokeWorkspaceSessions(t *testing.T, auth AuthContext, workspaceID uuid.UUID) (*RevokeSessionsResponse, int) {
+	t.Helper()
+
+	req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/api/v1/workspaces/%s/revoke-sessions", BaseURL, workspaceID), nil)
+	addAuth(t, req, auth)
+
+	resp, err := HTTPClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to revoke sessions: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, resp.StatusCode
+	}
+	var result RevokeSessionsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatalf("failed to decode revoke sessions response: %v", err)
+	}
+	return &result, resp.StatusCode
+}
+
+// getWorkspaceWithToken fetches workspaceID with token as the session cookie.
+func getWorkspaceWithToken(t *testing.T, token string, workspaceID uuid.UUID) int {
+	t.Helper()
+
+	req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/api/v1/workspaces/%s", BaseURL, workspaceID), nil)
+	req.AddCookie(&http.Cookie{Name: jwt.DefaultCookieName, Value: token})
+	resp, err := HTTPClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET workspace %s failed: %v", workspaceID, err)
+	}
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func sessionToken(t *testing.T, resp *http.Response) string {
+	t.Helper()
+	for _, cookie := range resp.Cookies() {
+		if cookie.Name == jwt.DefaultCookieName && cookie.Value != "" {
+			return cookie.Value
+		}
+	}
+	t.Fatalf("expected a %s cookie, got %v", jwt.DefaultCookieName, resp.Cookies())
+	return ""
+}
+
+func TestRevokeWorkspaceSessions_ExistingTokensFail(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, workspace.Name)
+	otherAuth, other := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, other.Name)
+
+	adminToken, _ := jwtSvc.GenerateToken(auth.UserID.String(), auth.UserName, "admin", workspace.ID.String())
+	memberToken, _ := jwtSvc.GenerateToken(uuid.NewString(), "Member", "user", workspace.ID.String())
+	otherToken, _ := jwtSvc.GenerateToken(otherAuth.UserID.String(), otherAuth.UserName, "admin", other.ID.String())
+	for name, token := range map[string]string{"admin": adminToken, "member": memberToken} {
+		if status := getWorkspaceWithToken(t, token, workspace.ID); status != http.StatusOK {
+			t.Fatalf("%s token before revocation: expected 200, got %d", name, status)
+		}
+	}
+	if status := getWorkspaceWithToken(t, otherToken, other.ID); status != http.StatusOK {
+		t.Fatalf("other workspace token before revocation: expected 200, got %d", status)
+	}
+
+	revoked, status := RevokeWorkspaceSessions(t, auth, workspace.ID)
+	if status != http.StatusOK {
+		t.Fatalf("revoke sessions: expected 200, got %d", status)
+	}
+	if revoked.WorkspaceID != workspace.ID || revoked.TokenEpoch != 1 {
+		t.Errorf("expected workspace %s at epoch 1, got %s at %d", workspace.ID, revoked.WorkspaceID, revoked.TokenEpoch)
+	}
+
+	if status := getWorkspaceWithToken(t, adminToken, workspace.ID); status != http.StatusUnauthorized {
+		t.Errorf("admin token after revocation: expected 401, got %d", status)
+	}
+	if status := getWorkspaceWithToken(t, memberToken, workspace.ID); status != http.StatusUnauthorized {
+		t.Errorf("member token after revocation: expected 401, got %d", status)
+	}
+	if status := getWorkspaceWithToken(t, otherToken, other.ID); status != http.StatusOK {
+		t.Errorf("other workspace token after revocation: expected 200, got %d", status)
+	}
+
+	fresh, _ := jwtSvc.GenerateScopedToken(auth.UserID.String(), auth.UserName, "admin", workspace.ID.String(), 0, jwt.ScopeWrite, jwt.WithWorkspaceEpoch(revoked.TokenEpoch))
+	if status := getWorkspaceWithToken(t, fresh, workspace.ID); status != http.StatusOK {
+		t.Errorf("token issued after revocation: expected 200, got %d", status)
+	}
+}
+
+func TestRevokeWorkspaceSessions_LoginIssuesValidToken(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, workspace.Name)
+
+	email := "revoke-sessions-login@example.com"
+	password := "SecureP@ssw0rd!"
+	if _, status := CreateUser(t, "Revoked User", email, password, workspace.ID); status != http.StatusCreated {
+		t.Fatalf("failed to create user: status %d", status)
+	}
+
+	resp, _, status := LoginUser(t, email, password)
+	if status != http.StatusOK {
+		t.Fatalf("login before revocation: expected 200, got %d", status)
+	}
+	before := sessionToken(t, resp)
+
+	if _, status := RevokeWorkspaceSessions(t, auth, workspace.ID); status != http.StatusOK {
+		t.Fatalf("revoke sessions: expected 200, got %d", status)
+	}
+	if status := getWorkspaceWithToken(t, before, workspace.ID); status != http.StatusUnauthorized {
+		t.Errorf("session from before revocation: expected 401, got %d", status)
+	}
+
+	resp, _, status = LoginUser(t, email, password)
+	if status != http.StatusOK {
+		t.Fatalf("login after revocation: expected 200, got %d", status)
+	}
+	if status := getWorkspaceWithToken(t, sessionToken(t, resp), workspace.ID); status != http.StatusOK {
+		t.Errorf("session from after revocation: expected 200, got %d", status)
+	}
+}
+
+func TestRevokeWorkspaceSessions_OtherWorkspace(t *testing.T) {
+	_, workspace := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, workspace.Name)
+	otherAuth, other := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, other.Name)
+
+	if _, status := RevokeWorkspaceSessions(t, otherAuth, workspace.ID); status != http.StatusNotFound {
+		t.Errorf("admin of another workspace: expected 404, got %d", status)
+	}
+
+	var epoch int64
+	if err := DbConnection.QueryRow("SELECT token_epoch FROM workspaces WHERE id = ?", workspace.ID).Scan(&epoch); err != nil {
+		t.Fatalf("failed to read token epoch: %v", err)
+	}
+	if epoch != 0 {
+		t.Errorf("expected the workspace's token epoch to stay 0, got %d", epoch)
+	}
+}
@@ -0,0 +1,74 @@
+package integration_tests
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestScope_ReadAPIKeyCanGetButNotPost(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, workspace.Name)
+
+	readKey, status := CreateScopedAPIKey(t, auth, workspace.ID, "Read Only", "admin", "read")
+	if status != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", status)
+	}
+	if readKey.Scope != "read" {
+		t.Errorf("expected scope 'read', got %q", readKey.Scope)
+	}
+
+	path := fmt.Sprintf("/api/v1/workspaces/%s/api-keys", workspace.ID)
+	if status := GetWithAPIKey(t, path, readKey.Key); status != http.StatusOK {
+		t.Errorf("expected 200 for GET with a read key, got %d", status)
+	}
+
+	payload := map[string]string{"name": "Minted", "role": "user"}
+	if status := SendWithAPIKey(t, http.MethodPost, path, readKey.Key, payload); status != http.StatusForbidden {
+		t.Errorf("expected 403 for POST with a read key, got %d", status)
+	}
+
+	writeKey, status := CreateAPIKey(t, auth, workspace.ID, "Read Write", "admin")
+	if status != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", status)
+	}
+	if writeKey.Scope != "write" {
+		t.Errorf("expected default scope 'write', got %q", writeKey.Scope)
+	}
+	if status := SendWithAPIKey(t, http.MethodPost, path, writeKey.Key, payload); status != http.StatusCreated {
+		t.Errorf("expected 201 for POST with a write key, got %d", status)
+	}
+}
+
+func TestScope_ReadLoginTokenCanGetButNotPost(t *testing.T) {
+	email := "scope-read@example.com"
+	password := "SecureP@ssw0rd!"
+	setupUserForLogin(t, email, password)
+
+	resp, login, status := LoginUserWithScope(t, email, password, "read")
+	if status != http.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+	if login.Scope != "read" {
+		t.Errorf("expected scope 'read', got %q", login.Scope)
+	}
+
+	// Users may list and create environments, so only the scope can refuse the POST
+	path := "/api/v1/environments"
+	if status := GetWithCookies(t, path, resp.Cookies()); status != http.StatusOK {
+		t.Errorf("expected 200 for GET with a read token, got %d", status)
+	}
+	if status := SendWithCookies(t, http.MethodPost, path, resp.Cookies()); status != http.StatusForbidden {
+		t.Errorf("expected 403 for POST with a read token, got %d", status)
+	}
+}
+
+func TestScope_InvalidLoginScope(t *testing.T) {
+	email := "scope-invalid@example.com"
+	password := "SecureP@ssw0rd!"
+	setupUserForLogin(t, email, password)
+
+	if _, _, status := LoginUserWithScope(t, email, password, "admin"); status != http.StatusBadRequest {
+		t.Errorf("expected 400 for an unknown scope, got %d", status)
+	}
+}
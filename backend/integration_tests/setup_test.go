@@ -19,10 +19,13 @@ import (
 	"backend/internal/infra/filestorage"
 	"backend/internal/infra/http/handlers"
 	"backend/internal/infra/http/middleware"
+	"backend/internal/infra/pathcheck"
 	"backend/internal/infra/sqlite"
 	"backend/internal/infra/terraform"
 	"backend/internal/infra/tfparser"
+	"backend/pkg/contracts"
 	"backend/pkg/crypto"
+	"backend/pkg/jsonutil"
 	"backend/pkg/jwt"
 	"backend/pkg/validation"
 
@@ -38,6 +41,9 @@ var (
 	HTTPClient   *http.Client
 	DbConnection *sql.DB
 	jwtSvc       *jwt.Service
+	// testServiceFactory is the factory behind the test server, for tests
+	// that call services directly
+	testServiceFactory *application.ServiceFactory
 )
 
 func TestMain(m *testing.M) {
@@ -90,6 +96,7 @@ func TestMain(m *testing.M) {
 		fmt.Fprintf(os.Stderr, "failed to register validations: %v\n", err)
 		os.Exit(1)
 	}
+	contracts.RegisterValidations(validator)
 
 	// Create temp directories for file storage
 	templateStorageDir := filepath.Join(tmpDir, "template_storage")
@@ -114,47 +121,74 @@ func TestMain(m *testing.M) {
 	executionStorage := filestorage.NewLocalExecutionStorage(executionDir, templateStorageDir)
 	tfExecutor := terraform.NewExecutor(executionDir, "")
 
+	pathChecker := pathcheck.NewChecker(templateStorageDir, nil, pathcheck.DefaultTimeout)
+
+	idGenerator, err := domain.NewIDGenerator(getEnv("ID_STRATEGY", domain.IDStrategyRandom))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to configure ID generation: %v\n", err)
+		os.Exit(1)
+	}
+
 	uowFactory := sqlite.NewUnitOfWorkFactory(DbConnection)
-	repoFactory := sqlite.NewRepositoryFactory()
-	serviceFactory := application.NewServiceFactory(uowFactory, repoFactory, validator, fileStorage, encryptor, tfParser, executionStorage, tfExecutor)
+	repoFactory := sqlite.NewRepositoryFactory(sqlite.WithIDGenerator(idGenerator))
+	crossTenantDenial := application.CrossTenantDenial(getEnv("CROSS_TENANT_DENIAL", string(application.CrossTenantNotFound)))
+	serviceFactory := application.NewServiceFactory(uowFactory, repoFactory, validator, fileStorage, encryptor, tfParser, executionStorage, tfExecutor, pathChecker).
+		WithCrossTenantDenial(crossTenantDenial).
+		WithIDGenerator(idGenerator)
+	testServiceFactory = serviceFactory
 
 	// Build the Fiber app (mirrors cmd/server/main.go).
 	app := fiber.New(fiber.Config{
 		AppName:      "Dev-Share Backend Test",
 		ErrorHandler: handlererrors.ErrorHandler(),
+		JSONDecoder:  jsonutil.Unmarshal,
 	})
+	app.Use(middleware.Recover())
+	app.Use(middleware.RequireTLS(false, "/health"))
+	app.Use(middleware.AuditTransactions())
+	app.Use(middleware.RejectUnexpectedBody(false))
+	if getEnv("RESPONSE_ENVELOPE", "false") == "true" {
+		app.Use(middleware.EnvelopeResponses())
+	}
 
 	app.Get("/health", func(c *fiber.Ctx) error {
 		return c.JSON(fiber.Map{"status": "healthy"})
 	})
 
-	adminHandler := handlers.NewAdminHandler(serviceFactory.NewAdminService, jwtSvc, "")
-	app.Post("/admin/init", adminHandler.InitializeSystem)
+	purger := application.NewSoftDeletePurger(uowFactory, repoFactory, fileStorage, executionStorage, validator, 0, application.DefaultPurgeRetention)
+	adminHandler := handlers.NewAdminHandler(serviceFactory.NewAdminService, jwtSvc, "").WithPurger(purger)
+	app.Post("/admin/init", middleware.NewFailureBackoff(middleware.DefaultBackoffMaxAttempts, middleware.DefaultBackoffBaseDelay).Handler(), adminHandler.InitializeSystem)
 
 	api := app.Group("/api/v1")
 
 	userHandler := handlers.NewUserHandler(serviceFactory.NewUserService, jwtSvc)
 	userHandler.RegisterRoutes(api)
-
-	protected := api.Group("", middleware.RequireAuth(jwtSvc, jwt.DefaultCookieConfig()))
-	workspaceHandler := handlers.NewWorkspaceHandler(serviceFactory.NewWorkspaceService)
-	workspaceHandler.RegisterRoutes(protected)
-
-	templateHandler := handlers.NewTemplateHandler(serviceFactory.NewTemplateService)
-	templateHandler.RegisterRoutes(protected)
-
-	templateVariableHandler := handlers.NewTemplateVariableHandler(serviceFactory.NewTemplateVariableService)
-	templateVariableHandler.RegisterRoutes(protected)
-
-	envVarValueHandler := handlers.NewEnvironmentVariableValueHandler(serviceFactory.NewEnvironmentVariableValueService)
-	envVarValueHandler.RegisterRoutes(protected)
-
-	// Admin-level routes — only admin can access
-	adminProtected := protected.Group("", middleware.RequireRole(domain.RoleAdmin))
-	adminHandler.RegisterAdminRoutes(adminProtected)
-
-	groupHandler := handlers.NewGroupHandler(serviceFactory.NewGroupService)
-	groupHandler.RegisterRoutes(adminProtected)
+	handlers.NewLimitsHandler(validator.ContentPolicy()).RegisterRoutes(api)
+	tokenEpochChecker := application.NewTokenEpochChecker(uowFactory, repoFactory)
+	handlers.NewAuthHandler(jwtSvc, tokenEpochChecker).RegisterRoutes(api)
+
+	// Protected routes — authorization for every route is declared in middleware.RoutePolicies
+	policies := middleware.NewPolicyMatrix("/api/v1", middleware.RoutePolicies()).WithCrossTenantDenial(crossTenantDenial)
+	activityTracker := application.NewActivityTracker(uowFactory, repoFactory, time.Hour)
+	protected := api.Group("",
+		middleware.AuthenticateAPIKey(serviceFactory.NewAPIKeyService()),
+		middleware.RequireAuth(jwtSvc, jwt.DefaultCookieConfig()),
+		middleware.RejectRevokedTokens(tokenEpochChecker),
+		middleware.ScopeToWorkspace(),
+		middleware.RequireScope(jwt.ScopeWrite),
+		middleware.Authorize(policies),
+		middleware.TrackActivity(activityTracker),
+	)
+	userHandler.RegisterProtectedRoutes(protected)
+	handlers.NewEnvironmentHandler(serviceFactory.NewEnvironmentService).WithCrossTenantDenial(crossTenantDenial).RegisterRoutes(protected)
+	handlers.NewEnvironmentVariableValueHandler(serviceFactory.NewEnvironmentVariableValueService).RegisterRoutes(protected)
+	handlers.NewWorkspaceHandler(serviceFactory.NewWorkspaceService).RegisterRoutes(protected)
+	handlers.NewTemplateHandler(serviceFactory.NewTemplateService).WithCrossTenantDenial(crossTenantDenial).RegisterRoutes(protected)
+	handlers.NewTemplateVariableHandler(serviceFactory.NewTemplateVariableService).RegisterRoutes(protected)
+	adminHandler.RegisterAdminRoutes(protected)
+	handlers.NewGroupHandler(serviceFactory.NewGroupService).RegisterRoutes(protected)
+	handlers.NewAPIKeyHandler(serviceFactory.NewAPIKeyService).RegisterRoutes(protected)
+	handlers.NewDebugHandler(time.Now()).RegisterRoutes(protected)
 
 	// Listen on a random available port.
 	ln, err := net.Listen("tcp", "127.0.0.1:0")
@@ -0,0 +1,107 @@
+package integration_tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// getRawBody performs an authenticated GET and returns the raw response body.
+func getRawBody(t *testing.T, auth AuthContext, path string) ([]byte, int) {
+	t.Helper()
+
+	req, _ := http.NewRequest(http.MethodGet, BaseURL+path, nil)
+	addAuth(t, req, auth)
+
+	resp, err := HTTPClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to GET %s: %v", path, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read body of %s: %v", path, err)
+	}
+	return body, resp.StatusCode
+}
+
+func TestListWorkspaces_StreamMatchesBuffered(t *testing.T) {
+	auth := AuthContext{
+		UserID:      uuid.New(),
+		UserName:    "Stream User",
+		Role:        "admin",
+		WorkspaceID: uuid.New(),
+	}
+
+	prefix := "Stream WS " + uuid.New().String()[:8]
+	for i := 0; i < 100; i++ {
+		if _, status := CreateWorkspace(t, auth, fmt.Sprintf("%s %03d", prefix, i), "streamed", uuid.New()); status != http.StatusCreated {
+			t.Fatalf("failed to create workspace %d: status %d", i, status)
+		}
+	}
+
+	query := "/api/v1/workspaces?limit=100&sort_by=name&order=ASC"
+	buffered, status := getRawBody(t, auth, query)
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 for buffered list, got %d", status)
+	}
+	streamed, status := getRawBody(t, auth, query+"&stream=true")
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 for streamed list, got %d", status)
+	}
+
+	if !bytes.Equal(buffered, streamed) {
+		t.Fatalf("streamed body differs from buffered body\nbuffered: %s\nstreamed: %s", buffered, streamed)
+	}
+
+	var workspaces []*WorkspaceResponse
+	if err := json.Unmarshal(streamed, &workspaces); err != nil {
+		t.Fatalf("streamed body is not valid JSON: %v", err)
+	}
+	if len(workspaces) != 100 {
+		t.Errorf("expected 100 workspaces, got %d", len(workspaces))
+	}
+}
+
+func TestListWorkspaces_StreamValidationError(t *testing.T) {
+	auth := AuthContext{
+		UserID:      uuid.New(),
+		UserName:    "Stream User",
+		Role:        "admin",
+		WorkspaceID: uuid.New(),
+	}
+
+	_, status := getRawBody(t, auth, "/api/v1/workspaces?sort_by=password&stream=true")
+	if status != http.StatusBadRequest {
+		t.Errorf("expected 400 before streaming starts, got %d", status)
+	}
+}
+
+func TestListTemplates_StreamMatchesBuffered(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+
+	for i := 0; i < 25; i++ {
+		if _, status := CreateTemplate(t, auth, fmt.Sprintf("Stream Template %02d", i), workspace.ID, defaultFiles()); status != http.StatusCreated {
+			t.Fatalf("failed to create template %d: status %d", i, status)
+		}
+	}
+
+	buffered, status := getRawBody(t, auth, "/api/v1/templates")
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 for buffered list, got %d", status)
+	}
+	streamed, status := getRawBody(t, auth, "/api/v1/templates?stream=true")
+	if status != http.StatusOK {
+		t.Fatalf("expected 200 for streamed list, got %d", status)
+	}
+
+	if !bytes.Equal(buffered, streamed) {
+		t.Fatalf("streamed body differs from buffered body\nbuffered: %s\nstreamed: %s", buffered, streamed)
+	}
+}
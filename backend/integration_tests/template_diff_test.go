@@ -0,0 +1,128 @@
+package integration_tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+type TemplateFileDiffResponse struct {
+	Path   string `json:"path"`
+	Status string `json:"status"`
+	Diff   string `json:"diff"`
+}
+
+type TemplateDiffResponse struct {
+	ID      uuid.UUID                  `json:"id"`
+	OtherID uuid.UUID                  `json:"other_id"`
+	Files   []TemplateFileDiffResponse `json:"files"`
+}
+
+func GetTemplateDiff(t *testing.T, auth AuthContext, id, otherID uuid.UUID) (*TemplateDiffResponse, int) {
+	t.Helper()
+
+	status, body := getRawList(t, auth, fmt.Sprintf("/api/v1/templates/%s/diff/%s", id, otherID))
+	if status != http.StatusOK {
+		return nil, status
+	}
+	var diff TemplateDiffResponse
+	if err := json.Unmarshal([]byte(body), &diff); err != nil {
+		t.Fatalf("failed to decode template diff: %v", err)
+	}
+	return &diff, status
+}
+
+func TestTemplateDiff_AddedRemovedModified(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, workspace.Name)
+
+	base, status := CreateTemplate(t, auth, "Diff Base", workspace.ID, map[string]string{
+		"main.tf":      "resource \"null_resource\" \"a\" {}\n",
+		"variables.tf": "variable \"region\" {}\n",
+		"outputs.tf":   "output \"id\" {}\n",
+	})
+	if status != http.StatusCreated {
+		t.Fatalf("failed to create template: status %d", status)
+	}
+	changed, status := CreateTemplate(t, auth, "Diff Changed", workspace.ID, map[string]string{
+		"main.tf":        "resource \"null_resource\" \"b\" {}\n",
+		"variables.tf":   "variable \"region\" {}\n",
+		"modules/ec2.tf": "module \"ec2\" {}\n",
+	})
+	if status != http.StatusCreated {
+		t.Fatalf("failed to create template: status %d", status)
+	}
+
+	diff, status := GetTemplateDiff(t, auth, base.ID, changed.ID)
+	if status != http.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+	if diff.ID != base.ID || diff.OtherID != changed.ID {
+		t.Errorf("expected a diff from %s to %s, got %s to %s", base.ID, changed.ID, diff.ID, diff.OtherID)
+	}
+
+	// Sorted by path; the identical variables.tf is left out
+	want := []struct{ path, status, line string }{
+		{"main.tf", "modified", "+resource \"null_resource\" \"b\" {}"},
+		{"modules/ec2.tf", "added", "+module \"ec2\" {}"},
+		{"outputs.tf", "removed", "-output \"id\" {}"},
+	}
+	if len(diff.Files) != len(want) {
+		t.Fatalf("expected %d differing files, got %+v", len(want), diff.Files)
+	}
+	for i, w := range want {
+		got := diff.Files[i]
+		if got.Path != w.path || got.Status != w.status {
+			t.Errorf("file %d: expected %s %s, got %s %s", i, w.path, w.status, got.Path, got.Status)
+		}
+		if !strings.Contains(got.Diff, w.line) {
+			t.Errorf("%s: expected the diff to contain %q, got %q", w.path, w.line, got.Diff)
+		}
+	}
+	if !strings.HasPrefix(diff.Files[1].Diff, "--- /dev/null") {
+		t.Errorf("expected an added file to be diffed from /dev/null, got %q", diff.Files[1].Diff)
+	}
+	if !strings.Contains(diff.Files[0].Diff, "-resource \"null_resource\" \"a\" {}") {
+		t.Errorf("expected the modified file to show the removed line, got %q", diff.Files[0].Diff)
+	}
+}
+
+func TestTemplateDiff_IdenticalTemplates(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, workspace.Name)
+
+	first, _ := CreateTemplate(t, auth, "Same One", workspace.ID, defaultFiles())
+	second, _ := CreateTemplate(t, auth, "Same Two", workspace.ID, defaultFiles())
+
+	diff, status := GetTemplateDiff(t, auth, first.ID, second.ID)
+	if status != http.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+	if diff.Files == nil || len(diff.Files) != 0 {
+		t.Errorf("expected an empty files array, got %v", diff.Files)
+	}
+}
+
+func TestTemplateDiff_WorkspaceScoped(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, workspace.Name)
+	otherAuth, other := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, other.Name)
+
+	own, _ := CreateTemplate(t, auth, "Own Template", workspace.ID, defaultFiles())
+	foreign, _ := CreateTemplate(t, otherAuth, "Foreign Template", other.ID, defaultFiles())
+
+	if _, status := GetTemplateDiff(t, auth, own.ID, foreign.ID); status != http.StatusNotFound {
+		t.Errorf("diff against another workspace's template: expected 404, got %d", status)
+	}
+	if _, status := GetTemplateDiff(t, auth, own.ID, uuid.New()); status != http.StatusNotFound {
+		t.Errorf("diff against a missing template: expected 404, got %d", status)
+	}
+	if status, _ := getRawList(t, auth, fmt.Sprintf("/api/v1/templates/%s/diff/not-a-uuid", own.ID)); status != http.StatusBadRequest {
+		t.Errorf("malformed id: expected 400, got %d", status)
+	}
+}
@@ -0,0 +1,49 @@
+package integration_tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestListTemplates_Fields(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, workspace.Name)
+
+	template, status := CreateTemplate(t, auth, "Fields Template", workspace.ID, defaultFiles())
+	if status != http.StatusCreated {
+		t.Fatalf("failed to create template: status %d", status)
+	}
+
+	status, body := getRawList(t, auth, "/api/v1/templates?fields=id,name,name")
+	if status != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", status, body)
+	}
+	var items []map[string]any
+	if err := json.Unmarshal([]byte(body), &items); err != nil {
+		t.Fatalf("failed to decode templates: %v", err)
+	}
+	if len(items) != 1 {
+		t.Fatalf("expected 1 template, got %d", len(items))
+	}
+	item := items[0]
+	if item["id"] != template.ID.String() || item["name"] != "Fields Template" {
+		t.Errorf("expected the requested fields to be set, got %v", item)
+	}
+	for _, omitted := range []string{"workspace_id", "path", "repo_url", "created_at", "updated_at"} {
+		if _, ok := item[omitted]; ok {
+			t.Errorf("expected %s to be omitted, got %v", omitted, item)
+		}
+	}
+}
+
+func TestListTemplates_InvalidFields(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, workspace.Name)
+
+	for _, query := range []string{"?fields=id,password", "?fields=id,,name", "?fields=id&stream=true"} {
+		if status, body := getRawList(t, auth, "/api/v1/templates"+query); status != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d: %s", query, status, body)
+		}
+	}
+}
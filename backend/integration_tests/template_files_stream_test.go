@@ -0,0 +1,70 @@
+package integration_tests
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestListTemplateFiles_StreamedAndCompressed(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, workspace.Name)
+
+	const fileCount = 250
+	files := map[string]string{}
+	for i := 0; i < fileCount; i++ {
+		files[fmt.Sprintf("modules/m%03d/main.tf", i)] = fmt.Sprintf("# module %d\n", i)
+	}
+	created, status := CreateTemplate(t, auth, "Streamed Files", workspace.ID, files)
+	if status != http.StatusCreated {
+		t.Fatalf("failed to create template: status %d", status)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/api/v1/templates/%s/files?stream=true", BaseURL, created.ID), nil)
+	// Set explicitly, the transport then leaves the body compressed
+	req.Header.Set("Accept-Encoding", "gzip")
+	addAuth(t, req, auth)
+
+	resp, err := HTTPClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to list template files: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+	if enc := resp.Header.Get("Content-Encoding"); enc != "gzip" {
+		t.Fatalf("expected a gzip response, got Content-Encoding %q", enc)
+	}
+
+	reader, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to open gzip body: %v", err)
+	}
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("failed to decompress body: %v", err)
+	}
+
+	var listed []TemplateFileInfoResponse
+	if err := json.Unmarshal(body, &listed); err != nil {
+		t.Fatalf("expected a complete JSON array, got %v", err)
+	}
+	if len(listed) != fileCount {
+		t.Fatalf("expected %d files, got %d", fileCount, len(listed))
+	}
+	seen := map[string]bool{}
+	for _, f := range listed {
+		if _, ok := files[f.Name]; !ok || seen[f.Name] {
+			t.Errorf("unexpected or duplicate file %q", f.Name)
+		}
+		seen[f.Name] = true
+		if f.Size != int64(len(files[f.Name])) {
+			t.Errorf("%s: expected size %d, got %d", f.Name, len(files[f.Name]), f.Size)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package integration_tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+type TemplateSchemeCount struct {
+	Scheme string `json:"scheme"`
+	Count  int    `json:"count"`
+}
+
+type TemplateSchemesResponse struct {
+	WorkspaceID uuid.UUID             `json:"workspace_id"`
+	Schemes     []TemplateSchemeCount `json:"schemes"`
+}
+
+func GetTemplateSchemes(t *testing.T, auth AuthContext, workspaceID uuid.UUID) (*TemplateSchemesResponse, int) {
+	t.Helper()
+
+	status, body := getRawList(t, auth, fmt.Sprintf("/api/v1/workspaces/%s/template-schemes", workspaceID))
+	if status != http.StatusOK {
+		return nil, status
+	}
+	var schemes TemplateSchemesResponse
+	if err := json.Unmarshal([]byte(body), &schemes); err != nil {
+		t.Fatalf("failed to decode template schemes: %v", err)
+	}
+	return &schemes, status
+}
+
+func TestTemplateSchemes_GroupsBySourceScheme(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, workspace.Name)
+
+	fixtures := []struct {
+		name    string
+		repoURL string
+	}{
+		{"Scheme Secure One", "https://git.example.com/one.git"},
+		{"Scheme Secure Two", "HTTPS://git.example.com/two.git"},
+		{"Scheme Secure Three", "https://git.example.com/three.git"},
+		{"Scheme Plain", "http://git.example.com/plain.git"},
+		{"Scheme Uploaded One", ""},
+		{"Scheme Uploaded Two", ""},
+	}
+	for _, f := range fixtures {
+		if _, status := CreateTemplateWithRepoURL(t, auth, f.name, workspace.ID, f.repoURL, defaultFiles()); status != http.StatusCreated {
+			t.Fatalf("failed to create template %q: status %d", f.name, status)
+		}
+	}
+
+	schemes, status := GetTemplateSchemes(t, auth, workspace.ID)
+	if status != http.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+	if schemes.WorkspaceID != workspace.ID {
+		t.Errorf("expected workspace %s, got %s", workspace.ID, schemes.WorkspaceID)
+	}
+
+	want := []TemplateSchemeCount{
+		{Scheme: "https", Count: 3},
+		{Scheme: "storage", Count: 2},
+		{Scheme: "http", Count: 1},
+	}
+	if len(schemes.Schemes) != len(want) {
+		t.Fatalf("expected %v, got %v", want, schemes.Schemes)
+	}
+	for i := range want {
+		if schemes.Schemes[i] != want[i] {
+			t.Errorf("position %d: expected %+v, got %+v", i, want[i], schemes.Schemes[i])
+		}
+	}
+}
+
+func TestTemplateSchemes_WorkspaceIsolation(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, workspace.Name)
+	otherAuth, other := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, other.Name)
+
+	if _, status := CreateTemplateWithRepoURL(t, auth, "Isolated Scheme", workspace.ID, "https://git.example.com/isolated.git", defaultFiles()); status != http.StatusCreated {
+		t.Fatalf("failed to create template: status %d", status)
+	}
+
+	if _, status := GetTemplateSchemes(t, otherAuth, workspace.ID); status != http.StatusNotFound {
+		t.Errorf("schemes for another workspace: expected 404, got %d", status)
+	}
+	schemes, status := GetTemplateSchemes(t, otherAuth, other.ID)
+	if status != http.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+	if schemes.Schemes == nil || len(schemes.Schemes) != 0 {
+		t.Errorf("expected an empty schemes array for the other workspace, got %v", schemes.Schemes)
+	}
+}
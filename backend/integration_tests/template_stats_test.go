@@ -0,0 +1,149 @@
+package integration_tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TemplateStatsEntry struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type TemplateStatsResponse struct {
+	WorkspaceID         uuid.UUID            `json:"workspace_id"`
+	Total               int                  `json:"total"`
+	RecentDays          int                  `json:"recent_days"`
+	UpdatedRecently     int                  `json:"updated_recently"`
+	LastUpdatedAt       *time.Time           `json:"last_updated_at"`
+	MostRecentlyUpdated []TemplateStatsEntry `json:"most_recently_updated"`
+}
+
+func GetTemplateStats(t *testing.T, auth AuthContext, workspaceID uuid.UUID, query string) (*TemplateStatsResponse, int) {
+	t.Helper()
+
+	status, body := getRawList(t, auth, fmt.Sprintf("/api/v1/workspaces/%s/template-stats%s", workspaceID, query))
+	if status != http.StatusOK {
+		return nil, status
+	}
+	var stats TemplateStatsResponse
+	if err := json.Unmarshal([]byte(body), &stats); err != nil {
+		t.Fatalf("failed to decode template stats: %v", err)
+	}
+	return &stats, status
+}
+
+// setTemplateUpdatedAt backdates a template so tests control the recency order.
+func setTemplateUpdatedAt(t *testing.T, id uuid.UUID, at time.Time) {
+	t.Helper()
+	if _, err := DbConnection.Exec("UPDATE templates SET updated_at = ? WHERE id = ?", at.UTC().Format("2006-01-02 15:04:05"), id); err != nil {
+		t.Fatalf("failed to set updated_at: %v", err)
+	}
+}
+
+func TestTemplateStats_Aggregates(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, workspace.Name)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	ages := map[string]time.Duration{
+		"Stats Old":    30 * 24 * time.Hour,
+		"Stats Recent": 2 * 24 * time.Hour,
+		"Stats Newest": time.Hour,
+	}
+	ids := map[string]uuid.UUID{}
+	for name, age := range ages {
+		template, status := CreateTemplate(t, auth, name, workspace.ID, defaultFiles())
+		if status != http.StatusCreated {
+			t.Fatalf("failed to create template %q: status %d", name, status)
+		}
+		ids[name] = template.ID
+		setTemplateUpdatedAt(t, template.ID, now.Add(-age))
+	}
+
+	stats, status := GetTemplateStats(t, auth, workspace.ID, "?recent=2")
+	if status != http.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+	if stats.WorkspaceID != workspace.ID {
+		t.Errorf("expected workspace %s, got %s", workspace.ID, stats.WorkspaceID)
+	}
+	if stats.Total != 3 {
+		t.Errorf("expected total 3, got %d", stats.Total)
+	}
+	if stats.RecentDays != 7 || stats.UpdatedRecently != 2 {
+		t.Errorf("expected 2 templates updated in the last 7 days, got %d in %d", stats.UpdatedRecently, stats.RecentDays)
+	}
+	if stats.LastUpdatedAt == nil || !stats.LastUpdatedAt.Equal(now.Add(-time.Hour)) {
+		t.Errorf("expected last_updated_at %v, got %v", now.Add(-time.Hour), stats.LastUpdatedAt)
+	}
+	if len(stats.MostRecentlyUpdated) != 2 {
+		t.Fatalf("expected 2 recent templates, got %d", len(stats.MostRecentlyUpdated))
+	}
+	if stats.MostRecentlyUpdated[0].ID != ids["Stats Newest"] || stats.MostRecentlyUpdated[1].ID != ids["Stats Recent"] {
+		t.Errorf("expected newest then recent, got %+v", stats.MostRecentlyUpdated)
+	}
+
+	wide, status := GetTemplateStats(t, auth, workspace.ID, "?recent_days=60")
+	if status != http.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+	if wide.UpdatedRecently != 3 || len(wide.MostRecentlyUpdated) != 3 {
+		t.Errorf("expected all 3 templates within 60 days, got %d (%d listed)", wide.UpdatedRecently, len(wide.MostRecentlyUpdated))
+	}
+}
+
+func TestTemplateStats_EmptyWorkspace(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, workspace.Name)
+
+	stats, status := GetTemplateStats(t, auth, workspace.ID, "")
+	if status != http.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+	if stats.Total != 0 || stats.UpdatedRecently != 0 || stats.LastUpdatedAt != nil {
+		t.Errorf("expected empty stats, got %+v", stats)
+	}
+	if stats.MostRecentlyUpdated == nil || len(stats.MostRecentlyUpdated) != 0 {
+		t.Errorf("expected an empty most_recently_updated array, got %v", stats.MostRecentlyUpdated)
+	}
+}
+
+func TestTemplateStats_WorkspaceIsolation(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, workspace.Name)
+	otherAuth, other := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, other.Name)
+
+	if _, status := CreateTemplate(t, auth, "Isolated Template", workspace.ID, defaultFiles()); status != http.StatusCreated {
+		t.Fatalf("failed to create template: status %d", status)
+	}
+
+	if _, status := GetTemplateStats(t, otherAuth, workspace.ID, ""); status != http.StatusNotFound {
+		t.Errorf("stats for another workspace: expected 404, got %d", status)
+	}
+	stats, status := GetTemplateStats(t, otherAuth, other.ID, "")
+	if status != http.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+	if stats.Total != 0 {
+		t.Errorf("expected the other workspace's templates not to be counted, got %d", stats.Total)
+	}
+}
+
+func TestTemplateStats_InvalidQuery(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, workspace.Name)
+
+	for _, query := range []string{"?recent_days=0", "?recent=51", "?recent=abc"} {
+		if _, status := GetTemplateStats(t, auth, workspace.ID, query); status != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", query, status)
+		}
+	}
+}
@@ -1,9 +1,19 @@
 package integration_tests
 
 import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	"backend/internal/infra/sqlite"
+	"backend/pkg/errors"
 
 	"github.com/google/uuid"
 )
@@ -85,6 +95,23 @@ func TestCreateTemplate_Success(t *testing.T) {
 	}
 }
 
+func TestCreateTemplate_DuplicateNameConflict(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+	otherAuth, otherWorkspace := setupWorkspaceForTemplates(t)
+
+	if _, status := CreateTemplate(t, auth, "Duplicate Name", workspace.ID, defaultFiles()); status != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d", status)
+	}
+
+	if _, status := CreateTemplate(t, auth, "Duplicate Name", workspace.ID, defaultFiles()); status != http.StatusConflict {
+		t.Errorf("expected status 409 for duplicate name, got %d", status)
+	}
+	// Names only need to be unique within a workspace
+	if _, status := CreateTemplate(t, otherAuth, "Duplicate Name", otherWorkspace.ID, defaultFiles()); status != http.StatusCreated {
+		t.Errorf("expected status 201 in another workspace, got %d", status)
+	}
+}
+
 func TestCreateTemplate_ValidationErrors(t *testing.T) {
 	auth, workspace := setupWorkspaceForTemplates(t)
 
@@ -130,6 +157,95 @@ func TestCreateTemplate_ValidationErrors(t *testing.T) {
 	}
 }
 
+func TestCreateTemplate_RepoURL(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+
+	tests := []struct {
+		name       string
+		repoURL    string
+		wantStatus int
+	}{
+		{"omitted", "", http.StatusCreated},
+		{"https", "https://github.com/example/infra.git", http.StatusCreated},
+		{"http with path", "http://git.internal:8080/team/repo", http.StatusCreated},
+		{"ssh scheme rejected", "ssh://git@github.com/example/infra.git", http.StatusBadRequest},
+		{"file scheme rejected", "file:///etc/passwd", http.StatusBadRequest},
+		{"not a url", "github.com/example/infra", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template, status := CreateTemplateWithRepoURL(t, auth, "Repo "+tt.name, workspace.ID, tt.repoURL, defaultFiles())
+			if status != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, status)
+			}
+			if status != http.StatusCreated {
+				return
+			}
+
+			if template.RepoURL != tt.repoURL {
+				t.Errorf("expected repo_url '%s', got '%s'", tt.repoURL, template.RepoURL)
+			}
+
+			fetched, status := GetTemplate(t, auth, template.ID)
+			if status != http.StatusOK {
+				t.Fatalf("expected status 200, got %d", status)
+			}
+			if fetched.RepoURL != tt.repoURL {
+				t.Errorf("expected persisted repo_url '%s', got '%s'", tt.repoURL, fetched.RepoURL)
+			}
+		})
+	}
+}
+
+func TestUpdateTemplate_RepoURL(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+	const repoURL = "https://github.com/example/infra.git"
+
+	template, status := CreateTemplateWithRepoURL(t, auth, "Repo Update", workspace.ID, repoURL, defaultFiles())
+	if status != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d", status)
+	}
+
+	// Omitting repo_url keeps it
+	updated, status := UpdateTemplate(t, auth, template.ID, "Repo Update Renamed")
+	if status != http.StatusOK {
+		t.Fatalf("rename: expected status 200, got %d", status)
+	}
+	if updated.RepoURL != repoURL {
+		t.Errorf("expected an omitted repo_url to be kept, got '%s'", updated.RepoURL)
+	}
+
+	if _, status := UpdateTemplateRepoURL(t, auth, template.ID, "ssh://git@github.com/example/infra.git"); status != http.StatusBadRequest {
+		t.Errorf("ssh repo_url: expected status 400, got %d", status)
+	}
+
+	// An empty repo_url clears it
+	updated, status = UpdateTemplateRepoURL(t, auth, template.ID, "")
+	if status != http.StatusOK {
+		t.Fatalf("clear: expected status 200, got %d", status)
+	}
+	if !updated.Modified || updated.RepoURL != "" {
+		t.Errorf("expected repo_url to be cleared, got modified=%v repo_url='%s'", updated.Modified, updated.RepoURL)
+	}
+	fetched, status := GetTemplate(t, auth, template.ID)
+	if status != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", status)
+	}
+	if fetched.RepoURL != "" {
+		t.Errorf("expected the cleared repo_url to be persisted, got '%s'", fetched.RepoURL)
+	}
+
+	// Clearing it again changes nothing
+	updated, status = UpdateTemplateRepoURL(t, auth, template.ID, "")
+	if status != http.StatusOK {
+		t.Fatalf("clear again: expected status 200, got %d", status)
+	}
+	if updated.Modified {
+		t.Error("expected clearing an unset repo_url to be a no-op")
+	}
+}
+
 func TestCreateTemplate_ForbiddenOtherWorkspace(t *testing.T) {
 	auth, _ := setupWorkspaceForTemplates(t)
 	_, otherWorkspace := setupWorkspaceForTemplates(t)
@@ -142,6 +258,30 @@ func TestCreateTemplate_ForbiddenOtherWorkspace(t *testing.T) {
 	}
 }
 
+func TestCreateTemplate_MissingWorkspaceID(t *testing.T) {
+	auth, _ := setupWorkspaceForTemplates(t)
+
+	// No workspace_id in the body is a malformed request, not a cross-workspace attempt -> 400
+	status := CreateTemplateWithFields(t, auth, map[string]string{"name": "No Workspace Template"}, defaultFiles())
+
+	if status != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", status)
+	}
+}
+
+func TestCreateTemplate_MalformedWorkspaceID(t *testing.T) {
+	auth, _ := setupWorkspaceForTemplates(t)
+
+	status := CreateTemplateWithFields(t, auth, map[string]string{
+		"name":         "Malformed Workspace Template",
+		"workspace_id": "not-a-uuid",
+	}, defaultFiles())
+
+	if status != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", status)
+	}
+}
+
 // --- Get ---
 
 func TestGetTemplate_Success(t *testing.T) {
@@ -194,7 +334,8 @@ func TestGetTemplate_InvalidID(t *testing.T) {
 	}
 }
 
-func TestGetTemplate_ForbiddenOtherWorkspace(t *testing.T) {
+// A template in another workspace must be indistinguishable from a missing one
+func TestGetTemplate_OtherWorkspaceNotFound(t *testing.T) {
 	auth, workspace := setupWorkspaceForTemplates(t)
 	otherAuth, _ := setupWorkspaceForTemplates(t)
 
@@ -202,13 +343,80 @@ func TestGetTemplate_ForbiddenOtherWorkspace(t *testing.T) {
 
 	_, status := GetTemplate(t, otherAuth, created.ID)
 
-	if status != http.StatusForbidden {
-		t.Errorf("expected status 403, got %d", status)
+	if status != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", status)
 	}
 }
 
 // --- GetByWorkspace ---
 
+// --- Validate path ---
+
+func TestValidateTemplatePath_StorageReachable(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+
+	template, status := CreateTemplate(t, auth, "Validate Storage", workspace.ID, defaultFiles())
+	if status != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d", status)
+	}
+
+	result, status := ValidateTemplatePath(t, auth, template.ID)
+	if status != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", status)
+	}
+	if !result.Reachable {
+		t.Errorf("expected template path to be reachable, got %+v", result.Checks)
+	}
+	if len(result.Checks) != 1 || result.Checks[0].Scheme != "storage" {
+		t.Errorf("expected a single storage check, got %+v", result.Checks)
+	}
+}
+
+// The server only checks repository URLs on public addresses, so a repo_url
+// pointing back at the host is reported unreachable without being contacted.
+func TestValidateTemplatePath_RepoURLOnLoopbackIsRefused(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+
+	var hits atomic.Int32
+	local := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+	}))
+	defer local.Close()
+
+	repoURL := local.URL + "/repo.git"
+	template, status := CreateTemplateWithRepoURL(t, auth, "Validate loopback", workspace.ID, repoURL, defaultFiles())
+	if status != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d", status)
+	}
+
+	result, status := ValidateTemplatePath(t, auth, template.ID)
+	if status != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", status)
+	}
+	if result.Reachable {
+		t.Errorf("expected reachable=false, got %+v", result.Checks)
+	}
+	if len(result.Checks) != 2 {
+		t.Fatalf("expected storage and repo_url checks, got %+v", result.Checks)
+	}
+	repoCheck := result.Checks[1]
+	if repoCheck.Target != repoURL || repoCheck.Reachable || repoCheck.Detail != "destination address is not allowed" {
+		t.Errorf("unexpected repo_url check: %+v", repoCheck)
+	}
+	if hits.Load() != 0 {
+		t.Errorf("expected the loopback server not to be contacted, got %d requests", hits.Load())
+	}
+}
+
+func TestValidateTemplatePath_NotFound(t *testing.T) {
+	auth, _ := setupWorkspaceForTemplates(t)
+
+	_, status := ValidateTemplatePath(t, auth, uuid.New())
+	if status != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", status)
+	}
+}
+
 func TestGetTemplatesByWorkspace_Success(t *testing.T) {
 	auth, workspace := setupWorkspaceForTemplates(t)
 
@@ -243,14 +451,67 @@ func TestGetTemplatesByWorkspace_Empty(t *testing.T) {
 	}
 }
 
-func TestGetTemplatesByWorkspace_Forbidden(t *testing.T) {
+func TestGetTemplatesByWorkspace_Paged(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+
+	for _, name := range []string{"Paged A", "Paged B", "Paged C", "Paged D", "Paged E"} {
+		if _, status := CreateTemplate(t, auth, name, workspace.ID, defaultFiles()); status != http.StatusCreated {
+			t.Fatalf("failed to create template %q: status %d", name, status)
+		}
+	}
+
+	page, status := GetTemplatesByWorkspacePage(t, auth, workspace.ID, "limit=2&offset=1&sort_by=name&order=ASC")
+	if status != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", status)
+	}
+	if page.Total != 5 {
+		t.Errorf("expected total 5, got %d", page.Total)
+	}
+	if page.Limit != 2 || page.Offset != 1 {
+		t.Errorf("expected limit 2 offset 1, got limit %d offset %d", page.Limit, page.Offset)
+	}
+	if len(page.Items) != 2 {
+		t.Fatalf("expected 2 items, got %d", len(page.Items))
+	}
+	if page.Items[0].Name != "Paged B" || page.Items[1].Name != "Paged C" {
+		t.Errorf("expected [Paged B, Paged C], got [%s, %s]", page.Items[0].Name, page.Items[1].Name)
+	}
+
+	// Past the end: empty window, total unchanged
+	page, status = GetTemplatesByWorkspacePage(t, auth, workspace.ID, "limit=10&offset=10")
+	if status != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", status)
+	}
+	if page.Total != 5 || len(page.Items) != 0 {
+		t.Errorf("expected total 5 and no items, got total %d and %d items", page.Total, len(page.Items))
+	}
+
+	// Without a limit the legacy array is returned
+	templates, status := GetTemplatesByWorkspace(t, auth, workspace.ID)
+	if status != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", status)
+	}
+	if len(templates) != 5 {
+		t.Errorf("expected 5 templates in legacy response, got %d", len(templates))
+	}
+}
+
+func TestGetTemplatesByWorkspace_PagedInvalidLimit(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+
+	if _, status := GetTemplatesByWorkspacePage(t, auth, workspace.ID, "limit=1000"); status != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", status)
+	}
+}
+
+func TestGetTemplatesByWorkspace_OtherWorkspaceNotFound(t *testing.T) {
 	_, workspace := setupWorkspaceForTemplates(t)
 	otherAuth, _ := setupWorkspaceForTemplates(t)
 
 	_, status := GetTemplatesByWorkspace(t, otherAuth, workspace.ID)
 
-	if status != http.StatusForbidden {
-		t.Errorf("expected status 403, got %d", status)
+	if status != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", status)
 	}
 }
 
@@ -297,6 +558,50 @@ func TestUpdateTemplate_PartialUpdate(t *testing.T) {
 	}
 }
 
+func TestUpdateTemplate_RenameConflict(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+	CreateTemplate(t, auth, "Taken Name", workspace.ID, defaultFiles())
+	created, _ := CreateTemplate(t, auth, "Rename Me", workspace.ID, defaultFiles())
+
+	if _, status := UpdateTemplate(t, auth, created.ID, "Taken Name"); status != http.StatusConflict {
+		t.Errorf("expected status 409 when renaming onto an existing name, got %d", status)
+	}
+	// Keeping its own name is not a conflict
+	if _, status := UpdateTemplate(t, auth, created.ID, "Rename Me"); status != http.StatusOK {
+		t.Errorf("expected status 200 when keeping the same name, got %d", status)
+	}
+}
+
+func TestUpdateTemplate_ReportsModified(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+	created, _ := CreateTemplate(t, auth, "Modified Template", workspace.ID, defaultFiles())
+
+	// Every field omitted
+	unchanged, status := UpdateTemplate(t, auth, created.ID, "")
+	if status != http.StatusOK {
+		t.Fatalf("no-op update: expected status 200, got %d", status)
+	}
+	if unchanged.Modified {
+		t.Error("expected a no-op update to report modified:false")
+	}
+	if !unchanged.UpdatedAt.Equal(created.UpdatedAt.Truncate(time.Second)) {
+		t.Errorf("expected a no-op update to keep updated_at %v, got %v", created.UpdatedAt, unchanged.UpdatedAt)
+	}
+
+	// Setting the current name changes nothing either
+	if same, _ := UpdateTemplate(t, auth, created.ID, "Modified Template"); same.Modified {
+		t.Error("expected an update to the current name to report modified:false")
+	}
+
+	renamed, status := UpdateTemplate(t, auth, created.ID, "Renamed Template")
+	if status != http.StatusOK {
+		t.Fatalf("update: expected status 200, got %d", status)
+	}
+	if !renamed.Modified || renamed.Name != "Renamed Template" {
+		t.Errorf("expected an effective update to report modified:true, got %+v", renamed)
+	}
+}
+
 func TestUpdateTemplate_NotFound(t *testing.T) {
 	auth, _ := setupWorkspaceForTemplates(t)
 
@@ -307,7 +612,8 @@ func TestUpdateTemplate_NotFound(t *testing.T) {
 	}
 }
 
-func TestUpdateTemplate_ForbiddenOtherWorkspace(t *testing.T) {
+// A template in another workspace must be indistinguishable from a missing one
+func TestUpdateTemplate_OtherWorkspaceNotFound(t *testing.T) {
 	auth, workspace := setupWorkspaceForTemplates(t)
 	otherAuth, _ := setupWorkspaceForTemplates(t)
 
@@ -315,8 +621,8 @@ func TestUpdateTemplate_ForbiddenOtherWorkspace(t *testing.T) {
 
 	_, status := UpdateTemplate(t, otherAuth, created.ID, "Forbidden Update")
 
-	if status != http.StatusForbidden {
-		t.Errorf("expected status 403, got %d", status)
+	if status != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", status)
 	}
 }
 
@@ -348,7 +654,8 @@ func TestDeleteTemplate_NotFound(t *testing.T) {
 	}
 }
 
-func TestDeleteTemplate_ForbiddenOtherWorkspace(t *testing.T) {
+// A template in another workspace must be indistinguishable from a missing one
+func TestDeleteTemplate_OtherWorkspaceNotFound(t *testing.T) {
 	auth, workspace := setupWorkspaceForTemplates(t)
 	otherAuth, _ := setupWorkspaceForTemplates(t)
 
@@ -356,8 +663,8 @@ func TestDeleteTemplate_ForbiddenOtherWorkspace(t *testing.T) {
 
 	status := DeleteTemplate(t, otherAuth, created.ID)
 
-	if status != http.StatusForbidden {
-		t.Errorf("expected status 403, got %d", status)
+	if status != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", status)
 	}
 }
 
@@ -400,6 +707,224 @@ func TestListTemplates_FilteredByWorkspace(t *testing.T) {
 	}
 }
 
+func TestListTemplates_FilterByWhitelistedColumn(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+
+	CreateTemplateWithRepoURL(t, auth, "Filter Networking", workspace.ID, "https://git.example.com/net.git", defaultFiles())
+	CreateTemplateWithRepoURL(t, auth, "Filter Compute", workspace.ID, "https://git.example.com/compute.git", defaultFiles())
+
+	templates, status := ListTemplatesWithFilters(t, auth, 10, 0, map[string]string{"name": "Filter Networking"})
+	if status != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", status)
+	}
+	if len(templates) != 1 || templates[0].Name != "Filter Networking" {
+		t.Fatalf("expected only 'Filter Networking', got %d templates", len(templates))
+	}
+
+	templates, status = ListTemplatesWithFilters(t, auth, 10, 0, map[string]string{"repo_url": "https://git.example.com/compute.git"})
+	if status != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", status)
+	}
+	if len(templates) != 1 || templates[0].Name != "Filter Compute" {
+		t.Fatalf("expected only 'Filter Compute', got %d templates", len(templates))
+	}
+}
+
+func TestListTemplates_MalformedLimit(t *testing.T) {
+	auth, _ := setupWorkspaceForTemplates(t)
+
+	req, _ := http.NewRequest(http.MethodGet, BaseURL+"/api/v1/templates?limit=abc", nil)
+	addAuth(t, req, auth)
+	resp, err := HTTPClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to list templates: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", resp.StatusCode)
+	}
+	var errResp struct {
+		Error ErrorResponse `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&errResp); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+	fields, _ := errResp.Error.Metadata["fields"].(map[string]interface{})
+	if fields["limit"] != "limit must be an integer" {
+		t.Errorf("expected a limit field error, got metadata %v", errResp.Error.Metadata)
+	}
+}
+
+func TestListTemplates_UnknownFilterRejected(t *testing.T) {
+	auth, _ := setupWorkspaceForTemplates(t)
+
+	_, status := ListTemplatesWithFilters(t, auth, 10, 0, map[string]string{"path": "anything"})
+	if status != http.StatusBadRequest {
+		t.Errorf("expected status 400 for non-whitelisted filter, got %d", status)
+	}
+}
+
+func TestListTemplates_FilterWithPagination(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+
+	const repoURL = "https://git.example.com/paged.git"
+	for i := range 5 {
+		CreateTemplateWithRepoURL(t, auth, fmt.Sprintf("Paged %d", i), workspace.ID, repoURL, defaultFiles())
+	}
+	CreateTemplate(t, auth, "Paged Unrelated", workspace.ID, defaultFiles())
+
+	seen := make(map[uuid.UUID]bool)
+	for offset := 0; offset < 6; offset += 2 {
+		page, status := ListTemplatesWithFilters(t, auth, 2, offset, map[string]string{"repo_url": repoURL})
+		if status != http.StatusOK {
+			t.Fatalf("offset %d: expected status 200, got %d", offset, status)
+		}
+		if want := min(2, 5-offset); len(page) != want {
+			t.Fatalf("offset %d: expected %d templates, got %d", offset, want, len(page))
+		}
+		for _, tmpl := range page {
+			if tmpl.RepoURL != repoURL {
+				t.Errorf("offset %d: template %q does not match the filter", offset, tmpl.Name)
+			}
+			if seen[tmpl.ID] {
+				t.Errorf("offset %d: template %q returned on more than one page", offset, tmpl.Name)
+			}
+			seen[tmpl.ID] = true
+		}
+	}
+	if len(seen) != 5 {
+		t.Errorf("expected 5 filtered templates across pages, got %d", len(seen))
+	}
+}
+
+func TestListTemplates_CSVExport(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+
+	created := map[string]*TemplateResponse{}
+	for _, name := range []string{"CSV Alpha", "CSV Beta, with comma"} {
+		template, status := CreateTemplate(t, auth, name, workspace.ID, defaultFiles())
+		if status != http.StatusCreated {
+			t.Fatalf("failed to create template %q: status %d", name, status)
+		}
+		created[template.ID.String()] = template
+	}
+
+	body, contentType, status := ListTemplatesWithAccept(t, auth, "text/csv")
+	if status != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", status)
+	}
+	if !strings.HasPrefix(contentType, "text/csv") {
+		t.Errorf("expected text/csv content type, got %q", contentType)
+	}
+
+	records, err := csv.NewReader(strings.NewReader(body)).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to parse CSV: %v\n%s", err, body)
+	}
+	if len(records) == 0 {
+		t.Fatal("expected a header row, got an empty body")
+	}
+	if header := strings.Join(records[0], ","); header != "id,name,path,created_at,updated_at" {
+		t.Errorf("unexpected header row %q", header)
+	}
+
+	rows := records[1:]
+	if len(rows) != len(created) {
+		t.Fatalf("expected %d rows, got %d", len(created), len(rows))
+	}
+	for _, row := range rows {
+		template, ok := created[row[0]]
+		if !ok {
+			t.Errorf("unexpected template id %s", row[0])
+			continue
+		}
+		if row[1] != template.Name || row[2] != template.Path {
+			t.Errorf("row %v does not match template %q", row, template.Name)
+		}
+	}
+}
+
+func TestListTemplates_CSVExportIncludesEveryRow(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+
+	// One more than the default page size
+	const total = 51
+	for i := 0; i < total; i++ {
+		if _, status := CreateTemplate(t, auth, fmt.Sprintf("Export %02d", i), workspace.ID, defaultFiles()); status != http.StatusCreated {
+			t.Fatalf("failed to create template %d: status %d", i, status)
+		}
+	}
+
+	body, _, status := ListTemplatesWithAccept(t, auth, "text/csv")
+	if status != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", status)
+	}
+	records, err := csv.NewReader(strings.NewReader(body)).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to parse CSV: %v", err)
+	}
+	if rows := len(records) - 1; rows != total {
+		t.Errorf("expected all %d templates in the export, got %d", total, rows)
+	}
+}
+
+func TestListTemplates_CSVExportEscapesFormulas(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+
+	names := []string{"=HYPERLINK(\"http://evil\")", "+1+1", "-2+3", "@SUM(A1)", "Plain"}
+	for _, name := range names {
+		if _, status := CreateTemplate(t, auth, name, workspace.ID, defaultFiles()); status != http.StatusCreated {
+			t.Fatalf("failed to create template %q: status %d", name, status)
+		}
+	}
+
+	body, _, status := ListTemplatesWithAccept(t, auth, "text/csv")
+	if status != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", status)
+	}
+	records, err := csv.NewReader(strings.NewReader(body)).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to parse CSV: %v", err)
+	}
+
+	exported := map[string]bool{}
+	for _, row := range records[1:] {
+		exported[row[1]] = true
+	}
+	for _, name := range names {
+		want := name
+		if name != "Plain" {
+			want = "'" + name
+		}
+		if !exported[want] {
+			t.Errorf("expected name %q to be exported as %q, got %v", name, want, exported)
+		}
+	}
+}
+
+func TestListTemplates_JSONIsDefault(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+	CreateTemplate(t, auth, "JSON Default", workspace.ID, defaultFiles())
+
+	for _, accept := range []string{"", "*/*", "application/json"} {
+		body, contentType, status := ListTemplatesWithAccept(t, auth, accept)
+		if status != http.StatusOK {
+			t.Fatalf("Accept %q: expected status 200, got %d", accept, status)
+		}
+		if !strings.HasPrefix(contentType, "application/json") {
+			t.Errorf("Accept %q: expected application/json, got %q", accept, contentType)
+		}
+		var templates []*TemplateResponse
+		if err := json.Unmarshal([]byte(body), &templates); err != nil {
+			t.Errorf("Accept %q: expected a JSON array: %v", accept, err)
+		}
+		if len(templates) != 1 {
+			t.Errorf("Accept %q: expected 1 template, got %d", accept, len(templates))
+		}
+	}
+}
+
 // --- Nested files ---
 
 func TestCreateTemplate_NestedFiles(t *testing.T) {
@@ -465,6 +990,27 @@ func TestGetTemplateFileContent_NestedPath(t *testing.T) {
 	}
 }
 
+func TestGetTemplateFileContent_DetectsLanguage(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+
+	created, status := CreateTemplate(t, auth, "Language Template", workspace.ID, defaultFiles())
+	if status != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d", status)
+	}
+
+	status, body := getRawList(t, auth, fmt.Sprintf("/api/v1/templates/%s/files/content?path=main.tf", created.ID))
+	if status != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", status)
+	}
+	var file TemplateFileContentResponse
+	if err := json.Unmarshal([]byte(body), &file); err != nil {
+		t.Fatalf("expected a JSON file response, got %q", body)
+	}
+	if file.Path != "main.tf" || file.Language != "hcl" || file.Content == "" {
+		t.Errorf("expected main.tf as hcl with its content, got %+v", file)
+	}
+}
+
 func TestGetTemplateFileContent_PathTraversal(t *testing.T) {
 	auth, workspace := setupWorkspaceForTemplates(t)
 
@@ -520,3 +1066,124 @@ func TestListTemplates_InvalidSortBy(t *testing.T) {
 		})
 	}
 }
+
+func TestTemplateFiles_OtherWorkspaceNotFound(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+	otherAuth, _ := setupWorkspaceForTemplates(t)
+
+	created, _ := CreateTemplate(t, auth, "Other WS Files Template", workspace.ID, defaultFiles())
+
+	if _, status := ListTemplateFiles(t, otherAuth, created.ID); status != http.StatusNotFound {
+		t.Errorf("expected status 404 listing files, got %d", status)
+	}
+	if _, status := GetTemplateFileContent(t, otherAuth, created.ID, "main.tf"); status != http.StatusNotFound {
+		t.Errorf("expected status 404 reading a file, got %d", status)
+	}
+}
+
+func TestGetTemplate_OtherWorkspaceMatchesMissingResponse(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+	otherAuth, _ := setupWorkspaceForTemplates(t)
+
+	created, _ := CreateTemplate(t, auth, "Probe Target Template", workspace.ID, defaultFiles())
+
+	getError := func(id uuid.UUID) (int, *ErrorResponse) {
+		req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/api/v1/templates/%s", BaseURL, id), nil)
+		addAuth(t, req, otherAuth)
+
+		resp, err := HTTPClient.Do(req)
+		if err != nil {
+			t.Fatalf("failed to get template: %v", err)
+		}
+		defer resp.Body.Close()
+
+		var body struct {
+			Error ErrorResponse `json:"error"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode error response: %v", err)
+		}
+		return resp.StatusCode, &body.Error
+	}
+
+	crossStatus, crossErr := getError(created.ID)
+	missingStatus, missingErr := getError(uuid.New())
+
+	if crossStatus != http.StatusNotFound || missingStatus != http.StatusNotFound {
+		t.Errorf("expected 404 for both, got cross-workspace %d and missing %d", crossStatus, missingStatus)
+	}
+	if crossErr.Code == "" || crossErr.Code != missingErr.Code {
+		t.Errorf("expected same error code, got %q and %q", crossErr.Code, missingErr.Code)
+	}
+	if strings.Contains(crossErr.Message, "workspace") {
+		t.Errorf("error message reveals the workspace check: %q", crossErr.Message)
+	}
+}
+
+// --- GetByWorkspaceAndName ---
+
+func TestTemplateRepository_GetByWorkspaceAndName(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+	created, status := CreateTemplate(t, auth, "Lookup By Name", workspace.ID, defaultFiles())
+	if status != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d", status)
+	}
+
+	repo := sqlite.NewRepositoryFactory().CreateTemplateRepository(sqlite.NewUnitOfWork(DbConnection))
+
+	t.Run("found", func(t *testing.T) {
+		template, err := repo.GetByWorkspaceAndName(context.Background(), workspace.ID, "Lookup By Name")
+		if err != nil {
+			t.Fatalf("expected template, got error: %v", err)
+		}
+		if template.ID != created.ID {
+			t.Errorf("expected template %s, got %s", created.ID, template.ID)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := repo.GetByWorkspaceAndName(context.Background(), workspace.ID, "No Such Template")
+		if err == nil || err.Code() != errors.CodeNotFound {
+			t.Errorf("expected NOT_FOUND, got %v", err)
+		}
+	})
+
+	t.Run("other workspace", func(t *testing.T) {
+		_, err := repo.GetByWorkspaceAndName(context.Background(), uuid.New(), "Lookup By Name")
+		if err == nil || err.Code() != errors.CodeNotFound {
+			t.Errorf("expected NOT_FOUND, got %v", err)
+		}
+	})
+}
+
+// A token outliving its workspace gets a distinct 401 instead of an empty list
+func TestListTemplates_WorkspaceDeleted(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+	if _, status := CreateTemplate(t, auth, "Orphaned Template", workspace.ID, defaultFiles()); status != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d", status)
+	}
+	if status := DeleteWorkspace(t, auth, workspace.ID); status != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", status)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, BaseURL+"/api/v1/templates", nil)
+	addAuth(t, req, auth)
+	resp, err := HTTPClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to list templates: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected status 401, got %d", resp.StatusCode)
+	}
+	var errResp struct {
+		Error ErrorResponse `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&errResp); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+	if errResp.Error.Code != "UNAUTHORIZED" || errResp.Error.Metadata["reason"] != "workspace_deleted" {
+		t.Errorf("expected UNAUTHORIZED with reason workspace_deleted, got %+v", errResp.Error)
+	}
+}
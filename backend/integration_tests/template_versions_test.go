@@ -0,0 +1,199 @@
+package integration_tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+type TemplateVersionResponse struct {
+	ID         uuid.UUID `json:"id"`
+	TemplateID uuid.UUID `json:"template_id"`
+	Version    int       `json:"version"`
+	Name       string    `json:"name"`
+	Path       string    `json:"path"`
+}
+
+func ListTemplateVersions(t *testing.T, auth AuthContext, id uuid.UUID) ([]TemplateVersionResponse, int) {
+	t.Helper()
+
+	status, body := getRawList(t, auth, fmt.Sprintf("/api/v1/templates/%s/versions", id))
+	if status != http.StatusOK {
+		return nil, status
+	}
+	var versions []TemplateVersionResponse
+	if err := json.Unmarshal([]byte(body), &versions); err != nil {
+		t.Fatalf("failed to decode template versions: %v", err)
+	}
+	return versions, status
+}
+
+func RestoreTemplateVersion(t *testing.T, auth AuthContext, id uuid.UUID, version int) (*TemplateResponse, int) {
+	t.Helper()
+
+	req, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/api/v1/templates/%s/versions/%d/restore", BaseURL, id, version), nil)
+	addAuth(t, req, auth)
+
+	resp, err := HTTPClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to restore template version: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		io.Copy(io.Discard, resp.Body)
+		return nil, resp.StatusCode
+	}
+	var template TemplateResponse
+	if err := json.NewDecoder(resp.Body).Decode(&template); err != nil {
+		t.Fatalf("failed to decode restored template: %v", err)
+	}
+	return &template, resp.StatusCode
+}
+
+func TestUpdateTemplate_CreatesVersion(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, workspace.Name)
+
+	template, status := CreateTemplate(t, auth, "Versioned", workspace.ID, map[string]string{"main.tf": "# v1\n"})
+	if status != http.StatusCreated {
+		t.Fatalf("failed to create template: status %d", status)
+	}
+
+	if versions, status := ListTemplateVersions(t, auth, template.ID); status != http.StatusOK || len(versions) != 0 {
+		t.Fatalf("expected no versions before any update, got %d (status %d)", len(versions), status)
+	}
+
+	if _, status := UpdateTemplate(t, auth, template.ID, "Versioned Renamed"); status != http.StatusOK {
+		t.Fatalf("update failed: status %d", status)
+	}
+	// A no-op update is not a change and takes no snapshot
+	if _, status := UpdateTemplate(t, auth, template.ID, "Versioned Renamed"); status != http.StatusOK {
+		t.Fatalf("no-op update failed: status %d", status)
+	}
+	if _, status := UpdateTemplate(t, auth, template.ID, "", map[string]string{"main.tf": "# v2\n"}); status != http.StatusOK {
+		t.Fatalf("file update failed: status %d", status)
+	}
+
+	versions, status := ListTemplateVersions(t, auth, template.ID)
+	if status != http.StatusOK {
+		t.Fatalf("list versions: expected 200, got %d", status)
+	}
+	if len(versions) != 2 {
+		t.Fatalf("expected 2 versions, got %d", len(versions))
+	}
+	if versions[0].Version != 2 || versions[0].Name != "Versioned Renamed" {
+		t.Errorf("expected version 2 to hold the renamed template, got %+v", versions[0])
+	}
+	if versions[1].Version != 1 || versions[1].Name != "Versioned" {
+		t.Errorf("expected version 1 to hold the original template, got %+v", versions[1])
+	}
+
+	otherAuth, other := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, other.Name)
+	if _, status := ListTemplateVersions(t, otherAuth, template.ID); status != http.StatusNotFound {
+		t.Errorf("list versions from another workspace: expected 404, got %d", status)
+	}
+}
+
+func TestRestoreTemplateVersion_RevertsContent(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, workspace.Name)
+
+	template, status := CreateTemplate(t, auth, "Restorable", workspace.ID, map[string]string{"main.tf": "# original\n"})
+	if status != http.StatusCreated {
+		t.Fatalf("failed to create template: status %d", status)
+	}
+
+	if _, status := UpdateTemplate(t, auth, template.ID, "Restorable Edited", map[string]string{
+		"main.tf":  "# edited\n",
+		"extra.tf": "# added later\n",
+	}); status != http.StatusOK {
+		t.Fatalf("update failed: status %d", status)
+	}
+
+	restored, status := RestoreTemplateVersion(t, auth, template.ID, 1)
+	if status != http.StatusOK {
+		t.Fatalf("restore: expected 200, got %d", status)
+	}
+	if restored.Name != "Restorable" {
+		t.Errorf("expected the original name, got %q", restored.Name)
+	}
+
+	if content, status := GetTemplateFileContent(t, auth, template.ID, "main.tf"); status != http.StatusOK || content != "# original\n" {
+		t.Errorf("expected the original main.tf, got %q (status %d)", content, status)
+	}
+	files, status := ListTemplateFiles(t, auth, template.ID)
+	if status != http.StatusOK {
+		t.Fatalf("list files: expected 200, got %d", status)
+	}
+	if len(files) != 1 {
+		t.Errorf("expected the file added after version 1 to be dropped, got %d files", len(files))
+	}
+
+	// The restore snapshots the edited state, so it can be undone
+	versions, _ := ListTemplateVersions(t, auth, template.ID)
+	if len(versions) != 2 || versions[0].Name != "Restorable Edited" {
+		t.Fatalf("expected the edited state as version 2, got %+v", versions)
+	}
+	if _, status := RestoreTemplateVersion(t, auth, template.ID, 2); status != http.StatusOK {
+		t.Fatalf("undo restore: expected 200, got %d", status)
+	}
+	if content, _ := GetTemplateFileContent(t, auth, template.ID, "extra.tf"); content != "# added later\n" {
+		t.Errorf("expected extra.tf back after undoing the restore, got %q", content)
+	}
+
+	if _, status := RestoreTemplateVersion(t, auth, template.ID, 99); status != http.StatusNotFound {
+		t.Errorf("restore a missing version: expected 404, got %d", status)
+	}
+}
+
+// failTemplateWrites makes every UPDATE of the template fail inside the
+// database, after the service has taken its snapshot.
+func failTemplateWrites(t *testing.T, id uuid.UUID) {
+	t.Helper()
+	trigger := "fail_template_" + strings.ReplaceAll(id.String(), "-", "")
+	if _, err := DbConnection.Exec(fmt.Sprintf(
+		"CREATE TRIGGER %s BEFORE UPDATE ON templates WHEN OLD.id = '%s' BEGIN SELECT RAISE(ABORT, 'injected failure'); END", trigger, id)); err != nil {
+		t.Fatalf("failed to create trigger: %v", err)
+	}
+	t.Cleanup(func() { DbConnection.Exec("DROP TRIGGER IF EXISTS " + trigger) })
+}
+
+func TestTemplateFileChanges_FailedWriteKeepsFilesAndSnapshots(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, workspace.Name)
+
+	template, status := CreateTemplate(t, auth, "Guarded", workspace.ID, map[string]string{"main.tf": "# original\n"})
+	if status != http.StatusCreated {
+		t.Fatalf("failed to create template: status %d", status)
+	}
+	if _, status := UpdateTemplate(t, auth, template.ID, "", map[string]string{"main.tf": "# second\n"}); status != http.StatusOK {
+		t.Fatalf("update failed: status %d", status)
+	}
+
+	failTemplateWrites(t, template.ID)
+
+	if _, status := UpdateTemplate(t, auth, template.ID, "", map[string]string{"main.tf": "# lost?\n"}); status != http.StatusInternalServerError {
+		t.Errorf("update: expected 500, got %d", status)
+	}
+	if _, status := RestoreTemplateVersion(t, auth, template.ID, 1); status != http.StatusInternalServerError {
+		t.Errorf("restore: expected 500, got %d", status)
+	}
+
+	if content, status := GetTemplateFileContent(t, auth, template.ID, "main.tf"); status != http.StatusOK || content != "# second\n" {
+		t.Errorf("expected the current files to survive the failed writes, got %q (status %d)", content, status)
+	}
+	if versions, _ := ListTemplateVersions(t, auth, template.ID); len(versions) != 1 {
+		t.Errorf("expected the failed writes to add no versions, got %d", len(versions))
+	}
+	files, status := ListTemplateFiles(t, auth, template.ID)
+	if status != http.StatusOK || len(files) != 1 {
+		t.Errorf("expected only main.tf, got %+v (status %d)", files, status)
+	}
+}
@@ -1,9 +1,12 @@
 package integration_tests
 
 import (
+	"encoding/json"
 	"net/http"
 	"testing"
 
+	"backend/pkg/validation"
+
 	"github.com/google/uuid"
 )
 
@@ -11,6 +14,7 @@ func TestCreateUser_Success(t *testing.T) {
 	auth := AuthContext{
 		UserID:      uuid.New(),
 		UserName:    "Test User",
+		Role:        "admin",
 		WorkspaceID: uuid.New(),
 	}
 	adminID := uuid.New()
@@ -35,6 +39,7 @@ func TestCreateUser_DuplicateEmail(t *testing.T) {
 	auth := AuthContext{
 		UserID:      uuid.New(),
 		UserName:    "Test User",
+		Role:        "admin",
 		WorkspaceID: uuid.New(),
 	}
 	adminID := uuid.New()
@@ -59,10 +64,66 @@ func TestCreateUser_InvalidWorkspace(t *testing.T) {
 	}
 }
 
+// disableForeignKeys turns off SQLite foreign key enforcement on the shared
+// connection for the rest of the test.
+func disableForeignKeys(t *testing.T) {
+	t.Helper()
+	if _, err := DbConnection.Exec("PRAGMA foreign_keys = OFF"); err != nil {
+		t.Fatalf("failed to disable foreign keys: %v", err)
+	}
+	t.Cleanup(func() {
+		if _, err := DbConnection.Exec("PRAGMA foreign_keys = ON"); err != nil {
+			t.Errorf("failed to re-enable foreign keys: %v", err)
+		}
+	})
+}
+
+func TestCreateUser_DuplicateEmail_ForeignKeysDisabled(t *testing.T) {
+	auth := AuthContext{
+		UserID:      uuid.New(),
+		UserName:    "Test User",
+		Role:        "admin",
+		WorkspaceID: uuid.New(),
+	}
+	workspace, _ := CreateWorkspace(t, auth, "Duplicate Email No FK", "Workspace", uuid.New())
+	defer TearDownWorkspace(t, workspace.Name)
+
+	disableForeignKeys(t)
+
+	email := "dup-nofk-" + uuid.NewString()[:8] + "@example.com"
+	if _, status := CreateUser(t, "First User", email, "SecureP@ss1!", workspace.ID); status != http.StatusCreated {
+		t.Fatalf("expected status 201 for first user, got %d", status)
+	}
+
+	_, status := CreateUser(t, "Second User", email, "DifferentP@ss1!", workspace.ID)
+	if status != http.StatusConflict {
+		t.Errorf("expected status 409 for duplicate email, got %d", status)
+	}
+}
+
+func TestCreateUser_InvalidWorkspace_ForeignKeysDisabled(t *testing.T) {
+	disableForeignKeys(t)
+
+	email := "nows-nofk-" + uuid.NewString()[:8] + "@example.com"
+	_, status := CreateUser(t, "Test User", email, "ValidP@ssw0rd!", uuid.New())
+	if status != http.StatusBadRequest {
+		t.Errorf("expected status 400 for missing workspace, got %d", status)
+	}
+
+	var count int
+	if err := DbConnection.QueryRow("SELECT COUNT(*) FROM users WHERE email = ?", email).Scan(&count); err != nil {
+		t.Fatalf("failed to count users: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("expected no user row for missing workspace, got %d", count)
+	}
+}
+
 func TestCreateUser_WeakPassword(t *testing.T) {
 	auth := AuthContext{
 		UserID:      uuid.New(),
 		UserName:    "Test User",
+		Role:        "admin",
 		WorkspaceID: uuid.New(),
 	}
 	adminID := uuid.New()
@@ -109,6 +170,7 @@ func TestCreateUser_ValidationErrors(t *testing.T) {
 	auth := AuthContext{
 		UserID:      uuid.New(),
 		UserName:    "Test User",
+		Role:        "admin",
 		WorkspaceID: uuid.New(),
 	}
 	adminID := uuid.New()
@@ -173,6 +235,7 @@ func TestCreateUser_CascadeDelete(t *testing.T) {
 	auth := AuthContext{
 		UserID:      uuid.New(),
 		UserName:    "Test User",
+		Role:        "admin",
 		WorkspaceID: uuid.New(),
 	}
 	adminID := uuid.New()
@@ -192,3 +255,24 @@ func TestCreateUser_CascadeDelete(t *testing.T) {
 		t.Fatalf("failed to delete workspace: status %d", deleteStatus)
 	}
 }
+
+func TestGetLimits_ServesMaxNameLength(t *testing.T) {
+	resp, err := HTTPClient.Get(BaseURL + "/api/v1/limits")
+	if err != nil {
+		t.Fatalf("failed to get limits: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200 without authentication, got %d", resp.StatusCode)
+	}
+	var limits struct {
+		MaxNameLength int `json:"max_name_length"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&limits); err != nil {
+		t.Fatalf("failed to decode limits: %v", err)
+	}
+	if limits.MaxNameLength != validation.MaxNameLength {
+		t.Errorf("expected max_name_length %d, got %d", validation.MaxNameLength, limits.MaxNameLength)
+	}
+}
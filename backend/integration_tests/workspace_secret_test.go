@@ -0,0 +1,112 @@
+package integration_tests
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"backend/internal/application"
+	"backend/internal/domain"
+	"backend/internal/infra/sqlite"
+	"backend/pkg/validation"
+
+	"github.com/google/uuid"
+)
+
+func newWorkspaceServiceForTest() application.WorkspaceService {
+	repos, uow := sqlite.NewRepositoryFactory(), sqlite.NewUnitOfWork(DbConnection)
+	return application.NewWorkspaceService(repos.CreateWorkspaceRepository(uow), repos.CreateUserRepository(uow), validation.New(), application.CrossTenantNotFound, domain.RandomIDGenerator{})
+}
+
+func TestRotateWorkspaceSecret_OldSecretStopsWorking(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, workspace.Name)
+	service := newWorkspaceServiceForTest()
+
+	first, status := RotateWorkspaceSecret(t, auth, workspace.ID)
+	if status != http.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+	if first.Secret == "" {
+		t.Fatal("expected a non-empty secret")
+	}
+	if first.WorkspaceID != workspace.ID {
+		t.Errorf("expected workspace ID %s, got %s", workspace.ID, first.WorkspaceID)
+	}
+
+	ok, err := service.VerifySecret(context.Background(), workspace.ID, first.Secret)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ok {
+		t.Fatal("expected the issued secret to verify")
+	}
+
+	second, status := RotateWorkspaceSecret(t, auth, workspace.ID)
+	if status != http.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+	if second.Secret == first.Secret {
+		t.Fatal("expected rotation to issue a new secret")
+	}
+
+	if ok, _ := service.VerifySecret(context.Background(), workspace.ID, first.Secret); ok {
+		t.Error("expected the old secret to stop working after rotation")
+	}
+	if ok, _ := service.VerifySecret(context.Background(), workspace.ID, second.Secret); !ok {
+		t.Error("expected the new secret to verify")
+	}
+}
+
+func TestRotateWorkspaceSecret_StoresOnlyHash(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, workspace.Name)
+
+	secret, status := RotateWorkspaceSecret(t, auth, workspace.ID)
+	if status != http.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+
+	var stored string
+	if err := DbConnection.QueryRow("SELECT secret_hash FROM workspaces WHERE id = ?", workspace.ID).Scan(&stored); err != nil {
+		t.Fatalf("failed to read secret hash: %v", err)
+	}
+	if stored == "" || stored == secret.Secret {
+		t.Errorf("expected only a hash to be stored, got %q", stored)
+	}
+}
+
+func TestRotateWorkspaceSecret_NoSecretIssued(t *testing.T) {
+	_, workspace := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, workspace.Name)
+
+	ok, err := newWorkspaceServiceForTest().VerifySecret(context.Background(), workspace.ID, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ok {
+		t.Error("expected verification to fail before any secret is issued")
+	}
+}
+
+func TestRotateWorkspaceSecret_OtherWorkspace(t *testing.T) {
+	_, workspace := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, workspace.Name)
+
+	otherAdmin, otherWorkspace := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, otherWorkspace.Name)
+	if _, status := RotateWorkspaceSecret(t, otherAdmin, workspace.ID); status != http.StatusNotFound {
+		t.Errorf("expected 404 for another workspace's admin, got %d", status)
+	}
+}
+
+func TestRotateWorkspaceSecret_InvalidID(t *testing.T) {
+	auth, workspace := setupWorkspaceForTemplates(t)
+	defer TearDownWorkspace(t, workspace.Name)
+
+	// The nil ID is not the caller's workspace, so the route policy answers
+	// before the request is validated
+	if _, status := RotateWorkspaceSecret(t, auth, uuid.Nil); status != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", status)
+	}
+}
@@ -1,7 +1,10 @@
 package integration_tests
 
 import (
+	"bytes"
+	"encoding/json"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
@@ -12,6 +15,7 @@ func TestCreateWorkspace_Success(t *testing.T) {
 	auth := AuthContext{
 		UserID:      uuid.New(),
 		UserName:    "Test User",
+		Role:        "admin",
 		WorkspaceID: uuid.New(),
 	}
 	adminID := uuid.New()
@@ -50,6 +54,7 @@ func TestCreateWorkspace_ValidationErrors(t *testing.T) {
 	auth := AuthContext{
 		UserID:      uuid.New(),
 		UserName:    "Test User",
+		Role:        "admin",
 		WorkspaceID: uuid.New(),
 	}
 
@@ -93,10 +98,127 @@ func TestCreateWorkspace_ValidationErrors(t *testing.T) {
 	}
 }
 
+func TestCreateWorkspace_LocalizedValidationMessages(t *testing.T) {
+	auth := AuthContext{
+		UserID:      uuid.New(),
+		UserName:    "Test User",
+		Role:        "admin",
+		WorkspaceID: uuid.New(),
+	}
+
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		wantMessage    string
+	}{
+		{"default english", "", "name is required"},
+		{"spanish", "es-ES,es;q=0.9", "name es obligatorio"},
+		{"unsupported falls back to english", "de-DE", "name is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, _ := json.Marshal(map[string]interface{}{
+				"name":     "",
+				"admin_id": uuid.New(),
+			})
+			req, _ := http.NewRequest(http.MethodPost, BaseURL+"/api/v1/workspaces", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+			addAuth(t, req, auth)
+
+			resp, err := HTTPClient.Do(req)
+			if err != nil {
+				t.Fatalf("failed to create workspace: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != http.StatusBadRequest {
+				t.Fatalf("expected status 400, got %d", resp.StatusCode)
+			}
+
+			var errResp struct {
+				Error ErrorResponse `json:"error"`
+			}
+			if err := json.NewDecoder(resp.Body).Decode(&errResp); err != nil {
+				t.Fatalf("failed to decode error response: %v", err)
+			}
+
+			fields, ok := errResp.Error.Metadata["fields"].(map[string]interface{})
+			if !ok {
+				t.Fatalf("expected fields in metadata, got: %v", errResp.Error.Metadata)
+			}
+			if fields["name"] != tt.wantMessage {
+				t.Errorf("expected name message %q, got %q", tt.wantMessage, fields["name"])
+			}
+			if _, leaked := errResp.Error.Metadata["violations"]; leaked {
+				t.Error("expected raw violations to be stripped from the response")
+			}
+		})
+	}
+}
+
+func TestCreateWorkspace_NoisyAdminID(t *testing.T) {
+	auth := AuthContext{
+		UserID:      uuid.New(),
+		UserName:    "Test User",
+		Role:        "admin",
+		WorkspaceID: uuid.New(),
+	}
+	adminID := uuid.New()
+
+	tests := []struct {
+		name       string
+		adminID    string
+		wantStatus int
+	}{
+		{"uppercase", strings.ToUpper(adminID.String()), http.StatusCreated},
+		{"braced", "{" + adminID.String() + "}", http.StatusCreated},
+		{"whitespace padded", "  " + adminID.String() + " ", http.StatusCreated},
+		{"garbage", "not-a-uuid", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, _ := json.Marshal(map[string]interface{}{
+				"name":     "Noisy " + tt.name,
+				"admin_id": tt.adminID,
+			})
+			req, _ := http.NewRequest(http.MethodPost, BaseURL+"/api/v1/workspaces", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			addAuth(t, req, auth)
+
+			resp, err := HTTPClient.Do(req)
+			if err != nil {
+				t.Fatalf("failed to create workspace: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, resp.StatusCode)
+			}
+			if tt.wantStatus != http.StatusCreated {
+				return
+			}
+
+			var workspace WorkspaceResponse
+			if err := json.NewDecoder(resp.Body).Decode(&workspace); err != nil {
+				t.Fatalf("failed to decode workspace response: %v", err)
+			}
+			if workspace.AdminID != adminID {
+				t.Errorf("expected admin ID %s, got %s", adminID, workspace.AdminID)
+			}
+		})
+	}
+}
+
 func TestGetWorkspace_Success(t *testing.T) {
 	auth := AuthContext{
 		UserID:      uuid.New(),
 		UserName:    "Test User",
+		Role:        "admin",
 		WorkspaceID: uuid.New(),
 	}
 	adminID := uuid.New()
@@ -129,6 +251,7 @@ func TestGetWorkspace_NotFound(t *testing.T) {
 	auth := AuthContext{
 		UserID:      uuid.New(),
 		UserName:    "Test User",
+		Role:        "admin",
 		WorkspaceID: uuid.New(),
 	}
 	randomID := uuid.New()
@@ -143,6 +266,7 @@ func TestGetWorkspacesByAdmin_Success(t *testing.T) {
 	auth := AuthContext{
 		UserID:      uuid.New(),
 		UserName:    "Test User",
+		Role:        "admin",
 		WorkspaceID: uuid.New(),
 	}
 	adminID := uuid.New()
@@ -171,6 +295,7 @@ func TestGetWorkspacesByAdmin_Empty(t *testing.T) {
 	auth := AuthContext{
 		UserID:      uuid.New(),
 		UserName:    "Test User",
+		Role:        "admin",
 		WorkspaceID: uuid.New(),
 	}
 	randomAdminID := uuid.New()
@@ -186,10 +311,84 @@ func TestGetWorkspacesByAdmin_Empty(t *testing.T) {
 	}
 }
 
+func TestGetWorkspacesByIDs_PartialHits(t *testing.T) {
+	auth := AuthContext{
+		UserID:      uuid.New(),
+		UserName:    "Batch Admin",
+		Role:        "admin",
+		WorkspaceID: uuid.New(),
+	}
+
+	first, _ := CreateWorkspace(t, auth, "Batch Workspace 1", "", auth.UserID)
+	defer TearDownWorkspace(t, first.Name)
+	second, _ := CreateWorkspace(t, auth, "Batch Workspace 2", "", auth.UserID)
+	defer TearDownWorkspace(t, second.Name)
+	notMine, _ := CreateWorkspace(t, auth, "Batch Workspace Other Admin", "", uuid.New())
+	defer TearDownWorkspace(t, notMine.Name)
+
+	workspaces, status := GetWorkspacesByIDs(t, auth, first.ID, uuid.New(), second.ID, notMine.ID)
+	if status != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", status)
+	}
+
+	got := map[uuid.UUID]bool{}
+	for _, ws := range workspaces {
+		got[ws.ID] = true
+	}
+	if len(workspaces) != 2 || !got[first.ID] || !got[second.ID] {
+		t.Errorf("expected only the caller's two workspaces, got %v", got)
+	}
+}
+
+func TestGetWorkspacesByIDs_ExcludesSoftDeleted(t *testing.T) {
+	auth := AuthContext{
+		UserID:      uuid.New(),
+		UserName:    "Batch Admin",
+		Role:        "admin",
+		WorkspaceID: uuid.New(),
+	}
+
+	live, _ := CreateWorkspace(t, auth, "Batch Live Workspace", "", auth.UserID)
+	defer TearDownWorkspace(t, live.Name)
+	deleted, _ := CreateWorkspace(t, auth, "Batch Deleted Workspace", "", auth.UserID)
+	defer TearDownWorkspace(t, deleted.Name)
+	if status := DeleteWorkspace(t, auth, deleted.ID); status != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", status)
+	}
+
+	workspaces, status := GetWorkspacesByIDs(t, auth, live.ID, deleted.ID)
+	if status != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", status)
+	}
+	if len(workspaces) != 1 || workspaces[0].ID != live.ID {
+		t.Errorf("expected only workspace %s, got %d workspaces", live.ID, len(workspaces))
+	}
+}
+
+func TestGetWorkspacesByIDs_Validation(t *testing.T) {
+	auth := AuthContext{UserID: uuid.New(), UserName: "Batch Admin", Role: "admin", WorkspaceID: uuid.New()}
+
+	if _, status := GetWorkspacesByIDs(t, auth); status != http.StatusBadRequest {
+		t.Errorf("expected status 400 without ids, got %d", status)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, BaseURL+"/api/v1/workspaces/batch?ids=not-a-uuid", nil)
+	addAuth(t, req, auth)
+	resp, err := HTTPClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected status 400 for a malformed id, got %d", resp.StatusCode)
+	}
+}
+
 func TestUpdateWorkspace_Success(t *testing.T) {
 	auth := AuthContext{
 		UserID:      uuid.New(),
 		UserName:    "Test User",
+		Role:        "admin",
 		WorkspaceID: uuid.New(),
 	}
 	adminID := uuid.New()
@@ -225,10 +424,66 @@ func TestUpdateWorkspace_Success(t *testing.T) {
 	}
 }
 
+func TestUpdateWorkspace_StaleVersionConflict(t *testing.T) {
+	auth := AuthContext{
+		UserID:      uuid.New(),
+		UserName:    "Test User",
+		Role:        "admin",
+		WorkspaceID: uuid.New(),
+	}
+	created, _ := CreateWorkspace(t, auth, "Versioned Workspace", "", uuid.New())
+	defer TearDownWorkspace(t, "Versioned Workspace Renamed")
+	if created.Version != 1 {
+		t.Fatalf("expected a new workspace at version 1, got %d", created.Version)
+	}
+
+	resp := UpdateWorkspaceAtVersion(t, auth, created.ID, "Versioned Workspace Renamed", 1)
+	var updated WorkspaceResponse
+	json.NewDecoder(resp.Body).Decode(&updated)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	if updated.Version != 2 {
+		t.Fatalf("expected version 2 after update, got %d", updated.Version)
+	}
+
+	// A second writer still holding version 1 must not overwrite the change
+	resp = UpdateWorkspaceAtVersion(t, auth, created.ID, "Lost Update", 1)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusConflict {
+		t.Fatalf("expected status 409, got %d", resp.StatusCode)
+	}
+	if resp.Header.Get("Retry-After") == "" {
+		t.Error("expected a Retry-After header on the version conflict")
+	}
+
+	var errResp struct {
+		Error ErrorResponse `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&errResp); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+	if v, _ := errResp.Error.Metadata["current_version"].(float64); int(v) != updated.Version {
+		t.Errorf("expected current_version %d, got %v", updated.Version, errResp.Error.Metadata["current_version"])
+	}
+	raw, _ := errResp.Error.Metadata["updated_at"].(string)
+	updatedAt, err := time.Parse(time.RFC3339Nano, raw)
+	if err != nil || !updatedAt.Equal(updated.UpdatedAt) {
+		t.Errorf("expected updated_at %s, got %q", updated.UpdatedAt, raw)
+	}
+
+	current, _ := GetWorkspace(t, auth, created.ID)
+	if current.Name != "Versioned Workspace Renamed" {
+		t.Errorf("expected the stale update to be rejected, name is %q", current.Name)
+	}
+}
+
 func TestUpdateWorkspace_NotFound(t *testing.T) {
 	auth := AuthContext{
 		UserID:      uuid.New(),
 		UserName:    "Test User",
+		Role:        "admin",
 		WorkspaceID: uuid.New(),
 	}
 	randomID := uuid.New()
@@ -239,10 +494,67 @@ func TestUpdateWorkspace_NotFound(t *testing.T) {
 	}
 }
 
+func TestUpdateWorkspace_AdminID(t *testing.T) {
+	auth, workspace := setupAdministeredWorkspace(t)
+	defer TearDownWorkspace(t, workspace.Name)
+	otherAuth, otherWorkspace := setupAdministeredWorkspace(t)
+	defer TearDownWorkspace(t, otherWorkspace.Name)
+
+	member, status := CreateUser(t, "New Admin", "new-admin-"+uuid.New().String()[:8]+"@example.com", "SecureP@ssw0rd!", workspace.ID)
+	if status != http.StatusCreated {
+		t.Fatalf("failed to create member: status %d", status)
+	}
+	outsider, status := CreateUser(t, "Outsider", "outsider-"+uuid.New().String()[:8]+"@example.com", "SecureP@ssw0rd!", otherWorkspace.ID)
+	if status != http.StatusCreated {
+		t.Fatalf("failed to create outsider: status %d", status)
+	}
+
+	t.Run("nonexistent user", func(t *testing.T) {
+		if status := UpdateWorkspaceAdmin(t, auth, workspace.ID, uuid.New()); status != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", status)
+		}
+	})
+
+	t.Run("user of another workspace", func(t *testing.T) {
+		if status := UpdateWorkspaceAdmin(t, auth, workspace.ID, outsider.UserID); status != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", status)
+		}
+	})
+
+	t.Run("editor promoting themselves", func(t *testing.T) {
+		editor := AuthContext{UserID: member.UserID, UserName: "New Admin", Role: "editor", WorkspaceID: workspace.ID}
+		if status := UpdateWorkspaceAdmin(t, editor, workspace.ID, member.UserID); status != http.StatusForbidden {
+			t.Errorf("expected status 403, got %d", status)
+		}
+		if got := GetWorkspaceFromDB(t, workspace.ID).AdminID; got != auth.UserID {
+			t.Errorf("expected the admin to stay %s, got %s", auth.UserID, got)
+		}
+	})
+
+	t.Run("admin of another workspace", func(t *testing.T) {
+		if status := UpdateWorkspaceAdmin(t, otherAuth, workspace.ID, member.UserID); status != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", status)
+		}
+		if got := GetWorkspaceFromDB(t, workspace.ID).AdminID; got != auth.UserID {
+			t.Errorf("expected the admin to stay %s, got %s", auth.UserID, got)
+		}
+	})
+
+	t.Run("member of the workspace", func(t *testing.T) {
+		if status := UpdateWorkspaceAdmin(t, auth, workspace.ID, member.UserID); status != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", status)
+		}
+		if got := GetWorkspaceFromDB(t, workspace.ID).AdminID; got != member.UserID {
+			t.Errorf("expected admin %s, got %s", member.UserID, got)
+		}
+	})
+}
+
 func TestDeleteWorkspace_Success(t *testing.T) {
 	auth := AuthContext{
 		UserID:      uuid.New(),
 		UserName:    "Test User",
+		Role:        "admin",
 		WorkspaceID: uuid.New(),
 	}
 	adminID := uuid.New()
@@ -264,6 +576,7 @@ func TestDeleteWorkspace_NotFound(t *testing.T) {
 	auth := AuthContext{
 		UserID:      uuid.New(),
 		UserName:    "Test User",
+		Role:        "admin",
 		WorkspaceID: uuid.New(),
 	}
 	randomID := uuid.New()
@@ -278,6 +591,7 @@ func TestListWorkspaces_Success(t *testing.T) {
 	auth := AuthContext{
 		UserID:      uuid.New(),
 		UserName:    "Test User",
+		Role:        "admin",
 		WorkspaceID: uuid.New(),
 	}
 	adminID := uuid.New()
@@ -301,6 +615,7 @@ func TestListWorkspaces_Pagination(t *testing.T) {
 	auth := AuthContext{
 		UserID:      uuid.New(),
 		UserName:    "Test User",
+		Role:        "admin",
 		WorkspaceID: uuid.New(),
 	}
 	adminID := uuid.New()
@@ -331,3 +646,78 @@ func TestListWorkspaces_Pagination(t *testing.T) {
 		t.Error("pages should not have overlapping workspaces")
 	}
 }
+
+func TestListWorkspaces_OmittedParamsUseDefaults(t *testing.T) {
+	auth := AuthContext{
+		UserID:      uuid.New(),
+		UserName:    "Test User",
+		Role:        "admin",
+		WorkspaceID: uuid.New(),
+	}
+	CreateWorkspace(t, auth, "Defaults Test Older", "", uuid.New())
+	CreateWorkspace(t, auth, "Defaults Test Newer", "", uuid.New())
+
+	req, _ := http.NewRequest(http.MethodGet, BaseURL+"/api/v1/workspaces", nil)
+	addAuth(t, req, auth)
+	resp, err := HTTPClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to list workspaces: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	var workspaces []*WorkspaceResponse
+	if err := json.NewDecoder(resp.Body).Decode(&workspaces); err != nil {
+		t.Fatalf("failed to decode workspaces: %v", err)
+	}
+
+	// Defaults: limit=50, sort_by=created_at, order=DESC
+	if len(workspaces) == 0 || len(workspaces) > 50 {
+		t.Fatalf("expected between 1 and 50 workspaces, got %d", len(workspaces))
+	}
+	for i := 1; i < len(workspaces); i++ {
+		if workspaces[i].CreatedAt.After(workspaces[i-1].CreatedAt) {
+			t.Fatalf("expected newest first, but %s follows %s", workspaces[i].CreatedAt, workspaces[i-1].CreatedAt)
+		}
+	}
+}
+
+func TestListWorkspaces_MalformedNumericParams(t *testing.T) {
+	auth := AuthContext{
+		UserID:      uuid.New(),
+		UserName:    "Test User",
+		Role:        "admin",
+		WorkspaceID: uuid.New(),
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, BaseURL+"/api/v1/workspaces?limit=abc&offset=1.5", nil)
+	addAuth(t, req, auth)
+	resp, err := HTTPClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to list workspaces: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", resp.StatusCode)
+	}
+	var errResp struct {
+		Error ErrorResponse `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&errResp); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+
+	fields, ok := errResp.Error.Metadata["fields"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected fields in metadata, got: %v", errResp.Error.Metadata)
+	}
+	if fields["limit"] != "limit must be an integer" {
+		t.Errorf("unexpected limit message %q", fields["limit"])
+	}
+	if fields["offset"] != "offset must be an integer" {
+		t.Errorf("unexpected offset message %q", fields["offset"])
+	}
+}
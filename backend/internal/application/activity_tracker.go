@@ -0,0 +1,94 @@
+package application
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	apphandlers "backend/internal/application/handlers"
+	"backend/internal/domain/repository"
+	"backend/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+const DefaultActivityInterval = 5 * time.Minute
+
+// ActivityTracker records when users were last active. Writes are throttled
+// to at most one per user per interval: an in-memory record of recent writes
+// skips the database entirely, and the UPDATE itself is conditional on the
+// stored value being older than the interval so multiple instances stay in
+// step.
+type ActivityTracker struct {
+	userRepository repository.UserRepository
+	interval       time.Duration
+	now            func() time.Time
+
+	mu        sync.Mutex
+	lastWrite map[uuid.UUID]time.Time
+	lastSweep time.Time
+}
+
+func NewActivityTracker(
+	uowFactory apphandlers.UnitOfWorkFactory,
+	repoFactory apphandlers.RepositoryFactory,
+	interval time.Duration,
+) *ActivityTracker {
+	if interval <= 0 {
+		interval = DefaultActivityInterval
+	}
+	return &ActivityTracker{
+		userRepository: repoFactory.CreateUserRepository(uowFactory.Create()),
+		interval:       interval,
+		now:            time.Now,
+		lastWrite:      make(map[uuid.UUID]time.Time),
+	}
+}
+
+// Touch records activity for userID. It is a no-op when the user's activity
+// was already recorded within the interval.
+func (t *ActivityTracker) Touch(ctx context.Context, userID uuid.UUID) *errors.Error {
+	now := t.now().UTC()
+	if !t.reserve(userID, now) {
+		return nil
+	}
+
+	if _, err := t.userRepository.TouchLastActive(ctx, userID, now, now.Add(-t.interval)); err != nil {
+		t.release(userID, now)
+		return err
+	}
+	return nil
+}
+
+// reserve claims the write slot for userID at now, returning false when a
+// write already happened within the interval. Expired entries are swept at
+// most once per interval so the map stays bounded by the active user count.
+func (t *ActivityTracker) reserve(userID uuid.UUID, now time.Time) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if last, ok := t.lastWrite[userID]; ok && now.Sub(last) < t.interval {
+		return false
+	}
+	t.lastWrite[userID] = now
+
+	if now.Sub(t.lastSweep) >= t.interval {
+		for id, last := range t.lastWrite {
+			if now.Sub(last) >= t.interval {
+				delete(t.lastWrite, id)
+			}
+		}
+		t.lastSweep = now
+	}
+	return true
+}
+
+// release undoes a reservation after a failed write so the next request retries.
+func (t *ActivityTracker) release(userID uuid.UUID, at time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if last, ok := t.lastWrite[userID]; ok && last.Equal(at) {
+		delete(t.lastWrite, userID)
+	}
+}
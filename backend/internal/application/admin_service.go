@@ -2,7 +2,11 @@ package application
 
 import (
 	"context"
+	"database/sql"
+	"net/http"
+	"time"
 
+	apperrors "backend/internal/application/errors"
 	"backend/internal/application/handlers"
 	"backend/internal/domain"
 	domainerrors "backend/internal/domain/errors"
@@ -19,20 +23,26 @@ type AdminService struct {
 	workspaceRepository repository.WorkspaceRepository
 	userService         UserService
 	userRepository      repository.UserRepository
+	systemInitRepo      repository.SystemInitRepository
 	validator           *validation.Service
+	ids                 domain.IDGenerator
 }
 
 func NewAdminService(
 	workspaceRepo repository.WorkspaceRepository,
 	userService UserService,
 	userRepo repository.UserRepository,
+	systemInitRepo repository.SystemInitRepository,
 	validator *validation.Service,
+	ids domain.IDGenerator,
 ) *AdminService {
 	return &AdminService{
 		workspaceRepository: workspaceRepo,
 		userService:         userService,
 		userRepository:      userRepo,
+		systemInitRepo:      systemInitRepo,
 		validator:           validator,
+		ids:                 ids,
 	}
 }
 
@@ -45,25 +55,36 @@ func (s *AdminService) InitializeSystem(
 		return nil, err
 	}
 
-	// Pre-flight check — no transaction needed
+	// Fast path — no transaction needed. Concurrent inits can all pass this
+	// check; MarkInitialized below lets exactly one of them commit.
 	count, err := s.userRepository.Count(ctx)
 	if err != nil {
 		return nil, err
 	}
 	if count > 0 {
-		return nil, errors.WithCode(errors.CodeConflict, "System already initialized").WithHTTPStatus(409)
+		return nil, domainerrors.ErrSystemAlreadyInitialized
 	}
 
-	if err = uow.Begin(); err != nil {
+	// The Count above ran outside this transaction, so the isolation level
+	// does not cover it: concurrent inits are decided by MarkInitialized, whose
+	// single system_init row only one transaction can insert. Serializable
+	// keeps the writes below from interleaving with another init.
+	if err = uow.Begin(ctx, handlers.WithIsolation(sql.LevelSerializable)); err != nil {
 		return nil, err
 	}
 	defer uow.Rollback()
 
 	// Direct repo call: workspace created with nil adminID
-	workspace := domain.NewWorkspace(request.WorkspaceName, request.WorkspaceDescription, nil)
+	workspace, err := domain.NewWorkspace(s.ids, s.validator.ContentPolicy(), request.WorkspaceName, request.WorkspaceDescription, nil)
+	if err != nil {
+		return nil, err
+	}
 	if err = s.workspaceRepository.Create(ctx, workspace); err != nil {
 		return nil, err
 	}
+	if err = s.systemInitRepo.MarkInitialized(ctx, workspace.ID); err != nil {
+		return nil, err
+	}
 
 	adminUser, userErr := s.userService.CreateLocalUser(ctx, uow, contracts.CreateLocalUser{
 		Name:        request.AdminName,
@@ -86,7 +107,7 @@ func (s *AdminService) InitializeSystem(
 		return nil, err
 	}
 
-	if err = uow.Commit(); err != nil { // depth→0, actual DB commit
+	if err = uow.Commit(ctx); err != nil { // depth→0, actual DB commit
 		return nil, err
 	}
 
@@ -116,7 +137,7 @@ func (s *AdminService) InviteUser(
 	}
 	context, ok := jwt.ClaimsFromContext(ctx)
 	if !ok {
-		return nil, errors.WithCode(errors.CodeUnauthorized, "missing JWT claims in context").WithHTTPStatus(401)
+		return nil, apperrors.ReturnMissingClaims()
 	}
 	callerWorkspaceID, prsErr := uuid.Parse(context.WorkspaceID)
 	if prsErr != nil {
@@ -129,7 +150,7 @@ func (s *AdminService) InviteUser(
 		return nil, errors.Wrap(genErr, "failed to generate password").WithHTTPStatus(500)
 	}
 
-	if beginErr := uow.Begin(); beginErr != nil {
+	if beginErr := uow.Begin(ctx); beginErr != nil {
 		return nil, beginErr
 	}
 	defer uow.Rollback()
@@ -150,7 +171,7 @@ func (s *AdminService) InviteUser(
 		return nil, err
 	}
 
-	if err := uow.Commit(); err != nil {
+	if err := uow.Commit(ctx); err != nil {
 		return nil, err
 	}
 
@@ -163,6 +184,29 @@ func (s *AdminService) InviteUser(
 	}, nil
 }
 
+// InviteUsers invites each user in its own transaction and reports per-item
+// outcomes, so a rejected entry does not prevent the others from being created.
+func (s *AdminService) InviteUsers(
+	ctx context.Context,
+	uow handlers.UnitOfWork,
+	request contracts.InviteUsers,
+) (*contracts.BatchResponse[contracts.InviteUserResponse], *errors.Error) {
+	if err := s.validator.Validate(request); err != nil {
+		return nil, err
+	}
+
+	response := &contracts.BatchResponse[contracts.InviteUserResponse]{}
+	for i, user := range request.Users {
+		invited, err := s.InviteUser(ctx, uow, user)
+		if err != nil {
+			response.AddFailure(i, err)
+			continue
+		}
+		response.AddSuccess(i, http.StatusCreated, invited)
+	}
+	return response, nil
+}
+
 func (s *AdminService) ResetUserPassword(
 	ctx context.Context,
 	uow handlers.UnitOfWork,
@@ -174,7 +218,7 @@ func (s *AdminService) ResetUserPassword(
 	}
 	claims, ok := jwt.ClaimsFromContext(ctx)
 	if !ok {
-		return nil, errors.WithCode(errors.CodeUnauthorized, "missing JWT claims in context").WithHTTPStatus(401)
+		return nil, apperrors.ReturnMissingClaims()
 	}
 
 	if claims.WorkspaceID != user.WorkspaceID.String() {
@@ -195,7 +239,7 @@ func (s *AdminService) ResetUserPassword(
 		return nil, hashErr
 	}
 
-	if beginErr := uow.Begin(); beginErr != nil {
+	if beginErr := uow.Begin(ctx); beginErr != nil {
 		return nil, beginErr
 	}
 	defer uow.Rollback()
@@ -205,7 +249,7 @@ func (s *AdminService) ResetUserPassword(
 		return nil, err
 	}
 
-	if err := uow.Commit(); err != nil {
+	if err := uow.Commit(ctx); err != nil {
 		return nil, err
 	}
 
@@ -215,18 +259,22 @@ func (s *AdminService) ResetUserPassword(
 	}, nil
 }
 
-func (s *AdminService) ListUsers(ctx context.Context) ([]*contracts.AdminUserResponse, *errors.Error) {
+// ListUsers returns the users of the caller's workspace, newest first.
+// Listing inactive users goes through ListInactiveUsers.
+func (s *AdminService) ListUsers(ctx context.Context, request contracts.ListUsers) ([]*contracts.AdminUserResponse, *errors.Error) {
 	claims, ok := jwt.ClaimsFromContext(ctx)
 	if !ok {
-		return nil, errors.WithCode(errors.CodeUnauthorized, "missing JWT claims in context").WithHTTPStatus(401)
+		return nil, apperrors.ReturnMissingClaims()
 	}
-	filterBy := make(map[string]string)
-	filterBy["workspace_id"] = claims.WorkspaceID
+	if err := s.validator.Validate(request); err != nil {
+		return nil, err
+	}
+
 	users, err := s.userRepository.List(ctx, repository.ListOptions{
-		Limit:    1000,
-		SortBy:   "created_at",
-		FilterBy: filterBy,
-		Order:    "DESC",
+		Limit:   1000,
+		SortBy:  "created_at",
+		Filters: map[string]any{"workspace_id": claims.WorkspaceID},
+		Order:   "DESC",
 	})
 	if err != nil {
 		return nil, err
@@ -234,19 +282,234 @@ func (s *AdminService) ListUsers(ctx context.Context) ([]*contracts.AdminUserRes
 
 	result := make([]*contracts.AdminUserResponse, len(users))
 	for i, u := range users {
-		result[i] = &contracts.AdminUserResponse{
-			ID:          u.ID,
-			Name:        u.Name,
-			Email:       u.Email,
-			Role:        string(u.Role),
-			WorkspaceID: u.WorkspaceID,
-			CreatedAt:   u.CreatedAt,
-			UpdatedAt:   u.UpdatedAt,
-		}
+		result[i] = newAdminUserResponse(u)
 	}
 	return result, nil
 }
 
+// ListInactiveUsers returns one page of the caller's workspace's users not
+// active since request.InactiveSince, including those never active, oldest
+// activity first.
+func (s *AdminService) ListInactiveUsers(
+	ctx context.Context,
+	uow handlers.UnitOfWork,
+	request contracts.ListUsers,
+) (*contracts.PagedResponse[*contracts.AdminUserResponse], *errors.Error) {
+	claims, ok := jwt.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, apperrors.ReturnMissingClaims()
+	}
+	if err := s.validator.Validate(request); err != nil {
+		return nil, err
+	}
+	if request.InactiveSince == "" {
+		return nil, domainerrors.InvalidInput("inactive_since", "inactive_since is required")
+	}
+	workspaceID, parseErr := uuid.Parse(claims.WorkspaceID)
+	if parseErr != nil {
+		return nil, apperrors.ReturnInvalidClaims("workspace_id")
+	}
+	// Validated as RFC 3339 above
+	since, _ := time.Parse(time.RFC3339, request.InactiveSince)
+
+	opts := repository.ListOptions{Limit: request.Limit, Offset: request.Offset}
+	opts.ApplyDefaults()
+
+	return inReadTx(ctx, uow, func() (*contracts.PagedResponse[*contracts.AdminUserResponse], *errors.Error) {
+		users, err := s.userRepository.ListInactiveSince(ctx, workspaceID, since, opts.Limit, opts.Offset)
+		if err != nil {
+			return nil, err
+		}
+
+		total, err := s.userRepository.CountInactiveSince(ctx, workspaceID, since)
+		if err != nil {
+			return nil, err
+		}
+
+		items := make([]*contracts.AdminUserResponse, len(users))
+		for i, u := range users {
+			items[i] = newAdminUserResponse(u)
+		}
+		return &contracts.PagedResponse[*contracts.AdminUserResponse]{
+			Items:  items,
+			Total:  total,
+			Limit:  opts.Limit,
+			Offset: opts.Offset,
+		}, nil
+	})
+}
+
+// GetUserByEmail looks up a user in the caller's workspace. Users in other
+// workspaces are reported as not found so the lookup cannot be used to probe
+// which emails are registered elsewhere.
+func (s *AdminService) GetUserByEmail(ctx context.Context, request contracts.GetUserByEmail) (*contracts.AdminUserResponse, *errors.Error) {
+	if err := s.validator.Validate(request); err != nil {
+		return nil, err
+	}
+
+	claims, ok := jwt.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, apperrors.ReturnMissingClaims()
+	}
+
+	user, err := s.userRepository.GetByEmail(ctx, request.Email)
+	if err != nil {
+		return nil, err
+	}
+	if user.WorkspaceID.String() != claims.WorkspaceID {
+		return nil, domainerrors.NotFound("User", request.Email)
+	}
+
+	return newAdminUserResponse(user), nil
+}
+
+// RecordInitAttempt stores a request to POST /admin/init and how it ended.
+// The route is unauthenticated, so repeats of an outcome from the same IP
+// within domain.AdminInitAttemptWindow are dropped and the log is pruned to
+// domain.MaxAdminInitAttempts whenever it grows.
+func (s *AdminService) RecordInitAttempt(ctx context.Context, outcome, ip string) *errors.Error {
+	attempt := domain.NewAdminInitAttempt(s.ids, outcome, ip)
+	recorded, err := s.systemInitRepo.RecordAttempt(ctx, attempt, attempt.AttemptedAt.Add(-domain.AdminInitAttemptWindow))
+	if err != nil || !recorded {
+		return err
+	}
+	return s.systemInitRepo.PruneAttempts(ctx, domain.MaxAdminInitAttempts)
+}
+
+// ListInitAttempts returns one page of recorded /admin/init requests, most
+// recent first, so an admin can spot attempts to take over the deployment.
+func (s *AdminService) ListInitAttempts(
+	ctx context.Context,
+	uow handlers.UnitOfWork,
+	request contracts.ListAdminInitAttempts,
+) (*contracts.PagedResponse[*domain.AdminInitAttempt], *errors.Error) {
+	if err := s.validator.Validate(request); err != nil {
+		return nil, err
+	}
+
+	opts := repository.ListOptions{Limit: request.Limit, Offset: request.Offset}
+	opts.ApplyDefaults()
+
+	return inReadTx(ctx, uow, func() (*contracts.PagedResponse[*domain.AdminInitAttempt], *errors.Error) {
+		attempts, err := s.systemInitRepo.ListAttempts(ctx, opts.Limit, opts.Offset)
+		if err != nil {
+			return nil, err
+		}
+
+		total, err := s.systemInitRepo.CountAttempts(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return &contracts.PagedResponse[*domain.AdminInitAttempt]{
+			Items:  attempts,
+			Total:  total,
+			Limit:  opts.Limit,
+			Offset: opts.Offset,
+		}, nil
+	})
+}
+
+// ListDeletedWorkspaces returns one page of the soft-deleted workspaces the
+// caller administers, most recently deleted first, so they can find one to
+// restore.
+func (s *AdminService) ListDeletedWorkspaces(
+	ctx context.Context,
+	uow handlers.UnitOfWork,
+	request contracts.ListDeletedWorkspaces,
+) (*contracts.PagedResponse[*domain.Workspace], *errors.Error) {
+	if err := s.validator.Validate(request); err != nil {
+		return nil, err
+	}
+
+	claims, ok := jwt.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, apperrors.ReturnMissingClaims()
+	}
+	callerID, callerErr := callerUserID(claims)
+	if callerErr != nil {
+		return nil, callerErr
+	}
+
+	opts := repository.ListOptions{
+		Limit:  request.Limit,
+		Offset: request.Offset,
+		SortBy: "deleted_at",
+		Order:  "DESC",
+	}
+	opts.ApplyDefaults()
+
+	return inReadTx(ctx, uow, func() (*contracts.PagedResponse[*domain.Workspace], *errors.Error) {
+		workspaces, err := s.workspaceRepository.ListDeleted(ctx, callerID, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		total, err := s.workspaceRepository.CountDeleted(ctx, callerID)
+		if err != nil {
+			return nil, err
+		}
+
+		return &contracts.PagedResponse[*domain.Workspace]{
+			Items:  workspaces,
+			Total:  total,
+			Limit:  opts.Limit,
+			Offset: opts.Offset,
+		}, nil
+	})
+}
+
+// RestoreWorkspace undoes the soft delete of a workspace the caller
+// administers and returns the restored workspace. Any other workspace is
+// reported not found.
+func (s *AdminService) RestoreWorkspace(
+	ctx context.Context,
+	uow handlers.UnitOfWork,
+	request contracts.RestoreWorkspace,
+) (*domain.Workspace, *errors.Error) {
+	if err := s.validator.Validate(request); err != nil {
+		return nil, err
+	}
+
+	claims, ok := jwt.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, apperrors.ReturnMissingClaims()
+	}
+	callerID, callerErr := callerUserID(claims)
+	if callerErr != nil {
+		return nil, callerErr
+	}
+
+	if err := uow.Begin(ctx); err != nil {
+		return nil, err
+	}
+	defer uow.Rollback()
+
+	if err := s.workspaceRepository.Restore(ctx, request.ID, callerID); err != nil {
+		return nil, err
+	}
+
+	workspace, err := s.workspaceRepository.GetByID(ctx, request.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return workspace, uow.Commit(ctx)
+}
+
+func newAdminUserResponse(u *domain.UserAggregate) *contracts.AdminUserResponse {
+	return &contracts.AdminUserResponse{
+		ID:           u.ID,
+		Name:         u.Name,
+		Email:        u.Email,
+		Role:         string(u.Role),
+		WorkspaceID:  u.WorkspaceID,
+		CreatedAt:    u.CreatedAt,
+		UpdatedAt:    u.UpdatedAt,
+		LastActiveAt: u.LastActiveAt,
+	}
+}
+
 func (s *AdminService) DeleteUser(
 	ctx context.Context,
 	uow handlers.UnitOfWork,
@@ -254,7 +517,7 @@ func (s *AdminService) DeleteUser(
 ) *errors.Error {
 	claims, ok := jwt.ClaimsFromContext(ctx)
 	if !ok {
-		return errors.WithCode(errors.CodeUnauthorized, "missing JWT claims in context").WithHTTPStatus(401)
+		return apperrors.ReturnMissingClaims()
 	}
 	callerId, prsErr := uuid.Parse(claims.ID)
 	if prsErr != nil {
@@ -277,7 +540,7 @@ func (s *AdminService) DeleteUser(
 		return err
 	}
 
-	if err := uow.Begin(); err != nil {
+	if err := uow.Begin(ctx); err != nil {
 		return err
 	}
 	defer uow.Rollback()
@@ -286,9 +549,69 @@ func (s *AdminService) DeleteUser(
 		return err
 	}
 
-	if err := uow.Commit(); err != nil {
+	if err := uow.Commit(ctx); err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// MoveUser reassigns a user from the caller's workspace to another workspace
+// the caller administers and bumps the user's token epoch so tokens scoped to
+// the old workspace stop working. The user arrives with the user role, whatever
+// they held before, and has to log in again to pick up the new workspace.
+func (s *AdminService) MoveUser(
+	ctx context.Context,
+	uow handlers.UnitOfWork,
+	request contracts.MoveUser,
+) (*contracts.AdminUserResponse, *errors.Error) {
+	if err := s.validator.Validate(request); err != nil {
+		return nil, err
+	}
+
+	claims, ok := jwt.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, apperrors.ReturnMissingClaims()
+	}
+	if claims.ID == request.UserID.String() {
+		return nil, domainerrors.InvalidInput("user_id", "cannot move yourself")
+	}
+	if err := uow.Begin(ctx, handlers.WithIsolation(sql.LevelSerializable)); err != nil {
+		return nil, err
+	}
+	defer uow.Rollback()
+
+	user, err := s.userRepository.GetByID(ctx, request.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user.WorkspaceID.String() != claims.WorkspaceID {
+		return nil, forbid(claims, "User", user.ID.String(), user.WorkspaceID.String(), "user does not belong to your workspace")
+	}
+	if user.WorkspaceID == request.WorkspaceID {
+		return nil, domainerrors.InvalidInput("workspace_id", "user is already in this workspace")
+	}
+
+	target, err := s.workspaceRepository.GetByID(ctx, request.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if target.AdminID == nil || target.AdminID.String() != claims.ID {
+		return nil, forbid(claims, "Workspace", target.ID.String(), target.ID.String(), "you do not administer the target workspace")
+	}
+
+	user.WorkspaceID = request.WorkspaceID
+	user.Role = domain.RoleUser
+	if err := s.userRepository.Update(ctx, *user); err != nil {
+		return nil, err
+	}
+	if err := s.userRepository.BumpTokenEpoch(ctx, user.ID); err != nil {
+		return nil, err
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return newAdminUserResponse(user), nil
+}
@@ -0,0 +1,145 @@
+package application
+
+import (
+	"context"
+
+	apperrors "backend/internal/application/errors"
+	"backend/internal/domain"
+	domainerrors "backend/internal/domain/errors"
+	"backend/internal/domain/repository"
+	"backend/pkg/contracts"
+	"backend/pkg/errors"
+	"backend/pkg/jwt"
+	"backend/pkg/validation"
+
+	"github.com/google/uuid"
+)
+
+type APIKeyService struct {
+	apiKeyRepository repository.APIKeyRepository
+	validator        *validation.Service
+	ids              domain.IDGenerator
+}
+
+func NewAPIKeyService(apiKeyRepo repository.APIKeyRepository, validator *validation.Service, ids domain.IDGenerator) APIKeyService {
+	return APIKeyService{
+		apiKeyRepository: apiKeyRepo,
+		validator:        validator,
+		ids:              ids,
+	}
+}
+
+// CreateAPIKey issues a new key for the caller's workspace. The plaintext key
+// is only part of this response; afterwards just its hash is stored.
+func (s APIKeyService) CreateAPIKey(ctx context.Context, request contracts.CreateAPIKey) (*contracts.CreateAPIKeyResponse, *errors.Error) {
+	if err := s.validator.Validate(request); err != nil {
+		return nil, err
+	}
+
+	claims, ok := jwt.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, apperrors.ReturnMissingClaims()
+	}
+	if claims.WorkspaceID != request.WorkspaceID.String() {
+		return nil, forbid(claims, "Workspace", request.WorkspaceID.String(), request.WorkspaceID.String(), "user does not belong to the specified workspace")
+	}
+
+	var createdBy *uuid.UUID
+	if callerID, err := uuid.Parse(claims.ID); err == nil {
+		createdBy = &callerID
+	}
+
+	scope := request.Scope
+	if scope == "" {
+		scope = jwt.ScopeWrite
+	}
+
+	key, plaintext, genErr := domain.NewAPIKey(s.ids, request.Name, domain.Role(request.Role), scope, request.WorkspaceID, createdBy)
+	if genErr != nil {
+		return nil, errors.Wrap(genErr, "failed to generate API key").WithHTTPStatus(500)
+	}
+
+	if err := s.apiKeyRepository.Create(ctx, key); err != nil {
+		return nil, err
+	}
+
+	return &contracts.CreateAPIKeyResponse{
+		APIKeyResponse: newAPIKeyResponse(key),
+		Key:            plaintext,
+	}, nil
+}
+
+// ListAPIKeys returns the active keys of the caller's workspace without their secrets.
+func (s APIKeyService) ListAPIKeys(ctx context.Context, workspaceID uuid.UUID) ([]contracts.APIKeyResponse, *errors.Error) {
+	claims, ok := jwt.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, apperrors.ReturnMissingClaims()
+	}
+	if claims.WorkspaceID != workspaceID.String() {
+		return nil, forbid(claims, "Workspace", workspaceID.String(), workspaceID.String(), "user does not belong to the specified workspace")
+	}
+
+	keys, err := s.apiKeyRepository.ListByWorkspace(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]contracts.APIKeyResponse, len(keys))
+	for i, key := range keys {
+		result[i] = newAPIKeyResponse(key)
+	}
+	return result, nil
+}
+
+// RevokeAPIKey revokes a key in the caller's workspace. Authentication looks
+// keys up on every request, so the key stops working immediately.
+func (s APIKeyService) RevokeAPIKey(ctx context.Context, workspaceID, keyID uuid.UUID) *errors.Error {
+	claims, ok := jwt.ClaimsFromContext(ctx)
+	if !ok {
+		return apperrors.ReturnMissingClaims()
+	}
+	if claims.WorkspaceID != workspaceID.String() {
+		return forbid(claims, "Workspace", workspaceID.String(), workspaceID.String(), "user does not belong to the specified workspace")
+	}
+
+	return s.apiKeyRepository.Revoke(ctx, workspaceID, keyID)
+}
+
+// Authenticate resolves a plaintext API key to the claims it acts with. The
+// claims carry the key's ID, not a user's, so flows that record the caller as
+// a user refuse them through callerUserID.
+func (s APIKeyService) Authenticate(ctx context.Context, key string) (*jwt.Claims, *errors.Error) {
+	if !domain.IsAPIKey(key) {
+		return nil, domainerrors.ErrInvalidAPIKey
+	}
+
+	apiKey, err := s.apiKeyRepository.GetActiveByHash(ctx, domain.HashAPIKey(key))
+	if err != nil {
+		if err.Code() == errors.CodeNotFound {
+			return nil, domainerrors.ErrInvalidAPIKey
+		}
+		return nil, err
+	}
+
+	return &jwt.Claims{
+		ID:          apiKey.ID.String(),
+		Name:        apiKey.Name,
+		Role:        string(apiKey.Role),
+		WorkspaceID: apiKey.WorkspaceID.String(),
+		Scope:       apiKey.Scope,
+		ViaAPIKey:   true,
+	}, nil
+}
+
+func newAPIKeyResponse(key *domain.APIKey) contracts.APIKeyResponse {
+	return contracts.APIKeyResponse{
+		ID:          key.ID,
+		WorkspaceID: key.WorkspaceID,
+		Name:        key.Name,
+		Role:        string(key.Role),
+		Scope:       key.Scope,
+		KeyPrefix:   key.KeyPrefix,
+		CreatedBy:   key.CreatedBy,
+		CreatedAt:   key.CreatedAt,
+	}
+}
@@ -0,0 +1,48 @@
+package application
+
+import (
+	"log/slog"
+
+	apperrors "backend/internal/application/errors"
+	domainerrors "backend/internal/domain/errors"
+	"backend/pkg/errors"
+	"backend/pkg/jwt"
+
+	"github.com/google/uuid"
+)
+
+// logAuthzDenial logs a refused access at warn level: who asked, for which
+// resource, and the workspace the resource is in against the caller's, so
+// probing across workspaces stands out from ordinary request errors.
+func logAuthzDenial(claims *jwt.Claims, resource, id, resourceWorkspaceID, reason string) {
+	slog.Warn("authorization denied",
+		"actor_id", claims.ID,
+		"actor_role", claims.Role,
+		"actor_workspace_id", claims.WorkspaceID,
+		"resource_type", resource,
+		"resource_id", id,
+		"resource_workspace_id", resourceWorkspaceID,
+		"reason", reason,
+	)
+}
+
+// forbid logs an authorization denial and returns the 403 for it.
+func forbid(claims *jwt.Claims, resource, id, resourceWorkspaceID, reason string) *errors.Error {
+	logAuthzDenial(claims, resource, id, resourceWorkspaceID, reason)
+	return apperrors.ReturnForbidden(reason)
+}
+
+// callerUserID returns the ID of the user behind claims. API key claims carry
+// the key's ID instead, which must never be stored where a user is expected,
+// so they are refused with ErrUserRequired.
+func callerUserID(claims *jwt.Claims) (uuid.UUID, *errors.Error) {
+	if claims.ViaAPIKey {
+		logAuthzDenial(claims, "User", claims.ID, claims.WorkspaceID, "action requires a user, not an API key")
+		return uuid.Nil, domainerrors.ErrUserRequired
+	}
+	userID, err := uuid.Parse(claims.ID)
+	if err != nil {
+		return uuid.Nil, errors.WithCode(errors.CodeUnauthorized, "invalid user ID in JWT claims").WithHTTPStatus(401)
+	}
+	return userID, nil
+}
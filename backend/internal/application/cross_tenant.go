@@ -0,0 +1,34 @@
+package application
+
+import (
+	apperrors "backend/internal/application/errors"
+	domainerrors "backend/internal/domain/errors"
+	"backend/pkg/errors"
+	"backend/pkg/jwt"
+)
+
+// CrossTenantDenial selects how services answer a request for a resource in
+// another workspace.
+type CrossTenantDenial string
+
+const (
+	// CrossTenantNotFound reports the resource as missing, so callers cannot
+	// tell which IDs exist in other workspaces. This is the default.
+	CrossTenantNotFound CrossTenantDenial = "not_found"
+
+	// CrossTenantForbidden answers with an explicit 403, which is easier to
+	// debug but confirms that the resource exists.
+	CrossTenantForbidden CrossTenantDenial = "forbidden"
+)
+
+// Deny builds the error for a cross-workspace access to the resource with id,
+// which lives in resourceWorkspaceID. The denial is always logged with its real
+// reason, whichever answer the caller gets.
+func (d CrossTenantDenial) Deny(claims *jwt.Claims, resource, id, resourceWorkspaceID, reason string) *errors.Error {
+	logAuthzDenial(claims, resource, id, resourceWorkspaceID, reason)
+
+	if d == CrossTenantForbidden {
+		return apperrors.ReturnForbidden(reason)
+	}
+	return domainerrors.NotFound(resource, id)
+}
@@ -43,6 +43,8 @@ func NewEnvironmentReaper(
 		repoFactory.CreateEnvironmentRepository(uow),
 		encryptor,
 		validator,
+		// The reaper only reads variable values, so it never generates IDs
+		domain.RandomIDGenerator{},
 	)
 
 	interval := defaultReaperInterval
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	apperrors "backend/internal/application/errors"
+	apphandlers "backend/internal/application/handlers"
 	"backend/internal/domain"
 	"backend/internal/domain/repository"
 	"backend/internal/domain/storage"
@@ -30,6 +31,8 @@ type EnvironmentService struct {
 	tfExecutor       *terraform.Executor
 	envVarService    EnvironmentVariableValueService
 	teardownRepo     repository.TeardownQueueRepository
+	uow              apphandlers.UnitOfWork
+	ids              domain.IDGenerator
 }
 
 func NewEnvironmentService(
@@ -42,6 +45,8 @@ func NewEnvironmentService(
 	tfExecutor *terraform.Executor,
 	envVarService EnvironmentVariableValueService,
 	teardownRepo repository.TeardownQueueRepository,
+	uow apphandlers.UnitOfWork,
+	ids domain.IDGenerator,
 ) EnvironmentService {
 	return EnvironmentService{
 		envRepo:          envRepo,
@@ -53,6 +58,8 @@ func NewEnvironmentService(
 		groupRepo:        groupRepo,
 		envVarService:    envVarService,
 		teardownRepo:     teardownRepo,
+		uow:              uow,
+		ids:              ids,
 	}
 }
 
@@ -60,7 +67,7 @@ func (s EnvironmentService) verifyEnvironmentOwnership(ctx context.Context, envI
 	var err *errors.Error
 	claims, ok := jwt.ClaimsFromContext(ctx)
 	if !ok {
-		return nil, apperrors.ReturnUnauthorized("missing JWT claims in context")
+		return nil, apperrors.ReturnMissingClaims()
 	}
 
 	env, err := s.envRepo.GetByID(ctx, envID)
@@ -69,7 +76,7 @@ func (s EnvironmentService) verifyEnvironmentOwnership(ctx context.Context, envI
 	}
 
 	if env.WorkspaceID.String() != claims.WorkspaceID {
-		return nil, apperrors.ReturnForbidden("environment does not belong to your workspace")
+		return nil, forbid(claims, "Environment", env.ID.String(), env.WorkspaceID.String(), "environment does not belong to your workspace")
 	}
 
 	return env, nil
@@ -80,7 +87,11 @@ func (s EnvironmentService) verifyEnvironmentOwnership(ctx context.Context, envI
 func (s EnvironmentService) CreateEnvironment(ctx context.Context, request contracts.CreateEnvironment) (*domain.Environment, *errors.Error) {
 	claims, ok := jwt.ClaimsFromContext(ctx)
 	if !ok {
-		return nil, apperrors.ReturnUnauthorized("missing JWT claims in context")
+		return nil, apperrors.ReturnMissingClaims()
+	}
+	createdBy, callerErr := callerUserID(claims)
+	if callerErr != nil {
+		return nil, callerErr
 	}
 
 	if err := s.validator.Validate(request); err != nil {
@@ -95,11 +106,9 @@ func (s EnvironmentService) CreateEnvironment(ctx context.Context, request contr
 		return nil, repoErr
 	}
 	if template.WorkspaceID != workspaceID {
-		return nil, apperrors.ReturnForbidden("template does not belong to your workspace")
+		return nil, forbid(claims, "Template", template.ID.String(), template.WorkspaceID.String(), "template does not belong to your workspace")
 	}
 
-	createdBy, _ := uuid.Parse(claims.ID)
-
 	// Group-based template access check (admins bypass)
 	isAdmin := domain.Role(claims.Role) == domain.RoleAdmin
 	hasAccess, accessErr := CanAccessTemplate(ctx, s.groupRepo, createdBy, workspaceID, request.TemplateID, isAdmin)
@@ -107,10 +116,10 @@ func (s EnvironmentService) CreateEnvironment(ctx context.Context, request contr
 		return nil, accessErr
 	}
 	if !hasAccess {
-		return nil, apperrors.ReturnForbidden("you do not have access to this template")
+		return nil, forbid(claims, "Template", template.ID.String(), template.WorkspaceID.String(), "you do not have access to this template")
 	}
 
-	env := domain.NewEnvironment(request.Name, request.Description, createdBy, workspaceID, request.TemplateID, request.TTLSeconds)
+	env := domain.NewEnvironment(s.ids, request.Name, request.Description, createdBy, workspaceID, request.TemplateID, request.TTLSeconds)
 	//Verify user didn't created env from the template
 	userCreatedEnv, repoErr := s.envRepo.GetByCreatedBy(ctx, createdBy)
 	for _, env := range userCreatedEnv {
@@ -157,7 +166,7 @@ func (s EnvironmentService) CreateEnvironment(ctx context.Context, request contr
 func (s EnvironmentService) GetEnvironment(ctx context.Context, request contracts.GetEnvironment) (*domain.Environment, *errors.Error) {
 	claims, ok := jwt.ClaimsFromContext(ctx)
 	if !ok {
-		return nil, apperrors.ReturnUnauthorized("missing JWT claims in context")
+		return nil, apperrors.ReturnMissingClaims()
 	}
 
 	if err := s.validator.Validate(request); err != nil {
@@ -170,7 +179,7 @@ func (s EnvironmentService) GetEnvironment(ctx context.Context, request contract
 	}
 
 	if env.WorkspaceID.String() != claims.WorkspaceID {
-		return nil, apperrors.ReturnForbidden("environment does not belong to your workspace")
+		return nil, forbid(claims, "Environment", env.ID.String(), env.WorkspaceID.String(), "environment does not belong to your workspace")
 	}
 
 	return env, nil
@@ -216,13 +225,65 @@ func (s EnvironmentService) GetEnvironmentOutputs(ctx context.Context, request c
 
 // ListEnvironments retrieves environments with scope-based filtering and enriched response.
 func (s EnvironmentService) ListEnvironments(ctx context.Context, request contracts.ListEnvironments) ([]*contracts.EnvironmentResponse, *errors.Error) {
+	opts, err := s.listOptions(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	envs, repoErr := s.envRepo.ListFiltered(ctx, opts)
+	if repoErr != nil {
+		return nil, apperrors.ReturnInternalError("failed to list environments")
+	}
+
+	if envs == nil {
+		envs = []*contracts.EnvironmentResponse{}
+	}
+
+	return envs, nil
+}
+
+// ListEnvironmentsPage returns one page of the caller's environments along
+// with the total number matching the request. The page and the count share a
+// read transaction so they agree.
+func (s EnvironmentService) ListEnvironmentsPage(ctx context.Context, request contracts.ListEnvironments) (*contracts.PagedResponse[*contracts.EnvironmentResponse], *errors.Error) {
+	opts, err := s.listOptions(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return inReadTx(ctx, s.uow, func() (*contracts.PagedResponse[*contracts.EnvironmentResponse], *errors.Error) {
+		envs, err := s.envRepo.ListFiltered(ctx, opts)
+		if err != nil {
+			return nil, apperrors.ReturnInternalError("failed to list environments")
+		}
+
+		total, err := s.envRepo.CountFiltered(ctx, opts)
+		if err != nil {
+			return nil, apperrors.ReturnInternalError("failed to count environments")
+		}
+
+		page := repository.ListOptions{Limit: opts.Limit, Offset: opts.Offset}
+		page.ApplyDefaults()
+		return &contracts.PagedResponse[*contracts.EnvironmentResponse]{
+			Items:  envs,
+			Total:  total,
+			Limit:  page.Limit,
+			Offset: page.Offset,
+		}, nil
+	})
+}
+
+// listOptions validates a list request and converts it to repository options
+// scoped to the caller's workspace and, for scope=user, to environments
+// created by the caller or their group co-members.
+func (s EnvironmentService) listOptions(ctx context.Context, request contracts.ListEnvironments) (repository.EnvironmentListOptions, *errors.Error) {
 	claims, ok := jwt.ClaimsFromContext(ctx)
 	if !ok {
-		return nil, apperrors.ReturnUnauthorized("missing JWT claims in context")
+		return repository.EnvironmentListOptions{}, apperrors.ReturnMissingClaims()
 	}
 
 	if err := s.validator.Validate(request); err != nil {
-		return nil, err
+		return repository.EnvironmentListOptions{}, err
 	}
 
 	workspaceID, _ := uuid.Parse(claims.WorkspaceID)
@@ -235,7 +296,7 @@ func (s EnvironmentService) ListEnvironments(ctx context.Context, request contra
 	}
 
 	if scope == "all" && !isAdmin {
-		return nil, apperrors.ReturnForbidden("only admins can list all environments")
+		return repository.EnvironmentListOptions{}, apperrors.ReturnForbidden("only admins can list all environments")
 	}
 
 	opts := repository.EnvironmentListOptions{
@@ -251,7 +312,7 @@ func (s EnvironmentService) ListEnvironments(ctx context.Context, request contra
 	if scope == "user" {
 		coMembers, coErr := s.groupRepo.GetCoMemberUserIDs(ctx, userID, workspaceID)
 		if coErr != nil {
-			return nil, apperrors.ReturnInternalError("failed to resolve group co-members")
+			return repository.EnvironmentListOptions{}, apperrors.ReturnInternalError("failed to resolve group co-members")
 		}
 		opts.CreatorIDs = append(coMembers, userID)
 	}
@@ -265,7 +326,7 @@ func (s EnvironmentService) ListEnvironments(ctx context.Context, request contra
 	if request.TemplateID != "" {
 		tid, err := uuid.Parse(request.TemplateID)
 		if err != nil {
-			return nil, apperrors.ReturnBadRequest("invalid template_id")
+			return repository.EnvironmentListOptions{}, apperrors.ReturnBadRequest("invalid template_id")
 		}
 		opts.TemplateID = &tid
 	}
@@ -274,23 +335,14 @@ func (s EnvironmentService) ListEnvironments(ctx context.Context, request contra
 	if request.CreatedBy != "" {
 		cbID, err := uuid.Parse(request.CreatedBy)
 		if err != nil {
-			return nil, apperrors.ReturnBadRequest("invalid created_by")
+			return repository.EnvironmentListOptions{}, apperrors.ReturnBadRequest("invalid created_by")
 		}
 		// If scope=user, further restrict within the already-resolved set.
 		// If scope=all (admin), filter to this specific creator.
 		opts.CreatorIDs = []uuid.UUID{cbID}
 	}
 
-	envs, repoErr := s.envRepo.ListFiltered(ctx, opts)
-	if repoErr != nil {
-		return nil, apperrors.ReturnInternalError("failed to list environments")
-	}
-
-	if envs == nil {
-		envs = []*contracts.EnvironmentResponse{}
-	}
-
-	return envs, nil
+	return opts, nil
 }
 
 // PlanEnvironment runs terraform plan on the environment.
@@ -317,8 +369,9 @@ func (s EnvironmentService) DestroyEnvironment(ctx context.Context, request cont
 	return s.startOperation(ctx, request.ID, domain.EnvironmentStatusDestroying)
 }
 
-// DeleteEnvironment runs terraform destroy on the environment, then deletes
-// it from the database and cleans up the execution directory.
+// DeleteEnvironment runs terraform destroy on the environment, then
+// soft-deletes it. The execution directory is kept so a restored environment
+// can be applied again.
 func (s EnvironmentService) DeleteEnvironment(ctx context.Context, request contracts.DeleteEnvironment) *errors.Error {
 	env, err := s.verifyEnvironmentOwnership(ctx, request.ID)
 	if err != nil {
@@ -343,18 +396,59 @@ func (s EnvironmentService) executeDeleteWithDestroy(env *domain.Environment) {
 		slog.Error("failed to write tfvars before delete-destroy", "env_id", env.ID, "error", err)
 	}
 
-	if _, tfErr := s.tfExecutor.Destroy(ctx, env.ExecutionPath()); tfErr != nil {
+	_, tfErr := s.tfExecutor.Destroy(ctx, env.ExecutionPath())
+	if tfErr != nil {
 		slog.Error("terraform destroy failed during delete", "env_id", env.ID, "error", tfErr)
 	}
 
+	// Record the outcome so a restored environment shows what was destroyed
+	s.completeOperation(env, domain.EnvironmentStatusDestroying, tfErr)
+
 	if repoErr := s.envRepo.Delete(ctx, env.ID); repoErr != nil {
 		slog.Error("failed to delete environment after destroy", "env_id", env.ID, "error", repoErr)
-		return
 	}
+}
 
-	if cleanupErr := s.executionStorage.DeleteDir(env.ExecutionPath()); cleanupErr != nil {
-		slog.Error("failed to cleanup execution dir after delete", "path", env.ExecutionPath(), "error", cleanupErr)
+// RestoreEnvironment undoes an environment soft delete and returns the
+// restored environment. It is refused while the caller already has another
+// environment from the same template.
+func (s EnvironmentService) RestoreEnvironment(ctx context.Context, request contracts.RestoreEnvironment) (*domain.Environment, *errors.Error) {
+	claims, ok := jwt.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, apperrors.ReturnMissingClaims()
 	}
+
+	if err := s.validator.Validate(request); err != nil {
+		return nil, err
+	}
+
+	workspaceID, _ := uuid.Parse(claims.WorkspaceID)
+
+	if err := s.uow.Begin(ctx); err != nil {
+		return nil, err
+	}
+	defer s.uow.Rollback()
+
+	if err := s.envRepo.Restore(ctx, request.ID, workspaceID); err != nil {
+		return nil, err
+	}
+
+	env, err := s.envRepo.GetByID(ctx, request.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	siblings, err := s.envRepo.GetByCreatedBy(ctx, env.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+	for _, sibling := range siblings {
+		if sibling.ID != env.ID && sibling.TemplateID == env.TemplateID {
+			return nil, apperrors.ReturnConflict("an environment from this template already exists")
+		}
+	}
+
+	return env, s.uow.Commit(ctx)
 }
 
 // startOperation acquires the atomic lock and dispatches the terraform command
@@ -24,6 +24,7 @@ type EnvironmentVariableValueService struct {
 	environmentRepo repository.EnvironmentRepository
 	encryptor       crypto.Encryptor
 	validator       *validation.Service
+	ids             domain.IDGenerator
 }
 
 func NewEnvironmentVariableValueService(
@@ -32,6 +33,7 @@ func NewEnvironmentVariableValueService(
 	environmentRepo repository.EnvironmentRepository,
 	encryptor crypto.Encryptor,
 	validator *validation.Service,
+	ids domain.IDGenerator,
 ) EnvironmentVariableValueService {
 	return EnvironmentVariableValueService{
 		envVarRepo:      envVarRepo,
@@ -39,13 +41,14 @@ func NewEnvironmentVariableValueService(
 		environmentRepo: environmentRepo,
 		encryptor:       encryptor,
 		validator:       validator,
+		ids:             ids,
 	}
 }
 
 func (s EnvironmentVariableValueService) verifyEnvironmentOwnership(ctx context.Context, environmentID uuid.UUID) (*domain.Environment, *errors.Error) {
 	claims, ok := jwt.ClaimsFromContext(ctx)
 	if !ok {
-		return nil, apperrors.ReturnUnauthorized("missing JWT claims in context")
+		return nil, apperrors.ReturnMissingClaims()
 	}
 
 	env, repoErr := s.environmentRepo.GetByID(ctx, environmentID)
@@ -54,7 +57,7 @@ func (s EnvironmentVariableValueService) verifyEnvironmentOwnership(ctx context.
 	}
 
 	if env.WorkspaceID.String() != claims.WorkspaceID {
-		return nil, apperrors.ReturnForbidden("environment does not belong to your workspace")
+		return nil, forbid(claims, "Environment", env.ID.String(), env.WorkspaceID.String(), "environment does not belong to your workspace")
 	}
 
 	return env, nil
@@ -125,6 +128,7 @@ func (s EnvironmentVariableValueService) SetVariableValues(ctx context.Context,
 		}
 
 		values = append(values, *domain.NewEnvironmentVariableValue(
+			s.ids,
 			request.EnvironmentID,
 			entry.TemplateVariableID,
 			finalValue,
@@ -167,7 +171,7 @@ func (s EnvironmentVariableValueService) GetVariableValues(ctx context.Context,
 		return nil, repoErr
 	}
 
-	var response []EnvironmentVariableValueResponse
+	response := []EnvironmentVariableValueResponse{}
 	for _, sv := range storedValues {
 		tv, exists := templateVarByID[sv.TemplateVariableID]
 		if !exists {
@@ -6,7 +6,9 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 
+	"backend/pkg/contracts"
 	pkgerrors "backend/pkg/errors"
+	"backend/pkg/validation"
 )
 
 // ErrorHandler returns a Fiber error handler that converts errors to JSON responses
@@ -39,15 +41,49 @@ func ErrorHandler() func(*fiber.Ctx, error) error {
 
 		// Return JSON error response
 		return c.Status(appErr.HTTPStatus()).JSON(ErrorResponse{
-			Error: ErrorDetail{
-				Code:     string(appErr.Code()),
-				Message:  appErr.Error(),
-				Metadata: appErr.GetMetadata(),
-			},
+			Error: NewErrorDetail(c, appErr),
 		})
 	}
 }
 
+// NewErrorDetail renders err for the client: metadata keys are formatted,
+// validation messages localized for the request and a panic value dropped. Errors reported inside a
+// successful response, such as batch item failures, use it too.
+func NewErrorDetail(c *fiber.Ctx, err *pkgerrors.Error) ErrorDetail {
+	metadata := err.GetMetadata()
+	delete(metadata, pkgerrors.MetadataPanic)
+	return ErrorDetail{
+		Code:     string(err.Code()),
+		Message:  err.Error(),
+		Metadata: pkgerrors.FormatMetadata(localizeMetadata(c, metadata)),
+	}
+}
+
+// localizeMetadata re-renders validation field messages in the locale requested
+// via Accept-Language and drops the raw violations from the client response
+func localizeMetadata(c *fiber.Ctx, metadata map[string]interface{}) map[string]interface{} {
+	violations, ok := metadata[validation.MetadataViolationsKey].(validation.FieldViolations)
+	if !ok {
+		return metadata
+	}
+
+	delete(metadata, validation.MetadataViolationsKey)
+	locale := validation.ParseAcceptLanguage(c.Get(fiber.HeaderAcceptLanguage))
+
+	// Keep field errors merged in from other layers; only re-render the validator's own
+	fields := make(map[string]string)
+	if existing, ok := metadata[pkgerrors.MetadataFields].(map[string]string); ok {
+		for field, message := range existing {
+			fields[field] = message
+		}
+	}
+	for field, message := range validation.LocalizeFields(violations, locale) {
+		fields[field] = message
+	}
+	metadata[pkgerrors.MetadataFields] = fields
+	return metadata
+}
+
 // logError logs the error with structured context
 func logError(c *fiber.Ctx, err *pkgerrors.Error) {
 	// Build log attributes
@@ -88,8 +124,4 @@ type ErrorResponse struct {
 }
 
 // ErrorDetail contains the error information returned to clients
-type ErrorDetail struct {
-	Code     string                 `json:"code"`
-	Message  string                 `json:"message"`
-	Metadata map[string]interface{} `json:"metadata,omitempty"`
-}
+type ErrorDetail = contracts.ErrorDetail
@@ -1,6 +1,9 @@
 package errors
 
 import (
+	"log/slog"
+	"runtime"
+
 	"github.com/gofiber/fiber/v2"
 
 	pkgerrors "backend/pkg/errors"
@@ -40,6 +43,36 @@ func ReturnUnauthorized(message string) *pkgerrors.Error {
 		WithSeverity(pkgerrors.SeverityWarning)
 }
 
+// ReturnMissingClaims is returned by services that require JWT claims but
+// found none in the context. Behind RequireAuth that cannot happen, so it
+// most likely means a route was registered without authentication; the
+// client still sees a 401 but the misconfiguration is logged with its caller.
+func ReturnMissingClaims() *pkgerrors.Error {
+	caller := "unknown"
+	if pc, _, _, ok := runtime.Caller(1); ok {
+		if fn := runtime.FuncForPC(pc); fn != nil {
+			caller = fn.Name()
+		}
+	}
+	slog.Warn("JWT claims missing from context; route is probably not behind RequireAuth", "caller", caller)
+
+	return pkgerrors.WithCode(pkgerrors.CodeUnauthorized, "missing JWT claims in context").
+		WithHTTPStatus(fiber.StatusUnauthorized).
+		WithSeverity(pkgerrors.SeverityWarning).
+		WithMetadata(pkgerrors.MetadataReason, "missing_claims")
+}
+
+// ReturnInvalidClaims is returned when a token verified but one of its claims
+// cannot be used, e.g. a workspace ID that is not a UUID. The token is at
+// fault rather than the server, so the client gets a 401 and re-authenticates.
+func ReturnInvalidClaims(claim string) *pkgerrors.Error {
+	return pkgerrors.WithCode(pkgerrors.CodeUnauthorized, "invalid "+claim+" in token").
+		WithHTTPStatus(fiber.StatusUnauthorized).
+		WithSeverity(pkgerrors.SeverityWarning).
+		WithMetadata(pkgerrors.MetadataReason, "invalid_claims").
+		WithMetadata(pkgerrors.MetadataClaim, claim)
+}
+
 // ReturnForbidden is a shorthand for forbidden errors
 func ReturnForbidden(message string) *pkgerrors.Error {
 	return pkgerrors.WithCode(pkgerrors.CodeForbidden, message).
@@ -48,7 +48,7 @@ func GetAccessibleTemplates(
 		accessSet[id] = struct{}{}
 	}
 
-	var filtered []*domain.Template
+	filtered := []*domain.Template{}
 	for _, t := range allTemplates {
 		if _, ok := accessSet[t.ID]; ok {
 			filtered = append(filtered, t)
@@ -58,6 +58,33 @@ func GetAccessibleTemplates(
 	return filtered, nil
 }
 
+// AccessibleTemplateFilters returns list filters that restrict templates to the
+// ones a user can access in a workspace. Admins and members of an access-all
+// group are scoped to the workspace only; everyone else additionally to the
+// template IDs granted through their groups.
+func AccessibleTemplateFilters(
+	ctx context.Context,
+	groupRepo repository.GroupRepository,
+	userID uuid.UUID,
+	workspaceID uuid.UUID,
+	isAdmin bool,
+) (map[string]any, *errors.Error) {
+	filters := map[string]any{"workspace_id": workspaceID}
+	if isAdmin {
+		return filters, nil
+	}
+
+	accessibleIDs, hasAccessAll, err := groupRepo.GetAccessibleTemplateIDs(ctx, userID, workspaceID)
+	if err != nil {
+		return nil, apperrors.ReturnInternalError("failed to check template access")
+	}
+
+	if !hasAccessAll {
+		filters["id"] = accessibleIDs
+	}
+	return filters, nil
+}
+
 // CanAccessTemplate checks whether a user can access a specific template
 // based on their group memberships. Admins always have access.
 func CanAccessTemplate(
@@ -18,19 +18,21 @@ import (
 type GroupService struct {
 	groupRepo repository.GroupRepository
 	validator *validation.Service
+	ids       domain.IDGenerator
 }
 
-func NewGroupService(groupRepo repository.GroupRepository, validator *validation.Service) GroupService {
+func NewGroupService(groupRepo repository.GroupRepository, validator *validation.Service, ids domain.IDGenerator) GroupService {
 	return GroupService{
 		groupRepo: groupRepo,
 		validator: validator,
+		ids:       ids,
 	}
 }
 
 func (s GroupService) CreateGroup(ctx context.Context, request contracts.CreateGroup) (*domain.Group, *errors.Error) {
 	claims, ok := jwt.ClaimsFromContext(ctx)
 	if !ok {
-		return nil, apperrors.ReturnUnauthorized("missing JWT claims in context")
+		return nil, apperrors.ReturnMissingClaims()
 	}
 
 	if err := s.validator.Validate(request); err != nil {
@@ -38,7 +40,7 @@ func (s GroupService) CreateGroup(ctx context.Context, request contracts.CreateG
 	}
 
 	workspaceID, _ := uuid.Parse(claims.WorkspaceID)
-	group := domain.NewGroup(request.Name, request.Description, workspaceID, request.AccessAllTemplates)
+	group := domain.NewGroup(s.ids, request.Name, request.Description, workspaceID, request.AccessAllTemplates)
 
 	if err := s.groupRepo.Create(ctx, group); err != nil {
 		return nil, err
@@ -50,7 +52,7 @@ func (s GroupService) CreateGroup(ctx context.Context, request contracts.CreateG
 func (s GroupService) GetGroup(ctx context.Context, id uuid.UUID) (*domain.Group, *errors.Error) {
 	claims, ok := jwt.ClaimsFromContext(ctx)
 	if !ok {
-		return nil, apperrors.ReturnUnauthorized("missing JWT claims in context")
+		return nil, apperrors.ReturnMissingClaims()
 	}
 
 	group, err := s.groupRepo.GetByID(ctx, id)
@@ -59,7 +61,7 @@ func (s GroupService) GetGroup(ctx context.Context, id uuid.UUID) (*domain.Group
 	}
 
 	if group.WorkspaceID.String() != claims.WorkspaceID {
-		return nil, apperrors.ReturnForbidden("group does not belong to your workspace")
+		return nil, forbid(claims, "Group", group.ID.String(), group.WorkspaceID.String(), "group does not belong to your workspace")
 	}
 
 	return group, nil
@@ -68,7 +70,7 @@ func (s GroupService) GetGroup(ctx context.Context, id uuid.UUID) (*domain.Group
 func (s GroupService) ListGroups(ctx context.Context) ([]*domain.Group, *errors.Error) {
 	claims, ok := jwt.ClaimsFromContext(ctx)
 	if !ok {
-		return nil, apperrors.ReturnUnauthorized("missing JWT claims in context")
+		return nil, apperrors.ReturnMissingClaims()
 	}
 
 	workspaceID, _ := uuid.Parse(claims.WorkspaceID)
@@ -78,7 +80,7 @@ func (s GroupService) ListGroups(ctx context.Context) ([]*domain.Group, *errors.
 func (s GroupService) UpdateGroup(ctx context.Context, id uuid.UUID, request contracts.UpdateGroup) (*domain.Group, *errors.Error) {
 	claims, ok := jwt.ClaimsFromContext(ctx)
 	if !ok {
-		return nil, apperrors.ReturnUnauthorized("missing JWT claims in context")
+		return nil, apperrors.ReturnMissingClaims()
 	}
 
 	if err := s.validator.Validate(request); err != nil {
@@ -91,7 +93,7 @@ func (s GroupService) UpdateGroup(ctx context.Context, id uuid.UUID, request con
 	}
 
 	if group.WorkspaceID.String() != claims.WorkspaceID {
-		return nil, apperrors.ReturnForbidden("group does not belong to your workspace")
+		return nil, forbid(claims, "Group", group.ID.String(), group.WorkspaceID.String(), "group does not belong to your workspace")
 	}
 
 	if request.Name != "" {
@@ -115,7 +117,7 @@ func (s GroupService) UpdateGroup(ctx context.Context, id uuid.UUID, request con
 func (s GroupService) DeleteGroup(ctx context.Context, id uuid.UUID) *errors.Error {
 	claims, ok := jwt.ClaimsFromContext(ctx)
 	if !ok {
-		return apperrors.ReturnUnauthorized("missing JWT claims in context")
+		return apperrors.ReturnMissingClaims()
 	}
 
 	group, err := s.groupRepo.GetByID(ctx, id)
@@ -124,7 +126,7 @@ func (s GroupService) DeleteGroup(ctx context.Context, id uuid.UUID) *errors.Err
 	}
 
 	if group.WorkspaceID.String() != claims.WorkspaceID {
-		return apperrors.ReturnForbidden("group does not belong to your workspace")
+		return forbid(claims, "Group", group.ID.String(), group.WorkspaceID.String(), "group does not belong to your workspace")
 	}
 
 	return s.groupRepo.Delete(ctx, id)
@@ -195,7 +197,7 @@ func (s GroupService) GetTemplateAccess(ctx context.Context, groupID uuid.UUID)
 func (s GroupService) verifyGroupWorkspace(ctx context.Context, groupID uuid.UUID) (*domain.Group, *errors.Error) {
 	claims, ok := jwt.ClaimsFromContext(ctx)
 	if !ok {
-		return nil, apperrors.ReturnUnauthorized("missing JWT claims in context")
+		return nil, apperrors.ReturnMissingClaims()
 	}
 
 	group, err := s.groupRepo.GetByID(ctx, groupID)
@@ -204,7 +206,7 @@ func (s GroupService) verifyGroupWorkspace(ctx context.Context, groupID uuid.UUI
 	}
 
 	if group.WorkspaceID.String() != claims.WorkspaceID {
-		return nil, apperrors.ReturnForbidden("group does not belong to your workspace")
+		return nil, forbid(claims, "Group", group.ID.String(), group.WorkspaceID.String(), "group does not belong to your workspace")
 	}
 
 	return group, nil
@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// txAnomalies counts unit-of-work misuse since the process started.
+var txAnomalies struct {
+	commitWithoutBegin atomic.Int64
+	leftOpen           atomic.Int64
+}
+
+// RecordCommitWithoutBegin counts a Commit made with no open transaction.
+func RecordCommitWithoutBegin() {
+	txAnomalies.commitWithoutBegin.Add(1)
+}
+
+// TxAnomalies returns how many commits were made without a transaction and
+// how many transactions were still open when their request ended.
+func TxAnomalies() (commitWithoutBegin, leftOpen int64) {
+	return txAnomalies.commitWithoutBegin.Load(), txAnomalies.leftOpen.Load()
+}
+
+type txTrackerKey struct{}
+
+// TxTrackerKey is the context key a TxTracker is stored under. HTTP
+// middleware sets it as a request local, which the request's context exposes
+// as a value.
+var TxTrackerKey = txTrackerKey{}
+
+// TxTracker counts the transactions units of work open and close while
+// serving one request, so a Begin without a matching Commit or Rollback can
+// be reported when the request ends.
+type TxTracker struct {
+	open atomic.Int64
+}
+
+// WithTxTracker returns a copy of ctx carrying tracker.
+func WithTxTracker(ctx context.Context, tracker *TxTracker) context.Context {
+	return context.WithValue(ctx, TxTrackerKey, tracker)
+}
+
+// TxTrackerFromContext returns the tracker stored in ctx, if any.
+func TxTrackerFromContext(ctx context.Context) (*TxTracker, bool) {
+	tracker, ok := ctx.Value(TxTrackerKey).(*TxTracker)
+	return tracker, ok && tracker != nil
+}
+
+// Opened records that a unit of work began a transaction.
+func (t *TxTracker) Opened() { t.open.Add(1) }
+
+// Closed records that a unit of work ended its transaction.
+func (t *TxTracker) Closed() { t.open.Add(-1) }
+
+// Open returns how many transactions are currently open.
+func (t *TxTracker) Open() int64 { return t.open.Load() }
+
+// Finish returns how many transactions are still open and counts them as
+// left open.
+func (t *TxTracker) Finish() int64 {
+	open := t.Open()
+	if open > 0 {
+		txAnomalies.leftOpen.Add(open)
+	}
+	return open
+}
@@ -1,6 +1,9 @@
 package handlers
 
 import (
+	"context"
+	"database/sql"
+
 	"backend/internal/domain/repository"
 	"backend/pkg/errors"
 )
@@ -9,19 +12,59 @@ type (
 	UnitOfWorkFactory interface {
 		Create() UnitOfWork
 	}
+	// UnitOfWork scopes a transaction to ctx: Begin fails once ctx is done,
+	// and a transaction whose ctx ends before Commit is rolled back.
 	UnitOfWork interface {
-		Begin() *errors.Error
-		Commit() *errors.Error
+		// Begin starts a transaction, or joins the open one; options only
+		// apply when it starts one.
+		Begin(ctx context.Context, opts ...TxOption) *errors.Error
+		// BeginReadOnly starts a transaction that rejects writes, so a series of
+		// reads sees one consistent snapshot. Nested inside an open transaction it
+		// joins that transaction instead.
+		BeginReadOnly(ctx context.Context) *errors.Error
+		Commit(ctx context.Context) *errors.Error
+		// Rollback takes no context: it must run even after ctx is cancelled
+		// to release the connection.
 		Rollback() *errors.Error
 	}
 	RepositoryFactory interface {
 		CreateUserRepository(uow UnitOfWork) repository.UserRepository
 		CreateWorkspaceRepository(uow UnitOfWork) repository.WorkspaceRepository
 		CreateTemplateRepository(uow UnitOfWork) repository.TemplateRepository
+		CreateTemplateVersionRepository(uow UnitOfWork) repository.TemplateVersionRepository
 		CreateEnvironmentRepository(uow UnitOfWork) repository.EnvironmentRepository
 		CreateTemplateVariableRepository(uow UnitOfWork) repository.TemplateVariableRepository
 		CreateEnvironmentVariableValueRepository(uow UnitOfWork) repository.EnvironmentVariableValueRepository
 		CreateTeardownQueueRepository(uow UnitOfWork) repository.TeardownQueueRepository
 		CreateGroupRepository(uow UnitOfWork) repository.GroupRepository
+		CreateSystemInitRepository(uow UnitOfWork) repository.SystemInitRepository
+		CreateAPIKeyRepository(uow UnitOfWork) repository.APIKeyRepository
+		CreateOutboxRepository(uow UnitOfWork) repository.OutboxRepository
 	}
 )
+
+// TxOptions are the settings Begin starts a transaction with.
+type TxOptions struct {
+	// Isolation is the isolation level requested from the database;
+	// sql.LevelDefault leaves it to the driver. SQLite transactions are
+	// serializable whatever is requested.
+	Isolation sql.IsolationLevel
+}
+
+// TxOption configures the transaction Begin starts.
+type TxOption func(*TxOptions)
+
+// WithIsolation requests level for the transaction, for operations that must
+// not interleave with concurrent writers.
+func WithIsolation(level sql.IsolationLevel) TxOption {
+	return func(o *TxOptions) { o.Isolation = level }
+}
+
+// NewTxOptions applies opts over the driver defaults.
+func NewTxOptions(opts ...TxOption) TxOptions {
+	var o TxOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
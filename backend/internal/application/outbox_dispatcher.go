@@ -0,0 +1,124 @@
+package application
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	apphandlers "backend/internal/application/handlers"
+	"backend/internal/domain"
+	"backend/pkg/errors"
+)
+
+const (
+	DefaultOutboxInterval = 5 * time.Second
+
+	// outboxBatchSize caps how many events one run dispatches.
+	outboxBatchSize = 100
+)
+
+// OutboxHandler handles one event. Events are delivered at least once, so
+// handlers must be idempotent. It runs in a transaction on uow, which also
+// marks the event processed.
+type OutboxHandler func(ctx context.Context, uow apphandlers.UnitOfWork, event *domain.OutboxEvent) *errors.Error
+
+// OutboxDispatcher delivers pending outbox events to the handlers registered
+// for their type. Each event is handled and marked processed in its own
+// transaction, so a failing event is retried on the next run without holding
+// up the others. Events with no handler are marked processed.
+type OutboxDispatcher struct {
+	uowFactory  apphandlers.UnitOfWorkFactory
+	repoFactory apphandlers.RepositoryFactory
+	handlers    map[string]OutboxHandler
+	interval    time.Duration
+	now         func() time.Time
+}
+
+// NewOutboxDispatcher returns a dispatcher with the application's event
+// handlers registered.
+func NewOutboxDispatcher(uowFactory apphandlers.UnitOfWorkFactory, repoFactory apphandlers.RepositoryFactory, interval time.Duration) *OutboxDispatcher {
+	if interval <= 0 {
+		interval = DefaultOutboxInterval
+	}
+	d := &OutboxDispatcher{
+		uowFactory:  uowFactory,
+		repoFactory: repoFactory,
+		handlers:    make(map[string]OutboxHandler),
+		interval:    interval,
+		now:         time.Now,
+	}
+	d.handlers[domain.EventUserCreated] = d.initializeLastActive
+	return d
+}
+
+// Start dispatches once, then again every interval until ctx is cancelled.
+func (d *OutboxDispatcher) Start(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := d.DispatchOnce(ctx); err != nil {
+			slog.Error("outbox: run failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			slog.Info("outbox: shutting down")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchOnce handles the pending events and returns how many were
+// processed. It stops early when ctx is cancelled.
+func (d *OutboxDispatcher) DispatchOnce(ctx context.Context) (int, *errors.Error) {
+	uow := d.uowFactory.Create()
+	events, err := d.repoFactory.CreateOutboxRepository(uow).FindPending(ctx, outboxBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	processed := 0
+	for _, event := range events {
+		if ctx.Err() != nil {
+			break
+		}
+		if err := d.dispatch(ctx, uow, event); err != nil {
+			slog.Error("outbox: failed to handle event", "event_id", event.ID, "type", event.Type, "error", err)
+			continue
+		}
+		processed++
+	}
+
+	return processed, nil
+}
+
+func (d *OutboxDispatcher) dispatch(ctx context.Context, uow apphandlers.UnitOfWork, event *domain.OutboxEvent) *errors.Error {
+	if err := uow.Begin(ctx); err != nil {
+		return err
+	}
+	defer uow.Rollback()
+
+	if handler, ok := d.handlers[event.Type]; ok {
+		if err := handler(ctx, uow, event); err != nil {
+			return err
+		}
+	} else {
+		slog.Warn("outbox: no handler for event type", "event_id", event.ID, "type", event.Type)
+	}
+
+	if err := d.repoFactory.CreateOutboxRepository(uow).MarkProcessed(ctx, event.ID, d.now()); err != nil {
+		return err
+	}
+
+	return uow.Commit(ctx)
+}
+
+// initializeLastActive handles user.created by setting the new user's
+// last_active_at to their registration time, unless they have been active
+// since.
+func (d *OutboxDispatcher) initializeLastActive(ctx context.Context, uow apphandlers.UnitOfWork, event *domain.OutboxEvent) *errors.Error {
+	_, err := d.repoFactory.CreateUserRepository(uow).TouchLastActive(ctx, event.AggregateID, event.CreatedAt, event.CreatedAt)
+	return err
+}
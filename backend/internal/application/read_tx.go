@@ -0,0 +1,28 @@
+package application
+
+import (
+	"context"
+
+	apphandlers "backend/internal/application/handlers"
+	"backend/pkg/errors"
+)
+
+// inReadTx runs fn inside a read-only transaction on uow, so the queries it
+// makes through repositories bound to uow all see the same snapshot. Use it for
+// endpoints that answer from more than one read.
+func inReadTx[T any](ctx context.Context, uow apphandlers.UnitOfWork, fn func() (T, *errors.Error)) (T, *errors.Error) {
+	var zero T
+	if err := uow.BeginReadOnly(ctx); err != nil {
+		return zero, err
+	}
+	defer uow.Rollback()
+
+	result, err := fn()
+	if err != nil {
+		return zero, err
+	}
+	if err := uow.Commit(ctx); err != nil {
+		return zero, err
+	}
+	return result, nil
+}
@@ -2,6 +2,7 @@ package application
 
 import (
 	apphandlers "backend/internal/application/handlers"
+	"backend/internal/domain"
 	"backend/internal/domain/storage"
 	"backend/internal/infra/terraform"
 	"backend/internal/infra/tfparser"
@@ -18,6 +19,9 @@ type ServiceFactory struct {
 	tfParser         tfparser.TFParser
 	executionStorage storage.ExecutionStorage
 	tfExecutor       *terraform.Executor
+	pathChecker      storage.PathChecker
+	crossTenant      CrossTenantDenial
+	ids              domain.IDGenerator
 }
 
 func NewServiceFactory(
@@ -29,6 +33,7 @@ func NewServiceFactory(
 	tfParser tfparser.TFParser,
 	executionStorage storage.ExecutionStorage,
 	tfExecutor *terraform.Executor,
+	pathChecker storage.PathChecker,
 ) *ServiceFactory {
 	return &ServiceFactory{
 		uowFactory:       uowFactory,
@@ -39,27 +44,51 @@ func NewServiceFactory(
 		tfParser:         tfParser,
 		executionStorage: executionStorage,
 		tfExecutor:       tfExecutor,
+		pathChecker:      pathChecker,
+		crossTenant:      CrossTenantNotFound,
+		ids:              domain.RandomIDGenerator{},
 	}
 }
 
+// WithCrossTenantDenial returns a copy of the factory whose services answer
+// cross-workspace access as d instead of the default CrossTenantNotFound.
+func (f *ServiceFactory) WithCrossTenantDenial(d CrossTenantDenial) *ServiceFactory {
+	copied := *f
+	copied.crossTenant = d
+	return &copied
+}
+
+// WithIDGenerator returns a copy of the factory whose services create entities
+// with IDs from ids instead of the default random UUIDs.
+func (f *ServiceFactory) WithIDGenerator(ids domain.IDGenerator) *ServiceFactory {
+	copied := *f
+	copied.ids = ids
+	return &copied
+}
+
 func (f *ServiceFactory) NewUserService() (UserService, apphandlers.UnitOfWork) {
 	uow := f.uowFactory.Create()
-	return NewUserService(f.repoFactory.CreateUserRepository(uow), f.validator), uow
+	return NewUserService(f.repoFactory.CreateUserRepository(uow), f.repoFactory.CreateWorkspaceRepository(uow), f.repoFactory.CreateOutboxRepository(uow), f.validator, f.ids), uow
 }
 
 func (f *ServiceFactory) NewWorkspaceService() (WorkspaceService, apphandlers.UnitOfWork) {
 	uow := f.uowFactory.Create()
-	return NewWorkspaceService(f.repoFactory.CreateWorkspaceRepository(uow), f.validator), uow
+	return NewWorkspaceService(f.repoFactory.CreateWorkspaceRepository(uow), f.repoFactory.CreateUserRepository(uow), f.validator, f.crossTenant, f.ids), uow
 }
 
 func (f *ServiceFactory) NewTemplateService() TemplateService {
 	uow := f.uowFactory.Create()
 	return NewTemplateService(
 		f.repoFactory.CreateTemplateRepository(uow),
+		f.repoFactory.CreateTemplateVersionRepository(uow),
 		f.repoFactory.CreateWorkspaceRepository(uow),
 		*f.validator,
 		f.fileStorage,
 		f.repoFactory.CreateGroupRepository(uow),
+		f.pathChecker,
+		uow,
+		f.crossTenant,
+		f.ids,
 	)
 }
 
@@ -71,6 +100,7 @@ func (f *ServiceFactory) NewEnvironmentService() EnvironmentService {
 		f.repoFactory.CreateEnvironmentRepository(uow),
 		f.encryptor,
 		f.validator,
+		f.ids,
 	)
 	return NewEnvironmentService(
 		f.repoFactory.CreateEnvironmentRepository(uow),
@@ -82,6 +112,8 @@ func (f *ServiceFactory) NewEnvironmentService() EnvironmentService {
 		f.tfExecutor,
 		envVarService,
 		f.repoFactory.CreateTeardownQueueRepository(uow),
+		uow,
+		f.ids,
 	)
 }
 
@@ -89,8 +121,9 @@ func (f *ServiceFactory) NewAdminService() (*AdminService, apphandlers.UnitOfWor
 	uow := f.uowFactory.Create()
 	userRepo := f.repoFactory.CreateUserRepository(uow)
 	workspaceRepo := f.repoFactory.CreateWorkspaceRepository(uow)
-	userService := NewUserService(userRepo, f.validator)
-	return NewAdminService(workspaceRepo, userService, userRepo, f.validator), uow
+	systemInitRepo := f.repoFactory.CreateSystemInitRepository(uow)
+	userService := NewUserService(userRepo, workspaceRepo, f.repoFactory.CreateOutboxRepository(uow), f.validator, f.ids)
+	return NewAdminService(workspaceRepo, userService, userRepo, systemInitRepo, f.validator, f.ids), uow
 }
 
 func (f *ServiceFactory) NewTemplateVariableService() TemplateVariableService {
@@ -103,12 +136,18 @@ func (f *ServiceFactory) NewTemplateVariableService() TemplateVariableService {
 		f.fileStorage,
 		f.tfParser,
 		uow,
+		f.ids,
 	)
 }
 
 func (f *ServiceFactory) NewGroupService() GroupService {
 	uow := f.uowFactory.Create()
-	return NewGroupService(f.repoFactory.CreateGroupRepository(uow), f.validator)
+	return NewGroupService(f.repoFactory.CreateGroupRepository(uow), f.validator, f.ids)
+}
+
+func (f *ServiceFactory) NewAPIKeyService() APIKeyService {
+	uow := f.uowFactory.Create()
+	return NewAPIKeyService(f.repoFactory.CreateAPIKeyRepository(uow), f.validator, f.ids)
 }
 
 func (f *ServiceFactory) NewEnvironmentVariableValueService() EnvironmentVariableValueService {
@@ -119,5 +158,6 @@ func (f *ServiceFactory) NewEnvironmentVariableValueService() EnvironmentVariabl
 		f.repoFactory.CreateEnvironmentRepository(uow),
 		f.encryptor,
 		f.validator,
+		f.ids,
 	)
 }
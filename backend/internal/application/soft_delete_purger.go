@@ -0,0 +1,272 @@
+package application
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	apperrors "backend/internal/application/errors"
+	apphandlers "backend/internal/application/handlers"
+	"backend/internal/domain/storage"
+	"backend/pkg/contracts"
+	"backend/pkg/errors"
+	"backend/pkg/jwt"
+	"backend/pkg/validation"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultPurgeInterval  = time.Hour
+	DefaultPurgeRetention = 30 * 24 * time.Hour
+)
+
+// SoftDeletePurger hard-deletes workspaces that have been soft-deleted for
+// longer than the retention window. Each workspace is purged in its own
+// transaction, so the write lock is held briefly and a failure only skips that
+// workspace until the next run. Templates, users and environments go with
+// their workspace through ON DELETE CASCADE; template files and execution
+// directories are removed from storage once the purge has committed.
+//
+// A workspace whose environments may still hold cloud resources is not
+// purged: their teardown is queued for the environment reaper instead, and
+// the workspace is purged on a later run once they are all destroyed.
+//
+// Soft-deleted environments in live workspaces are purged after the same
+// retention window, along with their execution directories.
+type SoftDeletePurger struct {
+	uowFactory       apphandlers.UnitOfWorkFactory
+	repoFactory      apphandlers.RepositoryFactory
+	fileStorage      storage.FileStorage
+	executionStorage storage.ExecutionStorage
+	validator        *validation.Service
+	interval         time.Duration
+	retention        time.Duration
+	now              func() time.Time
+}
+
+func NewSoftDeletePurger(
+	uowFactory apphandlers.UnitOfWorkFactory,
+	repoFactory apphandlers.RepositoryFactory,
+	fileStorage storage.FileStorage,
+	executionStorage storage.ExecutionStorage,
+	validator *validation.Service,
+	interval time.Duration,
+	retention time.Duration,
+) *SoftDeletePurger {
+	if interval <= 0 {
+		interval = DefaultPurgeInterval
+	}
+	if retention <= 0 {
+		retention = DefaultPurgeRetention
+	}
+	return &SoftDeletePurger{
+		uowFactory:       uowFactory,
+		repoFactory:      repoFactory,
+		fileStorage:      fileStorage,
+		executionStorage: executionStorage,
+		validator:        validator,
+		interval:         interval,
+		retention:        retention,
+		now:              time.Now,
+	}
+}
+
+// Start purges once, then again every interval until ctx is cancelled.
+func (p *SoftDeletePurger) Start(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		if purged, err := p.PurgeOnce(ctx); err != nil {
+			slog.Error("purger: run failed", "error", err)
+		} else if purged > 0 {
+			slog.Info("purger: purged soft-deleted workspaces", "count", purged)
+		}
+		if purged, err := p.PurgeEnvironments(ctx); err != nil {
+			slog.Error("purger: environment run failed", "error", err)
+		} else if purged > 0 {
+			slog.Info("purger: purged soft-deleted environments", "count", purged)
+		}
+
+		select {
+		case <-ctx.Done():
+			slog.Info("purger: shutting down")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// PurgeReport lists the workspaces administered by the caller that a purge
+// would remove, soft-deleted more than request.OlderThanDays ago, without
+// deleting anything. With no age given it reports which of them the next run
+// of the job would remove.
+func (p *SoftDeletePurger) PurgeReport(ctx context.Context, request contracts.PurgeReport) (*contracts.PurgeReportResponse, *errors.Error) {
+	if err := p.validator.Validate(request); err != nil {
+		return nil, err
+	}
+
+	claims, ok := jwt.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, apperrors.ReturnMissingClaims()
+	}
+	callerID, callerErr := callerUserID(claims)
+	if callerErr != nil {
+		return nil, callerErr
+	}
+
+	olderThan := p.retention
+	if request.OlderThanDays > 0 {
+		olderThan = time.Duration(request.OlderThanDays) * 24 * time.Hour
+	}
+	cutoff := p.now().Add(-olderThan)
+
+	ids, err := p.repoFactory.CreateWorkspaceRepository(p.uowFactory.Create()).ListDeletedBefore(ctx, cutoff, &callerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &contracts.PurgeReportResponse{
+		OlderThanDays:  int(olderThan / (24 * time.Hour)),
+		Cutoff:         cutoff.UTC(),
+		WorkspaceCount: len(ids),
+		WorkspaceIDs:   ids,
+	}, nil
+}
+
+// PurgeOnce hard-deletes every workspace soft-deleted before the retention
+// window and returns how many were removed. It stops early when ctx is
+// cancelled.
+func (p *SoftDeletePurger) PurgeOnce(ctx context.Context) (int, *errors.Error) {
+	before := p.now().Add(-p.retention)
+
+	uow := p.uowFactory.Create()
+	ids, err := p.repoFactory.CreateWorkspaceRepository(uow).ListDeletedBefore(ctx, before, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, id := range ids {
+		if ctx.Err() != nil {
+			break
+		}
+
+		paths, ok, err := p.purgeWorkspace(ctx, uow, id, before)
+		if err != nil {
+			slog.Error("purger: failed to purge workspace", "workspace_id", id, "error", err)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		purged++
+
+		// Best-effort cleanup of files
+		for _, path := range paths {
+			if cleanupErr := p.fileStorage.DeleteDir(path); cleanupErr != nil {
+				slog.Error("purger: failed to cleanup template files", "workspace_id", id, "path", path, "error", cleanupErr)
+			}
+		}
+		// Execution directories live under the workspace ID, soft-deleted
+		// environments' included
+		if cleanupErr := p.executionStorage.DeleteDir(id.String()); cleanupErr != nil {
+			slog.Error("purger: failed to cleanup execution directories", "workspace_id", id, "error", cleanupErr)
+		}
+	}
+
+	return purged, nil
+}
+
+// PurgeEnvironments hard-deletes every environment soft-deleted before the
+// retention window, removes its execution directory and returns how many were
+// removed. An environment whose destroy failed is kept: its execution
+// directory holds the Terraform state needed to restore it and destroy again.
+func (p *SoftDeletePurger) PurgeEnvironments(ctx context.Context) (int, *errors.Error) {
+	before := p.now().Add(-p.retention)
+
+	envRepo := p.repoFactory.CreateEnvironmentRepository(p.uowFactory.Create())
+	environments, err := envRepo.ListDeletedBefore(ctx, before)
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, env := range environments {
+		if ctx.Err() != nil {
+			break
+		}
+		if env.MayHoldResources() {
+			slog.Warn("purger: deleted environment may still hold resources, keeping it", "env_id", env.ID, "status", env.Status)
+			continue
+		}
+
+		ok, err := envRepo.Purge(ctx, env.ID, before)
+		if err != nil {
+			slog.Error("purger: failed to purge environment", "env_id", env.ID, "error", err)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		purged++
+
+		if cleanupErr := p.executionStorage.DeleteDir(env.ExecutionPath()); cleanupErr != nil {
+			slog.Error("purger: failed to cleanup execution directory", "env_id", env.ID, "error", cleanupErr)
+		}
+	}
+
+	return purged, nil
+}
+
+// purgeWorkspace removes one workspace in a transaction, returning the storage
+// paths of the templates it held and whether it was removed. A workspace that
+// was restored since it was listed is left alone, as is one with environments
+// that may still hold resources, whose teardown is scheduled instead.
+func (p *SoftDeletePurger) purgeWorkspace(ctx context.Context, uow apphandlers.UnitOfWork, id uuid.UUID, before time.Time) ([]string, bool, *errors.Error) {
+	if err := uow.Begin(ctx); err != nil {
+		return nil, false, err
+	}
+	defer uow.Rollback()
+
+	environments, err := p.repoFactory.CreateEnvironmentRepository(uow).GetByWorkspaceID(ctx, id)
+	if err != nil {
+		return nil, false, err
+	}
+	teardownQueue := p.repoFactory.CreateTeardownQueueRepository(uow)
+	pending := 0
+	for _, env := range environments {
+		if !env.MayHoldResources() {
+			continue
+		}
+		if err := teardownQueue.Schedule(ctx, env.ID, p.now()); err != nil {
+			return nil, false, err
+		}
+		pending++
+	}
+	if pending > 0 {
+		slog.Info("purger: workspace has environments to destroy first, teardown scheduled", "workspace_id", id, "environments", pending)
+		return nil, false, uow.Commit(ctx)
+	}
+
+	templates, err := p.repoFactory.CreateTemplateRepository(uow).GetByWorkspaceID(ctx, id)
+	if err != nil {
+		return nil, false, err
+	}
+
+	ok, err := p.repoFactory.CreateWorkspaceRepository(uow).Purge(ctx, id, before)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		return nil, false, err
+	}
+
+	paths := make([]string, 0, len(templates))
+	for _, template := range templates {
+		paths = append(paths, template.Path)
+	}
+	return paths, true, nil
+}
@@ -0,0 +1,13 @@
+package application
+
+import (
+	"context"
+
+	"backend/pkg/errors"
+)
+
+// Stream emits list items to fn one at a time as they are read from storage,
+// so callers can write large lists without materializing them. Request
+// validation and authorization happen before a Stream is returned; running it
+// only performs the read.
+type Stream[T any] func(ctx context.Context, fn func(T) error) *errors.Error
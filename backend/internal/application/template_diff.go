@@ -0,0 +1,141 @@
+package application
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+
+	apperrors "backend/internal/application/errors"
+	"backend/internal/domain"
+	"backend/pkg/contracts"
+	"backend/pkg/errors"
+	"backend/pkg/jwt"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+const (
+	FileDiffAdded    = "added"
+	FileDiffRemoved  = "removed"
+	FileDiffModified = "modified"
+
+	// diffContextLines is how many unchanged lines surround each hunk.
+	diffContextLines = 3
+)
+
+// DiffTemplates compares the files of two templates in the caller's
+// workspace, returning a unified diff for each file that differs.
+func (s TemplateService) DiffTemplates(ctx context.Context, request contracts.DiffTemplates) (*contracts.TemplateDiff, *errors.Error) {
+	claims, ok := jwt.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, apperrors.ReturnMissingClaims()
+	}
+
+	if err := s.validator.Validate(request); err != nil {
+		return nil, err
+	}
+
+	from, err := s.getWorkspaceTemplate(ctx, request.ID, claims)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.getWorkspaceTemplate(ctx, request.OtherID, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	fromFiles, err := s.readTemplateFiles(from)
+	if err != nil {
+		return nil, err
+	}
+	toFiles, err := s.readTemplateFiles(to)
+	if err != nil {
+		return nil, err
+	}
+
+	return &contracts.TemplateDiff{
+		ID:      from.ID,
+		OtherID: to.ID,
+		Files:   diffFiles(fromFiles, toFiles),
+	}, nil
+}
+
+// readTemplateFiles loads every file of a template, keyed by its path.
+func (s TemplateService) readTemplateFiles(template *domain.Template) (map[string]string, *errors.Error) {
+	infos, err := s.fileStorage.ListFiles(template.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]string, len(infos))
+	for _, info := range infos {
+		content, err := s.fileStorage.ReadFile(filepath.Join(template.Path, info.Name))
+		if err != nil {
+			return nil, err
+		}
+		files[info.Name] = string(content)
+	}
+	return files, nil
+}
+
+// diffFiles compares two file sets keyed by path and returns the differing
+// files sorted by path.
+func diffFiles(from, to map[string]string) []contracts.TemplateFileDiff {
+	paths := make([]string, 0, len(from)+len(to))
+	for path := range from {
+		paths = append(paths, path)
+	}
+	for path := range to {
+		if _, ok := from[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	diffs := []contracts.TemplateFileDiff{}
+	for _, path := range paths {
+		old, inFrom := from[path]
+		updated, inTo := to[path]
+
+		var status string
+		switch {
+		case !inFrom:
+			status = FileDiffAdded
+		case !inTo:
+			status = FileDiffRemoved
+		case old != updated:
+			status = FileDiffModified
+		default:
+			continue
+		}
+
+		diffs = append(diffs, contracts.TemplateFileDiff{
+			Path:   path,
+			Status: status,
+			Diff:   unifiedDiff(path, old, updated, inFrom, inTo),
+		})
+	}
+	return diffs
+}
+
+// unifiedDiff renders a file's change as a unified diff, using /dev/null for
+// the side the file is missing from as git does.
+func unifiedDiff(path, old, updated string, inFrom, inTo bool) string {
+	fromFile, toFile := "a/"+path, "b/"+path
+	if !inFrom {
+		fromFile = "/dev/null"
+	}
+	if !inTo {
+		toFile = "/dev/null"
+	}
+
+	// Only fails writing to its buffer, which cannot happen
+	diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(old),
+		B:        difflib.SplitLines(updated),
+		FromFile: fromFile,
+		ToFile:   toFile,
+		Context:  diffContextLines,
+	})
+	return diff
+}
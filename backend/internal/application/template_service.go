@@ -2,13 +2,17 @@ package application
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
 	apperrors "backend/internal/application/errors"
+	apphandlers "backend/internal/application/handlers"
 	"backend/internal/domain"
+	domainerrors "backend/internal/domain/errors"
 	"backend/internal/domain/repository"
 	"backend/internal/domain/storage"
 	"backend/pkg/contracts"
@@ -28,19 +32,29 @@ var allowedExtensions = map[string]bool{
 
 type TemplateService struct {
 	templateRepository  repository.TemplateRepository
+	versionRepository   repository.TemplateVersionRepository
 	workspaceRepository repository.WorkspaceRepository
 	groupRepo           repository.GroupRepository
 	validator           validation.Service
 	fileStorage         storage.FileStorage
+	pathChecker         storage.PathChecker
+	uow                 apphandlers.UnitOfWork
+	crossTenant         CrossTenantDenial
+	ids                 domain.IDGenerator
 }
 
-func NewTemplateService(templateRepo repository.TemplateRepository, workspaceRepository repository.WorkspaceRepository, validator validation.Service, fileStorage storage.FileStorage, groupRepo repository.GroupRepository) TemplateService {
+func NewTemplateService(templateRepo repository.TemplateRepository, versionRepo repository.TemplateVersionRepository, workspaceRepository repository.WorkspaceRepository, validator validation.Service, fileStorage storage.FileStorage, groupRepo repository.GroupRepository, pathChecker storage.PathChecker, uow apphandlers.UnitOfWork, crossTenant CrossTenantDenial, ids domain.IDGenerator) TemplateService {
 	return TemplateService{
 		templateRepository:  templateRepo,
+		versionRepository:   versionRepo,
 		workspaceRepository: workspaceRepository,
 		groupRepo:           groupRepo,
 		validator:           validator,
 		fileStorage:         fileStorage,
+		pathChecker:         pathChecker,
+		uow:                 uow,
+		crossTenant:         crossTenant,
+		ids:                 ids,
 	}
 }
 
@@ -48,16 +62,18 @@ func NewTemplateService(templateRepo repository.TemplateRepository, workspaceRep
 func (s TemplateService) CreateTemplate(ctx context.Context, request contracts.CreateTemplate, files []storage.FileInput) (*domain.Template, *errors.Error) {
 	claims, ok := jwt.ClaimsFromContext(ctx)
 	if !ok {
-		return nil, apperrors.ReturnUnauthorized("missing JWT claims in context")
+		return nil, apperrors.ReturnMissingClaims()
 	}
-	if claims.WorkspaceID != request.WorkspaceID.String() {
-		return nil, apperrors.ReturnForbidden("user does not belong to the specified workspace")
-	}
-
+	// Validate before the workspace comparison so an omitted workspace_id is
+	// reported as a malformed request rather than a cross-workspace attempt
 	if err := s.validator.Validate(request); err != nil {
 		return nil, err
 	}
 
+	if claims.WorkspaceID != request.WorkspaceID.String() {
+		return nil, forbid(claims, "Workspace", request.WorkspaceID.String(), request.WorkspaceID.String(), "user does not belong to the specified workspace")
+	}
+
 	// Validate files
 	if len(files) == 0 {
 		return nil, apperrors.ReturnBadRequest("at least one file is required")
@@ -74,7 +90,20 @@ func (s TemplateService) CreateTemplate(ctx context.Context, request contracts.C
 		}
 	}
 
-	template, err := domain.NewTemplate(request.Name, request.WorkspaceID, s.validator)
+	// Checked explicitly; the foreign key is only enforced when the pragma is on
+	workspaceExists, err := s.workspaceRepository.Exists(ctx, request.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if !workspaceExists {
+		return nil, domainerrors.InvalidInput("workspace_id", "workspace does not exist")
+	}
+
+	if err := s.ensureNameAvailable(ctx, request.WorkspaceID, request.Name, uuid.Nil); err != nil {
+		return nil, err
+	}
+
+	template, err := domain.NewTemplate(s.ids, request.Name, request.WorkspaceID, request.RepoURL, s.validator)
 	if err != nil {
 		return nil, err
 	}
@@ -99,34 +128,102 @@ func (s TemplateService) CreateTemplate(ctx context.Context, request contracts.C
 func (s TemplateService) GetTemplate(ctx context.Context, request contracts.GetTemplate) (*domain.Template, *errors.Error) {
 	claims, ok := jwt.ClaimsFromContext(ctx)
 	if !ok {
-		return nil, apperrors.ReturnUnauthorized("missing JWT claims in context")
+		return nil, apperrors.ReturnMissingClaims()
 	}
 
 	if err := s.validator.Validate(request); err != nil {
 		return nil, err
 	}
 
-	template, err := s.templateRepository.GetByID(ctx, request.ID)
+	return s.getWorkspaceTemplate(ctx, request.ID, claims)
+}
+
+// ensureNameAvailable returns a Conflict when another template in the
+// workspace already has name. The template being renamed is passed as self so
+// keeping its own name is not a conflict.
+func (s TemplateService) ensureNameAvailable(ctx context.Context, workspaceID uuid.UUID, name string, self uuid.UUID) *errors.Error {
+	existing, err := s.templateRepository.GetByWorkspaceAndName(ctx, workspaceID, name)
+	if err != nil {
+		if err.Code() == errors.CodeNotFound {
+			return nil
+		}
+		return err
+	}
+	if existing.ID == self {
+		return nil
+	}
+	return domainerrors.Conflict("Template", "name", name)
+}
+
+// getWorkspaceTemplate loads a template in the caller's workspace. By default a
+// template in another workspace is reported exactly like a missing one, so
+// probing IDs cannot reveal which exist elsewhere; see CrossTenantDenial.
+func (s TemplateService) getWorkspaceTemplate(ctx context.Context, id uuid.UUID, claims *jwt.Claims) (*domain.Template, *errors.Error) {
+	template, err := s.templateRepository.GetByID(ctx, id)
 	if err != nil {
+		// Reads are scoped to the caller's workspace, so a template elsewhere
+		// comes back missing; look it up unscoped to deny it like one that
+		// was loaded
+		if err.Code() == errors.CodeNotFound {
+			if workspaceID, probeErr := s.templateRepository.WorkspaceOf(ctx, id); probeErr == nil && workspaceID.String() != claims.WorkspaceID {
+				return nil, s.crossTenant.Deny(claims, "Template", id.String(), workspaceID.String(), "template does not belong to your workspace")
+			}
+		}
 		return nil, err
 	}
 
-	// Verify the template belongs to the user's workspace
 	if template.WorkspaceID.String() != claims.WorkspaceID {
-		return nil, apperrors.ReturnForbidden("template does not belong to your workspace")
+		return nil, s.crossTenant.Deny(claims, "Template", id.String(), template.WorkspaceID.String(), "template does not belong to your workspace")
 	}
 
 	return template, nil
 }
 
+// ValidateTemplatePath checks that the template's storage path, and its
+// repository URL when one is set, currently resolve.
+func (s TemplateService) ValidateTemplatePath(ctx context.Context, request contracts.ValidateTemplatePath) (*contracts.ValidateTemplatePathResponse, *errors.Error) {
+	if err := s.validator.Validate(request); err != nil {
+		return nil, err
+	}
+
+	template, err := s.GetTemplate(ctx, contracts.GetTemplate{ID: request.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	targets := []string{template.Path}
+	if template.RepoURL != "" {
+		targets = append(targets, template.RepoURL)
+	}
+
+	response := &contracts.ValidateTemplatePathResponse{
+		TemplateID: template.ID,
+		Reachable:  true,
+		Checks:     make([]contracts.PathCheckResult, 0, len(targets)),
+	}
+	for _, target := range targets {
+		check := s.pathChecker.Check(ctx, target)
+		response.Reachable = response.Reachable && check.Reachable
+		response.Checks = append(response.Checks, contracts.PathCheckResult{
+			Target:     check.Target,
+			Scheme:     check.Scheme,
+			Reachable:  check.Reachable,
+			StatusCode: check.StatusCode,
+			Detail:     check.Detail,
+		})
+	}
+
+	return response, nil
+}
+
 // GetTemplatesByWorkspace retrieves all templates for a given workspace
 func (s TemplateService) GetTemplatesByWorkspace(ctx context.Context, request contracts.GetTemplatesByWorkspace) ([]*domain.Template, *errors.Error) {
 	claims, ok := jwt.ClaimsFromContext(ctx)
 	if !ok {
-		return nil, apperrors.ReturnUnauthorized("missing JWT claims in context")
+		return nil, apperrors.ReturnMissingClaims()
 	}
 	if claims.WorkspaceID != request.WorkspaceID.String() {
-		return nil, apperrors.ReturnForbidden("cannot access templates from another workspace")
+		return nil, s.crossTenant.Deny(claims, "Workspace", request.WorkspaceID.String(), request.WorkspaceID.String(), "cannot access templates from another workspace")
 	}
 
 	if err := s.validator.Validate(request); err != nil {
@@ -138,84 +235,254 @@ func (s TemplateService) GetTemplatesByWorkspace(ctx context.Context, request co
 	return GetAccessibleTemplates(ctx, s.groupRepo, s.templateRepository, userID, request.WorkspaceID, isAdmin)
 }
 
-// UpdateTemplate updates an existing template and optionally adds files
-func (s TemplateService) UpdateTemplate(ctx context.Context, request contracts.UpdateTemplate, files []storage.FileInput) (*domain.Template, *errors.Error) {
+// GetTemplatesByWorkspacePage returns one page of the workspace's templates the
+// caller can access, along with the total number of them. The page and the
+// count share a read transaction so they agree.
+func (s TemplateService) GetTemplatesByWorkspacePage(ctx context.Context, request contracts.GetTemplatesByWorkspace) (*contracts.PagedResponse[*domain.Template], *errors.Error) {
+	claims, ok := jwt.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, apperrors.ReturnMissingClaims()
+	}
+	if claims.WorkspaceID != request.WorkspaceID.String() {
+		return nil, s.crossTenant.Deny(claims, "Workspace", request.WorkspaceID.String(), request.WorkspaceID.String(), "cannot access templates from another workspace")
+	}
+
+	if err := s.validator.Validate(request); err != nil {
+		return nil, err
+	}
+
+	userID, _ := uuid.Parse(claims.ID)
+	isAdmin := domain.Role(claims.Role) == domain.RoleAdmin
+	return inReadTx(ctx, s.uow, func() (*contracts.PagedResponse[*domain.Template], *errors.Error) {
+		filters, err := AccessibleTemplateFilters(ctx, s.groupRepo, userID, request.WorkspaceID, isAdmin)
+		if err != nil {
+			return nil, err
+		}
+
+		opts := repository.ListOptions{
+			Limit:   request.Limit,
+			Offset:  request.Offset,
+			SortBy:  request.SortBy,
+			Order:   request.Order,
+			Filters: filters,
+		}
+		opts.ApplyDefaults()
+
+		templates, err := s.templateRepository.List(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		total, err := s.templateRepository.CountByWorkspaceID(ctx, request.WorkspaceID, filters)
+		if err != nil {
+			return nil, err
+		}
+
+		return &contracts.PagedResponse[*domain.Template]{
+			Items:  templates,
+			Total:  total,
+			Limit:  opts.Limit,
+			Offset: opts.Offset,
+		}, nil
+	})
+}
+
+// GetTemplateStats aggregates the workspace's templates the caller can
+// access. The counts are computed by the database rather than by loading the
+// templates.
+func (s TemplateService) GetTemplateStats(ctx context.Context, request contracts.GetTemplateStats) (*contracts.TemplateStatsResponse, *errors.Error) {
 	claims, ok := jwt.ClaimsFromContext(ctx)
 	if !ok {
-		return nil, apperrors.ReturnUnauthorized("missing JWT claims in context")
+		return nil, apperrors.ReturnMissingClaims()
+	}
+	if claims.WorkspaceID != request.WorkspaceID.String() {
+		return nil, s.crossTenant.Deny(claims, "Workspace", request.WorkspaceID.String(), request.WorkspaceID.String(), "cannot access templates from another workspace")
 	}
 
 	if err := s.validator.Validate(request); err != nil {
 		return nil, err
 	}
 
-	// Get existing template
-	template, err := s.templateRepository.GetByID(ctx, request.ID)
+	userID, _ := uuid.Parse(claims.ID)
+	isAdmin := domain.Role(claims.Role) == domain.RoleAdmin
+	since := time.Now().AddDate(0, 0, -request.RecentDays)
+	stats, err := inReadTx(ctx, s.uow, func() (*repository.TemplateStats, *errors.Error) {
+		filters, err := AccessibleTemplateFilters(ctx, s.groupRepo, userID, request.WorkspaceID, isAdmin)
+		if err != nil {
+			return nil, err
+		}
+		return s.templateRepository.Stats(ctx, request.WorkspaceID, filters, since, request.Recent)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	// Verify the template belongs to the user's workspace
-	if template.WorkspaceID.String() != claims.WorkspaceID {
-		return nil, apperrors.ReturnForbidden("template does not belong to your workspace")
+	response := &contracts.TemplateStatsResponse{
+		WorkspaceID:         request.WorkspaceID,
+		Total:               stats.Total,
+		RecentDays:          request.RecentDays,
+		UpdatedRecently:     stats.UpdatedSince,
+		LastUpdatedAt:       stats.LastUpdatedAt,
+		MostRecentlyUpdated: make([]contracts.TemplateStatsEntry, 0, len(stats.MostRecentlyUpdated)),
+	}
+	for _, template := range stats.MostRecentlyUpdated {
+		response.MostRecentlyUpdated = append(response.MostRecentlyUpdated, contracts.TemplateStatsEntry{
+			ID:        template.ID,
+			Name:      template.Name,
+			UpdatedAt: template.UpdatedAt,
+		})
+	}
+	return response, nil
+}
+
+// GetTemplateSchemes counts the workspace's templates the caller can access by
+// source scheme.
+func (s TemplateService) GetTemplateSchemes(ctx context.Context, request contracts.GetTemplateSchemes) (*contracts.TemplateSchemesResponse, *errors.Error) {
+	claims, ok := jwt.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, apperrors.ReturnMissingClaims()
+	}
+	if claims.WorkspaceID != request.WorkspaceID.String() {
+		return nil, s.crossTenant.Deny(claims, "Workspace", request.WorkspaceID.String(), request.WorkspaceID.String(), "cannot access templates from another workspace")
+	}
+
+	if err := s.validator.Validate(request); err != nil {
+		return nil, err
+	}
+
+	userID, _ := uuid.Parse(claims.ID)
+	isAdmin := domain.Role(claims.Role) == domain.RoleAdmin
+	counts, err := inReadTx(ctx, s.uow, func() ([]repository.SchemeCount, *errors.Error) {
+		filters, err := AccessibleTemplateFilters(ctx, s.groupRepo, userID, request.WorkspaceID, isAdmin)
+		if err != nil {
+			return nil, err
+		}
+		return s.templateRepository.CountBySourceScheme(ctx, request.WorkspaceID, filters)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := &contracts.TemplateSchemesResponse{
+		WorkspaceID: request.WorkspaceID,
+		Schemes:     make([]contracts.TemplateSchemeCount, 0, len(counts)),
+	}
+	for _, count := range counts {
+		response.Schemes = append(response.Schemes, contracts.TemplateSchemeCount{Scheme: count.Scheme, Count: count.Count})
+	}
+	return response, nil
+}
+
+// UpdateTemplate updates an existing template and optionally adds files. It
+// reports whether anything changed; a no-op update writes nothing. Before a
+// change is applied the template is snapshotted as a new version.
+func (s TemplateService) UpdateTemplate(ctx context.Context, request contracts.UpdateTemplate, files []storage.FileInput) (*domain.Template, bool, *errors.Error) {
+	claims, ok := jwt.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, false, apperrors.ReturnMissingClaims()
+	}
+
+	if err := s.validator.Validate(request); err != nil {
+		return nil, false, err
 	}
 
-	// Validate and save additional files
+	// Validate additional files
 	for _, f := range files {
 		if err := s.validator.Validate(f); err != nil {
-			return nil, err
+			return nil, false, err
 		}
 
 		ext := strings.ToLower(filepath.Ext(f.Name))
 		if !allowedExtensions[ext] {
-			return nil, apperrors.ReturnBadRequest("file extension not allowed: " + ext + " (allowed: .tf, .tfvars, .hcl, .json)")
+			return nil, false, apperrors.ReturnBadRequest("file extension not allowed: " + ext + " (allowed: .tf, .tfvars, .hcl, .json)")
 		}
 	}
 
-	if len(files) > 0 {
-		if err := s.fileStorage.SaveFiles(template.Path, files); err != nil {
-			return nil, err
+	if err := s.uow.Begin(ctx); err != nil {
+		return nil, false, err
+	}
+	defer s.uow.Rollback()
+
+	template, err := s.getWorkspaceTemplate(ctx, request.ID, claims)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Update the fields that are set and differ
+	updated := *template
+	modified := len(files) > 0
+	if request.Name != "" && request.Name != template.Name {
+		if err := s.ensureNameAvailable(ctx, template.WorkspaceID, request.Name, template.ID); err != nil {
+			return nil, false, err
 		}
+		updated.Name = request.Name
+		modified = true
+	}
+	if request.RepoURL != nil && *request.RepoURL != template.RepoURL {
+		updated.RepoURL = *request.RepoURL
+		modified = true
+	}
+
+	// A no-op update leaves the template and its timestamp untouched
+	if !modified {
+		return template, false, nil
 	}
 
-	// Update non-empty fields
-	if request.Name != "" {
-		template.Name = request.Name
+	version, err := s.snapshotTemplate(ctx, template)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Uploaded files are layered over the current ones in a staging directory
+	// that replaces the template's only after the snapshot has committed, so
+	// a failed update cannot lose the files the snapshot was meant to keep.
+	var staging string
+	if len(files) > 0 {
+		staging, err = s.stageTemplateFiles(template, versionFileInputs(version.Files), files)
+		if err != nil {
+			return nil, false, err
+		}
+		defer s.fileStorage.DeleteDir(staging)
 	}
 
 	// Update timestamp
-	template.UpdatedAt = time.Now()
+	updated.UpdatedAt = time.Now()
 
 	// Save changes
-	if err := s.templateRepository.Update(ctx, *template); err != nil {
-		return nil, err
+	if err := s.templateRepository.Update(ctx, updated); err != nil {
+		return nil, false, err
 	}
 
-	return template, nil
+	if err := s.uow.Commit(ctx); err != nil {
+		return nil, false, err
+	}
+
+	if staging != "" {
+		if err := s.fileStorage.ReplaceDir(staging, template.Path); err != nil {
+			slog.Error("failed to swap in updated template files", "template_id", template.ID, "error", err)
+			return nil, false, err
+		}
+	}
+
+	return &updated, true, nil
 }
 
 // DeleteTemplate deletes a template by ID
 func (s TemplateService) DeleteTemplate(ctx context.Context, request contracts.DeleteTemplate) *errors.Error {
 	claims, ok := jwt.ClaimsFromContext(ctx)
 	if !ok {
-		return apperrors.ReturnUnauthorized("missing JWT claims in context")
+		return apperrors.ReturnMissingClaims()
 	}
 
 	if err := s.validator.Validate(request); err != nil {
 		return err
 	}
 
-	// Get existing template to verify ownership
-	template, err := s.templateRepository.GetByID(ctx, request.ID)
+	template, err := s.getWorkspaceTemplate(ctx, request.ID, claims)
 	if err != nil {
 		return err
 	}
 
-	// Verify the template belongs to the user's workspace
-	if template.WorkspaceID.String() != claims.WorkspaceID {
-		return apperrors.ReturnForbidden("template does not belong to your workspace")
-	}
-
 	if err := s.templateRepository.Delete(ctx, request.ID); err != nil {
 		return err
 	}
@@ -229,47 +496,156 @@ func (s TemplateService) DeleteTemplate(ctx context.Context, request contracts.D
 }
 
 // ListTemplates retrieves a paginated list of templates for the user's workspace,
-// filtered by group-based access (admins see all templates).
+// filtered by group-based access (admins see all templates). The access lookup
+// and the listing share a read transaction so they agree on group membership.
 func (s TemplateService) ListTemplates(ctx context.Context, request contracts.ListTemplates) ([]*domain.Template, *errors.Error) {
+	return inReadTx(ctx, s.uow, func() ([]*domain.Template, *errors.Error) {
+		opts, err := s.listOptions(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		return s.templateRepository.List(ctx, opts)
+	})
+}
+
+// ListTemplateFields is ListTemplates limited to the comma-separated
+// request.Fields, each of which must be one of contracts.TemplateFields.
+func (s TemplateService) ListTemplateFields(ctx context.Context, request contracts.ListTemplates) ([]*contracts.PartialTemplate, *errors.Error) {
+	fields, err := parseTemplateFields(request.Fields)
+	if err != nil {
+		return nil, err
+	}
+
+	return inReadTx(ctx, s.uow, func() ([]*contracts.PartialTemplate, *errors.Error) {
+		opts, err := s.listOptions(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		return s.templateRepository.ListFields(ctx, opts, fields)
+	})
+}
+
+// parseTemplateFields splits a fields parameter, rejecting unknown names and
+// dropping duplicates.
+func parseTemplateFields(raw string) ([]string, *errors.Error) {
+	var fields []string
+	for _, field := range strings.Split(raw, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			return nil, domainerrors.InvalidInput("fields", "field names must not be empty")
+		}
+		if !slices.Contains(contracts.TemplateFields, field) {
+			return nil, domainerrors.InvalidInput("fields", fmt.Sprintf("unknown field %q, expected one of %s", field, strings.Join(contracts.TemplateFields, ", ")))
+		}
+		if !slices.Contains(fields, field) {
+			fields = append(fields, field)
+		}
+	}
+	return fields, nil
+}
+
+// StreamTemplates validates a list request and returns a Stream over the templates
+// the caller can access in their workspace
+func (s TemplateService) StreamTemplates(ctx context.Context, request contracts.ListTemplates) (Stream[*domain.Template], *errors.Error) {
+	opts, err := s.listOptions(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context, fn func(*domain.Template) error) *errors.Error {
+		return s.templateRepository.ListEach(ctx, opts, fn)
+	}, nil
+}
+
+// ExportTemplates is StreamTemplates over every matching template: filters and
+// sort order apply, but limit and offset are ignored so an export is never cut
+// off at a page.
+func (s TemplateService) ExportTemplates(ctx context.Context, request contracts.ListTemplates) (Stream[*domain.Template], *errors.Error) {
+	opts, err := s.listOptions(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	opts.Unpaged = true
+
+	return func(ctx context.Context, fn func(*domain.Template) error) *errors.Error {
+		return s.templateRepository.ListEach(ctx, opts, fn)
+	}, nil
+}
+
+// listOptions validates a list request and converts it to repository options.
+// Caller-supplied filters are combined with the workspace and group-access
+// scoping, which always takes precedence.
+func (s TemplateService) listOptions(ctx context.Context, request contracts.ListTemplates) (repository.ListOptions, *errors.Error) {
 	claims, ok := jwt.ClaimsFromContext(ctx)
 	if !ok {
-		return nil, apperrors.ReturnUnauthorized("missing JWT claims in context")
+		return repository.ListOptions{}, apperrors.ReturnMissingClaims()
 	}
 
 	if err := s.validator.Validate(request); err != nil {
-		return nil, err
+		return repository.ListOptions{}, err
 	}
 
 	workspaceID, err := parseWorkspaceID(claims.WorkspaceID)
 	if err != nil {
-		return nil, apperrors.ReturnInternalError("invalid workspace ID in token")
+		return repository.ListOptions{}, apperrors.ReturnInvalidClaims("workspace_id")
+	}
+	// A token can outlive its workspace; say so rather than listing nothing
+	exists, existsErr := s.workspaceRepository.Exists(ctx, workspaceID)
+	if existsErr != nil {
+		return repository.ListOptions{}, existsErr
+	}
+	if !exists {
+		return repository.ListOptions{}, domainerrors.ErrTokenWorkspaceGone
 	}
 
 	userID, _ := uuid.Parse(claims.ID)
 	isAdmin := domain.Role(claims.Role) == domain.RoleAdmin
-	return GetAccessibleTemplates(ctx, s.groupRepo, s.templateRepository, userID, workspaceID, isAdmin)
+	scope, scopeErr := AccessibleTemplateFilters(ctx, s.groupRepo, userID, workspaceID, isAdmin)
+	if scopeErr != nil {
+		return repository.ListOptions{}, scopeErr
+	}
+
+	filters := make(map[string]any, len(request.Filters)+len(scope))
+	for key, value := range request.Filters {
+		filters[key] = value
+	}
+	for key, value := range scope {
+		filters[key] = value
+	}
+
+	opts := repository.ListOptions{
+		Limit:   request.Limit,
+		Offset:  request.Offset,
+		SortBy:  request.SortBy,
+		Order:   request.Order,
+		Filters: filters,
+	}
+
+	opts.ApplyDefaults()
+
+	if err := opts.Validate(); err != nil {
+		return repository.ListOptions{}, err
+	}
+
+	return opts, nil
 }
 
 // ListTemplateFiles returns the list of files for a given template
 func (s TemplateService) ListTemplateFiles(ctx context.Context, request contracts.ListTemplateFiles) ([]contracts.TemplateFileInfo, *errors.Error) {
 	claims, ok := jwt.ClaimsFromContext(ctx)
 	if !ok {
-		return nil, apperrors.ReturnUnauthorized("missing JWT claims in context")
+		return nil, apperrors.ReturnMissingClaims()
 	}
 
 	if err := s.validator.Validate(request); err != nil {
 		return nil, err
 	}
 
-	template, err := s.templateRepository.GetByID(ctx, request.ID)
+	template, err := s.getWorkspaceTemplate(ctx, request.ID, claims)
 	if err != nil {
 		return nil, err
 	}
 
-	if template.WorkspaceID.String() != claims.WorkspaceID {
-		return nil, apperrors.ReturnForbidden("template does not belong to your workspace")
-	}
-
 	files, err := s.fileStorage.ListFiles(template.Path)
 	if err != nil {
 		return nil, err
@@ -283,11 +659,38 @@ func (s TemplateService) ListTemplateFiles(ctx context.Context, request contract
 	return result, nil
 }
 
+// StreamTemplateFiles checks access to a template and returns a Stream over
+// its files, read from storage as they are written out.
+func (s TemplateService) StreamTemplateFiles(ctx context.Context, request contracts.ListTemplateFiles) (Stream[contracts.TemplateFileInfo], *errors.Error) {
+	claims, ok := jwt.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, apperrors.ReturnMissingClaims()
+	}
+
+	if err := s.validator.Validate(request); err != nil {
+		return nil, err
+	}
+
+	template, err := s.getWorkspaceTemplate(ctx, request.ID, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context, fn func(contracts.TemplateFileInfo) error) *errors.Error {
+		return s.fileStorage.WalkFiles(template.Path, func(f storage.FileInfo) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			return fn(contracts.TemplateFileInfo{Name: f.Name, Size: f.Size})
+		})
+	}, nil
+}
+
 // GetTemplateFileContent returns the content of a specific file within a template
-func (s TemplateService) GetTemplateFileContent(ctx context.Context, request contracts.GetTemplateFileContent) ([]byte, *errors.Error) {
+func (s TemplateService) GetTemplateFileContent(ctx context.Context, request contracts.GetTemplateFileContent) (*contracts.TemplateFileContent, *errors.Error) {
 	claims, ok := jwt.ClaimsFromContext(ctx)
 	if !ok {
-		return nil, apperrors.ReturnUnauthorized("missing JWT claims in context")
+		return nil, apperrors.ReturnMissingClaims()
 	}
 
 	if err := s.validator.Validate(request); err != nil {
@@ -299,16 +702,21 @@ func (s TemplateService) GetTemplateFileContent(ctx context.Context, request con
 		return nil, apperrors.ReturnBadRequest("file extension not allowed: " + ext)
 	}
 
-	template, err := s.templateRepository.GetByID(ctx, request.ID)
+	template, err := s.getWorkspaceTemplate(ctx, request.ID, claims)
 	if err != nil {
 		return nil, err
 	}
 
-	if template.WorkspaceID.String() != claims.WorkspaceID {
-		return nil, apperrors.ReturnForbidden("template does not belong to your workspace")
+	content, err := s.fileStorage.ReadFile(filepath.Join(template.Path, request.Filename))
+	if err != nil {
+		return nil, err
 	}
 
-	return s.fileStorage.ReadFile(filepath.Join(template.Path, request.Filename))
+	return &contracts.TemplateFileContent{
+		Path:     request.Filename,
+		Language: storage.DetectLanguage(request.Filename),
+		Content:  string(content),
+	}, nil
 }
 
 // parseWorkspaceID converts a workspace ID string to uuid.UUID
@@ -27,6 +27,7 @@ type TemplateVariableService struct {
 	fileStorage     storage.FileStorage
 	tfParser        tfparser.TFParser
 	uow             apphandlers.UnitOfWork
+	ids             domain.IDGenerator
 }
 
 func NewTemplateVariableService(
@@ -37,6 +38,7 @@ func NewTemplateVariableService(
 	fileStorage storage.FileStorage,
 	tfParser tfparser.TFParser,
 	uow apphandlers.UnitOfWork,
+	ids domain.IDGenerator,
 ) TemplateVariableService {
 	return TemplateVariableService{
 		templateVarRepo: templateVarRepo,
@@ -46,13 +48,14 @@ func NewTemplateVariableService(
 		fileStorage:     fileStorage,
 		tfParser:        tfParser,
 		uow:             uow,
+		ids:             ids,
 	}
 }
 
 func (s TemplateVariableService) verifyTemplateOwnership(ctx context.Context, templateID uuid.UUID) (*domain.Template, *errors.Error) {
 	claims, ok := jwt.ClaimsFromContext(ctx)
 	if !ok {
-		return nil, apperrors.ReturnUnauthorized("missing JWT claims in context")
+		return nil, apperrors.ReturnMissingClaims()
 	}
 
 	template, err := s.templateRepo.GetByID(ctx, templateID)
@@ -61,7 +64,7 @@ func (s TemplateVariableService) verifyTemplateOwnership(ctx context.Context, te
 	}
 
 	if template.WorkspaceID.String() != claims.WorkspaceID {
-		return nil, apperrors.ReturnForbidden("template does not belong to your workspace")
+		return nil, forbid(claims, "Template", template.ID.String(), template.WorkspaceID.String(), "template does not belong to your workspace")
 	}
 
 	return template, nil
@@ -76,7 +79,7 @@ func (s TemplateVariableService) CreateVariable(ctx context.Context, request con
 		return nil, err
 	}
 
-	variable := domain.NewTemplateVariable(domain.NewTemplateVariableParams{
+	variable := domain.NewTemplateVariable(s.ids, domain.NewTemplateVariableParams{
 		TemplateID:      request.TemplateID,
 		Key:             request.Key,
 		Description:     request.Description,
@@ -231,7 +234,7 @@ func (s TemplateVariableService) ParseAndReconcileVariables(ctx context.Context,
 				toUpdate = append(toUpdate, *existingVar)
 			}
 		} else {
-			variable := domain.NewTemplateVariable(domain.NewTemplateVariableParams{
+			variable := domain.NewTemplateVariable(s.ids, domain.NewTemplateVariableParams{
 				TemplateID:   request.TemplateID,
 				Key:          p.Key,
 				Description:  p.Description,
@@ -252,7 +255,7 @@ func (s TemplateVariableService) ParseAndReconcileVariables(ctx context.Context,
 	}
 
 	// Execute all mutations in a single transaction with concurrent goroutines
-	if err := s.uow.Begin(); err != nil {
+	if err := s.uow.Begin(ctx); err != nil {
 		return nil, err
 	}
 	defer s.uow.Rollback()
@@ -291,7 +294,7 @@ func (s TemplateVariableService) ParseAndReconcileVariables(ctx context.Context,
 		}
 	}
 
-	if err := s.uow.Commit(); err != nil {
+	if err := s.uow.Commit(ctx); err != nil {
 		return nil, err
 	}
 
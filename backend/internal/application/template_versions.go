@@ -0,0 +1,151 @@
+package application
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	apperrors "backend/internal/application/errors"
+	"backend/internal/domain"
+	"backend/internal/domain/storage"
+	"backend/pkg/contracts"
+	"backend/pkg/errors"
+	"backend/pkg/jwt"
+
+	"github.com/google/uuid"
+)
+
+// ListTemplateVersions returns the versions of a template in the caller's
+// workspace, newest first.
+func (s TemplateService) ListTemplateVersions(ctx context.Context, request contracts.ListTemplateVersions) ([]*domain.TemplateVersion, *errors.Error) {
+	claims, ok := jwt.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, apperrors.ReturnMissingClaims()
+	}
+
+	if err := s.validator.Validate(request); err != nil {
+		return nil, err
+	}
+
+	return inReadTx(ctx, s.uow, func() ([]*domain.TemplateVersion, *errors.Error) {
+		template, err := s.getWorkspaceTemplate(ctx, request.ID, claims)
+		if err != nil {
+			return nil, err
+		}
+		return s.versionRepository.ListByTemplateID(ctx, template.ID)
+	})
+}
+
+// RestoreTemplateVersion puts a template's name, repository URL and files
+// back to how they were in a version. The current state is snapshotted first,
+// so a restore can itself be undone. The version's files are staged beside
+// the template's and swapped in only once the snapshot has committed, so a
+// failed restore leaves the current files and their snapshot untouched.
+func (s TemplateService) RestoreTemplateVersion(ctx context.Context, request contracts.RestoreTemplateVersion) (*domain.Template, *errors.Error) {
+	claims, ok := jwt.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, apperrors.ReturnMissingClaims()
+	}
+
+	if err := s.validator.Validate(request); err != nil {
+		return nil, err
+	}
+
+	if err := s.uow.Begin(ctx); err != nil {
+		return nil, err
+	}
+	defer s.uow.Rollback()
+
+	template, err := s.getWorkspaceTemplate(ctx, request.ID, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := s.versionRepository.GetByTemplateAndVersion(ctx, template.ID, request.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	if version.Name != template.Name {
+		if err := s.ensureNameAvailable(ctx, template.WorkspaceID, version.Name, template.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := s.snapshotTemplate(ctx, template); err != nil {
+		return nil, err
+	}
+
+	// The whole directory is replaced so files added since the version are dropped
+	staging, err := s.stageTemplateFiles(template, versionFileInputs(version.Files))
+	if err != nil {
+		return nil, err
+	}
+	defer s.fileStorage.DeleteDir(staging)
+
+	restored := *template
+	restored.Name = version.Name
+	restored.RepoURL = version.RepoURL
+	restored.UpdatedAt = time.Now()
+	if err := s.templateRepository.Update(ctx, restored); err != nil {
+		return nil, err
+	}
+
+	if err := s.uow.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := s.fileStorage.ReplaceDir(staging, template.Path); err != nil {
+		slog.Error("failed to swap in restored template files", "template_id", template.ID, "error", err)
+		return nil, err
+	}
+
+	return &restored, nil
+}
+
+// stageTemplateFiles writes the file sets, later ones overwriting earlier
+// ones, into a new directory beside the template's and returns its path. The
+// template's own directory is untouched until ReplaceDir swaps the staging
+// directory in; callers remove it if they never do.
+func (s TemplateService) stageTemplateFiles(template *domain.Template, fileSets ...[]storage.FileInput) (string, *errors.Error) {
+	staging := template.Path + ".staging-" + uuid.NewString()
+	for _, files := range fileSets {
+		if err := s.fileStorage.SaveFiles(staging, files); err != nil {
+			s.fileStorage.DeleteDir(staging)
+			return "", err
+		}
+	}
+	return staging, nil
+}
+
+// snapshotTemplate stores the template and its current files as its next
+// version.
+func (s TemplateService) snapshotTemplate(ctx context.Context, template *domain.Template) (*domain.TemplateVersion, *errors.Error) {
+	files, err := s.readTemplateFiles(template)
+	if err != nil {
+		return nil, err
+	}
+
+	version := domain.NewTemplateVersion(s.ids, template, files)
+	if err := s.versionRepository.Create(ctx, version); err != nil {
+		return nil, err
+	}
+	return version, nil
+}
+
+// versionFileInputs turns a version's files into storage inputs, sorted by
+// path.
+func versionFileInputs(files map[string]string) []storage.FileInput {
+	inputs := make([]storage.FileInput, 0, len(files))
+	for path, content := range files {
+		inputs = append(inputs, storage.FileInput{
+			Name:   path,
+			Reader: strings.NewReader(content),
+			Size:   int64(len(content)),
+		})
+	}
+	sort.Slice(inputs, func(i, j int) bool { return inputs[i].Name < inputs[j].Name })
+	return inputs
+}
@@ -0,0 +1,63 @@
+package application
+
+import (
+	"context"
+
+	apphandlers "backend/internal/application/handlers"
+	"backend/internal/domain/repository"
+	"backend/pkg/errors"
+	"backend/pkg/jwt"
+
+	"github.com/google/uuid"
+)
+
+// TokenEpochChecker rejects tokens issued before their user's token epoch
+// was last bumped, e.g. by moving the user to another workspace, or before
+// their workspace's sessions were revoked.
+type TokenEpochChecker struct {
+	userRepository      repository.UserRepository
+	workspaceRepository repository.WorkspaceRepository
+}
+
+func NewTokenEpochChecker(
+	uowFactory apphandlers.UnitOfWorkFactory,
+	repoFactory apphandlers.RepositoryFactory,
+) *TokenEpochChecker {
+	uow := uowFactory.Create()
+	return &TokenEpochChecker{
+		userRepository:      repoFactory.CreateUserRepository(uow),
+		workspaceRepository: repoFactory.CreateWorkspaceRepository(uow),
+	}
+}
+
+// Check returns jwt.ErrRevokedToken when claims predate the user's or the
+// workspace's current epoch. API key claims and claims for users or
+// workspaces that no longer exist are left to the services, which already
+// handle them.
+func (c *TokenEpochChecker) Check(ctx context.Context, claims *jwt.Claims) *errors.Error {
+	if claims.ViaAPIKey {
+		return nil
+	}
+
+	if userID, err := uuid.Parse(claims.ID); err == nil {
+		epoch, repoErr := c.userRepository.GetTokenEpoch(ctx, userID)
+		if repoErr != nil && repoErr.Code() != errors.CodeNotFound {
+			return repoErr
+		}
+		if repoErr == nil && claims.Epoch < epoch {
+			return jwt.ErrRevokedToken
+		}
+	}
+
+	if workspaceID, err := uuid.Parse(claims.WorkspaceID); err == nil {
+		epoch, repoErr := c.workspaceRepository.GetTokenEpoch(ctx, workspaceID)
+		if repoErr != nil && repoErr.Code() != errors.CodeNotFound {
+			return repoErr
+		}
+		if repoErr == nil && claims.WorkspaceEpoch < epoch {
+			return jwt.ErrRevokedToken
+		}
+	}
+
+	return nil
+}
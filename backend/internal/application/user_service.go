@@ -1,25 +1,35 @@
 package application
 
 import (
+	apperrors "backend/internal/application/errors"
 	"backend/internal/application/handlers"
 	"backend/internal/domain"
 	domainerrors "backend/internal/domain/errors"
 	"backend/internal/domain/repository"
 	"backend/pkg/contracts"
 	"backend/pkg/errors"
+	"backend/pkg/jwt"
 	"backend/pkg/validation"
 	"context"
+
+	"github.com/google/uuid"
 )
 
 type UserService struct {
-	userRepository repository.UserRepository
-	validator      *validation.Service
+	userRepository      repository.UserRepository
+	workspaceRepository repository.WorkspaceRepository
+	outboxRepository    repository.OutboxRepository
+	validator           *validation.Service
+	ids                 domain.IDGenerator
 }
 
-func NewUserService(userRepo repository.UserRepository, validator *validation.Service) UserService {
+func NewUserService(userRepo repository.UserRepository, workspaceRepo repository.WorkspaceRepository, outboxRepo repository.OutboxRepository, validator *validation.Service, ids domain.IDGenerator) UserService {
 	return UserService{
-		userRepository: userRepo,
-		validator:      validator,
+		userRepository:      userRepo,
+		workspaceRepository: workspaceRepo,
+		outboxRepository:    outboxRepo,
+		validator:           validator,
+		ids:                 ids,
 	}
 }
 
@@ -37,13 +47,25 @@ func (s UserService) CreateLocalUser(ctx context.Context, uow handlers.UnitOfWor
 		return domain.UserAggregate{}, err
 	}
 
-	// Check if user already exists with this email
-	_, err = s.userRepository.GetByEmail(ctx, request.Email)
-	if err != nil && err.HTTPStatus() != domainerrors.ErrNotFound.HTTPStatus() {
+	// Check references explicitly rather than relying on constraint errors,
+	// which depend on SQLite's foreign_keys pragma being enabled
+	emailTaken, err := s.userRepository.ExistsByEmail(ctx, request.Email)
+	if err != nil {
+		return domain.UserAggregate{}, err
+	}
+	if emailTaken {
+		return domain.UserAggregate{}, domainerrors.Conflict("User", "email", request.Email)
+	}
+
+	workspaceExists, err := s.workspaceRepository.Exists(ctx, request.WorkspaceID)
+	if err != nil {
 		return domain.UserAggregate{}, err
 	}
+	if !workspaceExists {
+		return domain.UserAggregate{}, domainerrors.InvalidInput("workspace_id", "workspace does not exist")
+	}
 
-	userFactory := domain.UserFactory{}
+	userFactory := domain.NewUserFactory(s.ids)
 	user, err = userFactory.Create(
 		nil,
 		nil,
@@ -58,7 +80,7 @@ func (s UserService) CreateLocalUser(ctx context.Context, uow handlers.UnitOfWor
 		return domain.UserAggregate{}, err
 	}
 
-	if beginErr := uow.Begin(); beginErr != nil {
+	if beginErr := uow.Begin(ctx); beginErr != nil {
 		return domain.UserAggregate{}, beginErr
 	}
 
@@ -68,7 +90,16 @@ func (s UserService) CreateLocalUser(ctx context.Context, uow handlers.UnitOfWor
 		return domain.UserAggregate{}, err
 	}
 
-	if commitErr := uow.Commit(); commitErr != nil {
+	// Side effects of registration run from the event, outside this transaction
+	event, eventErr := domain.NewOutboxEvent(s.ids, domain.EventUserCreated, user.ID, domain.UserCreatedPayload{WorkspaceID: user.WorkspaceID})
+	if eventErr != nil {
+		return domain.UserAggregate{}, errors.Wrap(eventErr, "failed to record user.created event").WithHTTPStatus(500)
+	}
+	if err = s.outboxRepository.Add(ctx, event); err != nil {
+		return domain.UserAggregate{}, err
+	}
+
+	if commitErr := uow.Commit(ctx); commitErr != nil {
 		return domain.UserAggregate{}, commitErr
 	}
 
@@ -82,12 +113,16 @@ func (s UserService) AuthenticateLocalUser(ctx context.Context, request contract
 
 	unauthorized := domainerrors.Unauthorized("invalid email or password")
 
+	// Every failure path below costs one argon2 verification so timing does
+	// not reveal whether the email is registered
 	user, err := s.userRepository.GetByEmail(ctx, request.Email)
 	if err != nil {
+		domain.SimulatePasswordCheck(request.Password)
 		return contracts.LoginResponse{}, unauthorized
 	}
 
 	if user.LocalUser == nil {
+		domain.SimulatePasswordCheck(request.Password)
 		return contracts.LoginResponse{}, unauthorized
 	}
 
@@ -95,11 +130,56 @@ func (s UserService) AuthenticateLocalUser(ctx context.Context, request contract
 		return contracts.LoginResponse{}, unauthorized
 	}
 
+	workspaceEpoch, err := s.WorkspaceTokenEpoch(ctx, user.WorkspaceID)
+	if err != nil {
+		return contracts.LoginResponse{}, err
+	}
+
 	resp := contracts.LoginResponse{
-		UserID:      user.ID,
-		Name:        user.Name,
-		Role:        string(user.Role),
-		WorkspaceID: user.WorkspaceID,
+		UserID:              user.ID,
+		Name:                user.Name,
+		Role:                string(user.Role),
+		WorkspaceID:         user.WorkspaceID,
+		Scope:               jwt.ScopeWrite,
+		TokenEpoch:          user.TokenEpoch,
+		WorkspaceTokenEpoch: workspaceEpoch,
+	}
+	if request.Scope != "" {
+		resp.Scope = request.Scope
 	}
 	return resp, nil
 }
+
+// WorkspaceTokenEpoch returns the token epoch to embed in tokens issued for
+// workspaceID. A workspace that no longer exists has none, so 0 is returned
+// and the request fails later on the missing workspace instead.
+func (s UserService) WorkspaceTokenEpoch(ctx context.Context, workspaceID uuid.UUID) (int64, *errors.Error) {
+	epoch, err := s.workspaceRepository.GetTokenEpoch(ctx, workspaceID)
+	if err != nil && err.Code() != errors.CodeNotFound {
+		return 0, err
+	}
+	return epoch, nil
+}
+
+// GetUser returns a user in the caller's workspace. Users in other workspaces
+// are reported as not found; only admins may read users other than
+// themselves.
+func (s UserService) GetUser(ctx context.Context, id uuid.UUID) (*contracts.AdminUserResponse, *errors.Error) {
+	claims, ok := jwt.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, apperrors.ReturnMissingClaims()
+	}
+
+	user, err := s.userRepository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if user.WorkspaceID.String() != claims.WorkspaceID {
+		return nil, domainerrors.NotFound("User", id.String())
+	}
+	if claims.ID != id.String() && domain.Role(claims.Role) != domain.RoleAdmin {
+		return nil, forbid(claims, "User", id.String(), claims.WorkspaceID, "only admins can view other users")
+	}
+
+	return newAdminUserResponse(user), nil
+}
@@ -4,23 +4,34 @@ import (
 	"context"
 	"time"
 
+	apperrors "backend/internal/application/errors"
 	"backend/internal/application/handlers"
 	"backend/internal/domain"
+	domainerrors "backend/internal/domain/errors"
 	"backend/internal/domain/repository"
 	"backend/pkg/contracts"
 	"backend/pkg/errors"
+	"backend/pkg/jwt"
 	"backend/pkg/validation"
+
+	"github.com/google/uuid"
 )
 
 type WorkspaceService struct {
 	workspaceRepository repository.WorkspaceRepository
+	userRepository      repository.UserRepository
 	validator           *validation.Service
+	crossTenant         CrossTenantDenial
+	ids                 domain.IDGenerator
 }
 
-func NewWorkspaceService(workspaceRepo repository.WorkspaceRepository, validator *validation.Service) WorkspaceService {
+func NewWorkspaceService(workspaceRepo repository.WorkspaceRepository, userRepo repository.UserRepository, validator *validation.Service, crossTenant CrossTenantDenial, ids domain.IDGenerator) WorkspaceService {
 	return WorkspaceService{
 		workspaceRepository: workspaceRepo,
+		userRepository:      userRepo,
 		validator:           validator,
+		crossTenant:         crossTenant,
+		ids:                 ids,
 	}
 }
 
@@ -30,18 +41,21 @@ func (s WorkspaceService) CreateWorkspace(ctx context.Context, uow handlers.Unit
 		return nil, err
 	}
 
-	if err := uow.Begin(); err != nil {
+	if err := uow.Begin(ctx); err != nil {
 		return nil, err
 	}
 	defer uow.Rollback()
 
-	workspace := domain.NewWorkspace(request.Name, request.Description, &request.AdminID)
+	workspace, err := domain.NewWorkspace(s.ids, s.validator.ContentPolicy(), request.Name, request.Description, &request.AdminID)
+	if err != nil {
+		return nil, err
+	}
 
 	if err := s.workspaceRepository.Create(ctx, workspace); err != nil {
 		return nil, err
 	}
 
-	return workspace, uow.Commit()
+	return workspace, uow.Commit(ctx)
 }
 
 // GetWorkspace retrieves a workspace by ID
@@ -53,6 +67,41 @@ func (s WorkspaceService) GetWorkspace(ctx context.Context, request contracts.Ge
 	return s.workspaceRepository.GetByID(ctx, request.ID)
 }
 
+// WorkspaceWithAdmin is a workspace with its admin user embedded. Admin is
+// nil when the workspace has no admin or the admin user no longer exists.
+type WorkspaceWithAdmin struct {
+	*domain.Workspace
+	Admin *contracts.WorkspaceAdmin `json:"admin"`
+}
+
+// GetWorkspaceWithAdmin retrieves a workspace by ID along with its admin
+// user's ID, name and email.
+func (s WorkspaceService) GetWorkspaceWithAdmin(ctx context.Context, request contracts.GetWorkspace) (*WorkspaceWithAdmin, *errors.Error) {
+	workspace, err := s.GetWorkspace(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &WorkspaceWithAdmin{Workspace: workspace}
+	if workspace.AdminID == nil {
+		return result, nil
+	}
+
+	admin, err := s.userRepository.GetByID(ctx, *workspace.AdminID)
+	if err != nil {
+		if err.Code() == errors.CodeNotFound {
+			return result, nil
+		}
+		return nil, err
+	}
+	result.Admin = &contracts.WorkspaceAdmin{
+		ID:    admin.ID,
+		Name:  admin.Name,
+		Email: admin.Email,
+	}
+	return result, nil
+}
+
 // GetWorkspacesByAdmin retrieves all workspaces for a given admin
 func (s WorkspaceService) GetWorkspacesByAdmin(ctx context.Context, request contracts.GetWorkspacesByAdmin) ([]*domain.Workspace, *errors.Error) {
 	if err := s.validator.Validate(request); err != nil {
@@ -62,13 +111,44 @@ func (s WorkspaceService) GetWorkspacesByAdmin(ctx context.Context, request cont
 	return s.workspaceRepository.GetByAdminID(ctx, request.AdminID)
 }
 
+// GetWorkspacesByIDs fetches the requested workspaces in one query, keeping
+// only those the caller administers. IDs that are missing, soft-deleted or
+// administered by someone else are left out of the result.
+func (s WorkspaceService) GetWorkspacesByIDs(ctx context.Context, request contracts.GetWorkspacesByIDs) ([]*domain.Workspace, *errors.Error) {
+	if err := s.validator.Validate(request); err != nil {
+		return nil, err
+	}
+
+	claims, ok := jwt.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, apperrors.ReturnMissingClaims()
+	}
+	callerID, parseErr := uuid.Parse(claims.ID)
+	if parseErr != nil {
+		return nil, errors.WithCode(errors.CodeUnauthorized, "invalid user ID in JWT claims").WithHTTPStatus(401)
+	}
+
+	workspaces, err := s.workspaceRepository.GetByIDs(ctx, request.IDs)
+	if err != nil {
+		return nil, err
+	}
+
+	administered := make([]*domain.Workspace, 0, len(workspaces))
+	for _, w := range workspaces {
+		if w.AdminID != nil && *w.AdminID == callerID {
+			administered = append(administered, w)
+		}
+	}
+	return administered, nil
+}
+
 // UpdateWorkspace updates an existing workspace
 func (s WorkspaceService) UpdateWorkspace(ctx context.Context, uow handlers.UnitOfWork, request contracts.UpdateWorkspace) (*domain.Workspace, *errors.Error) {
 	if err := s.validator.Validate(request); err != nil {
 		return nil, err
 	}
 
-	if err := uow.Begin(); err != nil {
+	if err := uow.Begin(ctx); err != nil {
 		return nil, err
 	}
 	defer uow.Rollback()
@@ -78,11 +158,28 @@ func (s WorkspaceService) UpdateWorkspace(ctx context.Context, uow handlers.Unit
 		return nil, err
 	}
 
+	if request.Version != nil && *request.Version != workspace.Version {
+		return nil, domainerrors.VersionConflict("Workspace", workspace.ID.String(), workspace.Version, workspace.UpdatedAt)
+	}
+
+	if request.AdminID != nil {
+		if err := s.checkCanHandOver(ctx, workspace, *request.AdminID); err != nil {
+			return nil, err
+		}
+	}
+
 	if request.Name != "" {
-		workspace.Name = request.Name
+		if err := workspace.Rename(s.validator.ContentPolicy(), request.Name); err != nil {
+			return nil, err
+		}
 	}
 	if request.Description != "" {
-		workspace.Description = request.Description
+		if err := workspace.SetDescription(s.validator.ContentPolicy(), request.Description); err != nil {
+			return nil, err
+		}
+	}
+	if request.AdminID != nil {
+		workspace.AdminID = request.AdminID
 	}
 
 	workspace.UpdatedAt = time.Now()
@@ -91,7 +188,41 @@ func (s WorkspaceService) UpdateWorkspace(ctx context.Context, uow handlers.Unit
 		return nil, err
 	}
 
-	return workspace, uow.Commit()
+	return workspace, uow.Commit(ctx)
+}
+
+// checkCanHandOver allows only the workspace's current admin, signed in to
+// that workspace, to make adminID its admin, and requires adminID to be one
+// of its users.
+func (s WorkspaceService) checkCanHandOver(ctx context.Context, workspace *domain.Workspace, adminID uuid.UUID) *errors.Error {
+	claims, ok := jwt.ClaimsFromContext(ctx)
+	if !ok {
+		return apperrors.ReturnMissingClaims()
+	}
+	if claims.WorkspaceID != workspace.ID.String() {
+		return s.crossTenant.Deny(claims, "Workspace", workspace.ID.String(), workspace.ID.String(), "cannot change the admin of another workspace")
+	}
+	callerID, err := callerUserID(claims)
+	if err != nil {
+		return err
+	}
+	if workspace.AdminID == nil || *workspace.AdminID != callerID {
+		return forbid(claims, "Workspace", workspace.ID.String(), workspace.ID.String(), "only the workspace admin can change its admin")
+	}
+	return s.checkWorkspaceMember(ctx, workspace.ID, adminID)
+}
+
+// checkWorkspaceMember fails with 404 when userID is not a user and with 400
+// when the user belongs to another workspace.
+func (s WorkspaceService) checkWorkspaceMember(ctx context.Context, workspaceID, userID uuid.UUID) *errors.Error {
+	user, err := s.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.WorkspaceID != workspaceID {
+		return domainerrors.InvalidInput("admin_id", "user does not belong to this workspace")
+	}
+	return nil
 }
 
 // DeleteWorkspace deletes a workspace by ID
@@ -100,36 +231,168 @@ func (s WorkspaceService) DeleteWorkspace(ctx context.Context, uow handlers.Unit
 		return err
 	}
 
-	if err := uow.Begin(); err != nil {
+	if err := uow.Begin(ctx); err != nil {
 		return err
 	}
 	defer uow.Rollback()
 
-	if err := s.workspaceRepository.Delete(ctx, request.ID); err != nil {
+	var deletedBy *uuid.UUID
+	if claims, ok := jwt.ClaimsFromContext(ctx); ok {
+		if callerID, err := uuid.Parse(claims.ID); err == nil {
+			deletedBy = &callerID
+		}
+	}
+
+	if err := s.workspaceRepository.Delete(ctx, request.ID, deletedBy); err != nil {
 		return err
 	}
 
-	return uow.Commit()
+	return uow.Commit(ctx)
+}
+
+// RotateSecret issues a new secret for the workspace, replacing the previous
+// one. The plaintext is returned once and never stored.
+func (s WorkspaceService) RotateSecret(ctx context.Context, uow handlers.UnitOfWork, request contracts.RotateWorkspaceSecret) (*contracts.WorkspaceSecretResponse, *errors.Error) {
+	if err := s.validator.Validate(request); err != nil {
+		return nil, err
+	}
+
+	claims, ok := jwt.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, apperrors.ReturnMissingClaims()
+	}
+	if claims.WorkspaceID != request.ID.String() {
+		return nil, s.crossTenant.Deny(claims, "Workspace", request.ID.String(), request.ID.String(), "user does not belong to the specified workspace")
+	}
+
+	secret, secretHash, genErr := domain.GenerateWorkspaceSecret()
+	if genErr != nil {
+		return nil, errors.Wrap(genErr, "failed to generate workspace secret").WithHTTPStatus(500)
+	}
+
+	if err := uow.Begin(ctx); err != nil {
+		return nil, err
+	}
+	defer uow.Rollback()
+
+	rotatedAt, err := s.workspaceRepository.SetSecretHash(ctx, request.ID, secretHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return &contracts.WorkspaceSecretResponse{
+		WorkspaceID: request.ID,
+		Secret:      secret,
+		RotatedAt:   rotatedAt,
+	}, nil
+}
+
+// RevokeSessions bumps the workspace's token epoch, signing out everyone in
+// the workspace, the caller included. API keys are not affected.
+func (s WorkspaceService) RevokeSessions(ctx context.Context, uow handlers.UnitOfWork, request contracts.RevokeWorkspaceSessions) (*contracts.RevokeWorkspaceSessionsResponse, *errors.Error) {
+	if err := s.validator.Validate(request); err != nil {
+		return nil, err
+	}
+
+	claims, ok := jwt.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, apperrors.ReturnMissingClaims()
+	}
+	if claims.WorkspaceID != request.ID.String() {
+		return nil, s.crossTenant.Deny(claims, "Workspace", request.ID.String(), request.ID.String(), "user does not belong to the specified workspace")
+	}
+
+	if err := uow.Begin(ctx); err != nil {
+		return nil, err
+	}
+	defer uow.Rollback()
+
+	epoch, err := s.workspaceRepository.BumpTokenEpoch(ctx, request.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return &contracts.RevokeWorkspaceSessionsResponse{
+		WorkspaceID: request.ID,
+		TokenEpoch:  epoch,
+	}, nil
+}
+
+// VerifySecret reports whether secret is the workspace's current secret.
+func (s WorkspaceService) VerifySecret(ctx context.Context, workspaceID uuid.UUID, secret string) (bool, *errors.Error) {
+	secretHash, err := s.workspaceRepository.GetSecretHash(ctx, workspaceID)
+	if err != nil {
+		return false, err
+	}
+	return domain.VerifyWorkspaceSecret(secret, secretHash), nil
 }
 
 // ListWorkspaces retrieves a paginated list of workspaces
 func (s WorkspaceService) ListWorkspaces(ctx context.Context, request contracts.ListWorkspaces) ([]*domain.Workspace, *errors.Error) {
-	if err := s.validator.Validate(request); err != nil {
+	opts, err := s.listOptions(ctx, request)
+	if err != nil {
 		return nil, err
 	}
 
+	return s.workspaceRepository.List(ctx, opts)
+}
+
+// StreamWorkspaces validates a list request and returns a Stream over the matching workspaces
+func (s WorkspaceService) StreamWorkspaces(ctx context.Context, request contracts.ListWorkspaces) (Stream[*domain.Workspace], *errors.Error) {
+	opts, err := s.listOptions(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context, fn func(*domain.Workspace) error) *errors.Error {
+		return s.workspaceRepository.ListEach(ctx, opts, fn)
+	}, nil
+}
+
+// listOptions validates a list request and converts it to repository options
+func (s WorkspaceService) listOptions(ctx context.Context, request contracts.ListWorkspaces) (repository.ListOptions, *errors.Error) {
+	if err := s.validator.Validate(request); err != nil {
+		return repository.ListOptions{}, err
+	}
+
 	opts := repository.ListOptions{
-		Limit:  request.Limit,
-		Offset: request.Offset,
-		SortBy: request.SortBy,
-		Order:  request.Order,
+		Limit:          request.Limit,
+		Offset:         request.Offset,
+		SortBy:         request.SortBy,
+		Order:          request.Order,
+		IncludeDeleted: request.IncludeDeleted,
+	}
+
+	// Like /admin/workspaces/deleted, only the workspaces the caller
+	// administers are listed once deleted
+	if request.IncludeDeleted {
+		claims, ok := jwt.ClaimsFromContext(ctx)
+		if !ok {
+			return repository.ListOptions{}, apperrors.ReturnMissingClaims()
+		}
+		if domain.Role(claims.Role) != domain.RoleAdmin {
+			return repository.ListOptions{}, apperrors.ReturnForbidden("only admins can list deleted workspaces")
+		}
+		callerID, err := callerUserID(claims)
+		if err != nil {
+			return repository.ListOptions{}, err
+		}
+		opts.DeletedAdminID = callerID
 	}
 
 	opts.ApplyDefaults()
 
 	if err := opts.Validate(); err != nil {
-		return nil, err
+		return repository.ListOptions{}, err
 	}
 
-	return s.workspaceRepository.List(ctx, opts)
+	return opts, nil
 }
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Outcomes of a request to POST /admin/init.
+const (
+	AdminInitSucceeded    = "success"
+	AdminInitConflict     = "conflict"
+	AdminInitUnauthorized = "unauthorized"
+	AdminInitInvalid      = "invalid"
+	AdminInitFailed       = "error"
+)
+
+// Bounds on the admin_init_attempts table, which unauthenticated requests
+// write to: an outcome is recorded at most once per IP within
+// AdminInitAttemptWindow, and only the newest MaxAdminInitAttempts are kept.
+const (
+	AdminInitAttemptWindow = time.Minute
+	MaxAdminInitAttempts   = 10000
+)
+
+// AdminInitAttempt records one request to POST /admin/init and how it ended,
+// so repeated attempts against a deployment can be spotted.
+type AdminInitAttempt struct {
+	ID          uuid.UUID `json:"id"`
+	Outcome     string    `json:"outcome"`
+	IP          string    `json:"ip"`
+	AttemptedAt time.Time `json:"attempted_at"`
+}
+
+func NewAdminInitAttempt(ids IDGenerator, outcome, ip string) *AdminInitAttempt {
+	return &AdminInitAttempt{
+		ID:          ids.NewID(),
+		Outcome:     outcome,
+		IP:          ip,
+		AttemptedAt: time.Now(),
+	}
+}
@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// APIKeyPrefix marks a bearer credential as an API key rather than a JWT.
+	APIKeyPrefix = "dsk_"
+
+	// apiKeyDisplayLength is how much of the key is kept in clear so admins
+	// can tell keys apart in listings.
+	apiKeyDisplayLength = len(APIKeyPrefix) + 8
+)
+
+// APIKey is a workspace-scoped credential for integrations. Only a hash of
+// the key is stored; the plaintext is returned once at creation.
+type APIKey struct {
+	ID          uuid.UUID  `json:"id"`
+	WorkspaceID uuid.UUID  `json:"workspace_id"`
+	Name        string     `json:"name"`
+	Role        Role       `json:"role"`
+	Scope       string     `json:"scope"`
+	KeyPrefix   string     `json:"key_prefix"`
+	KeyHash     string     `json:"-"`
+	CreatedBy   *uuid.UUID `json:"created_by,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+}
+
+// NewAPIKey creates an API key and returns it along with its plaintext value.
+func NewAPIKey(ids IDGenerator, name string, role Role, scope string, workspaceID uuid.UUID, createdBy *uuid.UUID) (*APIKey, string, error) {
+	key, err := generateSecretToken(APIKeyPrefix)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate API key: %w", err)
+	}
+
+	return &APIKey{
+		ID:          ids.NewID(),
+		WorkspaceID: workspaceID,
+		Name:        name,
+		Role:        role,
+		Scope:       scope,
+		KeyPrefix:   key[:apiKeyDisplayLength],
+		KeyHash:     HashAPIKey(key),
+		CreatedBy:   createdBy,
+		CreatedAt:   time.Now(),
+	}, key, nil
+}
+
+// HashAPIKey hashes a plaintext API key for lookup and storage.
+func HashAPIKey(key string) string {
+	return hashSecretToken(key)
+}
+
+// IsAPIKey reports whether a bearer credential has the API key format.
+func IsAPIKey(credential string) bool {
+	return strings.HasPrefix(credential, APIKeyPrefix)
+}
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestNewAPIKey(t *testing.T) {
+	workspaceID := uuid.New()
+	key, plaintext, err := NewAPIKey(RandomIDGenerator{}, "CI", RoleEditor, "read", workspaceID, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !IsAPIKey(plaintext) {
+		t.Errorf("expected %q to be recognised as an API key", plaintext)
+	}
+	if !strings.HasPrefix(plaintext, key.KeyPrefix) || len(key.KeyPrefix) >= len(plaintext) {
+		t.Errorf("expected key prefix %q to be a strict prefix of the key", key.KeyPrefix)
+	}
+	if key.KeyHash != HashAPIKey(plaintext) {
+		t.Error("expected the stored hash to match the plaintext key")
+	}
+	if strings.Contains(key.KeyHash, plaintext) {
+		t.Error("hash must not contain the plaintext key")
+	}
+	if key.WorkspaceID != workspaceID || key.Role != RoleEditor {
+		t.Errorf("unexpected key fields: %+v", key)
+	}
+}
+
+func TestIsAPIKey(t *testing.T) {
+	if IsAPIKey("eyJhbGciOiJIUzI1NiJ9.payload.sig") {
+		t.Error("expected a JWT not to be treated as an API key")
+	}
+	if !IsAPIKey(APIKeyPrefix + "abc") {
+		t.Error("expected a prefixed credential to be treated as an API key")
+	}
+}
@@ -57,9 +57,9 @@ type Environment struct {
 	UpdatedAt     time.Time         `json:"updated_at"`
 }
 
-func NewEnvironment(name, description string, createdBy, workspaceID, templateId uuid.UUID, ttlSeconds *int) *Environment {
+func NewEnvironment(ids IDGenerator, name, description string, createdBy, workspaceID, templateId uuid.UUID, ttlSeconds *int) *Environment {
 	return &Environment{
-		ID:          uuid.New(),
+		ID:          ids.NewID(),
 		Name:        name,
 		Description: description,
 		CreatedBy:   createdBy,
@@ -76,6 +76,13 @@ func (e Environment) ExecutionPath() string {
 	return filepath.Join(e.WorkspaceID.String(), e.ID.String())
 }
 
+// MayHoldResources reports whether terraform may have provisioned resources
+// for the environment that a destroy has yet to remove. Only environments
+// that never ran an operation, or were destroyed, are known to hold none.
+func (e Environment) MayHoldResources() bool {
+	return e.Status != EnvironmentStatusPending && e.Status != EnvironmentStatusDestroyed
+}
+
 // CanStartOperation checks whether the environment can accept a new Terraform
 // operation. Returns an error describing why not if the status is blocking.
 func (e *Environment) CanStartOperation() error {
@@ -15,10 +15,10 @@ type EnvironmentVariableValue struct {
 	UpdatedAt          time.Time `json:"updated_at"`
 }
 
-func NewEnvironmentVariableValue(environmentID, templateVariableID uuid.UUID, value string) *EnvironmentVariableValue {
+func NewEnvironmentVariableValue(ids IDGenerator, environmentID, templateVariableID uuid.UUID, value string) *EnvironmentVariableValue {
 	now := time.Now()
 	return &EnvironmentVariableValue{
-		ID:                 uuid.New(),
+		ID:                 ids.NewID(),
 		EnvironmentID:      environmentID,
 		TemplateVariableID: templateVariableID,
 		Value:              value,
@@ -3,6 +3,7 @@ package errors
 import (
 	"fmt"
 	"net/http"
+	"time"
 
 	pkgerrors "backend/pkg/errors"
 )
@@ -15,6 +16,20 @@ var (
 	ErrConflict = pkgerrors.WithCode(pkgerrors.CodeConflict, "entity already exists")
 	// ErrInvalidInput is a generic invalid input error
 	ErrInvalidInput = pkgerrors.WithCode(pkgerrors.CodeInvalidInput, "invalid input")
+	// ErrSystemAlreadyInitialized is returned when admin init runs on an initialized system
+	ErrSystemAlreadyInitialized = pkgerrors.NewSentinel(pkgerrors.CodeConflict, "system_already_initialized", "System already initialized")
+	// ErrInvalidAPIKey is returned when a bearer API key is unknown or revoked
+	ErrInvalidAPIKey = pkgerrors.NewSentinel(pkgerrors.CodeUnauthorized, "invalid_api_key", "invalid API key")
+	// ErrUserRequired is returned when an API key attempts an action that is
+	// recorded against a user account, such as creating an environment
+	ErrUserRequired = pkgerrors.NewSentinel(pkgerrors.CodeForbidden, "user_required", "this action must be performed by a user; API keys cannot perform it")
+	// ErrVersionConflict matches errors from VersionConflict with errors.Is.
+	ErrVersionConflict = pkgerrors.NewSentinel(pkgerrors.CodeConflict, "version_conflict", "resource was modified concurrently")
+	// ErrTokenWorkspaceGone is returned when the workspace named in a token was
+	// deleted after the token was issued. The "reason" metadata lets clients
+	// tell it apart from other 401s and send the user back to sign in.
+	ErrTokenWorkspaceGone = pkgerrors.NewSentinel(pkgerrors.CodeUnauthorized, "workspace_deleted", "your workspace no longer exists; sign in again").
+				WithMetadata(pkgerrors.MetadataReason, "workspace_deleted")
 )
 
 // NotFound creates a domain NotFound error with entity context
@@ -23,8 +38,8 @@ func NotFound(entityType string, id string) *pkgerrors.Error {
 		pkgerrors.CodeNotFound,
 		fmt.Sprintf("%s not found: %s", entityType, id),
 	).
-		WithMetadata("entity_type", entityType).
-		WithMetadata("entity_id", id).
+		WithMetadata(pkgerrors.MetadataEntityType, entityType).
+		WithMetadata(pkgerrors.MetadataEntityID, id).
 		WithHTTPStatus(http.StatusNotFound).
 		WithSeverity(pkgerrors.SeverityWarning) // Not found is expected, not critical
 }
@@ -35,9 +50,9 @@ func NotFoundByField(entityType, field, value string) *pkgerrors.Error {
 		pkgerrors.CodeNotFound,
 		fmt.Sprintf("%s not found with %s: %s", entityType, field, value),
 	).
-		WithMetadata("entity_type", entityType).
-		WithMetadata("field", field).
-		WithMetadata("value", value).
+		WithMetadata(pkgerrors.MetadataEntityType, entityType).
+		WithMetadata(pkgerrors.MetadataField, field).
+		WithMetadata(pkgerrors.MetadataValue, value).
 		WithHTTPStatus(http.StatusNotFound).
 		WithSeverity(pkgerrors.SeverityWarning)
 }
@@ -48,21 +63,38 @@ func Conflict(entityType, field, value string) *pkgerrors.Error {
 		pkgerrors.CodeConflict,
 		fmt.Sprintf("%s already exists with %s: %s", entityType, field, value),
 	).
-		WithMetadata("entity_type", entityType).
-		WithMetadata("field", field).
-		WithMetadata("value", value).
+		WithMetadata(pkgerrors.MetadataEntityType, entityType).
+		WithMetadata(pkgerrors.MetadataField, field).
+		WithMetadata(pkgerrors.MetadataValue, value).
 		WithHTTPStatus(http.StatusConflict).
 		WithSeverity(pkgerrors.SeverityWarning) // Conflicts are expected, not critical
 }
 
+// VersionConflict reports an update made against a stale version. The
+// resource's current version and updated_at travel in the metadata so the
+// client can reconcile without another GET.
+func VersionConflict(entityType, id string, currentVersion int, updatedAt time.Time) *pkgerrors.Error {
+	return pkgerrors.WithCode(
+		pkgerrors.CodeConflict,
+		fmt.Sprintf("%s %s was modified concurrently", entityType, id),
+	).
+		WithKind("version_conflict").
+		WithMetadata(pkgerrors.MetadataEntityType, entityType).
+		WithMetadata(pkgerrors.MetadataEntityID, id).
+		WithMetadata(pkgerrors.MetadataCurrentVersion, currentVersion).
+		WithMetadata(pkgerrors.MetadataUpdatedAt, updatedAt).
+		WithHTTPStatus(http.StatusConflict).
+		WithSeverity(pkgerrors.SeverityWarning)
+}
+
 // InvalidInput creates a validation error
 func InvalidInput(field, reason string) *pkgerrors.Error {
 	return pkgerrors.WithCode(
 		pkgerrors.CodeInvalidInput,
 		fmt.Sprintf("invalid input for %s: %s", field, reason),
 	).
-		WithMetadata("field", field).
-		WithMetadata("reason", reason).
+		WithMetadata(pkgerrors.MetadataField, field).
+		WithMetadata(pkgerrors.MetadataReason, reason).
 		WithHTTPStatus(http.StatusBadRequest).
 		WithSeverity(pkgerrors.SeverityWarning) // Validation errors are expected
 }
@@ -81,7 +113,8 @@ func ValidationError(message string, fieldErrors map[string]string) *pkgerrors.E
 		err = err.WithMetadata(fmt.Sprintf("field_%s", field), reason)
 	}
 
-	return err
+	// Also expose them under "fields", merging with any existing field errors
+	return err.MergeMetadata(map[string]interface{}{pkgerrors.MetadataFields: fieldErrors})
 }
 
 // Unauthorized creates an unauthorized error
@@ -90,7 +123,7 @@ func Unauthorized(reason string) *pkgerrors.Error {
 		pkgerrors.CodeUnauthorized,
 		fmt.Sprintf("unauthorized: %s", reason),
 	).
-		WithMetadata("reason", reason).
+		WithMetadata(pkgerrors.MetadataReason, reason).
 		WithHTTPStatus(http.StatusUnauthorized).
 		WithSeverity(pkgerrors.SeverityWarning)
 }
@@ -101,8 +134,8 @@ func Forbidden(resource, action string) *pkgerrors.Error {
 		pkgerrors.CodeForbidden,
 		fmt.Sprintf("forbidden: insufficient permissions to %s %s", action, resource),
 	).
-		WithMetadata("resource", resource).
-		WithMetadata("action", action).
+		WithMetadata(pkgerrors.MetadataResource, resource).
+		WithMetadata(pkgerrors.MetadataAction, action).
 		WithHTTPStatus(http.StatusForbidden).
 		WithSeverity(pkgerrors.SeverityWarning)
 }
@@ -16,10 +16,10 @@ type Group struct {
 	UpdatedAt          time.Time `json:"updated_at"`
 }
 
-func NewGroup(name, description string, workspaceID uuid.UUID, accessAllTemplates bool) *Group {
+func NewGroup(ids IDGenerator, name, description string, workspaceID uuid.UUID, accessAllTemplates bool) *Group {
 	now := time.Now()
 	return &Group{
-		ID:                 uuid.New(),
+		ID:                 ids.NewID(),
 		Name:               name,
 		Description:        description,
 		WorkspaceID:        workspaceID,
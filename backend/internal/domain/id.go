@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// IDStrategyRandom generates random (version 4) UUIDs. This is the default.
+	IDStrategyRandom = "uuidv4"
+
+	// IDStrategyTimeOrdered generates time-ordered (version 7) UUIDs, which
+	// sort by creation time and keep index inserts local.
+	IDStrategyTimeOrdered = "uuidv7"
+)
+
+// IDGenerator produces the IDs of new entities.
+type IDGenerator interface {
+	NewID() uuid.UUID
+}
+
+// IDGeneratorFunc adapts a function to IDGenerator.
+type IDGeneratorFunc func() uuid.UUID
+
+func (f IDGeneratorFunc) NewID() uuid.UUID {
+	return f()
+}
+
+// RandomIDGenerator generates version 4 UUIDs.
+type RandomIDGenerator struct{}
+
+func (RandomIDGenerator) NewID() uuid.UUID {
+	return uuid.New()
+}
+
+// TimeOrderedIDGenerator generates version 7 UUIDs: a millisecond timestamp
+// followed by a counter and random bits, so later IDs sort after earlier ones.
+type TimeOrderedIDGenerator struct{}
+
+func (TimeOrderedIDGenerator) NewID() uuid.UUID {
+	return uuid.Must(uuid.NewV7())
+}
+
+// NewIDGenerator returns the generator for a strategy name (IDStrategyRandom
+// or IDStrategyTimeOrdered).
+func NewIDGenerator(strategy string) (IDGenerator, error) {
+	switch strategy {
+	case IDStrategyRandom:
+		return RandomIDGenerator{}, nil
+	case IDStrategyTimeOrdered:
+		return TimeOrderedIDGenerator{}, nil
+	default:
+		return nil, fmt.Errorf("unknown ID strategy %q (allowed: %s, %s)", strategy, IDStrategyRandom, IDStrategyTimeOrdered)
+	}
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"backend/pkg/validation"
+
+	"github.com/google/uuid"
+)
+
+func TestConstructors_UseInjectedIDGenerator(t *testing.T) {
+	want := uuid.MustParse("11111111-2222-4333-8444-555555555555")
+	ids := IDGeneratorFunc(func() uuid.UUID { return want })
+
+	workspace, wsErr := NewWorkspace(ids, validation.DefaultContentPolicy(), "Injected", "", nil)
+	if wsErr != nil {
+		t.Fatalf("unexpected error: %v", wsErr)
+	}
+	if workspace.ID != want {
+		t.Errorf("NewWorkspace: expected ID %s, got %s", want, workspace.ID)
+	}
+	key, _, err := NewAPIKey(ids, "Injected", RoleUser, "read", uuid.New(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if key.ID != want {
+		t.Errorf("NewAPIKey: expected ID %s, got %s", want, key.ID)
+	}
+}
+
+func TestTimeOrderedIDGenerator_SortsByCreationTime(t *testing.T) {
+	gen := TimeOrderedIDGenerator{}
+
+	ids := make([]uuid.UUID, 5)
+	for i := range ids {
+		ids[i] = gen.NewID()
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	for i, id := range ids {
+		if id.Version() != 7 {
+			t.Errorf("expected a version 7 UUID, got version %d", id.Version())
+		}
+		if i > 0 && bytes.Compare(ids[i-1][:], id[:]) >= 0 {
+			t.Errorf("expected %s to sort after %s", id, ids[i-1])
+		}
+		if i > 0 && ids[i-1].String() >= id.String() {
+			t.Errorf("expected string form %s to sort after %s", id, ids[i-1])
+		}
+	}
+}
+
+func TestNewIDGenerator(t *testing.T) {
+	if gen, err := NewIDGenerator(IDStrategyRandom); err != nil || gen.NewID().Version() != 4 {
+		t.Errorf("expected a version 4 generator for %q, got err %v", IDStrategyRandom, err)
+	}
+	if gen, err := NewIDGenerator(IDStrategyTimeOrdered); err != nil || gen.NewID().Version() != 7 {
+		t.Errorf("expected a version 7 generator for %q, got err %v", IDStrategyTimeOrdered, err)
+	}
+	if _, err := NewIDGenerator("sequential"); err == nil {
+		t.Error("expected an error for an unknown strategy")
+	}
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventUserCreated is emitted when a user is registered. Its aggregate is the
+// user and its payload a UserCreatedPayload.
+const EventUserCreated = "user.created"
+
+// OutboxEvent is a domain event written in the same transaction as the change
+// it describes, then dispatched to its handlers in the background. An event
+// is only ever recorded if its change commits, and is delivered at least once.
+type OutboxEvent struct {
+	ID          uuid.UUID
+	Type        string
+	AggregateID uuid.UUID
+	Payload     json.RawMessage
+	CreatedAt   time.Time
+	ProcessedAt *time.Time
+}
+
+type UserCreatedPayload struct {
+	WorkspaceID uuid.UUID `json:"workspace_id"`
+}
+
+func NewOutboxEvent(ids IDGenerator, eventType string, aggregateID uuid.UUID, payload any) (*OutboxEvent, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s payload: %w", eventType, err)
+	}
+
+	return &OutboxEvent{
+		ID:          ids.NewID(),
+		Type:        eventType,
+		AggregateID: aggregateID,
+		Payload:     data,
+		CreatedAt:   time.Now(),
+	}, nil
+}
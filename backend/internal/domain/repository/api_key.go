@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+
+	"backend/internal/domain"
+	"backend/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *domain.APIKey) *errors.Error
+	// GetActiveByHash returns the unrevoked key with keyHash in a workspace
+	// that has not been deleted.
+	GetActiveByHash(ctx context.Context, keyHash string) (*domain.APIKey, *errors.Error)
+	// ListByWorkspace returns the workspace's unrevoked keys, newest first.
+	ListByWorkspace(ctx context.Context, workspaceID uuid.UUID) ([]*domain.APIKey, *errors.Error)
+	// Revoke marks an unrevoked key in workspaceID as revoked.
+	Revoke(ctx context.Context, workspaceID uuid.UUID, id uuid.UUID) *errors.Error
+}
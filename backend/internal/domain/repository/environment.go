@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"backend/internal/domain"
 	"backend/pkg/contracts"
@@ -17,7 +18,19 @@ type EnvironmentRepository interface {
 	GetByCreatedBy(ctx context.Context, userID uuid.UUID) ([]*domain.Environment, *errors.Error)
 	GetByTemplateID(ctx context.Context, templateID uuid.UUID) ([]*domain.Environment, *errors.Error)
 	Update(ctx context.Context, env *domain.Environment) *errors.Error
+	// Delete soft-deletes the environment. Deleted environments are excluded
+	// from every read until they are restored.
 	Delete(ctx context.Context, id uuid.UUID) *errors.Error
+	// Restore clears the soft delete, returning NotFound when the environment
+	// is not deleted or does not belong to workspaceID.
+	Restore(ctx context.Context, id uuid.UUID, workspaceID uuid.UUID) *errors.Error
+	// ListDeletedBefore returns the environments soft-deleted before before,
+	// oldest deletion first.
+	ListDeletedBefore(ctx context.Context, before time.Time) ([]*domain.Environment, *errors.Error)
+	// Purge hard-deletes the environment, and through cascades its variable
+	// values and teardown entry, if it is still soft-deleted with a deletion
+	// before before. It reports whether the environment was removed.
+	Purge(ctx context.Context, id uuid.UUID, before time.Time) (bool, *errors.Error)
 	List(ctx context.Context, opts ListOptions) ([]*domain.Environment, *errors.Error)
 
 	// AcquireOperation atomically sets the environment status to newStatus
@@ -28,4 +41,8 @@ type EnvironmentRepository interface {
 	// ListFiltered returns environments with enriched fields (created_by_name, template_name)
 	// using JOINs, filtered by the provided options.
 	ListFiltered(ctx context.Context, opts EnvironmentListOptions) ([]*contracts.EnvironmentResponse, *errors.Error)
+
+	// CountFiltered counts every environment matching the filters in opts,
+	// ignoring its sorting and pagination.
+	CountFiltered(ctx context.Context, opts EnvironmentListOptions) (int, *errors.Error)
 }
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"backend/internal/domain"
+	"backend/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+type OutboxRepository interface {
+	// Add records an event; it belongs to the caller's transaction, so the
+	// event is discarded if that transaction rolls back.
+	Add(ctx context.Context, event *domain.OutboxEvent) *errors.Error
+	// FindPending returns up to limit unprocessed events, oldest first.
+	FindPending(ctx context.Context, limit int) ([]*domain.OutboxEvent, *errors.Error)
+	MarkProcessed(ctx context.Context, id uuid.UUID, at time.Time) *errors.Error
+}
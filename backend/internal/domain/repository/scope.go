@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type workspaceScopeKeyType struct{}
+
+var workspaceScopeKey workspaceScopeKeyType
+
+// WithWorkspaceScope returns a context that confines scoped repository reads
+// to workspaceID. It is a safety net under the services' own workspace
+// checks: a read that forgets the check still cannot return another
+// workspace's rows.
+func WithWorkspaceScope(ctx context.Context, workspaceID uuid.UUID) context.Context {
+	return context.WithValue(ctx, workspaceScopeKey, workspaceID)
+}
+
+// WorkspaceScopeFromContext returns the workspace set by WithWorkspaceScope.
+// Without one (background jobs, unauthenticated routes) reads are unscoped.
+func WorkspaceScopeFromContext(ctx context.Context) (uuid.UUID, bool) {
+	workspaceID, ok := ctx.Value(workspaceScopeKey).(uuid.UUID)
+	return workspaceID, ok
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"backend/internal/domain"
+	"backend/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+type SystemInitRepository interface {
+	// MarkInitialized records that the system was initialized with workspaceID.
+	// It returns domainerrors.ErrSystemAlreadyInitialized if a record exists.
+	MarkInitialized(ctx context.Context, workspaceID uuid.UUID) *errors.Error
+	// RecordAttempt stores a request to initialize the system, unless the
+	// same IP already has an attempt with the same outcome at or after since.
+	// It reports whether the attempt was stored.
+	RecordAttempt(ctx context.Context, attempt *domain.AdminInitAttempt, since time.Time) (bool, *errors.Error)
+	// PruneAttempts deletes all but the newest keep attempts.
+	PruneAttempts(ctx context.Context, keep int) *errors.Error
+	// ListAttempts returns recorded attempts, most recent first.
+	ListAttempts(ctx context.Context, limit, offset int) ([]*domain.AdminInitAttempt, *errors.Error)
+	CountAttempts(ctx context.Context) (int, *errors.Error)
+}
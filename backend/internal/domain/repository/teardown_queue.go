@@ -12,6 +12,10 @@ import (
 
 type TeardownQueueRepository interface {
 	Enqueue(ctx context.Context, entry *domain.TeardownEntry) *errors.Error
+	// Schedule queues a teardown of the environment at the given time, or
+	// brings an existing entry forward to it. A completed entry is queued
+	// again; one being processed is left alone.
+	Schedule(ctx context.Context, envID uuid.UUID, at time.Time) *errors.Error
 	FindDue(ctx context.Context, now time.Time) (*domain.TeardownEntry, *errors.Error)
 	UpdateStatus(ctx context.Context, envID uuid.UUID, status domain.TeardownStatus) *errors.Error
 	ResetProcessing(ctx context.Context) *errors.Error
@@ -2,18 +2,61 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"backend/internal/domain"
+	"backend/pkg/contracts"
 	"backend/pkg/errors"
 
 	"github.com/google/uuid"
 )
 
+// TemplateRepository reads are confined to the workspace scope carried by
+// ctx, if any; see WithWorkspaceScope.
 type TemplateRepository interface {
 	Create(ctx context.Context, template domain.Template) *errors.Error
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.Template, *errors.Error)
+	// WorkspaceOf returns the workspace of the template, or NotFound. Unlike
+	// the reads it ignores the workspace scope, so a scoped miss can be told
+	// apart from a missing template; use it only to answer and log a denial.
+	WorkspaceOf(ctx context.Context, id uuid.UUID) (uuid.UUID, *errors.Error)
+	// GetByWorkspaceAndName returns the template with the exact name in the
+	// workspace, or NotFound.
+	GetByWorkspaceAndName(ctx context.Context, workspaceID uuid.UUID, name string) (*domain.Template, *errors.Error)
 	GetByWorkspaceID(ctx context.Context, workspaceID uuid.UUID) ([]*domain.Template, *errors.Error)
+	// CountByWorkspaceID counts the templates in a workspace that also match
+	// filters, which take the same columns as ListOptions.Filters.
+	CountByWorkspaceID(ctx context.Context, workspaceID uuid.UUID, filters map[string]any) (int, *errors.Error)
 	Update(ctx context.Context, template domain.Template) *errors.Error
 	Delete(ctx context.Context, id uuid.UUID) *errors.Error
 	List(ctx context.Context, opts ListOptions) ([]*domain.Template, *errors.Error)
+	// ListEach is like List but hands each template to fn as it is read instead of
+	// collecting them. Iteration stops at the first error returned by fn.
+	ListEach(ctx context.Context, opts ListOptions, fn func(*domain.Template) error) *errors.Error
+	// ListFields is like List but selects only the given columns, each one of
+	// contracts.TemplateFields, leaving the other fields of each result unset.
+	ListFields(ctx context.Context, opts ListOptions, fields []string) ([]*contracts.PartialTemplate, *errors.Error)
+	// Stats aggregates the templates in a workspace that match filters, counting
+	// those updated at or after since and returning up to recent of the most
+	// recently updated ones.
+	Stats(ctx context.Context, workspaceID uuid.UUID, filters map[string]any, since time.Time, recent int) (*TemplateStats, *errors.Error)
+	// CountBySourceScheme groups the templates in a workspace that match
+	// filters by the scheme of their repo_url, "storage" for templates that
+	// only live in template storage. Larger groups come first.
+	CountBySourceScheme(ctx context.Context, workspaceID uuid.UUID, filters map[string]any) ([]SchemeCount, *errors.Error)
+}
+
+// SchemeCount is the number of templates whose source uses Scheme.
+type SchemeCount struct {
+	Scheme string
+	Count  int
+}
+
+// TemplateStats is the aggregate view of a workspace's templates.
+type TemplateStats struct {
+	Total         int
+	UpdatedSince  int
+	LastUpdatedAt *time.Time
+	// MostRecentlyUpdated holds only ID, Name and UpdatedAt of each template.
+	MostRecentlyUpdated []domain.Template
 }
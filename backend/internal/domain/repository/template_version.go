@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	"backend/internal/domain"
+	"backend/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+// TemplateVersionRepository reads are confined to the workspace scope carried
+// by ctx, if any; see WithWorkspaceScope.
+type TemplateVersionRepository interface {
+	// Create stores version as the next version of its template and sets its
+	// Version and CreatedAt.
+	Create(ctx context.Context, version *domain.TemplateVersion) *errors.Error
+	// ListByTemplateID returns the template's versions newest first, without
+	// their files.
+	ListByTemplateID(ctx context.Context, templateID uuid.UUID) ([]*domain.TemplateVersion, *errors.Error)
+	// GetByTemplateAndVersion returns one version of a template with its files.
+	GetByTemplateAndVersion(ctx context.Context, templateID uuid.UUID, version int) (*domain.TemplateVersion, *errors.Error)
+}
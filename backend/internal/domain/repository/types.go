@@ -1,6 +1,10 @@
 package repository
 
 import (
+	"fmt"
+	"slices"
+	"strings"
+
 	"backend/internal/domain/errors"
 	pkgerrors "backend/pkg/errors"
 
@@ -8,11 +12,24 @@ import (
 )
 
 type ListOptions struct {
-	Limit    int
-	Offset   int
-	SortBy   string
-	Order    string // "ASC" or "DESC"
-	FilterBy map[string]string
+	Limit  int
+	Offset int
+	SortBy string
+	Order  string // "ASC" or "DESC"
+	// Filters are equality conditions (WHERE col = ?) keyed by column name. Each
+	// repository whitelists the columns it accepts; a slice value matches any
+	// of its elements (WHERE col IN (...)).
+	Filters map[string]any
+	// IncludeDeleted also returns soft-deleted rows. Services only set it
+	// for admin callers; repositories without soft delete ignore it.
+	IncludeDeleted bool
+	// DeletedAdminID limits the soft-deleted rows IncludeDeleted returns to
+	// those administered by this user, so an admin never sees another
+	// tenant's deleted workspaces.
+	DeletedAdminID uuid.UUID
+	// Unpaged ignores Limit and Offset and returns every matching row in
+	// order. Only for exports that stream rows rather than hold them.
+	Unpaged bool
 }
 
 func (o *ListOptions) Validate() *pkgerrors.Error {
@@ -28,6 +45,16 @@ func (o *ListOptions) Validate() *pkgerrors.Error {
 	return nil
 }
 
+// ValidateFilters rejects filter keys outside the allowed columns.
+func (o *ListOptions) ValidateFilters(allowed ...string) *pkgerrors.Error {
+	for key := range o.Filters {
+		if !slices.Contains(allowed, key) {
+			return errors.InvalidInput("filter", fmt.Sprintf("unknown filter %q (allowed: %s)", key, strings.Join(allowed, ", ")))
+		}
+	}
+	return nil
+}
+
 func (o *ListOptions) ApplyDefaults() {
 	if o.Limit == 0 {
 		o.Limit = 50 // Default page size
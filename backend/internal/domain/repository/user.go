@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"backend/internal/domain"
 	"backend/pkg/errors"
@@ -12,11 +13,29 @@ import (
 type UserRepository interface {
 	Create(ctx context.Context, user domain.UserAggregate) *errors.Error
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.UserAggregate, *errors.Error)
+	// GetByIDWithWorkspace returns the user with its workspace's name and
+	// deletion time, read in one query. Soft-deleted workspaces are included.
+	GetByIDWithWorkspace(ctx context.Context, id uuid.UUID) (*domain.UserWithWorkspace, *errors.Error)
 	GetByOAuthID(ctx context.Context, provider domain.OauthProvider, oauthID string) (*domain.UserAggregate, *errors.Error)
 	GetByEmail(ctx context.Context, email string) (*domain.UserAggregate, *errors.Error)
+	ExistsByEmail(ctx context.Context, email string) (bool, *errors.Error)
 	GetByWorkspaceID(ctx context.Context, workspaceID uuid.UUID) ([]*domain.UserAggregate, *errors.Error)
 	Update(ctx context.Context, user domain.UserAggregate) *errors.Error
 	Delete(ctx context.Context, id uuid.UUID) *errors.Error
 	List(ctx context.Context, opts ListOptions) ([]*domain.UserAggregate, *errors.Error)
 	Count(ctx context.Context) (int, *errors.Error)
+	// ListInactiveSince returns one page of the workspace's users whose last
+	// activity is before since or who were never active, never-active users
+	// first and the rest oldest activity first.
+	ListInactiveSince(ctx context.Context, workspaceID uuid.UUID, since time.Time, limit, offset int) ([]*domain.UserAggregate, *errors.Error)
+	// CountInactiveSince counts the users ListInactiveSince matches.
+	CountInactiveSince(ctx context.Context, workspaceID uuid.UUID, since time.Time) (int, *errors.Error)
+	// TouchLastActive sets last_active_at to at, unless the stored value is
+	// already at or after notBefore. It reports whether a row was written.
+	TouchLastActive(ctx context.Context, id uuid.UUID, at, notBefore time.Time) (bool, *errors.Error)
+	// GetTokenEpoch returns the user's current token epoch.
+	GetTokenEpoch(ctx context.Context, id uuid.UUID) (int64, *errors.Error)
+	// BumpTokenEpoch increments the user's token epoch, revoking all tokens
+	// issued before the call.
+	BumpTokenEpoch(ctx context.Context, id uuid.UUID) *errors.Error
 }
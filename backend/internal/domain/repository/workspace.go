@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"backend/internal/domain"
 	"backend/pkg/errors"
@@ -12,9 +13,45 @@ import (
 type WorkspaceRepository interface {
 	Create(ctx context.Context, workspace *domain.Workspace) *errors.Error
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.Workspace, *errors.Error)
+	// GetByIDs returns the workspaces among ids that exist and are not
+	// soft-deleted. Missing IDs are skipped rather than reported.
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.Workspace, *errors.Error)
+	// Exists reports whether a workspace that is not soft-deleted has id.
+	Exists(ctx context.Context, id uuid.UUID) (bool, *errors.Error)
 	GetByAdminID(ctx context.Context, adminID uuid.UUID) ([]*domain.Workspace, *errors.Error)
+	// Update saves workspace if its Version is still current, then sets the
+	// new Version and UpdatedAt on it. A stale Version yields a version
+	// conflict carrying the current one.
 	Update(ctx context.Context, workspace *domain.Workspace) *errors.Error
-	Delete(ctx context.Context, id uuid.UUID) *errors.Error
+	// Delete soft-deletes the workspace, recording deletedBy when known.
+	Delete(ctx context.Context, id uuid.UUID, deletedBy *uuid.UUID) *errors.Error
+	// ListDeleted lists only the soft-deleted workspaces administered by adminID.
+	ListDeleted(ctx context.Context, adminID uuid.UUID, opts ListOptions) ([]*domain.Workspace, *errors.Error)
+	CountDeleted(ctx context.Context, adminID uuid.UUID) (int, *errors.Error)
+	// Restore clears the soft delete, returning NotFound when the workspace
+	// does not exist, is not deleted or is not administered by adminID.
+	Restore(ctx context.Context, id uuid.UUID, adminID uuid.UUID) *errors.Error
+	// ListDeletedBefore returns the IDs of workspaces soft-deleted before
+	// before, oldest deletion first. A non-nil adminID limits them to the
+	// workspaces that user administers.
+	ListDeletedBefore(ctx context.Context, before time.Time, adminID *uuid.UUID) ([]uuid.UUID, *errors.Error)
+	// Purge hard-deletes the workspace, and through cascades everything in it,
+	// if it is still soft-deleted with a deletion before before. It reports
+	// whether the workspace was removed.
+	Purge(ctx context.Context, id uuid.UUID, before time.Time) (bool, *errors.Error)
 	List(ctx context.Context, opts ListOptions) ([]*domain.Workspace, *errors.Error)
+	// ListEach is like List but hands each workspace to fn as it is read instead of
+	// collecting them. Iteration stops at the first error returned by fn.
+	ListEach(ctx context.Context, opts ListOptions, fn func(*domain.Workspace) error) *errors.Error
 	UpdateAdminID(ctx context.Context, workspaceID uuid.UUID, adminID uuid.UUID) *errors.Error
+	// SetSecretHash replaces the workspace's secret hash, invalidating any
+	// previous secret, and returns when the rotation was recorded.
+	SetSecretHash(ctx context.Context, workspaceID uuid.UUID, secretHash string) (time.Time, *errors.Error)
+	// GetSecretHash returns the stored secret hash, or "" if no secret was ever issued.
+	GetSecretHash(ctx context.Context, workspaceID uuid.UUID) (string, *errors.Error)
+	// GetTokenEpoch returns the workspace's current token epoch.
+	GetTokenEpoch(ctx context.Context, workspaceID uuid.UUID) (int64, *errors.Error)
+	// BumpTokenEpoch increments the workspace's token epoch, revoking every
+	// token issued for the workspace before the call, and returns the new epoch.
+	BumpTokenEpoch(ctx context.Context, workspaceID uuid.UUID) (int64, *errors.Error)
 }
@@ -21,7 +21,13 @@ type FileStorage interface {
 	SaveFiles(dirPath string, files []FileInput) *pkgerrors.Error
 	DeleteDir(dirPath string) *pkgerrors.Error
 	ListFiles(dirPath string) ([]FileInfo, *pkgerrors.Error)
+	// WalkFiles calls fn for each file under dirPath as it is found, stopping
+	// at the first error fn returns. A missing directory has no files.
+	WalkFiles(dirPath string, fn func(FileInfo) error) *pkgerrors.Error
 	ReadFile(filePath string) ([]byte, *pkgerrors.Error)
+	// ReplaceDir swaps dirPath for the directory at stagingPath, which is
+	// consumed. If the swap fails, dirPath keeps its previous contents.
+	ReplaceDir(stagingPath, dirPath string) *pkgerrors.Error
 }
 
 // ExecutionStorage manages Terraform execution directories where environments
@@ -0,0 +1,40 @@
+package storage
+
+import (
+	"path"
+	"strings"
+)
+
+// PlainText is the language reported for files with no known extension.
+const PlainText = "plaintext"
+
+// languagesByExtension maps lowercase file extensions to the language names
+// syntax highlighters such as highlight.js and Prism use.
+var languagesByExtension = map[string]string{
+	".tf":     "hcl",
+	".tfvars": "hcl",
+	".hcl":    "hcl",
+	".json":   "json",
+	".yaml":   "yaml",
+	".yml":    "yaml",
+	".md":     "markdown",
+	".go":     "go",
+	".ts":     "typescript",
+	".tsx":    "typescript",
+	".js":     "javascript",
+	".jsx":    "javascript",
+	".py":     "python",
+	".sh":     "bash",
+	".toml":   "toml",
+	".xml":    "xml",
+	".sql":    "sql",
+}
+
+// DetectLanguage names the language of a file from its extension, for syntax
+// highlighting. Unknown extensions are PlainText.
+func DetectLanguage(filePath string) string {
+	if language, ok := languagesByExtension[strings.ToLower(path.Ext(filePath))]; ok {
+		return language
+	}
+	return PlainText
+}
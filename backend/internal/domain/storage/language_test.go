@@ -0,0 +1,28 @@
+package storage
+
+import "testing"
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"main.go", "go"},
+		{"src/app.ts", "typescript"},
+		{"README.md", "markdown"},
+		{"modules/vpc/main.tf", "hcl"},
+		{"prod.tfvars", "hcl"},
+		{"NOTES.MD", "markdown"},
+		{"archive.xyz", PlainText},
+		{"Makefile", PlainText},
+		{"dir.go/file", PlainText},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := DetectLanguage(tt.path); got != tt.want {
+				t.Errorf("DetectLanguage(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package storage
+
+import "context"
+
+// PathCheck is the outcome of a reachability check against a single location.
+type PathCheck struct {
+	Target     string
+	Scheme     string
+	Reachable  bool
+	StatusCode int    // HTTP status for http(s) targets, 0 otherwise
+	Detail     string // reason the target is unreachable, empty on success
+}
+
+// PathChecker performs lightweight reachability checks (HEAD for http(s),
+// stat for files) without fetching content.
+type PathChecker interface {
+	Check(ctx context.Context, target string) PathCheck
+}
@@ -12,20 +12,22 @@ import (
 type Template struct {
 	ID          uuid.UUID `json:"id"`
 	Name        string    `json:"name" validate:"required,min=3,max=255"`
-	WorkspaceID uuid.UUID `json:"workspace_id" validate:"required,uuid4"`
+	WorkspaceID uuid.UUID `json:"workspace_id" validate:"required,uuid"`
 	Path        string    `json:"path" validate:"required,filepath"`
+	RepoURL     string    `json:"repo_url,omitempty" validate:"omitempty,max=2048,httpurl"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 }
 
-func NewTemplate(name string, workspaceID uuid.UUID, validator Validator) (*Template, *pkgerrors.Error) {
+func NewTemplate(ids IDGenerator, name string, workspaceID uuid.UUID, repoURL string, validator Validator) (*Template, *pkgerrors.Error) {
 	now := time.Now()
-	id := uuid.New()
+	id := ids.NewID()
 	t := &Template{
 		ID:          id,
 		Name:        name,
 		WorkspaceID: workspaceID,
 		Path:        filepath.Join(workspaceID.String(), id.String()),
+		RepoURL:     repoURL,
 		CreatedAt:   now,
 		UpdatedAt:   now,
 	}
@@ -34,14 +34,14 @@ type NewTemplateVariableParams struct {
 	IsAutoParsed    bool
 }
 
-func NewTemplateVariable(params NewTemplateVariableParams) *TemplateVariable {
+func NewTemplateVariable(ids IDGenerator, params NewTemplateVariableParams) *TemplateVariable {
 	now := time.Now()
 	varType := params.VarType
 	if varType == "" {
 		varType = "string"
 	}
 	return &TemplateVariable{
-		ID:              uuid.New(),
+		ID:              ids.NewID(),
 		TemplateID:      params.TemplateID,
 		Key:             params.Key,
 		Description:     params.Description,
@@ -8,7 +8,7 @@ import (
 
 func TestNewTemplateVariableDefaults(t *testing.T) {
 	templateID := uuid.New()
-	v := NewTemplateVariable(NewTemplateVariableParams{
+	v := NewTemplateVariable(RandomIDGenerator{}, NewTemplateVariableParams{
 		TemplateID: templateID,
 		Key:        "test_key",
 		IsRequired: true,
@@ -45,7 +45,7 @@ func TestNewTemplateVariableDefaults(t *testing.T) {
 
 func TestNewTemplateVariableWithAllParams(t *testing.T) {
 	templateID := uuid.New()
-	v := NewTemplateVariable(NewTemplateVariableParams{
+	v := NewTemplateVariable(RandomIDGenerator{}, NewTemplateVariableParams{
 		TemplateID:      templateID,
 		Key:             "db_password",
 		Description:     "Database password",
@@ -78,7 +78,7 @@ func TestNewTemplateVariableWithAllParams(t *testing.T) {
 }
 
 func TestNewTemplateVariableEmptyVarTypeDefaultsToString(t *testing.T) {
-	v := NewTemplateVariable(NewTemplateVariableParams{
+	v := NewTemplateVariable(RandomIDGenerator{}, NewTemplateVariableParams{
 		TemplateID: uuid.New(),
 		Key:        "test",
 		VarType:    "",
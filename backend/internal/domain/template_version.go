@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TemplateVersion is a snapshot of a template taken before a change to it, so
+// the change can be undone. Versions are numbered from 1 per template.
+type TemplateVersion struct {
+	ID          uuid.UUID `json:"id"`
+	TemplateID  uuid.UUID `json:"template_id"`
+	WorkspaceID uuid.UUID `json:"workspace_id"`
+	Version     int       `json:"version"`
+	Name        string    `json:"name"`
+	Path        string    `json:"path"`
+	RepoURL     string    `json:"repo_url,omitempty"`
+	// Files maps each file's path within the template to its content. It is
+	// only loaded for a single version, not in listings.
+	Files     map[string]string `json:"-"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// NewTemplateVersion snapshots template with the given files. The version
+// number is assigned when it is stored.
+func NewTemplateVersion(ids IDGenerator, template *Template, files map[string]string) *TemplateVersion {
+	return &TemplateVersion{
+		ID:          ids.NewID(),
+		TemplateID:  template.ID,
+		WorkspaceID: template.WorkspaceID,
+		Name:        template.Name,
+		Path:        template.Path,
+		RepoURL:     template.RepoURL,
+		Files:       files,
+	}
+}
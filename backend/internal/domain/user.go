@@ -3,10 +3,13 @@ package domain
 import (
 	domainerrors "backend/internal/domain/errors"
 	"backend/pkg/errors"
+	"backend/pkg/validation"
 	"crypto/rand"
+	"crypto/subtle"
 	"encoding/base64"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -38,28 +41,43 @@ type (
 	}
 
 	LocalUser struct {
-		Password string `json:"password"`
+		// Password is the password hash; it is never serialized.
+		Password string `json:"-"`
 	}
 
 	BaseUser struct {
-		ID          uuid.UUID `json:"id"`
-		Name        string    `json:"name"`
-		Email       string    `json:"email"`
-		Role        Role      `json:"role"`
-		WorkspaceID uuid.UUID `json:"workspace_id"`
-		CreatedAt   time.Time `json:"created_at"`
-		UpdatedAt   time.Time `json:"updated_at"`
+		ID           uuid.UUID  `json:"id"`
+		Name         string     `json:"name"`
+		Email        string     `json:"email"`
+		Role         Role       `json:"role"`
+		WorkspaceID  uuid.UUID  `json:"workspace_id"`
+		CreatedAt    time.Time  `json:"created_at"`
+		UpdatedAt    time.Time  `json:"updated_at"`
+		LastActiveAt *time.Time `json:"last_active_at,omitempty"`
+		// TokenEpoch is embedded in issued tokens; tokens from an older epoch are rejected
+		TokenEpoch int64 `json:"-"`
 	}
 
 	ThirdPartyUser struct {
 		OauthProvider OauthProvider `json:"oauth_provider"`
 		OauthID       string        `json:"oauth_id"`
 	}
-	UserFactory   struct{}
+	// UserFactory builds user aggregates with IDs from its generator
+	UserFactory struct {
+		ids IDGenerator
+	}
 	OauthProvider string
 	Role          string
 )
 
+// UserWithWorkspace is a user read together with the workspace it belongs
+// to. WorkspaceDeletedAt is set when the workspace has been soft-deleted.
+type UserWithWorkspace struct {
+	User               UserAggregate
+	WorkspaceName      string
+	WorkspaceDeletedAt *time.Time
+}
+
 // roleRank maps roles to their privilege level for comparison.
 var roleRank = map[Role]int{
 	RoleUser:   0,
@@ -89,9 +107,9 @@ func NewLocalUser(password string) (LocalUser, *errors.Error) {
 	return LocalUser{Password: hashedPassword}, nil
 }
 
-func NewBaseUser(name, email string, role Role, workspaceID uuid.UUID) BaseUser {
+func NewBaseUser(ids IDGenerator, name, email string, role Role, workspaceID uuid.UUID) BaseUser {
 	return BaseUser{
-		ID:          uuid.New(),
+		ID:          ids.NewID(),
 		Name:        name,
 		Email:       email,
 		Role:        role,
@@ -101,7 +119,21 @@ func NewBaseUser(name, email string, role Role, workspaceID uuid.UUID) BaseUser
 	}
 }
 
+// IsValid returns true if the provider is one of the supported OAuth providers.
+func (p OauthProvider) IsValid() bool {
+	switch p {
+	case OauthProviderGitHub, OauthProviderGoogle:
+		return true
+	}
+	return false
+}
+
+// NewThirdPartyUser rejects unknown providers, since a user stored with one
+// could never be found again by GetByOAuthID.
 func NewThirdPartyUser(oauthProvider, oauthID string) (*ThirdPartyUser, *errors.Error) {
+	if !OauthProvider(oauthProvider).IsValid() {
+		return nil, domainerrors.InvalidInput("oauth_provider", fmt.Sprintf("unknown provider %q", oauthProvider))
+	}
 	return &ThirdPartyUser{
 		OauthProvider: OauthProvider(oauthProvider),
 		OauthID:       oauthID,
@@ -116,8 +148,30 @@ func (u *LocalUser) CheckPassword(password string) bool {
 	return valid
 }
 
+var (
+	dummyPasswordHashOnce sync.Once
+	dummyPasswordHash     string
+)
+
+// SimulatePasswordCheck performs the same argon2id work as CheckPassword
+// against a throwaway hash. Login calls it when there is no password to check
+// (unknown email, OAuth-only account) so those failures take as long as a
+// wrong password and response timing does not reveal which emails exist. The
+// throwaway hash uses the current argon2 parameters, so it tracks any change
+// to them.
+func SimulatePasswordCheck(password string) {
+	dummyPasswordHashOnce.Do(func() {
+		dummyPasswordHash, _ = hashPassword(uuid.NewString())
+	})
+	_, _ = verifyArgon2idHash(password, dummyPasswordHash)
+}
+
+func NewUserFactory(ids IDGenerator) *UserFactory {
+	return &UserFactory{ids: ids}
+}
+
 func (f *UserFactory) Create(oauthProvider *OauthProvider, oauthId *uuid.UUID, name, email string, password *string, role Role, workspaceID uuid.UUID) (UserAggregate, *errors.Error) {
-	baseUser := NewBaseUser(name, email, role, workspaceID)
+	baseUser := NewBaseUser(f.ids, name, email, role, workspaceID)
 	if oauthProvider != nil && oauthId != nil {
 		thirdPartyUser, err := NewThirdPartyUser(string(*oauthProvider), oauthId.String())
 		if err != nil {
@@ -145,10 +199,14 @@ func (f *UserFactory) Create(oauthProvider *OauthProvider, oauthId *uuid.UUID, n
 
 // GenerateRandomPassword generates a cryptographically secure random password
 // of the given length that satisfies the strongpassword validation rule.
+// Lengths below the password policy's minimum are raised to it.
 func GenerateRandomPassword(length int) (string, error) {
 	if length < 8 {
 		length = 8
 	}
+	if minLength := validation.CurrentPasswordPolicy().MinLength; length < minLength {
+		length = minLength
+	}
 
 	const (
 		upperChars   = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
@@ -243,15 +301,5 @@ func verifyArgon2idHash(password, encodedHash string) (bool, error) {
 	keyLength := uint32(len(decodedHash))
 	comparisonHash := argon2.IDKey([]byte(password), salt, time, memory, threads, keyLength)
 
-	if len(comparisonHash) != len(decodedHash) {
-		return false, nil
-	}
-
-	for i := range comparisonHash {
-		if comparisonHash[i] != decodedHash[i] {
-			return false, nil
-		}
-	}
-
-	return true, nil
+	return subtle.ConstantTimeCompare(comparisonHash, decodedHash) == 1, nil
 }
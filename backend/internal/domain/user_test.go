@@ -4,6 +4,8 @@ import (
 	"testing"
 	"time"
 
+	"backend/pkg/errors"
+
 	"github.com/google/uuid"
 )
 
@@ -61,7 +63,7 @@ func TestNewBaseUser(t *testing.T) {
 	workspaceID := uuid.New()
 
 	before := time.Now()
-	baseUser := NewBaseUser(name, email, RoleUser, workspaceID)
+	baseUser := NewBaseUser(RandomIDGenerator{}, name, email, RoleUser, workspaceID)
 	after := time.Now()
 
 	if baseUser.ID == uuid.Nil {
@@ -122,6 +124,39 @@ func TestNewThirdPartyUser(t *testing.T) {
 	}
 }
 
+func TestNewThirdPartyUser_UnknownProvider(t *testing.T) {
+	for _, provider := range []string{"githib", "GitHub", ""} {
+		t.Run(provider, func(t *testing.T) {
+			thirdPartyUser, err := NewThirdPartyUser(provider, "123456")
+
+			if err == nil {
+				t.Fatal("expected error for unknown provider")
+			}
+			if err.Code() != errors.CodeInvalidInput {
+				t.Errorf("expected code %s, got %s", errors.CodeInvalidInput, err.Code())
+			}
+			if thirdPartyUser != nil {
+				t.Error("expected nil third party user")
+			}
+		})
+	}
+}
+
+func TestUserFactory_Create_UnknownOAuthProvider(t *testing.T) {
+	factory := NewUserFactory(RandomIDGenerator{})
+	oauthProvider := OauthProvider("githib")
+	oauthID := uuid.New()
+
+	_, err := factory.Create(&oauthProvider, &oauthID, "Jane Doe", "jane@example.com", nil, RoleUser, uuid.New())
+
+	if err == nil {
+		t.Fatal("expected error for unknown provider")
+	}
+	if err.Code() != errors.CodeInvalidInput {
+		t.Errorf("expected code %s, got %s", errors.CodeInvalidInput, err.Code())
+	}
+}
+
 func TestLocalUser_CheckPassword(t *testing.T) {
 	password := "MySecretPassword123!"
 	localUser, err := NewLocalUser(password)
@@ -167,7 +202,7 @@ func TestLocalUser_CheckPassword(t *testing.T) {
 }
 
 func TestUserFactory_Create_LocalUser(t *testing.T) {
-	factory := &UserFactory{}
+	factory := NewUserFactory(RandomIDGenerator{})
 	name := "John Doe"
 	email := "john@example.com"
 	password := "ValidPassword123!"
@@ -196,7 +231,7 @@ func TestUserFactory_Create_LocalUser(t *testing.T) {
 }
 
 func TestUserFactory_Create_ThirdPartyUser(t *testing.T) {
-	factory := &UserFactory{}
+	factory := NewUserFactory(RandomIDGenerator{})
 	name := "Jane Doe"
 	email := "jane@example.com"
 	oauthProvider := OauthProviderGitHub
@@ -226,7 +261,7 @@ func TestUserFactory_Create_ThirdPartyUser(t *testing.T) {
 }
 
 func TestUserFactory_Create_NoAuthMethod(t *testing.T) {
-	factory := &UserFactory{}
+	factory := NewUserFactory(RandomIDGenerator{})
 	name := "Test User"
 	email := "test@example.com"
 	workspaceID := uuid.New()
@@ -245,7 +280,7 @@ func TestUserFactory_Create_NoAuthMethod(t *testing.T) {
 }
 
 func TestUserFactory_Create_PartialOAuthCredentials(t *testing.T) {
-	factory := &UserFactory{}
+	factory := NewUserFactory(RandomIDGenerator{})
 	name := "Test User"
 	email := "test@example.com"
 	workspaceID := uuid.New()
@@ -408,7 +443,7 @@ func TestOAuthProviderConstants(t *testing.T) {
 }
 
 func TestUserFactory_Create_BothAuthMethods(t *testing.T) {
-	factory := &UserFactory{}
+	factory := NewUserFactory(RandomIDGenerator{})
 	name := "Test User"
 	email := "test@example.com"
 	password := "Password123!"
@@ -1,7 +1,14 @@
 package domain
 
 import (
+	"fmt"
+	"strings"
 	"time"
+	"unicode/utf8"
+
+	domainerrors "backend/internal/domain/errors"
+	"backend/pkg/errors"
+	"backend/pkg/validation"
 
 	"github.com/google/uuid"
 )
@@ -10,18 +17,54 @@ type Workspace struct {
 	ID          uuid.UUID  `json:"id"`
 	Name        string     `json:"name"`
 	Description string     `json:"description"`
-	AdminID     *uuid.UUID `json:"admin"`
+	AdminID     *uuid.UUID `json:"admin_id"`
 	CreatedAt   time.Time  `json:"created_at"`
 	UpdatedAt   time.Time  `json:"updated_at"`
+	// Version starts at 1 and is incremented by every update.
+	Version int `json:"version"`
+	// DeletedAt and DeletedBy are only set on soft-deleted workspaces.
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	DeletedBy *uuid.UUID `json:"deleted_by,omitempty"`
+}
+
+// NewWorkspace builds a workspace, rejecting a description longer than
+// policy allows.
+func NewWorkspace(ids IDGenerator, policy validation.ContentPolicy, name string, description string, adminId *uuid.UUID) (*Workspace, *errors.Error) {
+	w := &Workspace{
+		ID:        ids.NewID(),
+		Name:      name,
+		AdminID:   adminId,
+		Version:   1,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	if err := w.SetDescription(policy, description); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Rename replaces the name and bumps UpdatedAt, rejecting a blank name or one
+// longer than policy allows. The workspace is unchanged on error.
+func (w *Workspace) Rename(policy validation.ContentPolicy, name string) *errors.Error {
+	if strings.TrimSpace(name) == "" {
+		return domainerrors.InvalidInput("name", "must not be blank")
+	}
+	if maxLength := policy.NameMaxLength; utf8.RuneCountInString(name) > maxLength {
+		return domainerrors.InvalidInput("name", fmt.Sprintf("must be at most %d characters", maxLength))
+	}
+	w.Name = name
+	w.UpdatedAt = time.Now()
+	return nil
 }
 
-func NewWorkspace(name string, description string, adminId *uuid.UUID) *Workspace {
-	return &Workspace{
-		ID:          uuid.New(),
-		Name:        name,
-		Description: description,
-		AdminID:     adminId,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+// SetDescription replaces the description, rejecting one longer than policy
+// allows. Contracts are checked against the same policy; this guard covers
+// every other path.
+func (w *Workspace) SetDescription(policy validation.ContentPolicy, description string) *errors.Error {
+	if maxLength := policy.DescriptionMaxLength; utf8.RuneCountInString(description) > maxLength {
+		return domainerrors.InvalidInput("description", fmt.Sprintf("must be at most %d characters", maxLength))
 	}
+	w.Description = description
+	return nil
 }
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+)
+
+const (
+	workspaceSecretPrefix = "dsws_"
+	secretTokenBytes      = 32
+)
+
+// GenerateWorkspaceSecret returns a new random workspace secret and the hash
+// to store for it. The plaintext is only ever handed to the caller once.
+func GenerateWorkspaceSecret() (secret string, hash string, err error) {
+	secret, err = generateSecretToken(workspaceSecretPrefix)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate workspace secret: %w", err)
+	}
+	return secret, HashWorkspaceSecret(secret), nil
+}
+
+// HashWorkspaceSecret hashes a workspace secret for storage.
+func HashWorkspaceSecret(secret string) string {
+	return hashSecretToken(secret)
+}
+
+// VerifyWorkspaceSecret reports whether secret matches the stored hash.
+func VerifyWorkspaceSecret(secret, hash string) bool {
+	if hash == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(HashWorkspaceSecret(secret)), []byte(hash)) == 1
+}
+
+// generateSecretToken returns prefix followed by 256 random bits, URL-safe encoded.
+func generateSecretToken(prefix string) (string, error) {
+	raw := make([]byte, secretTokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return prefix + base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// hashSecretToken hashes a generated token for storage. Tokens carry 256
+// bits of entropy, so a fast hash is sufficient; a slow KDF is only needed
+// for human-chosen passwords.
+func hashSecretToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
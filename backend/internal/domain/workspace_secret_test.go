@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateWorkspaceSecret(t *testing.T) {
+	secret, hash, err := GenerateWorkspaceSecret()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.HasPrefix(secret, workspaceSecretPrefix) {
+		t.Errorf("expected secret to start with %q, got %q", workspaceSecretPrefix, secret)
+	}
+	if hash == secret || strings.Contains(hash, secret) {
+		t.Error("hash must not contain the plaintext secret")
+	}
+	if !VerifyWorkspaceSecret(secret, hash) {
+		t.Error("expected generated secret to verify against its hash")
+	}
+
+	other, _, err := GenerateWorkspaceSecret()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if other == secret {
+		t.Error("expected distinct secrets across calls")
+	}
+	if VerifyWorkspaceSecret(other, hash) {
+		t.Error("expected a different secret not to verify")
+	}
+}
+
+func TestVerifyWorkspaceSecret_EmptyHash(t *testing.T) {
+	if VerifyWorkspaceSecret("", "") {
+		t.Error("expected an unset hash to reject every secret")
+	}
+}
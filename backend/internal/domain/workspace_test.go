@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"backend/pkg/errors"
+	"backend/pkg/validation"
+)
+
+func TestNewWorkspace_DescriptionLength(t *testing.T) {
+	atLimit := strings.Repeat("é", validation.MaxDescriptionLength)
+	workspace, err := NewWorkspace(RandomIDGenerator{}, validation.DefaultContentPolicy(), "Bounded", atLimit, nil)
+	if err != nil {
+		t.Fatalf("expected a description of exactly %d characters to be accepted, got %v", validation.MaxDescriptionLength, err)
+	}
+	if workspace.Description != atLimit {
+		t.Error("expected the description to be stored")
+	}
+
+	_, err = NewWorkspace(RandomIDGenerator{}, validation.DefaultContentPolicy(), "Too Long", atLimit+"x", nil)
+	if err == nil {
+		t.Fatal("expected an over-long description to be rejected")
+	}
+	if err.Code() != errors.CodeInvalidInput {
+		t.Errorf("expected code %s, got %s", errors.CodeInvalidInput, err.Code())
+	}
+}
+
+func TestWorkspace_SetDescriptionKeepsOldValueOnError(t *testing.T) {
+	workspace, err := NewWorkspace(RandomIDGenerator{}, validation.DefaultContentPolicy(), "Bounded", "original", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := workspace.SetDescription(validation.DefaultContentPolicy(), strings.Repeat("x", validation.MaxDescriptionLength+1)); err == nil {
+		t.Fatal("expected an over-long description to be rejected")
+	}
+	if workspace.Description != "original" {
+		t.Errorf("expected description to stay %q, got %q", "original", workspace.Description)
+	}
+}
+
+func TestWorkspace_Rename(t *testing.T) {
+	workspace, err := NewWorkspace(RandomIDGenerator{}, validation.DefaultContentPolicy(), "Original", "", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	workspace.UpdatedAt = time.Now().Add(-time.Hour)
+	before := workspace.UpdatedAt
+
+	if err := workspace.Rename(validation.DefaultContentPolicy(), "Renamed"); err != nil {
+		t.Fatalf("expected rename to succeed, got %v", err)
+	}
+	if workspace.Name != "Renamed" {
+		t.Errorf("expected name %q, got %q", "Renamed", workspace.Name)
+	}
+	if !workspace.UpdatedAt.After(before) {
+		t.Error("expected rename to bump UpdatedAt")
+	}
+}
+
+func TestWorkspace_RenameRejectsInvalidNames(t *testing.T) {
+	tests := []struct {
+		name    string
+		newName string
+	}{
+		{"blank", "   "},
+		{"empty", ""},
+		{"too long", strings.Repeat("é", validation.MaxNameLength+1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workspace, err := NewWorkspace(RandomIDGenerator{}, validation.DefaultContentPolicy(), "Original", "", nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			before := workspace.UpdatedAt
+
+			err = workspace.Rename(validation.DefaultContentPolicy(), tt.newName)
+			if err == nil {
+				t.Fatal("expected rename to be rejected")
+			}
+			if err.Code() != errors.CodeInvalidInput {
+				t.Errorf("expected code %s, got %s", errors.CodeInvalidInput, err.Code())
+			}
+			if workspace.Name != "Original" || !workspace.UpdatedAt.Equal(before) {
+				t.Error("expected a rejected rename to leave the workspace unchanged")
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package errors
+
+import (
+	"net/http"
+
+	pkgerrors "backend/pkg/errors"
+)
+
+// WrapQueryBuildError maps a failure to build a statement, such as a Squirrel
+// ToSql error, to an internal error. Building never touches the database, so
+// the failure is a bug in the repository rather than bad input or a database
+// fault.
+func WrapQueryBuildError(err error, operation string) *pkgerrors.Error {
+	if err == nil {
+		return nil
+	}
+
+	return pkgerrors.Wrap(err, "failed to build query").
+		WithCode(pkgerrors.CodeInternal).
+		WithMetadata(pkgerrors.MetadataOperation, operation).
+		WithHTTPStatus(http.StatusInternalServerError).
+		WithSeverity(pkgerrors.SeverityError)
+}
@@ -3,6 +3,7 @@ package errors
 import (
 	"database/sql"
 	"net/http"
+	"strings"
 
 	pkgerrors "backend/pkg/errors"
 
@@ -12,10 +13,16 @@ import (
 // SQLite extended result codes we care about.
 const (
 	sqliteConstraintUnique     = 2067
+	sqliteConstraintPrimaryKey = 1555
 	sqliteConstraintForeignKey = 787
 	sqliteConstraintNotNull    = 1299
+	sqliteConstraintCheck      = 275
 )
 
+// checkFailedPrefix precedes the constraint name (or, for unnamed constraints,
+// the CHECK expression) in SQLite's CHECK violation message.
+const checkFailedPrefix = "CHECK constraint failed: "
+
 // WrapSQLiteError maps SQLite errors to *pkgerrors.Error.
 func WrapSQLiteError(err error, operation string) *pkgerrors.Error {
 	if err == nil {
@@ -24,7 +31,7 @@ func WrapSQLiteError(err error, operation string) *pkgerrors.Error {
 
 	if err == sql.ErrNoRows {
 		return pkgerrors.WithCode(pkgerrors.CodeNotFound, "record not found").
-			WithMetadata("operation", operation).
+			WithMetadata(pkgerrors.MetadataOperation, operation).
 			WithSeverity(pkgerrors.SeverityWarning)
 	}
 
@@ -33,18 +40,18 @@ func WrapSQLiteError(err error, operation string) *pkgerrors.Error {
 	}
 
 	return pkgerrors.Wrap(err, "database operation failed").
-		WithMetadata("operation", operation).
+		WithMetadata(pkgerrors.MetadataOperation, operation).
 		WithHTTPStatus(http.StatusInternalServerError).
 		WithSeverity(pkgerrors.SeverityError)
 }
 
 func wrapSQLiteErr(err *sqlite.Error, operation string) *pkgerrors.Error {
 	base := pkgerrors.Wrap(err, "sqlite error").
-		WithMetadata("operation", operation).
-		WithMetadata("sqlite_code", err.Code())
+		WithMetadata(pkgerrors.MetadataOperation, operation).
+		WithMetadata(pkgerrors.MetadataSQLiteCode, err.Code())
 
 	switch int(err.Code()) {
-	case sqliteConstraintUnique:
+	case sqliteConstraintUnique, sqliteConstraintPrimaryKey:
 		return base.
 			WithCode(pkgerrors.CodeConflict).
 			WithHTTPStatus(http.StatusConflict).
@@ -62,6 +69,13 @@ func wrapSQLiteErr(err *sqlite.Error, operation string) *pkgerrors.Error {
 			WithHTTPStatus(http.StatusBadRequest).
 			WithSeverity(pkgerrors.SeverityWarning)
 
+	case sqliteConstraintCheck:
+		return base.
+			WithCode(pkgerrors.CodeValidation).
+			WithHTTPStatus(http.StatusBadRequest).
+			WithSeverity(pkgerrors.SeverityWarning).
+			WithMetadata(pkgerrors.MetadataConstraint, checkConstraintName(err))
+
 	default:
 		return base.
 			WithCode(pkgerrors.CodeDatabase).
@@ -69,3 +83,17 @@ func wrapSQLiteErr(err *sqlite.Error, operation string) *pkgerrors.Error {
 			WithSeverity(pkgerrors.SeverityError)
 	}
 }
+
+// checkConstraintName extracts the failing constraint from a CHECK violation.
+func checkConstraintName(err *sqlite.Error) string {
+	msg := err.Error()
+	i := strings.Index(msg, checkFailedPrefix)
+	if i < 0 {
+		return ""
+	}
+	name := msg[i+len(checkFailedPrefix):]
+	if j := strings.LastIndex(name, " ("); j >= 0 {
+		name = name[:j]
+	}
+	return name
+}
@@ -61,17 +61,58 @@ func (s *LocalFileStorage) DeleteDir(dirPath string) *pkgerrors.Error {
 	return nil
 }
 
+// ReplaceDir moves the current directory aside, renames the staging
+// directory into its place and only then removes the old contents, so a
+// failure part way leaves one complete version in place.
+func (s *LocalFileStorage) ReplaceDir(stagingPath, dirPath string) *pkgerrors.Error {
+	stagingFull := filepath.Join(s.basePath, stagingPath)
+	fullPath := filepath.Join(s.basePath, dirPath)
+	retired := fullPath + ".old"
+
+	if err := os.RemoveAll(retired); err != nil {
+		return apperrors.ReturnInternalError("failed to replace template directory")
+	}
+	hadPrevious := true
+	if err := os.Rename(fullPath, retired); err != nil {
+		if !os.IsNotExist(err) {
+			return apperrors.ReturnInternalError("failed to replace template directory")
+		}
+		hadPrevious = false
+	}
+	if err := os.Rename(stagingFull, fullPath); err != nil {
+		if hadPrevious {
+			os.Rename(retired, fullPath)
+		}
+		return apperrors.ReturnInternalError("failed to replace template directory")
+	}
+
+	os.RemoveAll(retired)
+	return nil
+}
+
 func (s *LocalFileStorage) ListFiles(dirPath string) ([]storage.FileInfo, *pkgerrors.Error) {
+	var files []storage.FileInfo
+	err := s.WalkFiles(dirPath, func(f storage.FileInfo) error {
+		files = append(files, f)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+func (s *LocalFileStorage) WalkFiles(dirPath string, fn func(storage.FileInfo) error) *pkgerrors.Error {
 	fullPath := filepath.Join(s.basePath, dirPath)
 
 	if _, err := os.Stat(fullPath); err != nil {
 		if os.IsNotExist(err) {
-			return nil, nil
+			return nil
 		}
-		return nil, apperrors.ReturnInternalError("failed to list template files")
+		return apperrors.ReturnInternalError("failed to list template files")
 	}
 
-	var files []storage.FileInfo
 	err := filepath.WalkDir(fullPath, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
@@ -87,17 +128,16 @@ func (s *LocalFileStorage) ListFiles(dirPath string) ([]storage.FileInfo, *pkger
 		if relErr != nil {
 			return nil
 		}
-		files = append(files, storage.FileInfo{
+		return fn(storage.FileInfo{
 			Name: filepath.ToSlash(relPath),
 			Size: info.Size(),
 		})
-		return nil
 	})
 	if err != nil {
-		return nil, apperrors.ReturnInternalError("failed to list template files")
+		return apperrors.ReturnInternalError("failed to list template files")
 	}
 
-	return files, nil
+	return nil
 }
 
 func (s *LocalFileStorage) ReadFile(filePath string) ([]byte, *pkgerrors.Error) {
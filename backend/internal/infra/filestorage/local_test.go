@@ -1,6 +1,7 @@
 package filestorage
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"sort"
@@ -103,6 +104,32 @@ func TestListFiles_NonExistentDir(t *testing.T) {
 	}
 }
 
+func TestWalkFiles_StopsOnError(t *testing.T) {
+	base := t.TempDir()
+	s := NewLocalFileStorage(base)
+
+	files := []storage.FileInput{
+		{Name: "a.tf", Reader: strings.NewReader("a"), Size: 1},
+		{Name: "b.tf", Reader: strings.NewReader("b"), Size: 1},
+		{Name: "c.tf", Reader: strings.NewReader("c"), Size: 1},
+	}
+	if err := s.SaveFiles("tmpl-walk", files); err != nil {
+		t.Fatalf("SaveFiles: %v", err)
+	}
+
+	visited := 0
+	err := s.WalkFiles("tmpl-walk", func(storage.FileInfo) error {
+		visited++
+		return errors.New("stop")
+	})
+	if err == nil {
+		t.Fatal("expected the callback error to end the walk")
+	}
+	if visited != 1 {
+		t.Errorf("expected the walk to stop after 1 file, visited %d", visited)
+	}
+}
+
 func TestReadFile_NestedPath(t *testing.T) {
 	base := t.TempDir()
 	s := NewLocalFileStorage(base)
@@ -133,3 +160,48 @@ func TestReadFile_NotFound(t *testing.T) {
 		t.Fatal("expected error for non-existent file")
 	}
 }
+
+func TestReplaceDir(t *testing.T) {
+	base := t.TempDir()
+	s := NewLocalFileStorage(base)
+
+	save := func(dir, name, content string) {
+		t.Helper()
+		if err := s.SaveFiles(dir, []storage.FileInput{{Name: name, Reader: strings.NewReader(content), Size: int64(len(content))}}); err != nil {
+			t.Fatalf("SaveFiles: %v", err)
+		}
+	}
+	save("tmpl", "old.tf", "old")
+	save("tmpl.staging", "new.tf", "new")
+
+	if err := s.ReplaceDir("tmpl.staging", "tmpl"); err != nil {
+		t.Fatalf("ReplaceDir: %v", err)
+	}
+
+	content, err := s.ReadFile("tmpl/new.tf")
+	if err != nil || string(content) != "new" {
+		t.Errorf("expected the staged file in place, got %q (%v)", content, err)
+	}
+	for _, gone := range []string{"tmpl/old.tf", "tmpl.staging", "tmpl.old"} {
+		if _, err := os.Stat(filepath.Join(base, gone)); !os.IsNotExist(err) {
+			t.Errorf("expected %s to be gone, stat returned %v", gone, err)
+		}
+	}
+}
+
+func TestReplaceDir_MissingStagingKeepsCurrent(t *testing.T) {
+	base := t.TempDir()
+	s := NewLocalFileStorage(base)
+	if err := s.SaveFiles("tmpl", []storage.FileInput{{Name: "main.tf", Reader: strings.NewReader("kept"), Size: 4}}); err != nil {
+		t.Fatalf("SaveFiles: %v", err)
+	}
+
+	if err := s.ReplaceDir("missing", "tmpl"); err == nil {
+		t.Fatal("expected an error for a missing staging directory")
+	}
+
+	content, err := s.ReadFile("tmpl/main.tf")
+	if err != nil || string(content) != "kept" {
+		t.Errorf("expected the current files to survive, got %q (%v)", content, err)
+	}
+}
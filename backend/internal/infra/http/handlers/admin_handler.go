@@ -1,11 +1,16 @@
 package handlers
 
 import (
+	"errors"
+	"log/slog"
+
 	"backend/internal/application"
 	handlererrors "backend/internal/application/errors"
 	apphandlers "backend/internal/application/handlers"
+	"backend/internal/domain"
 	"backend/internal/infra/http/middleware"
 	"backend/pkg/contracts"
+	pkgerrors "backend/pkg/errors"
 	"backend/pkg/jwt"
 
 	"github.com/gofiber/fiber/v2"
@@ -17,6 +22,7 @@ type AdminHandler struct {
 	jwtService     *jwt.Service
 	cookieCfg      jwt.CookieConfig
 	adminInitToken string
+	purger         *application.SoftDeletePurger
 }
 
 func NewAdminHandler(serviceFactory func() (*application.AdminService, apphandlers.UnitOfWork), jwtService *jwt.Service, adminInitToken string) *AdminHandler {
@@ -28,8 +34,25 @@ func NewAdminHandler(serviceFactory func() (*application.AdminService, apphandle
 	}
 }
 
-// InitializeSystem handles POST /admin/init
-func (h *AdminHandler) InitializeSystem(c *fiber.Ctx) error {
+// WithCookieConfig replaces the default settings of the auth cookie set on
+// successful initialization. RequireAuth must be given the same config.
+func (h *AdminHandler) WithCookieConfig(cfg jwt.CookieConfig) *AdminHandler {
+	h.cookieCfg = cfg
+	return h
+}
+
+// WithPurger enables the purge report, which previews the soft-delete purge
+// job. Without it the route answers 404.
+func (h *AdminHandler) WithPurger(purger *application.SoftDeletePurger) *AdminHandler {
+	h.purger = purger
+	return h
+}
+
+// InitializeSystem handles POST /admin/init. Requests are recorded with their
+// outcome and client IP, once per outcome and IP within a short window.
+func (h *AdminHandler) InitializeSystem(c *fiber.Ctx) (err error) {
+	defer func() { h.recordInitAttempt(c, err) }()
+
 	// Check optional ADMIN_INIT_TOKEN
 	if h.adminInitToken != "" {
 		providedToken := c.Get("X-Admin-Init-Token")
@@ -41,8 +64,8 @@ func (h *AdminHandler) InitializeSystem(c *fiber.Ctx) error {
 	var request contracts.AdminInit
 
 	// Parse and validate request body
-	if err := c.BodyParser(&request); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	if err := parseBody(c, &request); err != nil {
+		return err
 	}
 
 	// AdminService.InitializeSystem manages the transaction via defer uow.Rollback()
@@ -60,7 +83,44 @@ func (h *AdminHandler) InitializeSystem(c *fiber.Ctx) error {
 	middleware.SetTokenCookie(c, token, h.cookieCfg)
 
 	// Return 201 Created with response
-	return c.Status(fiber.StatusCreated).JSON(response)
+	return respond(c, fiber.StatusCreated, response)
+}
+
+// recordInitAttempt stores an /admin/init request with the outcome err maps
+// to. A failure to record is logged and does not change the response.
+func (h *AdminHandler) recordInitAttempt(c *fiber.Ctx, err error) {
+	service, _ := h.serviceFactory()
+	if recordErr := service.RecordInitAttempt(c.Context(), initAttemptOutcome(err), c.IP()); recordErr != nil {
+		slog.Error("failed to record admin init attempt", "ip", c.IP(), "error", recordErr)
+	}
+}
+
+// initAttemptOutcome classifies the error an /admin/init request ended with.
+func initAttemptOutcome(err error) string {
+	if err == nil {
+		return domain.AdminInitSucceeded
+	}
+
+	status := fiber.StatusInternalServerError
+	var appErr *pkgerrors.Error
+	var fiberErr *fiber.Error
+	switch {
+	case errors.As(err, &appErr):
+		status = appErr.HTTPStatus()
+	case errors.As(err, &fiberErr):
+		status = fiberErr.Code
+	}
+
+	switch {
+	case status == fiber.StatusUnauthorized:
+		return domain.AdminInitUnauthorized
+	case status == fiber.StatusConflict:
+		return domain.AdminInitConflict
+	case status < fiber.StatusInternalServerError:
+		return domain.AdminInitInvalid
+	default:
+		return domain.AdminInitFailed
+	}
 }
 
 // GetSystemStatus handles GET /admin/status
@@ -70,34 +130,70 @@ func (h *AdminHandler) GetSystemStatus(c *fiber.Ctx) error {
 	if err != nil {
 		return fiber.NewError(fiber.StatusInternalServerError, "Failed to check system status")
 	}
-	return c.JSON(fiber.Map{
+	return respond(c, fiber.StatusOK, fiber.Map{
 		"initialized": initialized,
 	})
 }
 
 // RegisterAdminRoutes registers admin-only user management routes.
 func (h *AdminHandler) RegisterAdminRoutes(router fiber.Router) {
+	router.Get("/admin/init-attempts", h.ListInitAttempts)
 	router.Get("/admin/users", h.ListUsers)
+	router.Get("/admin/users/by-email", h.GetUserByEmail)
 	router.Post("/admin/users/invite", h.InviteUser)
+	router.Post("/admin/users/invite/batch", h.InviteUsers)
 	router.Post("/admin/users/:id/reset-password", h.ResetPassword)
 	router.Delete("/admin/users/:id", h.DeleteUser)
+	router.Post("/users/:id/move", h.MoveUser)
+	router.Get("/admin/workspaces/deleted", h.ListDeletedWorkspaces)
+	router.Get("/admin/workspaces/purge-report", h.PurgeReport)
+	router.Post("/admin/workspaces/:id/restore", h.RestoreWorkspace)
 }
 
-// ListUsers handles GET /admin/users
+// ListUsers handles GET /admin/users. With inactive_since the listing is
+// paged, like the other admin lists.
 func (h *AdminHandler) ListUsers(c *fiber.Ctx) error {
+	var request contracts.ListUsers
+	if err := bindQuery(c, &request); err != nil {
+		return err
+	}
+
+	service, uow := h.serviceFactory()
+	if request.InactiveSince != "" {
+		page, serviceErr := service.ListInactiveUsers(middleware.ContextWithClaims(c), uow, request)
+		if serviceErr != nil {
+			return serviceErr
+		}
+		return respond(c, fiber.StatusOK, page)
+	}
+
+	users, serviceErr := service.ListUsers(middleware.ContextWithClaims(c), request)
+	if serviceErr != nil {
+		return serviceErr
+	}
+	return respond(c, fiber.StatusOK, users)
+}
+
+// GetUserByEmail handles GET /admin/users/by-email
+func (h *AdminHandler) GetUserByEmail(c *fiber.Ctx) error {
+	var request contracts.GetUserByEmail
+	if err := c.QueryParser(&request); err != nil {
+		return handlererrors.ReturnBadRequest("Invalid query parameters")
+	}
+
 	service, _ := h.serviceFactory()
-	users, serviceErr := service.ListUsers(middleware.ContextWithClaims(c))
+	user, serviceErr := service.GetUserByEmail(middleware.ContextWithClaims(c), request)
 	if serviceErr != nil {
 		return serviceErr
 	}
-	return c.JSON(users)
+	return respond(c, fiber.StatusOK, user)
 }
 
 // InviteUser handles POST /admin/users/invite
 func (h *AdminHandler) InviteUser(c *fiber.Ctx) error {
 	var request contracts.InviteUser
-	if err := c.BodyParser(&request); err != nil {
-		return handlererrors.ReturnBadRequest("Invalid request body")
+	if err := parseBody(c, &request); err != nil {
+		return err
 	}
 
 	service, uow := h.serviceFactory()
@@ -106,7 +202,26 @@ func (h *AdminHandler) InviteUser(c *fiber.Ctx) error {
 		return serviceErr
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(response)
+	return respond(c, fiber.StatusCreated, response)
+}
+
+// InviteUsers handles POST /admin/users/invite/batch
+func (h *AdminHandler) InviteUsers(c *fiber.Ctx) error {
+	var request contracts.InviteUsers
+	if err := parseBody(c, &request); err != nil {
+		return err
+	}
+
+	service, uow := h.serviceFactory()
+	response, serviceErr := service.InviteUsers(middleware.ContextWithClaims(c), uow, request)
+	if serviceErr != nil {
+		return serviceErr
+	}
+	response.FormatErrors(func(err *pkgerrors.Error) contracts.ErrorDetail {
+		return handlererrors.NewErrorDetail(c, err)
+	})
+
+	return respond(c, response.HTTPStatus(), response)
 }
 
 // ResetPassword handles POST /admin/users/:id/reset-password
@@ -122,7 +237,7 @@ func (h *AdminHandler) ResetPassword(c *fiber.Ctx) error {
 		return serviceErr
 	}
 
-	return c.JSON(response)
+	return respond(c, fiber.StatusOK, response)
 }
 
 // DeleteUser handles DELETE /admin/users/:id
@@ -139,3 +254,88 @@ func (h *AdminHandler) DeleteUser(c *fiber.Ctx) error {
 
 	return c.SendStatus(fiber.StatusNoContent)
 }
+
+// MoveUser handles POST /api/v1/users/:id/move
+func (h *AdminHandler) MoveUser(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return handlererrors.ReturnBadRequest("invalid user ID")
+	}
+
+	var request contracts.MoveUser
+	if err := parseBody(c, &request); err != nil {
+		return err
+	}
+	request.UserID = userID
+
+	service, uow := h.serviceFactory()
+	user, serviceErr := service.MoveUser(middleware.ContextWithClaims(c), uow, request)
+	if serviceErr != nil {
+		return serviceErr
+	}
+
+	return respond(c, fiber.StatusOK, user)
+}
+
+// ListInitAttempts handles GET /admin/init-attempts
+func (h *AdminHandler) ListInitAttempts(c *fiber.Ctx) error {
+	var request contracts.ListAdminInitAttempts
+	if err := c.QueryParser(&request); err != nil {
+		return handlererrors.ReturnBadRequest("Invalid query parameters")
+	}
+
+	service, uow := h.serviceFactory()
+	page, serviceErr := service.ListInitAttempts(middleware.ContextWithClaims(c), uow, request)
+	if serviceErr != nil {
+		return serviceErr
+	}
+	return respond(c, fiber.StatusOK, page)
+}
+
+// ListDeletedWorkspaces handles GET /admin/workspaces/deleted
+func (h *AdminHandler) ListDeletedWorkspaces(c *fiber.Ctx) error {
+	var request contracts.ListDeletedWorkspaces
+	if err := c.QueryParser(&request); err != nil {
+		return handlererrors.ReturnBadRequest("Invalid query parameters")
+	}
+
+	service, uow := h.serviceFactory()
+	page, serviceErr := service.ListDeletedWorkspaces(middleware.ContextWithClaims(c), uow, request)
+	if serviceErr != nil {
+		return serviceErr
+	}
+	return respond(c, fiber.StatusOK, page)
+}
+
+// PurgeReport handles GET /admin/workspaces/purge-report
+func (h *AdminHandler) PurgeReport(c *fiber.Ctx) error {
+	if h.purger == nil {
+		return fiber.ErrNotFound
+	}
+
+	var request contracts.PurgeReport
+	if err := bindQuery(c, &request); err != nil {
+		return err
+	}
+
+	report, serviceErr := h.purger.PurgeReport(middleware.ContextWithClaims(c), request)
+	if serviceErr != nil {
+		return serviceErr
+	}
+	return respond(c, fiber.StatusOK, report)
+}
+
+// RestoreWorkspace handles POST /admin/workspaces/:id/restore
+func (h *AdminHandler) RestoreWorkspace(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return handlererrors.ReturnBadRequest("invalid workspace ID")
+	}
+
+	service, uow := h.serviceFactory()
+	workspace, serviceErr := service.RestoreWorkspace(middleware.ContextWithClaims(c), uow, contracts.RestoreWorkspace{ID: id})
+	if serviceErr != nil {
+		return serviceErr
+	}
+	return respond(c, fiber.StatusOK, workspace)
+}
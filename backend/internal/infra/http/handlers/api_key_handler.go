@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"backend/internal/application"
+	"backend/internal/infra/http/middleware"
+	"backend/pkg/contracts"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type APIKeyHandler struct {
+	serviceFactory func() application.APIKeyService
+}
+
+func NewAPIKeyHandler(serviceFactory func() application.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{serviceFactory: serviceFactory}
+}
+
+func (h *APIKeyHandler) RegisterRoutes(router fiber.Router) {
+	router.Post("/workspaces/:id/api-keys", h.CreateAPIKey)
+	router.Get("/workspaces/:id/api-keys", h.ListAPIKeys)
+	router.Delete("/workspaces/:id/api-keys/:key_id", h.RevokeAPIKey)
+}
+
+// CreateAPIKey handles POST /api/v1/workspaces/:id/api-keys
+func (h *APIKeyHandler) CreateAPIKey(c *fiber.Ctx) error {
+	workspaceID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid workspace ID")
+	}
+
+	var request contracts.CreateAPIKey
+	if err := parseBody(c, &request); err != nil {
+		return err
+	}
+	request.WorkspaceID = workspaceID
+
+	service := h.serviceFactory()
+	response, serviceErr := service.CreateAPIKey(middleware.ContextWithClaims(c), request)
+	if serviceErr != nil {
+		return serviceErr
+	}
+
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return respond(c, fiber.StatusCreated, response)
+}
+
+// ListAPIKeys handles GET /api/v1/workspaces/:id/api-keys
+func (h *APIKeyHandler) ListAPIKeys(c *fiber.Ctx) error {
+	workspaceID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid workspace ID")
+	}
+
+	service := h.serviceFactory()
+	keys, serviceErr := service.ListAPIKeys(middleware.ContextWithClaims(c), workspaceID)
+	if serviceErr != nil {
+		return serviceErr
+	}
+
+	return respond(c, fiber.StatusOK, keys)
+}
+
+// RevokeAPIKey handles DELETE /api/v1/workspaces/:id/api-keys/:key_id
+func (h *APIKeyHandler) RevokeAPIKey(c *fiber.Ctx) error {
+	workspaceID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid workspace ID")
+	}
+	keyID, err := uuid.Parse(c.Params("key_id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid API key ID")
+	}
+
+	service := h.serviceFactory()
+	if serviceErr := service.RevokeAPIKey(middleware.ContextWithClaims(c), workspaceID, keyID); serviceErr != nil {
+		return serviceErr
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"backend/internal/infra/http/middleware"
+	"backend/pkg/contracts"
+	"backend/pkg/jwt"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/limiter"
+)
+
+// DefaultIntrospectRateLimit is how many introspection requests one client IP
+// may make per minute.
+const DefaultIntrospectRateLimit = 60
+
+type AuthHandler struct {
+	jwtService        *jwt.Service
+	revocationChecker middleware.TokenRevocationChecker
+	rateLimit         int
+}
+
+// NewAuthHandler creates the introspection handler. revocationChecker is the
+// same checker the protected routes use, so a token they would reject is
+// never reported active.
+func NewAuthHandler(jwtService *jwt.Service, revocationChecker middleware.TokenRevocationChecker) *AuthHandler {
+	return &AuthHandler{
+		jwtService:        jwtService,
+		revocationChecker: revocationChecker,
+		rateLimit:         DefaultIntrospectRateLimit,
+	}
+}
+
+// WithRateLimit sets how many introspection requests one client IP may make
+// per minute.
+func (h *AuthHandler) WithRateLimit(perMinute int) *AuthHandler {
+	if perMinute > 0 {
+		h.rateLimit = perMinute
+	}
+	return h
+}
+
+// RegisterRoutes registers the introspection route. Gateways call it with
+// tokens they have not validated themselves, so it is public and rate-limited
+// per client IP instead.
+func (h *AuthHandler) RegisterRoutes(router fiber.Router) {
+	router.Post("/auth/introspect", limiter.New(limiter.Config{
+		Max:        h.rateLimit,
+		Expiration: time.Minute,
+		LimitReached: func(c *fiber.Ctx) error {
+			return fiber.NewError(fiber.StatusTooManyRequests, "too many introspection requests")
+		},
+	}), h.Introspect)
+}
+
+// Introspect handles POST /api/v1/auth/introspect. The token comes from the
+// body, or from the Authorization header when the body has none. A token that
+// fails validation or has been revoked is reported inactive rather than
+// answered with an error.
+func (h *AuthHandler) Introspect(c *fiber.Ctx) error {
+	var request contracts.IntrospectToken
+	if len(c.Body()) > 0 {
+		if err := parseBody(c, &request); err != nil {
+			return err
+		}
+	}
+
+	token := request.Token
+	if token == "" {
+		token = bearerToken(c.Get(fiber.HeaderAuthorization))
+	}
+	if token == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "token is required")
+	}
+
+	claims, err := h.jwtService.ValidateToken(token)
+	if err != nil {
+		return respond(c, fiber.StatusOK, contracts.TokenIntrospection{Active: false})
+	}
+	if err := h.revocationChecker.Check(c.Context(), claims); err != nil {
+		if errors.Is(err, jwt.ErrRevokedToken) {
+			return respond(c, fiber.StatusOK, contracts.TokenIntrospection{Active: false})
+		}
+		return err
+	}
+
+	introspection := contracts.TokenIntrospection{
+		Active:      true,
+		UserID:      claims.ID,
+		WorkspaceID: claims.WorkspaceID,
+	}
+	if claims.ExpiresAt != nil {
+		introspection.Exp = claims.ExpiresAt.Unix()
+	}
+	return respond(c, fiber.StatusOK, introspection)
+}
+
+// bearerToken returns the token of an "Authorization: Bearer <token>" header.
+func bearerToken(header string) string {
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
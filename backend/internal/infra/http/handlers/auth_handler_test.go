@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	handlererrors "backend/internal/application/errors"
+	"backend/pkg/contracts"
+	"backend/pkg/errors"
+	"backend/pkg/jwt"
+
+	"github.com/gofiber/fiber/v2"
+	jwtlib "github.com/golang-jwt/jwt/v5"
+)
+
+const testSecret = "this-is-a-very-secure-secret-key-for-testing-purposes"
+
+// revokedUsers reports every token of the listed users as revoked.
+type revokedUsers map[string]bool
+
+func (r revokedUsers) Check(_ context.Context, claims *jwt.Claims) *errors.Error {
+	if r[claims.ID] {
+		return jwt.ErrRevokedToken
+	}
+	return nil
+}
+
+func newIntrospectApp(t *testing.T, rateLimit int) (*fiber.App, *jwt.Service) {
+	return newIntrospectAppWithRevoked(t, rateLimit, revokedUsers{})
+}
+
+func newIntrospectAppWithRevoked(t *testing.T, rateLimit int, revoked revokedUsers) (*fiber.App, *jwt.Service) {
+	t.Helper()
+	jwtService, err := jwt.NewService(testSecret)
+	if err != nil {
+		t.Fatalf("failed to create jwt service: %v", err)
+	}
+	app := fiber.New(fiber.Config{ErrorHandler: handlererrors.ErrorHandler()})
+	NewAuthHandler(jwtService, revoked).WithRateLimit(rateLimit).RegisterRoutes(app)
+	return app, jwtService
+}
+
+func introspect(t *testing.T, app *fiber.App, body, authorization string) (int, contracts.TokenIntrospection) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/auth/introspect", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var result contracts.TokenIntrospection
+	if resp.StatusCode == http.StatusOK {
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+	}
+	return resp.StatusCode, result
+}
+
+func tokenBody(token string) string {
+	body, _ := json.Marshal(contracts.IntrospectToken{Token: token})
+	return string(body)
+}
+
+func TestIntrospect_ValidToken(t *testing.T) {
+	app, jwtService := newIntrospectApp(t, 100)
+	token, err := jwtService.GenerateToken("user-1", "Test User", "user", "workspace-1")
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+
+	for name, send := range map[string]func() (int, contracts.TokenIntrospection){
+		"body":   func() (int, contracts.TokenIntrospection) { return introspect(t, app, tokenBody(token), "") },
+		"header": func() (int, contracts.TokenIntrospection) { return introspect(t, app, "", "Bearer "+token) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			status, result := send()
+			if status != http.StatusOK {
+				t.Fatalf("expected 200, got %d", status)
+			}
+			if !result.Active || result.UserID != "user-1" || result.WorkspaceID != "workspace-1" {
+				t.Errorf("unexpected introspection: %+v", result)
+			}
+			if exp := time.Unix(result.Exp, 0); exp.Before(time.Now()) || exp.After(time.Now().Add(jwt.DefaultTokenDuration+time.Minute)) {
+				t.Errorf("unexpected exp %v", exp)
+			}
+		})
+	}
+}
+
+func TestIntrospect_InactiveTokens(t *testing.T) {
+	app, _ := newIntrospectApp(t, 100)
+
+	past := time.Now().Add(-2 * time.Hour)
+	expired, err := jwtlib.NewWithClaims(jwtlib.SigningMethodHS256, jwt.Claims{
+		ID:          "user-1",
+		WorkspaceID: "workspace-1",
+		RegisteredClaims: jwtlib.RegisteredClaims{
+			ExpiresAt: jwtlib.NewNumericDate(past),
+			IssuedAt:  jwtlib.NewNumericDate(past.Add(-time.Hour)),
+		},
+	}).SignedString([]byte(testSecret))
+	if err != nil {
+		t.Fatalf("failed to sign expired token: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"expired", expired},
+		{"malformed", "not.a.jwt"},
+		{"wrong signature", expired[:strings.LastIndex(expired, ".")] + ".c2lnbmF0dXJl"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, result := introspect(t, app, tokenBody(tt.token), "")
+			if status != http.StatusOK {
+				t.Fatalf("expected 200, got %d", status)
+			}
+			if result != (contracts.TokenIntrospection{Active: false}) {
+				t.Errorf("expected only active=false, got %+v", result)
+			}
+		})
+	}
+}
+
+func TestIntrospect_RevokedToken(t *testing.T) {
+	app, jwtService := newIntrospectAppWithRevoked(t, 100, revokedUsers{"user-1": true})
+	token, err := jwtService.GenerateToken("user-1", "Test User", "user", "workspace-1")
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+
+	status, result := introspect(t, app, tokenBody(token), "")
+	if status != http.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+	if result != (contracts.TokenIntrospection{Active: false}) {
+		t.Errorf("expected only active=false for a revoked token, got %+v", result)
+	}
+}
+
+func TestIntrospect_MissingToken(t *testing.T) {
+	app, _ := newIntrospectApp(t, 100)
+
+	if status, _ := introspect(t, app, "", ""); status != http.StatusBadRequest {
+		t.Errorf("expected 400 without a token, got %d", status)
+	}
+}
+
+func TestIntrospect_RateLimited(t *testing.T) {
+	app, _ := newIntrospectApp(t, 2)
+
+	for i := 0; i < 2; i++ {
+		if status, _ := introspect(t, app, tokenBody("not.a.jwt"), ""); status != http.StatusOK {
+			t.Fatalf("request %d: expected 200, got %d", i+1, status)
+		}
+	}
+	if status, _ := introspect(t, app, tokenBody("not.a.jwt"), ""); status != http.StatusTooManyRequests {
+		t.Errorf("expected 429 past the limit, got %d", status)
+	}
+}
@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+
+	handlererrors "backend/internal/application/errors"
+	pkgerrors "backend/pkg/errors"
+	"backend/pkg/jsonutil"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// parseBody decodes the request body into dst. A body that repeats a key is
+// rejected with a 400 naming the key; any other decode failure is reported as
+// an invalid body.
+func parseBody(c *fiber.Ctx, dst any) *pkgerrors.Error {
+	err := c.BodyParser(dst)
+	if err == nil {
+		return nil
+	}
+
+	var duplicate *jsonutil.DuplicateKeyError
+	if errors.As(err, &duplicate) {
+		return handlererrors.ReturnBadRequest(fmt.Sprintf("duplicate key %q in request body", duplicate.Key)).
+			WithMetadata(pkgerrors.MetadataKey, duplicate.Key)
+	}
+	return handlererrors.ReturnBadRequest("Invalid request body")
+}
@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"runtime"
+	"time"
+
+	apphandlers "backend/internal/application/handlers"
+	"backend/pkg/buildinfo"
+	"backend/pkg/contracts"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type DebugHandler struct {
+	startedAt time.Time
+}
+
+func NewDebugHandler(startedAt time.Time) *DebugHandler {
+	return &DebugHandler{startedAt: startedAt}
+}
+
+// RegisterRoutes registers diagnostics routes. They expose process internals,
+// so the router passed in must be admin-gated.
+func (h *DebugHandler) RegisterRoutes(router fiber.Router) {
+	router.Get("/debug/info", h.GetInfo)
+}
+
+// GetInfo handles GET /api/v1/debug/info
+func (h *DebugHandler) GetInfo(c *fiber.Ctx) error {
+	commitsWithoutBegin, leftOpen := apphandlers.TxAnomalies()
+	return respond(c, fiber.StatusOK, contracts.DebugInfoResponse{
+		Version:              buildinfo.Version,
+		Commit:               buildinfo.Commit,
+		GoVersion:            runtime.Version(),
+		UptimeSeconds:        time.Since(h.startedAt).Seconds(),
+		Goroutines:           runtime.NumGoroutine(),
+		CommitsWithoutBegin:  commitsWithoutBegin,
+		TransactionsLeftOpen: leftOpen,
+	})
+}
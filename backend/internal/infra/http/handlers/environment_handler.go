@@ -1,9 +1,13 @@
 package handlers
 
 import (
+	"context"
+
 	"backend/internal/application"
+	"backend/internal/domain"
 	"backend/internal/infra/http/middleware"
 	"backend/pkg/contracts"
+	"backend/pkg/errors"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -11,10 +15,19 @@ import (
 
 type EnvironmentHandler struct {
 	serviceFactory func() application.EnvironmentService
+	crossTenant    application.CrossTenantDenial
 }
 
 func NewEnvironmentHandler(serviceFactory func() application.EnvironmentService) *EnvironmentHandler {
-	return &EnvironmentHandler{serviceFactory: serviceFactory}
+	return &EnvironmentHandler{serviceFactory: serviceFactory, crossTenant: application.CrossTenantNotFound}
+}
+
+// WithCrossTenantDenial answers requests for environments in another
+// workspace as d instead of the default CrossTenantNotFound. It should match
+// the services' policy.
+func (h *EnvironmentHandler) WithCrossTenantDenial(d application.CrossTenantDenial) *EnvironmentHandler {
+	h.crossTenant = d
+	return h
 }
 
 func (h *EnvironmentHandler) RegisterRoutes(router fiber.Router) {
@@ -26,12 +39,13 @@ func (h *EnvironmentHandler) RegisterRoutes(router fiber.Router) {
 	router.Post("/environments/:id/apply", h.ApplyEnvironment)
 	router.Post("/environments/:id/destroy", h.DestroyEnvironment)
 	router.Delete("/environments/:id", h.DeleteEnvironment)
+	router.Post("/environments/:id/restore", h.RestoreEnvironment)
 }
 
 func (h *EnvironmentHandler) CreateEnvironment(c *fiber.Ctx) error {
 	var request contracts.CreateEnvironment
-	if err := c.BodyParser(&request); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	if err := parseBody(c, &request); err != nil {
+		return err
 	}
 
 	service := h.serviceFactory()
@@ -40,22 +54,22 @@ func (h *EnvironmentHandler) CreateEnvironment(c *fiber.Ctx) error {
 		return serviceErr
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(env)
+	return respondCreated(c, env.ID, env)
 }
 
 func (h *EnvironmentHandler) GetEnvironment(c *fiber.Ctx) error {
-	id, err := uuid.Parse(c.Params("id"))
-	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, "Invalid environment ID")
-	}
-
 	service := h.serviceFactory()
-	env, serviceErr := service.GetEnvironment(middleware.ContextWithClaims(c), contracts.GetEnvironment{ID: id})
-	if serviceErr != nil {
-		return serviceErr
+	env, err := loadOwned(c, h.crossTenant, "Environment",
+		func(ctx context.Context, id uuid.UUID) (*domain.Environment, *errors.Error) {
+			return service.GetEnvironment(ctx, contracts.GetEnvironment{ID: id})
+		},
+		func(e *domain.Environment) uuid.UUID { return e.WorkspaceID },
+	)
+	if err != nil {
+		return err
 	}
 
-	return c.JSON(env)
+	return respond(c, fiber.StatusOK, env)
 }
 
 func (h *EnvironmentHandler) ListEnvironments(c *fiber.Ctx) error {
@@ -65,12 +79,22 @@ func (h *EnvironmentHandler) ListEnvironments(c *fiber.Ctx) error {
 	}
 
 	service := h.serviceFactory()
-	envs, serviceErr := service.ListEnvironments(middleware.ContextWithClaims(c), request)
+
+	// Without a limit keep the legacy response: a bare array of environments
+	if c.Query("limit") == "" {
+		envs, serviceErr := service.ListEnvironments(middleware.ContextWithClaims(c), request)
+		if serviceErr != nil {
+			return serviceErr
+		}
+		return respond(c, fiber.StatusOK, envs)
+	}
+
+	page, serviceErr := service.ListEnvironmentsPage(middleware.ContextWithClaims(c), request)
 	if serviceErr != nil {
 		return serviceErr
 	}
 
-	return c.JSON(envs)
+	return respond(c, fiber.StatusOK, page)
 }
 
 func (h *EnvironmentHandler) PlanEnvironment(c *fiber.Ctx) error {
@@ -85,7 +109,7 @@ func (h *EnvironmentHandler) PlanEnvironment(c *fiber.Ctx) error {
 		return serviceErr
 	}
 
-	return c.Status(fiber.StatusAccepted).JSON(env)
+	return respond(c, fiber.StatusAccepted, env)
 }
 
 func (h *EnvironmentHandler) ApplyEnvironment(c *fiber.Ctx) error {
@@ -100,7 +124,7 @@ func (h *EnvironmentHandler) ApplyEnvironment(c *fiber.Ctx) error {
 		return serviceErr
 	}
 
-	return c.Status(fiber.StatusAccepted).JSON(env)
+	return respond(c, fiber.StatusAccepted, env)
 }
 
 func (h *EnvironmentHandler) DestroyEnvironment(c *fiber.Ctx) error {
@@ -115,7 +139,7 @@ func (h *EnvironmentHandler) DestroyEnvironment(c *fiber.Ctx) error {
 		return serviceErr
 	}
 
-	return c.Status(fiber.StatusAccepted).JSON(env)
+	return respond(c, fiber.StatusAccepted, env)
 }
 
 func (h *EnvironmentHandler) GetEnvironmentOutputs(c *fiber.Ctx) error {
@@ -130,7 +154,7 @@ func (h *EnvironmentHandler) GetEnvironmentOutputs(c *fiber.Ctx) error {
 		return serviceErr
 	}
 
-	return c.JSON(outputs)
+	return respond(c, fiber.StatusOK, outputs)
 }
 
 func (h *EnvironmentHandler) DeleteEnvironment(c *fiber.Ctx) error {
@@ -146,3 +170,19 @@ func (h *EnvironmentHandler) DeleteEnvironment(c *fiber.Ctx) error {
 
 	return c.SendStatus(fiber.StatusNoContent)
 }
+
+// RestoreEnvironment handles POST /api/v1/environments/:id/restore
+func (h *EnvironmentHandler) RestoreEnvironment(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid environment ID")
+	}
+
+	service := h.serviceFactory()
+	env, serviceErr := service.RestoreEnvironment(middleware.ContextWithClaims(c), contracts.RestoreEnvironment{ID: id})
+	if serviceErr != nil {
+		return serviceErr
+	}
+
+	return respond(c, fiber.StatusOK, env)
+}
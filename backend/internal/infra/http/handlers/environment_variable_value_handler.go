@@ -29,8 +29,8 @@ func (h *EnvironmentVariableValueHandler) SetVariableValues(c *fiber.Ctx) error
 	}
 
 	var request contracts.SetEnvironmentVariableValues
-	if err := c.BodyParser(&request); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	if err := parseBody(c, &request); err != nil {
+		return err
 	}
 	request.EnvironmentID = environmentID
 
@@ -54,5 +54,5 @@ func (h *EnvironmentVariableValueHandler) GetVariableValues(c *fiber.Ctx) error
 		return serviceErr
 	}
 
-	return c.JSON(values)
+	return respond(c, fiber.StatusOK, values)
 }
@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// filterQueryPrefix marks list query parameters that are equality filters,
+// e.g. ?filter.name=network
+const filterQueryPrefix = "filter."
+
+// queryFilters collects filter.<column>=<value> query parameters. Column names
+// are validated against each repository's whitelist further down.
+func queryFilters(c *fiber.Ctx) map[string]string {
+	var filters map[string]string
+	for key, value := range c.Queries() {
+		column, ok := strings.CutPrefix(key, filterQueryPrefix)
+		if !ok {
+			continue
+		}
+		if filters == nil {
+			filters = make(map[string]string)
+		}
+		filters[column] = value
+	}
+	return filters
+}
@@ -33,8 +33,8 @@ func (h *GroupHandler) RegisterRoutes(router fiber.Router) {
 
 func (h *GroupHandler) CreateGroup(c *fiber.Ctx) error {
 	var request contracts.CreateGroup
-	if err := c.BodyParser(&request); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	if err := parseBody(c, &request); err != nil {
+		return err
 	}
 
 	service := h.serviceFactory()
@@ -43,7 +43,7 @@ func (h *GroupHandler) CreateGroup(c *fiber.Ctx) error {
 		return serviceErr
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(group)
+	return respond(c, fiber.StatusCreated, group)
 }
 
 func (h *GroupHandler) GetGroup(c *fiber.Ctx) error {
@@ -58,7 +58,7 @@ func (h *GroupHandler) GetGroup(c *fiber.Ctx) error {
 		return serviceErr
 	}
 
-	return c.JSON(group)
+	return respond(c, fiber.StatusOK, group)
 }
 
 func (h *GroupHandler) ListGroups(c *fiber.Ctx) error {
@@ -68,7 +68,7 @@ func (h *GroupHandler) ListGroups(c *fiber.Ctx) error {
 		return serviceErr
 	}
 
-	return c.JSON(groups)
+	return respond(c, fiber.StatusOK, groups)
 }
 
 func (h *GroupHandler) UpdateGroup(c *fiber.Ctx) error {
@@ -78,8 +78,8 @@ func (h *GroupHandler) UpdateGroup(c *fiber.Ctx) error {
 	}
 
 	var request contracts.UpdateGroup
-	if err := c.BodyParser(&request); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	if err := parseBody(c, &request); err != nil {
+		return err
 	}
 	request.ID = id
 
@@ -89,7 +89,7 @@ func (h *GroupHandler) UpdateGroup(c *fiber.Ctx) error {
 		return serviceErr
 	}
 
-	return c.JSON(group)
+	return respond(c, fiber.StatusOK, group)
 }
 
 func (h *GroupHandler) DeleteGroup(c *fiber.Ctx) error {
@@ -113,8 +113,8 @@ func (h *GroupHandler) AddMembers(c *fiber.Ctx) error {
 	}
 
 	var request contracts.AddGroupMembers
-	if err := c.BodyParser(&request); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	if err := parseBody(c, &request); err != nil {
+		return err
 	}
 
 	service := h.serviceFactory()
@@ -137,7 +137,7 @@ func (h *GroupHandler) GetMembers(c *fiber.Ctx) error {
 		return serviceErr
 	}
 
-	return c.JSON(members)
+	return respond(c, fiber.StatusOK, members)
 }
 
 func (h *GroupHandler) RemoveMember(c *fiber.Ctx) error {
@@ -166,8 +166,8 @@ func (h *GroupHandler) AddTemplateAccess(c *fiber.Ctx) error {
 	}
 
 	var request contracts.AddGroupTemplateAccess
-	if err := c.BodyParser(&request); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	if err := parseBody(c, &request); err != nil {
+		return err
 	}
 
 	service := h.serviceFactory()
@@ -190,7 +190,7 @@ func (h *GroupHandler) GetTemplateAccess(c *fiber.Ctx) error {
 		return serviceErr
 	}
 
-	return c.JSON(templateIDs)
+	return respond(c, fiber.StatusOK, templateIDs)
 }
 
 func (h *GroupHandler) RemoveTemplateAccess(c *fiber.Ctx) error {
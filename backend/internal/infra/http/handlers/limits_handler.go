@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"backend/pkg/contracts"
+	"backend/pkg/validation"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type LimitsHandler struct {
+	contentPolicy validation.ContentPolicy
+}
+
+func NewLimitsHandler(contentPolicy validation.ContentPolicy) *LimitsHandler {
+	return &LimitsHandler{contentPolicy: contentPolicy}
+}
+
+// RegisterRoutes registers the limits route. It reveals nothing sensitive and
+// is needed before login (e.g. by the registration form), so it is public.
+func (h *LimitsHandler) RegisterRoutes(router fiber.Router) {
+	router.Get("/limits", h.GetLimits)
+}
+
+// GetLimits handles GET /api/v1/limits
+func (h *LimitsHandler) GetLimits(c *fiber.Ctx) error {
+	return respond(c, fiber.StatusOK, contracts.LimitsResponse{
+		MaxNameLength: h.contentPolicy.NameMaxLength,
+	})
+}
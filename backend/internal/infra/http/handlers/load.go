@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"context"
+
+	"backend/internal/application"
+	handlererrors "backend/internal/application/errors"
+	"backend/internal/infra/http/middleware"
+	pkgerrors "backend/pkg/errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// loadOwned parses the :id route parameter, loads the resource with fetch and
+// checks it belongs to the caller's workspace. fetch receives the request
+// context with claims attached, so services keep doing their own checks; the
+// workspace comparison here makes sure a handler never serves a resource from
+// another workspace even if one is missed.
+//
+// A malformed id is a 400 and fetch's error is returned as is. A resource in
+// another workspace is logged as an authorization denial and answered as
+// denial says, like the services do.
+func loadOwned[T any](c *fiber.Ctx, denial application.CrossTenantDenial, resource string, fetch func(ctx context.Context, id uuid.UUID) (T, *pkgerrors.Error), workspaceOf func(T) uuid.UUID) (T, *pkgerrors.Error) {
+	var zero T
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return zero, handlererrors.ReturnBadRequest("Invalid ID")
+	}
+
+	claims, ok := middleware.GetClaims(c)
+	if !ok {
+		return zero, handlererrors.ReturnMissingClaims()
+	}
+
+	loaded, fetchErr := fetch(middleware.ContextWithClaims(c), id)
+	if fetchErr != nil {
+		return zero, fetchErr
+	}
+
+	if workspaceID := workspaceOf(loaded).String(); workspaceID != claims.WorkspaceID {
+		return zero, denial.Deny(claims, resource, id.String(), workspaceID, "resource does not belong to your workspace")
+	}
+	return loaded, nil
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"backend/internal/application"
+	handlererrors "backend/internal/application/errors"
+	domainerrors "backend/internal/domain/errors"
+	"backend/internal/infra/http/middleware"
+	pkgerrors "backend/pkg/errors"
+	"backend/pkg/jwt"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type ownedThing struct {
+	ID          uuid.UUID
+	WorkspaceID uuid.UUID
+}
+
+// newLoadOwnedApp serves GET /things/:id through loadOwned for a caller in
+// callerWorkspace, looking things up in store and answering cross-workspace
+// requests as denial.
+func newLoadOwnedApp(callerWorkspace uuid.UUID, store map[uuid.UUID]*ownedThing, denial application.CrossTenantDenial) *fiber.App {
+	app := fiber.New(fiber.Config{ErrorHandler: handlererrors.ErrorHandler()})
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals(middleware.ClaimsKey, &jwt.Claims{ID: uuid.NewString(), Role: "user", WorkspaceID: callerWorkspace.String()})
+		return c.Next()
+	})
+	app.Get("/things/:id", func(c *fiber.Ctx) error {
+		thing, err := loadOwned(c, denial, "Thing",
+			func(ctx context.Context, id uuid.UUID) (*ownedThing, *pkgerrors.Error) {
+				if _, ok := jwt.ClaimsFromContext(ctx); !ok {
+					return nil, handlererrors.ReturnMissingClaims()
+				}
+				thing, ok := store[id]
+				if !ok {
+					return nil, domainerrors.NotFound("Thing", id.String())
+				}
+				return thing, nil
+			},
+			func(t *ownedThing) uuid.UUID { return t.WorkspaceID },
+		)
+		if err != nil {
+			return err
+		}
+		return c.JSON(thing)
+	})
+	return app
+}
+
+func TestLoadOwned(t *testing.T) {
+	workspace, other := uuid.New(), uuid.New()
+	own := &ownedThing{ID: uuid.New(), WorkspaceID: workspace}
+	foreign := &ownedThing{ID: uuid.New(), WorkspaceID: other}
+	store := map[uuid.UUID]*ownedThing{own.ID: own, foreign.ID: foreign}
+
+	tests := []struct {
+		name   string
+		denial application.CrossTenantDenial
+		id     string
+		want   int
+	}{
+		{"owned resource", application.CrossTenantNotFound, own.ID.String(), fiber.StatusOK},
+		{"malformed id", application.CrossTenantNotFound, "not-a-uuid", fiber.StatusBadRequest},
+		{"missing resource", application.CrossTenantNotFound, uuid.NewString(), fiber.StatusNotFound},
+		{"another workspace's resource, not_found", application.CrossTenantNotFound, foreign.ID.String(), fiber.StatusNotFound},
+		{"another workspace's resource, forbidden", application.CrossTenantForbidden, foreign.ID.String(), fiber.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newLoadOwnedApp(workspace, store, tt.denial)
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/things/"+tt.id, nil))
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			if resp.StatusCode != tt.want {
+				t.Errorf("expected %d, got %d", tt.want, resp.StatusCode)
+			}
+		})
+	}
+}
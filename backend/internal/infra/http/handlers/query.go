@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	domainerrors "backend/internal/domain/errors"
+	"backend/pkg/errors"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// bindQuery fills the exported fields of the struct dst points to from query
+// parameters. A field is read from the parameter named by its query tag, or
+// its json tag when there is none, and skipped when either is "-". When the
+// parameter is absent the field's default tag applies, so a contract states
+// its own defaults. Values that do not parse as the field's type are reported
+// per field as a 400 instead of a single generic error.
+//
+// Supported field kinds are string, bool and the signed and unsigned integers.
+func bindQuery(c *fiber.Ctx, dst any) *errors.Error {
+	v := reflect.ValueOf(dst).Elem()
+	t := v.Type()
+
+	fieldErrors := make(map[string]string)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := queryName(field)
+		if name == "" {
+			continue
+		}
+
+		raw := c.Query(name)
+		if raw == "" {
+			raw = field.Tag.Get("default")
+		}
+		if raw == "" {
+			continue
+		}
+
+		if reason := setQueryValue(v.Field(i), raw); reason != "" {
+			fieldErrors[name] = fmt.Sprintf("%s %s", name, reason)
+		}
+	}
+
+	if len(fieldErrors) > 0 {
+		return domainerrors.ValidationError("invalid query parameters", fieldErrors)
+	}
+	return nil
+}
+
+// queryName returns the parameter a field binds to, or "" to skip it.
+func queryName(field reflect.StructField) string {
+	for _, key := range []string{"query", "json"} {
+		tag, ok := field.Tag.Lookup(key)
+		if !ok {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+// setQueryValue parses raw into f, returning why it failed or "" on success.
+func setQueryValue(f reflect.Value, raw string) string {
+	switch f.Kind() {
+	case reflect.String:
+		f.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return "must be true or false"
+		}
+		f.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, f.Type().Bits())
+		if err != nil {
+			return "must be an integer"
+		}
+		f.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, f.Type().Bits())
+		if err != nil {
+			return "must be a non-negative integer"
+		}
+		f.SetUint(n)
+	default:
+		return "is not a supported query parameter"
+	}
+	return ""
+}
@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"fmt"
+	"strings"
+
+	"backend/internal/infra/http/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// envelope is the {"data": ...} wrapper written when the response envelope is on.
+type envelope struct {
+	Data any `json:"data"`
+}
+
+// respond writes body as JSON with status, wrapped in {"data": ...} when the
+// response envelope is enabled. Handlers use it for every successful JSON body.
+func respond(c *fiber.Ctx, status int, body any) error {
+	if middleware.WantsEnvelope(c) {
+		return c.Status(status).JSON(envelope{Data: body})
+	}
+	return c.Status(status).JSON(body)
+}
+
+// respondCreated writes body with 201 Created and a Location header naming
+// the new resource. Create routes POST to their collection, so the location
+// is the request path followed by id.
+func respondCreated(c *fiber.Ctx, id fmt.Stringer, body any) error {
+	c.Location(strings.TrimSuffix(c.Path(), "/") + "/" + id.String())
+	return respond(c, fiber.StatusCreated, body)
+}
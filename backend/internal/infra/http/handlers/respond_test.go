@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"backend/internal/application"
+	handlererrors "backend/internal/application/errors"
+	"backend/internal/infra/http/middleware"
+	"backend/pkg/errors"
+	"backend/pkg/validation"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func newEnvelopeApp(enabled bool) *fiber.App {
+	app := fiber.New(fiber.Config{ErrorHandler: handlererrors.ErrorHandler()})
+	if enabled {
+		app.Use(middleware.EnvelopeResponses())
+	}
+	NewLimitsHandler(validation.DefaultContentPolicy()).RegisterRoutes(app)
+	app.Get("/stream", func(c *fiber.Ctx) error {
+		return streamJSONArray(c, application.Stream[int](func(ctx context.Context, fn func(int) error) *errors.Error {
+			for i := 1; i <= 2; i++ {
+				if err := fn(i); err != nil {
+					return errors.Wrap(err, "stream")
+				}
+			}
+			return nil
+		}))
+	})
+	app.Get("/fail", func(c *fiber.Ctx) error {
+		return fiber.NewError(fiber.StatusBadRequest, "bad")
+	})
+	return app
+}
+
+func getBody(t *testing.T, app *fiber.App, path string) (int, string) {
+	t.Helper()
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, path, nil))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read body: %v", err)
+	}
+	return resp.StatusCode, string(body)
+}
+
+func TestRespond_Envelope(t *testing.T) {
+	limits := `{"max_name_length":` + strconv.Itoa(validation.MaxNameLength) + `}`
+
+	tests := []struct {
+		name     string
+		envelope bool
+		path     string
+		want     string
+	}{
+		{"object, envelope off", false, "/limits", limits},
+		{"object, envelope on", true, "/limits", `{"data":` + limits + `}`},
+		{"stream, envelope off", false, "/stream", `[1,2]`},
+		{"stream, envelope on", true, "/stream", `{"data":[1,2]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body := getBody(t, newEnvelopeApp(tt.envelope), tt.path)
+			if status != http.StatusOK {
+				t.Fatalf("expected 200, got %d", status)
+			}
+			if body != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, body)
+			}
+		})
+	}
+}
+
+func TestRespond_EnvelopeLeavesErrorsUnchanged(t *testing.T) {
+	_, plain := getBody(t, newEnvelopeApp(false), "/fail")
+	status, enveloped := getBody(t, newEnvelopeApp(true), "/fail")
+	if status != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", status)
+	}
+	for _, body := range []string{plain, enveloped} {
+		if !strings.HasPrefix(body, `{"error":`) || strings.Contains(body, `"data"`) {
+			t.Errorf("expected the usual error shape, got %s", body)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"log/slog"
+	"strings"
+
+	"backend/internal/application"
+	"backend/internal/infra/http/middleware"
+	"backend/pkg/jwt"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// wantsStream reports whether a list request asked for a streamed response (?stream=true)
+func wantsStream(c *fiber.Ctx) bool {
+	return c.QueryBool("stream")
+}
+
+// streamJSONArray writes the items produced by stream as a JSON array using a
+// chunked response body, encoding each element as it is read so the full list
+// is never held in memory. Each element is encoded exactly as c.JSON would,
+// and the array is wrapped in {"data": ...} like respond does when the response
+// envelope is enabled.
+//
+// The status line is sent before the first row is read, so a storage error
+// mid-stream cannot become an error response; the array is left unterminated
+// instead so clients see invalid JSON rather than a silently truncated list.
+func streamJSONArray[T any](c *fiber.Ctx, stream application.Stream[T]) error {
+	ctx := streamContext(c)
+	path := c.Path()
+	prefix, suffix := "[", "]"
+	if middleware.WantsEnvelope(c) {
+		prefix, suffix = `{"data":[`, "]}"
+	}
+
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if _, err := w.WriteString(prefix); err != nil {
+			return
+		}
+
+		first := true
+		streamErr := stream(ctx, func(item T) error {
+			data, err := json.Marshal(item)
+			if err != nil {
+				return err
+			}
+			if !first {
+				if err := w.WriteByte(','); err != nil {
+					return err
+				}
+			}
+			first = false
+			_, err = w.Write(data)
+			return err
+		})
+		if streamErr != nil {
+			slog.Error("list stream aborted", "path", path, "error", streamErr)
+			_ = w.Flush()
+			return
+		}
+
+		if _, err := w.WriteString(suffix); err != nil {
+			return
+		}
+		_ = w.Flush()
+	})
+
+	return nil
+}
+
+// streamCSV writes the items produced by stream as CSV: a header row followed
+// by one record per item, encoded as it is read. A storage error mid-stream is
+// logged and ends the body early, as with streamJSONArray. Cells are passed
+// through csvSafe, since exports are meant to be opened in spreadsheets.
+func streamCSV[T any](c *fiber.Ctx, stream application.Stream[T], header []string, record func(T) []string) error {
+	ctx := streamContext(c)
+	path := c.Path()
+
+	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		cw := csv.NewWriter(w)
+		if err := cw.Write(header); err != nil {
+			return
+		}
+
+		streamErr := stream(ctx, func(item T) error {
+			row := record(item)
+			for i, cell := range row {
+				row[i] = csvSafe(cell)
+			}
+			return cw.Write(row)
+		})
+		cw.Flush()
+		if streamErr != nil {
+			slog.Error("csv stream aborted", "path", path, "error", streamErr)
+		}
+		_ = w.Flush()
+	})
+
+	return nil
+}
+
+// csvSafe prefixes a cell that a spreadsheet would evaluate as a formula with
+// a single quote, so user-supplied text such as a template name is shown
+// rather than run. A leading tab or carriage return counts too: spreadsheets
+// strip it and evaluate what follows.
+func csvSafe(cell string) string {
+	if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+		return "'" + cell
+	}
+	return cell
+}
+
+// streamContext returns the context for a stream writer. The writer runs after
+// the handler returns, when the request context is no longer valid, so only
+// the claims are carried over to a fresh context.
+func streamContext(c *fiber.Ctx) context.Context {
+	ctx := context.Background()
+	if claims, ok := middleware.GetClaims(c); ok {
+		ctx = jwt.WithClaims(ctx, claims)
+	}
+	return ctx
+}
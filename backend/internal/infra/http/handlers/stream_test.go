@@ -0,0 +1,26 @@
+package handlers
+
+import "testing"
+
+func TestCSVSafe(t *testing.T) {
+	tests := []struct {
+		cell string
+		want string
+	}{
+		{"", ""},
+		{"plain name", "plain name"},
+		{"a=b", "a=b"},
+		{"=SUM(A1:A2)", "'=SUM(A1:A2)"},
+		{"+1", "'+1"},
+		{"-1", "'-1"},
+		{"@cmd", "'@cmd"},
+		{"\t=SUM(A1:A2)", "'\t=SUM(A1:A2)"},
+		{"\r=SUM(A1:A2)", "'\r=SUM(A1:A2)"},
+	}
+
+	for _, tt := range tests {
+		if got := csvSafe(tt.cell); got != tt.want {
+			t.Errorf("csvSafe(%q) = %q, want %q", tt.cell, got, tt.want)
+		}
+	}
+}
@@ -1,34 +1,79 @@
 package handlers
 
 import (
+	"context"
+	"time"
+
 	"backend/internal/application"
+	"backend/internal/domain"
+	domainerrors "backend/internal/domain/errors"
 	"backend/internal/domain/storage"
 	"backend/internal/infra/http/middleware"
 	"backend/pkg/contracts"
+	"backend/pkg/errors"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/compress"
 	"github.com/google/uuid"
 )
 
+const mimeTextCSV = "text/csv"
+
+var templateCSVHeader = []string{"id", "name", "path", "created_at", "updated_at"}
+
+func templateCSVRecord(t *domain.Template) []string {
+	return []string{
+		t.ID.String(),
+		t.Name,
+		t.Path,
+		t.CreatedAt.UTC().Format(time.RFC3339),
+		t.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// updatedTemplate is the UpdateTemplate response: the template plus whether
+// the update changed anything, so clients can spot no-op calls.
+type updatedTemplate struct {
+	*domain.Template
+	Modified bool `json:"modified"`
+}
+
 type TemplateHandler struct {
 	serviceFactory func() application.TemplateService
+	crossTenant    application.CrossTenantDenial
 }
 
 func NewTemplateHandler(serviceFactory func() application.TemplateService) *TemplateHandler {
 	return &TemplateHandler{
 		serviceFactory: serviceFactory,
+		crossTenant:    application.CrossTenantNotFound,
 	}
 }
 
+// WithCrossTenantDenial answers requests for templates in another workspace
+// as d instead of the default CrossTenantNotFound. It should match the
+// services' policy.
+func (h *TemplateHandler) WithCrossTenantDenial(d application.CrossTenantDenial) *TemplateHandler {
+	h.crossTenant = d
+	return h
+}
+
 func (h *TemplateHandler) RegisterRoutes(router fiber.Router) {
 	router.Post("/templates", h.CreateTemplate)
 	router.Get("/templates/workspace/:workspace_id", h.GetTemplatesByWorkspace)
 	router.Get("/templates/:id/files/content", h.GetTemplateFileContent)
-	router.Get("/templates/:id/files", h.ListTemplateFiles)
+	// File trees can be large, so they are compressed for clients that accept it
+	router.Get("/templates/:id/files", compress.New(), h.ListTemplateFiles)
+	router.Get("/templates/:id/diff/:other_id", h.DiffTemplates)
+	router.Get("/templates/:id/versions", h.ListTemplateVersions)
+	router.Post("/templates/:id/versions/:version/restore", h.RestoreTemplateVersion)
 	router.Get("/templates/:id", h.GetTemplate)
+	router.Post("/templates/:id/validate-path", h.ValidateTemplatePath)
 	router.Put("/templates/:id", h.UpdateTemplate)
 	router.Delete("/templates/:id", h.DeleteTemplate)
 	router.Get("/templates", h.ListTemplates)
+	router.Get("/workspaces/:id/template-stats", h.GetTemplateStats)
+	router.Get("/workspaces/:id/template-schemes", h.GetTemplateSchemes)
 }
 
 // CreateTemplate handles POST /api/v1/templates
@@ -36,6 +81,7 @@ func (h *TemplateHandler) CreateTemplate(c *fiber.Ctx) error {
 	var request contracts.CreateTemplate
 
 	request.Name = c.FormValue("name")
+	request.RepoURL = c.FormValue("repo_url")
 	workspaceIDStr := c.FormValue("workspace_id")
 	if workspaceIDStr != "" {
 		wid, err := uuid.Parse(workspaceIDStr)
@@ -76,23 +122,39 @@ func (h *TemplateHandler) CreateTemplate(c *fiber.Ctx) error {
 		return serviceErr
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(template)
+	return respondCreated(c, template.ID, template)
 }
 
 // GetTemplate handles GET /api/v1/templates/:id
 func (h *TemplateHandler) GetTemplate(c *fiber.Ctx) error {
+	service := h.serviceFactory()
+	template, err := loadOwned(c, h.crossTenant, "Template",
+		func(ctx context.Context, id uuid.UUID) (*domain.Template, *errors.Error) {
+			return service.GetTemplate(ctx, contracts.GetTemplate{ID: id})
+		},
+		func(t *domain.Template) uuid.UUID { return t.WorkspaceID },
+	)
+	if err != nil {
+		return err
+	}
+
+	return respond(c, fiber.StatusOK, template)
+}
+
+// ValidateTemplatePath handles POST /api/v1/templates/:id/validate-path
+func (h *TemplateHandler) ValidateTemplatePath(c *fiber.Ctx) error {
 	id, err := uuid.Parse(c.Params("id"))
 	if err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, "Invalid template ID")
 	}
 
 	service := h.serviceFactory()
-	template, serviceErr := service.GetTemplate(middleware.ContextWithClaims(c), contracts.GetTemplate{ID: id})
+	result, serviceErr := service.ValidateTemplatePath(middleware.ContextWithClaims(c), contracts.ValidateTemplatePath{ID: id})
 	if serviceErr != nil {
 		return serviceErr
 	}
 
-	return c.JSON(template)
+	return respond(c, fiber.StatusOK, result)
 }
 
 // GetTemplatesByWorkspace handles GET /api/v1/templates/workspace/:workspace_id
@@ -102,13 +164,65 @@ func (h *TemplateHandler) GetTemplatesByWorkspace(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "Invalid workspace ID")
 	}
 
+	var request contracts.GetTemplatesByWorkspace
+	if err := c.QueryParser(&request); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid query parameters")
+	}
+	request.WorkspaceID = workspaceID
+
 	service := h.serviceFactory()
-	templates, serviceErr := service.GetTemplatesByWorkspace(middleware.ContextWithClaims(c), contracts.GetTemplatesByWorkspace{WorkspaceID: workspaceID})
+
+	// Without a limit keep the legacy response: every template as a bare array
+	if c.Query("limit") == "" {
+		templates, serviceErr := service.GetTemplatesByWorkspace(middleware.ContextWithClaims(c), request)
+		if serviceErr != nil {
+			return serviceErr
+		}
+		return respond(c, fiber.StatusOK, templates)
+	}
+
+	page, serviceErr := service.GetTemplatesByWorkspacePage(middleware.ContextWithClaims(c), request)
 	if serviceErr != nil {
 		return serviceErr
 	}
 
-	return c.JSON(templates)
+	return respond(c, fiber.StatusOK, page)
+}
+
+// GetTemplateStats handles GET /api/v1/workspaces/:id/template-stats
+func (h *TemplateHandler) GetTemplateStats(c *fiber.Ctx) error {
+	workspaceID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid workspace ID")
+	}
+
+	var request contracts.GetTemplateStats
+	if err := bindQuery(c, &request); err != nil {
+		return err
+	}
+	request.WorkspaceID = workspaceID
+
+	stats, serviceErr := h.serviceFactory().GetTemplateStats(middleware.ContextWithClaims(c), request)
+	if serviceErr != nil {
+		return serviceErr
+	}
+
+	return respond(c, fiber.StatusOK, stats)
+}
+
+// GetTemplateSchemes handles GET /api/v1/workspaces/:id/template-schemes
+func (h *TemplateHandler) GetTemplateSchemes(c *fiber.Ctx) error {
+	workspaceID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid workspace ID")
+	}
+
+	schemes, serviceErr := h.serviceFactory().GetTemplateSchemes(middleware.ContextWithClaims(c), contracts.GetTemplateSchemes{WorkspaceID: workspaceID})
+	if serviceErr != nil {
+		return serviceErr
+	}
+
+	return respond(c, fiber.StatusOK, schemes)
 }
 
 // UpdateTemplate handles PUT /api/v1/templates/:id
@@ -120,6 +234,7 @@ func (h *TemplateHandler) UpdateTemplate(c *fiber.Ctx) error {
 
 	var request contracts.UpdateTemplate
 	request.Name = c.FormValue("name")
+	request.RepoURL = optionalFormValue(c, "repo_url")
 	request.ID = id
 
 	// Parse uploaded files
@@ -146,12 +261,12 @@ func (h *TemplateHandler) UpdateTemplate(c *fiber.Ctx) error {
 	}
 
 	service := h.serviceFactory()
-	template, serviceErr := service.UpdateTemplate(middleware.ContextWithClaims(c), request, fileInputs)
+	template, modified, serviceErr := service.UpdateTemplate(middleware.ContextWithClaims(c), request, fileInputs)
 	if serviceErr != nil {
 		return serviceErr
 	}
 
-	return c.JSON(template)
+	return respond(c, fiber.StatusOK, updatedTemplate{Template: template, Modified: modified})
 }
 
 // DeleteTemplate handles DELETE /api/v1/templates/:id
@@ -177,12 +292,76 @@ func (h *TemplateHandler) ListTemplateFiles(c *fiber.Ctx) error {
 	}
 
 	service := h.serviceFactory()
+	if wantsStream(c) {
+		stream, serviceErr := service.StreamTemplateFiles(middleware.ContextWithClaims(c), contracts.ListTemplateFiles{ID: id})
+		if serviceErr != nil {
+			return serviceErr
+		}
+		return streamJSONArray(c, stream)
+	}
+
 	files, serviceErr := service.ListTemplateFiles(middleware.ContextWithClaims(c), contracts.ListTemplateFiles{ID: id})
 	if serviceErr != nil {
 		return serviceErr
 	}
 
-	return c.JSON(files)
+	return respond(c, fiber.StatusOK, files)
+}
+
+// DiffTemplates handles GET /api/v1/templates/:id/diff/:other_id
+func (h *TemplateHandler) DiffTemplates(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid template ID")
+	}
+	otherID, err := uuid.Parse(c.Params("other_id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid template ID")
+	}
+
+	service := h.serviceFactory()
+	diff, serviceErr := service.DiffTemplates(middleware.ContextWithClaims(c), contracts.DiffTemplates{ID: id, OtherID: otherID})
+	if serviceErr != nil {
+		return serviceErr
+	}
+
+	return respond(c, fiber.StatusOK, diff)
+}
+
+// ListTemplateVersions handles GET /api/v1/templates/:id/versions
+func (h *TemplateHandler) ListTemplateVersions(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid template ID")
+	}
+
+	service := h.serviceFactory()
+	versions, serviceErr := service.ListTemplateVersions(middleware.ContextWithClaims(c), contracts.ListTemplateVersions{ID: id})
+	if serviceErr != nil {
+		return serviceErr
+	}
+
+	return respond(c, fiber.StatusOK, versions)
+}
+
+// RestoreTemplateVersion handles POST /api/v1/templates/:id/versions/:version/restore
+func (h *TemplateHandler) RestoreTemplateVersion(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid template ID")
+	}
+	version, err := c.ParamsInt("version")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid template version")
+	}
+
+	service := h.serviceFactory()
+	template, serviceErr := service.RestoreTemplateVersion(middleware.ContextWithClaims(c), contracts.RestoreTemplateVersion{ID: id, Version: version})
+	if serviceErr != nil {
+		return serviceErr
+	}
+
+	return respond(c, fiber.StatusOK, template)
 }
 
 // GetTemplateFileContent handles GET /api/v1/templates/:id/files/content?path=...
@@ -198,28 +377,72 @@ func (h *TemplateHandler) GetTemplateFileContent(c *fiber.Ctx) error {
 	}
 
 	service := h.serviceFactory()
-	content, serviceErr := service.GetTemplateFileContent(middleware.ContextWithClaims(c), contracts.GetTemplateFileContent{ID: id, Filename: filename})
+	file, serviceErr := service.GetTemplateFileContent(middleware.ContextWithClaims(c), contracts.GetTemplateFileContent{ID: id, Filename: filename})
 	if serviceErr != nil {
 		return serviceErr
 	}
 
-	c.Set("Content-Type", "text/plain; charset=utf-8")
-	return c.Send(content)
+	return respond(c, fiber.StatusOK, file)
 }
 
 // ListTemplates handles GET /api/v1/templates
 func (h *TemplateHandler) ListTemplates(c *fiber.Ctx) error {
 	var request contracts.ListTemplates
 
-	if err := c.QueryParser(&request); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, "Invalid query parameters")
+	if err := bindQuery(c, &request); err != nil {
+		return err
 	}
+	request.Filters = queryFilters(c)
 
 	service := h.serviceFactory()
+	if request.Fields != "" {
+		if c.Accepts(fiber.MIMEApplicationJSON, mimeTextCSV) == mimeTextCSV || wantsStream(c) {
+			return domainerrors.InvalidInput("fields", "fields cannot be combined with streaming or CSV output")
+		}
+		templates, serviceErr := service.ListTemplateFields(middleware.ContextWithClaims(c), request)
+		if serviceErr != nil {
+			return serviceErr
+		}
+		return respond(c, fiber.StatusOK, templates)
+	}
+	if c.Accepts(fiber.MIMEApplicationJSON, mimeTextCSV) == mimeTextCSV {
+		stream, serviceErr := service.ExportTemplates(middleware.ContextWithClaims(c), request)
+		if serviceErr != nil {
+			return serviceErr
+		}
+		c.Attachment("templates.csv")
+		return streamCSV(c, stream, templateCSVHeader, templateCSVRecord)
+	}
+	if wantsStream(c) {
+		stream, serviceErr := service.StreamTemplates(middleware.ContextWithClaims(c), request)
+		if serviceErr != nil {
+			return serviceErr
+		}
+		return streamJSONArray(c, stream)
+	}
+
 	templates, serviceErr := service.ListTemplates(middleware.ContextWithClaims(c), request)
 	if serviceErr != nil {
 		return serviceErr
 	}
 
-	return c.JSON(templates)
+	return respond(c, fiber.StatusOK, templates)
+}
+
+// optionalFormValue returns the form field key, or nil when the request does
+// not carry it, so an empty value can be told apart from an omitted one.
+func optionalFormValue(c *fiber.Ctx, key string) *string {
+	if form, err := c.MultipartForm(); err == nil {
+		values, ok := form.Value[key]
+		if !ok || len(values) == 0 {
+			return nil
+		}
+		return &values[0]
+	}
+	args := c.Request().PostArgs()
+	if !args.Has(key) {
+		return nil
+	}
+	value := string(args.Peek(key))
+	return &value
 }
@@ -32,8 +32,8 @@ func (h *TemplateVariableHandler) CreateVariable(c *fiber.Ctx) error {
 	}
 
 	var request contracts.CreateTemplateVariable
-	if err := c.BodyParser(&request); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	if err := parseBody(c, &request); err != nil {
+		return err
 	}
 	request.TemplateID = templateID
 
@@ -43,7 +43,7 @@ func (h *TemplateVariableHandler) CreateVariable(c *fiber.Ctx) error {
 		return serviceErr
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(variable)
+	return respond(c, fiber.StatusCreated, variable)
 }
 
 func (h *TemplateVariableHandler) ListVariables(c *fiber.Ctx) error {
@@ -58,7 +58,7 @@ func (h *TemplateVariableHandler) ListVariables(c *fiber.Ctx) error {
 		return serviceErr
 	}
 
-	return c.JSON(variables)
+	return respond(c, fiber.StatusOK, variables)
 }
 
 func (h *TemplateVariableHandler) UpdateVariable(c *fiber.Ctx) error {
@@ -68,8 +68,8 @@ func (h *TemplateVariableHandler) UpdateVariable(c *fiber.Ctx) error {
 	}
 
 	var request contracts.UpdateTemplateVariable
-	if err := c.BodyParser(&request); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	if err := parseBody(c, &request); err != nil {
+		return err
 	}
 	request.ID = varID
 
@@ -79,7 +79,7 @@ func (h *TemplateVariableHandler) UpdateVariable(c *fiber.Ctx) error {
 		return serviceErr
 	}
 
-	return c.JSON(variable)
+	return respond(c, fiber.StatusOK, variable)
 }
 
 func (h *TemplateVariableHandler) DeleteVariable(c *fiber.Ctx) error {
@@ -108,5 +108,5 @@ func (h *TemplateVariableHandler) ParseAndReconcileVariables(c *fiber.Ctx) error
 		return serviceErr
 	}
 
-	return c.JSON(result)
+	return respond(c, fiber.StatusOK, result)
 }
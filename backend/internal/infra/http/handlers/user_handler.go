@@ -8,6 +8,7 @@ import (
 	"backend/pkg/jwt"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 )
 
 type UserHandler struct {
@@ -24,6 +25,13 @@ func NewUserHandler(serviceFactory func() (application.UserService, apphandlers.
 	}
 }
 
+// WithCookieConfig replaces the default settings of the auth cookie set on
+// successful login or registration. RequireAuth must be given the same config.
+func (h *UserHandler) WithCookieConfig(cfg jwt.CookieConfig) *UserHandler {
+	h.cookieCfg = cfg
+	return h
+}
+
 func (h *UserHandler) RegisterRoutes(router fiber.Router) {
 	router.Post("/users", h.CreateUser)
 	router.Post("/login", h.Login)
@@ -31,6 +39,7 @@ func (h *UserHandler) RegisterRoutes(router fiber.Router) {
 
 func (h *UserHandler) RegisterProtectedRoutes(router fiber.Router) {
 	router.Get("/me", h.Me)
+	router.Get("/users/:id", h.GetUser)
 }
 
 // CreateUser handles POST /api/v1/users
@@ -38,8 +47,8 @@ func (h *UserHandler) CreateUser(c *fiber.Ctx) error {
 	var request contracts.CreateLocalUser
 
 	// Parse and validate request body
-	if err := c.BodyParser(&request); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	if err := parseBody(c, &request); err != nil {
+		return err
 	}
 
 	// UserService.CreateLocalUser does not defer rollback internally (it can be called
@@ -53,14 +62,19 @@ func (h *UserHandler) CreateUser(c *fiber.Ctx) error {
 		return serviceErr
 	}
 
-	token, err := h.jwtService.GenerateToken(user.ID.String(), user.Name, string(user.Role), user.WorkspaceID.String())
+	workspaceEpoch, serviceErr := service.WorkspaceTokenEpoch(c.Context(), user.WorkspaceID)
+	if serviceErr != nil {
+		return serviceErr
+	}
+
+	token, err := h.jwtService.GenerateScopedToken(user.ID.String(), user.Name, string(user.Role), user.WorkspaceID.String(), user.TokenEpoch, jwt.ScopeWrite, jwt.WithWorkspaceEpoch(workspaceEpoch))
 	if err != nil {
 		return err
 	}
 
 	middleware.SetTokenCookie(c, token, h.cookieCfg)
 
-	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+	return respondCreated(c, user.ID, fiber.Map{
 		"message": "User created successfully",
 		"user_id": user.ID,
 	})
@@ -70,8 +84,8 @@ func (h *UserHandler) CreateUser(c *fiber.Ctx) error {
 func (h *UserHandler) Login(c *fiber.Ctx) error {
 	var request contracts.LoginLocalUser
 
-	if err := c.BodyParser(&request); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	if err := parseBody(c, &request); err != nil {
+		return err
 	}
 
 	service, _ := h.serviceFactory()
@@ -81,14 +95,14 @@ func (h *UserHandler) Login(c *fiber.Ctx) error {
 		return serviceErr
 	}
 
-	token, err := h.jwtService.GenerateToken(user.UserID.String(), user.Name, user.Role, user.WorkspaceID.String())
+	token, err := h.jwtService.GenerateScopedToken(user.UserID.String(), user.Name, user.Role, user.WorkspaceID.String(), user.TokenEpoch, user.Scope, jwt.WithWorkspaceEpoch(user.WorkspaceTokenEpoch))
 	if err != nil {
 		return err
 	}
 
 	middleware.SetTokenCookie(c, token, h.cookieCfg)
 
-	return c.Status(fiber.StatusOK).JSON(user)
+	return respond(c, fiber.StatusOK, user)
 }
 
 // Me handles GET /api/v1/me
@@ -98,10 +112,26 @@ func (h *UserHandler) Me(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusUnauthorized, "missing claims")
 	}
 
-	return c.JSON(fiber.Map{
+	return respond(c, fiber.StatusOK, fiber.Map{
 		"user_id":      claims.ID,
 		"name":         claims.Name,
 		"role":         claims.Role,
 		"workspace_id": claims.WorkspaceID,
 	})
 }
+
+// GetUser handles GET /api/v1/users/:id
+func (h *UserHandler) GetUser(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid user ID")
+	}
+
+	service, _ := h.serviceFactory()
+	user, serviceErr := service.GetUser(middleware.ContextWithClaims(c), id)
+	if serviceErr != nil {
+		return serviceErr
+	}
+
+	return respond(c, fiber.StatusOK, user)
+}
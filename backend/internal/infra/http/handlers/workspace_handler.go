@@ -1,8 +1,12 @@
 package handlers
 
 import (
+	"errors"
+	"strings"
+
 	"backend/internal/application"
 	apphandlers "backend/internal/application/handlers"
+	domainerrors "backend/internal/domain/errors"
 	"backend/internal/infra/http/middleware"
 	"backend/pkg/contracts"
 
@@ -23,9 +27,12 @@ func NewWorkspaceHandler(serviceFactory func() (application.WorkspaceService, ap
 func (h *WorkspaceHandler) RegisterRoutes(router fiber.Router) {
 	router.Post("/workspaces", h.CreateWorkspace)
 	router.Get("/workspaces/admin/:admin_id", h.GetWorkspacesByAdmin)
+	router.Get("/workspaces/batch", h.GetWorkspacesByIDs)
 	router.Get("/workspaces/:id", h.GetWorkspace)
 	router.Put("/workspaces/:id", h.UpdateWorkspace)
 	router.Delete("/workspaces/:id", h.DeleteWorkspace)
+	router.Post("/workspaces/:id/rotate-secret", h.RotateSecret)
+	router.Post("/workspaces/:id/revoke-sessions", h.RevokeSessions)
 	router.Get("/workspaces", h.ListWorkspaces)
 }
 
@@ -33,8 +40,8 @@ func (h *WorkspaceHandler) RegisterRoutes(router fiber.Router) {
 func (h *WorkspaceHandler) CreateWorkspace(c *fiber.Ctx) error {
 	var request contracts.CreateWorkspace
 
-	if err := c.BodyParser(&request); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	if err := parseBody(c, &request); err != nil {
+		return err
 	}
 
 	service, uow := h.serviceFactory()
@@ -43,23 +50,55 @@ func (h *WorkspaceHandler) CreateWorkspace(c *fiber.Ctx) error {
 		return serviceErr
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(workspace)
+	return respondCreated(c, workspace.ID, workspace)
 }
 
-// GetWorkspace handles GET /api/v1/workspaces/:id
+// GetWorkspace handles GET /api/v1/workspaces/:id?expand=admin
 func (h *WorkspaceHandler) GetWorkspace(c *fiber.Ctx) error {
 	id, err := uuid.Parse(c.Params("id"))
 	if err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, "Invalid workspace ID")
 	}
+	request := contracts.GetWorkspace{ID: id, Expand: c.Query("expand")}
 
 	service, _ := h.serviceFactory()
-	workspace, serviceErr := service.GetWorkspace(middleware.ContextWithClaims(c), contracts.GetWorkspace{ID: id})
+	if request.Expand == contracts.WorkspaceExpandAdmin {
+		workspace, serviceErr := service.GetWorkspaceWithAdmin(middleware.ContextWithClaims(c), request)
+		if serviceErr != nil {
+			return serviceErr
+		}
+		return respond(c, fiber.StatusOK, workspace)
+	}
+
+	workspace, serviceErr := service.GetWorkspace(middleware.ContextWithClaims(c), request)
 	if serviceErr != nil {
 		return serviceErr
 	}
 
-	return c.JSON(workspace)
+	return respond(c, fiber.StatusOK, workspace)
+}
+
+// GetWorkspacesByIDs handles GET /api/v1/workspaces/batch?ids=<id>,<id>
+func (h *WorkspaceHandler) GetWorkspacesByIDs(c *fiber.Ctx) error {
+	var request contracts.GetWorkspacesByIDs
+	for _, raw := range strings.Split(c.Query("ids"), ",") {
+		if raw = strings.TrimSpace(raw); raw == "" {
+			continue
+		}
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "Invalid workspace ID")
+		}
+		request.IDs = append(request.IDs, id)
+	}
+
+	service, _ := h.serviceFactory()
+	workspaces, serviceErr := service.GetWorkspacesByIDs(middleware.ContextWithClaims(c), request)
+	if serviceErr != nil {
+		return serviceErr
+	}
+
+	return respond(c, fiber.StatusOK, workspaces)
 }
 
 // GetWorkspacesByAdmin handles GET /api/v1/workspaces/admin/:admin_id
@@ -75,7 +114,7 @@ func (h *WorkspaceHandler) GetWorkspacesByAdmin(c *fiber.Ctx) error {
 		return serviceErr
 	}
 
-	return c.JSON(workspaces)
+	return respond(c, fiber.StatusOK, workspaces)
 }
 
 // UpdateWorkspace handles PUT /api/v1/workspaces/:id
@@ -86,8 +125,8 @@ func (h *WorkspaceHandler) UpdateWorkspace(c *fiber.Ctx) error {
 	}
 
 	var request contracts.UpdateWorkspace
-	if err := c.BodyParser(&request); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	if err := parseBody(c, &request); err != nil {
+		return err
 	}
 
 	request.ID = id
@@ -95,10 +134,14 @@ func (h *WorkspaceHandler) UpdateWorkspace(c *fiber.Ctx) error {
 	service, uow := h.serviceFactory()
 	workspace, serviceErr := service.UpdateWorkspace(middleware.ContextWithClaims(c), uow, request)
 	if serviceErr != nil {
+		if errors.Is(serviceErr, domainerrors.ErrVersionConflict) {
+			// Nothing to wait out: refetch (or use the metadata) and retry
+			c.Set(fiber.HeaderRetryAfter, "0")
+		}
 		return serviceErr
 	}
 
-	return c.JSON(workspace)
+	return respond(c, fiber.StatusOK, workspace)
 }
 
 // DeleteWorkspace handles DELETE /api/v1/workspaces/:id
@@ -120,15 +163,56 @@ func (h *WorkspaceHandler) DeleteWorkspace(c *fiber.Ctx) error {
 func (h *WorkspaceHandler) ListWorkspaces(c *fiber.Ctx) error {
 	var request contracts.ListWorkspaces
 
-	if err := c.QueryParser(&request); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, "Invalid query parameters")
+	if err := bindQuery(c, &request); err != nil {
+		return err
 	}
 
 	service, _ := h.serviceFactory()
+	if wantsStream(c) {
+		stream, serviceErr := service.StreamWorkspaces(middleware.ContextWithClaims(c), request)
+		if serviceErr != nil {
+			return serviceErr
+		}
+		return streamJSONArray(c, stream)
+	}
+
 	workspaces, serviceErr := service.ListWorkspaces(middleware.ContextWithClaims(c), request)
 	if serviceErr != nil {
 		return serviceErr
 	}
 
-	return c.JSON(workspaces)
+	return respond(c, fiber.StatusOK, workspaces)
+}
+
+// RotateSecret handles POST /api/v1/workspaces/:id/rotate-secret
+func (h *WorkspaceHandler) RotateSecret(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid workspace ID")
+	}
+
+	service, uow := h.serviceFactory()
+	response, serviceErr := service.RotateSecret(middleware.ContextWithClaims(c), uow, contracts.RotateWorkspaceSecret{ID: id})
+	if serviceErr != nil {
+		return serviceErr
+	}
+
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return respond(c, fiber.StatusOK, response)
+}
+
+// RevokeSessions handles POST /api/v1/workspaces/:id/revoke-sessions
+func (h *WorkspaceHandler) RevokeSessions(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid workspace ID")
+	}
+
+	service, uow := h.serviceFactory()
+	response, serviceErr := service.RevokeSessions(middleware.ContextWithClaims(c), uow, contracts.RevokeWorkspaceSessions{ID: id})
+	if serviceErr != nil {
+		return serviceErr
+	}
+
+	return respond(c, fiber.StatusOK, response)
 }
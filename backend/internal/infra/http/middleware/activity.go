@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+
+	"backend/pkg/errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// ActivityRecorder records that a user made an authenticated request.
+type ActivityRecorder interface {
+	Touch(ctx context.Context, userID uuid.UUID) *errors.Error
+}
+
+// TrackActivity returns a Fiber middleware that reports the authenticated user
+// to recorder. It must run after RequireAuth and Authorize, so a request the
+// route policy denies is not counted as activity. Recording failures are
+// logged and never fail the request.
+func TrackActivity(recorder ActivityRecorder) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims, ok := GetClaims(c)
+		if !ok {
+			return c.Next()
+		}
+
+		userID, err := uuid.Parse(claims.ID)
+		if err != nil {
+			return c.Next()
+		}
+
+		if err := recorder.Touch(c.Context(), userID); err != nil {
+			slog.Warn("failed to record user activity", "user_id", userID, "error", err)
+		}
+
+		return c.Next()
+	}
+}
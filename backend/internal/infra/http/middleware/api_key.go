@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+
+	"backend/internal/domain"
+	"backend/pkg/errors"
+	"backend/pkg/jwt"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// APIKeyAuthenticator resolves a plaintext API key to the claims it acts with.
+type APIKeyAuthenticator interface {
+	Authenticate(ctx context.Context, key string) (*jwt.Claims, *errors.Error)
+}
+
+// AuthenticateAPIKey returns a Fiber middleware that authenticates requests
+// carrying an API key as "Authorization: Bearer <key>". It must run before
+// RequireAuth, which then accepts the stored claims in place of a cookie.
+// Requests without an API key pass through untouched. Keys are looked up on
+// every request so revocation takes effect immediately.
+func AuthenticateAPIKey(authenticator APIKeyAuthenticator) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key, ok := bearerAPIKey(c)
+		if !ok {
+			return c.Next()
+		}
+
+		claims, err := authenticator.Authenticate(c.Context(), key)
+		if err != nil {
+			return err
+		}
+
+		c.Locals(ClaimsKey, claims)
+		return c.Next()
+	}
+}
+
+func bearerAPIKey(c *fiber.Ctx) (string, bool) {
+	scheme, credential, found := strings.Cut(c.Get(fiber.HeaderAuthorization), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	credential = strings.TrimSpace(credential)
+	return credential, domain.IsAPIKey(credential)
+}
@@ -0,0 +1,129 @@
+package middleware
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	DefaultBackoffMaxAttempts = 5
+	DefaultBackoffBaseDelay   = 30 * time.Second
+
+	// maxBackoffDelay caps how long one client IP can be blocked at a time.
+	maxBackoffDelay = time.Hour
+)
+
+// FailureBackoff blocks a client IP after repeated authentication failures on
+// the routes it guards. The first maxAttempts failures are let through; each
+// failure after that blocks the IP for baseDelay, doubling per further failure
+// up to an hour. A successful request resets the IP. It keeps its own state,
+// so it applies on top of any global rate limiter.
+type FailureBackoff struct {
+	maxAttempts int
+	baseDelay   time.Duration
+	now         func() time.Time
+
+	mu      sync.Mutex
+	clients map[string]*backoffState
+}
+
+type backoffState struct {
+	failures     int
+	lastFailure  time.Time
+	blockedUntil time.Time
+}
+
+func NewFailureBackoff(maxAttempts int, baseDelay time.Duration) *FailureBackoff {
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultBackoffMaxAttempts
+	}
+	if baseDelay <= 0 {
+		baseDelay = DefaultBackoffBaseDelay
+	}
+	return &FailureBackoff{
+		maxAttempts: maxAttempts,
+		baseDelay:   baseDelay,
+		now:         time.Now,
+		clients:     make(map[string]*backoffState),
+	}
+}
+
+// Handler returns the Fiber middleware. A request from a blocked IP is
+// answered 429 with Retry-After without reaching the route. A route error
+// with status 401 counts as a failure; a response below 400 resets the IP.
+func (b *FailureBackoff) Handler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ip := c.IP()
+		if wait := b.blockedFor(ip); wait > 0 {
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			return fiber.NewError(fiber.StatusTooManyRequests, "too many failed attempts, try again later")
+		}
+
+		err := c.Next()
+
+		var fiberErr *fiber.Error
+		switch {
+		case errors.As(err, &fiberErr) && fiberErr.Code == fiber.StatusUnauthorized:
+			b.recordFailure(ip)
+		case err == nil && c.Response().StatusCode() < fiber.StatusBadRequest:
+			b.reset(ip)
+		}
+		return err
+	}
+}
+
+func (b *FailureBackoff) blockedFor(ip string) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	state, ok := b.clients[ip]
+	if !ok {
+		return 0
+	}
+	return state.blockedUntil.Sub(b.now())
+}
+
+func (b *FailureBackoff) recordFailure(ip string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	b.forgetIdle(now)
+
+	state, ok := b.clients[ip]
+	if !ok {
+		state = &backoffState{}
+		b.clients[ip] = state
+	}
+	state.failures++
+	state.lastFailure = now
+
+	if over := state.failures - b.maxAttempts; over > 0 {
+		delay := maxBackoffDelay
+		if over < 32 {
+			delay = min(b.baseDelay<<(over-1), maxBackoffDelay)
+		}
+		state.blockedUntil = now.Add(delay)
+	}
+}
+
+func (b *FailureBackoff) reset(ip string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.clients, ip)
+}
+
+// forgetIdle drops IPs whose last failure is older than the longest block, so
+// scattered failures from many addresses don't accumulate forever.
+func (b *FailureBackoff) forgetIdle(now time.Time) {
+	for ip, state := range b.clients {
+		if now.Sub(state.lastFailure) > maxBackoffDelay && now.After(state.blockedUntil) {
+			delete(b.clients, ip)
+		}
+	}
+}
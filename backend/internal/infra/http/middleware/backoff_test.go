@@ -0,0 +1,106 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	handlererrors "backend/internal/application/errors"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// newBackoffApp serves POST /init, which succeeds only with the header
+// X-Token: secret. Client IPs come from X-Forwarded-For.
+func newBackoffApp(backoff *FailureBackoff) *fiber.App {
+	app := fiber.New(fiber.Config{
+		ErrorHandler: handlererrors.ErrorHandler(),
+		ProxyHeader:  fiber.HeaderXForwardedFor,
+	})
+	app.Post("/init", backoff.Handler(), func(c *fiber.Ctx) error {
+		if c.Get("X-Token") != "secret" {
+			return fiber.NewError(fiber.StatusUnauthorized, "invalid token")
+		}
+		return c.SendStatus(fiber.StatusCreated)
+	})
+	return app
+}
+
+func sendInit(t *testing.T, app *fiber.App, ip, token string) *http.Response {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/init", nil)
+	req.Header.Set(fiber.HeaderXForwardedFor, ip)
+	req.Header.Set("X-Token", token)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	return resp
+}
+
+func TestFailureBackoff_EscalatesPerIP(t *testing.T) {
+	now := time.Date(2024, 5, 17, 9, 30, 0, 0, time.UTC)
+	backoff := NewFailureBackoff(3, 10*time.Second)
+	backoff.now = func() time.Time { return now }
+	app := newBackoffApp(backoff)
+
+	const attacker, bystander = "203.0.113.7", "198.51.100.4"
+
+	// The first failures are let through to the route
+	for i := 0; i < 3; i++ {
+		if resp := sendInit(t, app, attacker, "wrong"); resp.StatusCode != http.StatusUnauthorized {
+			t.Fatalf("attempt %d: expected 401, got %d", i+1, resp.StatusCode)
+		}
+	}
+
+	// The next failure blocks for the base delay
+	if resp := sendInit(t, app, attacker, "wrong"); resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("attempt 4: expected 401, got %d", resp.StatusCode)
+	}
+	resp := sendInit(t, app, attacker, "secret")
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("blocked IP: expected 429 even with the right token, got %d", resp.StatusCode)
+	}
+	if got := resp.Header.Get(fiber.HeaderRetryAfter); got != "10" {
+		t.Errorf("expected Retry-After 10, got %q", got)
+	}
+
+	if resp := sendInit(t, app, bystander, "wrong"); resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("other IP: expected 401, got %d", resp.StatusCode)
+	}
+
+	// Each further failure doubles the block
+	now = now.Add(11 * time.Second)
+	if resp := sendInit(t, app, attacker, "wrong"); resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("attempt 5: expected 401, got %d", resp.StatusCode)
+	}
+	now = now.Add(11 * time.Second)
+	if resp := sendInit(t, app, attacker, "secret"); resp.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected the second block to outlast the first, got %d", resp.StatusCode)
+	}
+
+	now = now.Add(10 * time.Second)
+	if resp := sendInit(t, app, attacker, "secret"); resp.StatusCode != http.StatusCreated {
+		t.Fatalf("after the block: expected 201, got %d", resp.StatusCode)
+	}
+}
+
+func TestFailureBackoff_ResetsOnSuccess(t *testing.T) {
+	app := newBackoffApp(NewFailureBackoff(2, time.Minute))
+	const ip = "203.0.113.9"
+
+	for i := 0; i < 2; i++ {
+		sendInit(t, app, ip, "wrong")
+	}
+	if resp := sendInit(t, app, ip, "secret"); resp.StatusCode != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", resp.StatusCode)
+	}
+
+	// The count starts over, so the next failures are let through again
+	for i := 0; i < 3; i++ {
+		if resp := sendInit(t, app, ip, "wrong"); resp.StatusCode != http.StatusUnauthorized {
+			t.Fatalf("attempt %d after success: expected 401, got %d", i+1, resp.StatusCode)
+		}
+	}
+}
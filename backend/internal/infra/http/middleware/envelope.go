@@ -0,0 +1,21 @@
+package middleware
+
+import "github.com/gofiber/fiber/v2"
+
+const envelopeKey contextKeyType = "response_envelope"
+
+// EnvelopeResponses returns a Fiber middleware that asks handlers to wrap
+// successful JSON bodies as {"data": ...}, matching the {"error": ...} shape
+// of error responses. Error responses are unchanged.
+func EnvelopeResponses() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Locals(envelopeKey, true)
+		return c.Next()
+	}
+}
+
+// WantsEnvelope reports whether EnvelopeResponses ran for this request.
+func WantsEnvelope(c *fiber.Ctx) bool {
+	enabled, _ := c.Locals(envelopeKey).(bool)
+	return enabled
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+
+	"backend/pkg/errors"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RequireTLS returns a Fiber middleware that, in production, answers 403 to
+// requests that did not arrive over HTTPS, so credentials and session cookies
+// never travel in the clear. Behind a TLS-terminating proxy the scheme is
+// read from X-Forwarded-Proto, but only when the app is configured with
+// EnableTrustedProxyCheck and the request comes from one of its
+// TrustedProxies; anyone else could forge the header. Paths in exempt, such
+// as a health check probed from inside the container, are let through.
+// Outside production every request is let through.
+func RequireTLS(production bool, exempt ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if !production || c.Protocol() == "https" || slices.Contains(exempt, c.Path()) {
+			return c.Next()
+		}
+
+		return errors.WithCode(errors.CodeForbidden, "HTTPS is required").
+			WithMetadata(errors.MetadataReason, "https_required").
+			WithHTTPStatus(http.StatusForbidden).
+			WithSeverity(errors.SeverityWarning)
+	}
+}
@@ -0,0 +1,112 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	handlererrors "backend/internal/application/errors"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// testClientIP is the remote address app.Test sends requests from.
+const testClientIP = "0.0.0.0"
+
+func newRequireTLSApp(production bool, trustedProxies ...string) *fiber.App {
+	app := fiber.New(fiber.Config{
+		ErrorHandler:            handlererrors.ErrorHandler(),
+		EnableTrustedProxyCheck: true,
+		TrustedProxies:          trustedProxies,
+	})
+	app.Use(RequireTLS(production, "/health"))
+	ok := func(c *fiber.Ctx) error { return c.SendString("ok") }
+	app.Get("/resource", ok)
+	app.Get("/health", ok)
+	return app
+}
+
+func sendForwarded(t *testing.T, app *fiber.App, path, proto string) *http.Response {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if proto != "" {
+		req.Header.Set(fiber.HeaderXForwardedProto, proto)
+	}
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	return resp
+}
+
+func TestRequireTLS_Production(t *testing.T) {
+	app := newRequireTLSApp(true, testClientIP)
+
+	tests := []struct {
+		name  string
+		path  string
+		proto string
+		want  int
+	}{
+		{"forwarded https", "/resource", "https", http.StatusOK},
+		{"forwarded http", "/resource", "http", http.StatusForbidden},
+		{"no forwarded proto", "/resource", "", http.StatusForbidden},
+		{"exempt health check", "/health", "", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := sendForwarded(t, app, tt.path, tt.proto)
+			defer resp.Body.Close()
+			if resp.StatusCode != tt.want {
+				t.Fatalf("expected %d, got %d", tt.want, resp.StatusCode)
+			}
+			if tt.want != http.StatusForbidden {
+				return
+			}
+
+			var body struct {
+				Error struct {
+					Message  string         `json:"message"`
+					Metadata map[string]any `json:"metadata"`
+				} `json:"error"`
+			}
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatalf("failed to decode error body: %v", err)
+			}
+			if body.Error.Message != "HTTPS is required" {
+				t.Errorf("unexpected message %q", body.Error.Message)
+			}
+			if body.Error.Metadata["reason"] != "https_required" {
+				t.Errorf("expected reason https_required, got %v", body.Error.Metadata["reason"])
+			}
+		})
+	}
+}
+
+func TestRequireTLS_IgnoresForwardedProtoFromUntrustedClients(t *testing.T) {
+	for name, app := range map[string]*fiber.App{
+		"no trusted proxies":    newRequireTLSApp(true),
+		"other trusted proxies": newRequireTLSApp(true, "10.0.0.0/8"),
+	} {
+		t.Run(name, func(t *testing.T) {
+			resp := sendForwarded(t, app, "/resource", "https")
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusForbidden {
+				t.Errorf("expected 403 for a forged X-Forwarded-Proto, got %d", resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestRequireTLS_DevelopmentAllowsPlainHTTP(t *testing.T) {
+	app := newRequireTLSApp(false)
+
+	for _, proto := range []string{"http", "https", ""} {
+		resp := sendForwarded(t, app, "/resource", proto)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("X-Forwarded-Proto %q: expected 200, got %d", proto, resp.StatusCode)
+		}
+	}
+}
@@ -2,13 +2,17 @@ package middleware
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	"backend/internal/domain"
 	domainerrors "backend/internal/domain/errors"
+	"backend/internal/domain/repository"
 	"backend/pkg/jwt"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 )
 
 type contextKeyType string
@@ -17,8 +21,13 @@ const ClaimsKey contextKeyType = "claims"
 
 // RequireAuth returns a Fiber middleware that validates the JWT token
 // from the cookie defined in cfg and stores the claims in context locals.
+// Requests already authenticated by AuthenticateAPIKey skip the cookie.
 func RequireAuth(jwtService *jwt.Service, cfg jwt.CookieConfig) fiber.Handler {
 	return func(c *fiber.Ctx) error {
+		if _, ok := GetClaims(c); ok {
+			return c.Next()
+		}
+
 		tokenString := c.Cookies(cfg.Name)
 		if tokenString == "" {
 			return domainerrors.Unauthorized("missing auth cookie")
@@ -26,7 +35,7 @@ func RequireAuth(jwtService *jwt.Service, cfg jwt.CookieConfig) fiber.Handler {
 
 		claims, err := jwtService.ValidateToken(tokenString)
 		if err != nil {
-			return err
+			return rejectToken(c, err)
 		}
 
 		c.Locals(ClaimsKey, claims)
@@ -34,6 +43,20 @@ func RequireAuth(jwtService *jwt.Service, cfg jwt.CookieConfig) fiber.Handler {
 	}
 }
 
+// rejectToken answers a token that failed validation. The error's "reason"
+// metadata already separates an expired token (refresh) from an invalid one
+// (sign in again); the WWW-Authenticate header repeats it for clients that
+// only look at headers.
+func rejectToken(c *fiber.Ctx, err error) error {
+	description := "the access token is invalid"
+	if errors.Is(err, jwt.ErrExpiredToken) {
+		description = "the access token expired"
+	}
+
+	c.Set(fiber.HeaderWWWAuthenticate, fmt.Sprintf(`Bearer error="invalid_token", error_description=%q`, description))
+	return err
+}
+
 // SetTokenCookie writes the JWT token as a cookie on the response using the
 // settings from cfg.
 func SetTokenCookie(c *fiber.Ctx, token string, cfg jwt.CookieConfig) {
@@ -110,16 +133,21 @@ func RequireRoleForWrite(minRole domain.Role) fiber.Handler {
 // Returns (nil, false) if called on an unprotected route.
 func GetClaims(c *fiber.Ctx) (*jwt.Claims, bool) {
 	claims, ok := c.Locals(ClaimsKey).(*jwt.Claims)
-	return claims, ok
+	return claims, ok && claims != nil
 }
 
 // ContextWithClaims returns c.Context() enriched with JWT claims so the application
-// layer can call jwt.ClaimsFromContext without any Fiber dependency.
+// layer can call jwt.ClaimsFromContext without any Fiber dependency. The
+// workspace scope set by ScopeToWorkspace travels along with them.
 // If no claims are present (unprotected route), the original context is returned unchanged.
 func ContextWithClaims(c *fiber.Ctx) context.Context {
 	claims, ok := GetClaims(c)
 	if !ok {
 		return c.Context()
 	}
-	return jwt.WithClaims(c.Context(), claims)
+	ctx := jwt.WithClaims(c.Context(), claims)
+	if workspaceID, ok := c.Locals(WorkspaceScopeKey).(uuid.UUID); ok {
+		ctx = repository.WithWorkspaceScope(ctx, workspaceID)
+	}
+	return ctx
 }
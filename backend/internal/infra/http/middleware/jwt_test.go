@@ -1,16 +1,20 @@
 package middleware
 
 import (
+	"encoding/json"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	handlererrors "backend/internal/application/errors"
 	"backend/internal/domain"
 	"backend/pkg/jwt"
 
 	"github.com/gofiber/fiber/v2"
+	jwtlib "github.com/golang-jwt/jwt/v5"
 )
 
 const testSecret = "this-is-a-very-secure-secret-key-for-testing-purposes"
@@ -252,3 +256,88 @@ func TestRequireRole_NoAuth(t *testing.T) {
 		t.Errorf("expected 401 without auth, got %d", resp.StatusCode)
 	}
 }
+
+func TestRequireScope_Write(t *testing.T) {
+	app := setupTestAppWithMiddleware(RequireScope(jwt.ScopeWrite))
+	svc, _ := jwt.NewService(testSecret)
+
+	tests := []struct {
+		scope          string
+		method         string
+		expectedStatus int
+	}{
+		{jwt.ScopeWrite, http.MethodGet, http.StatusOK},
+		{jwt.ScopeWrite, http.MethodPost, http.StatusOK},
+		{jwt.ScopeWrite, http.MethodDelete, http.StatusOK},
+		{jwt.ScopeRead, http.MethodGet, http.StatusOK},
+		{jwt.ScopeRead, http.MethodPost, http.StatusForbidden},
+		{jwt.ScopeRead, http.MethodPut, http.StatusForbidden},
+		{jwt.ScopeRead, http.MethodDelete, http.StatusForbidden},
+		// Tokens issued before scopes existed keep full access
+		{"", http.MethodPost, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.scope+"_"+tt.method, func(t *testing.T) {
+			token, err := svc.GenerateScopedToken("user-1", "Test User", "admin", "workspace-1", 0, tt.scope)
+			if err != nil {
+				t.Fatalf("failed to generate token: %v", err)
+			}
+			resp := doRequest(t, app, tt.method, "/resource", token)
+
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("%s with scope %q: expected %d, got %d", tt.method, tt.scope, tt.expectedStatus, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestRequireAuth_ExpiredAndInvalidTokensAreDistinguishable(t *testing.T) {
+	app := setupTestAppWithMiddleware(RequireRole(domain.RoleUser))
+
+	past := time.Now().Add(-time.Hour)
+	expired, err := jwtlib.NewWithClaims(jwtlib.SigningMethodHS256, jwt.Claims{
+		ID:          "user-1",
+		Role:        "user",
+		WorkspaceID: "workspace-1",
+		RegisteredClaims: jwtlib.RegisteredClaims{
+			ExpiresAt: jwtlib.NewNumericDate(past),
+			IssuedAt:  jwtlib.NewNumericDate(past.Add(-time.Hour)),
+		},
+	}).SignedString([]byte(testSecret))
+	if err != nil {
+		t.Fatalf("failed to sign expired token: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		token       string
+		reason      string
+		description string
+	}{
+		{"expired", expired, "token_expired", "expired"},
+		{"garbage", "not-a-jwt", "invalid_token", "invalid"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := doRequest(t, app, http.MethodGet, "/resource", tt.token)
+			if resp.StatusCode != http.StatusUnauthorized {
+				t.Fatalf("expected 401, got %d", resp.StatusCode)
+			}
+
+			var body handlererrors.ErrorResponse
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if got := body.Error.Metadata["reason"]; got != tt.reason {
+				t.Errorf("expected reason %q, got %v", tt.reason, got)
+			}
+
+			header := resp.Header.Get(fiber.HeaderWWWAuthenticate)
+			if !strings.HasPrefix(header, `Bearer error="invalid_token"`) || !strings.Contains(header, tt.description) {
+				t.Errorf("unexpected WWW-Authenticate header %q", header)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package middleware
+
+import (
+	"strings"
+
+	"backend/internal/application"
+	"backend/internal/domain"
+	domainerrors "backend/internal/domain/errors"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RoutePolicy declares who may call a single method+route pair.
+type RoutePolicy struct {
+	Method string
+	// Path is a Fiber-style pattern relative to the matrix prefix, e.g. "/groups/:id"
+	Path string
+	// MinRole is the lowest role allowed to call the route
+	MinRole domain.Role
+	// WorkspaceParam optionally names a path parameter that must equal the
+	// caller's workspace ID (ownership check)
+	WorkspaceParam string
+}
+
+// PolicyMatrix resolves incoming requests to their RoutePolicy.
+type PolicyMatrix struct {
+	prefix      string
+	policies    []RoutePolicy
+	crossTenant application.CrossTenantDenial
+}
+
+// NewPolicyMatrix creates a matrix for routes mounted under prefix (e.g. "/api/v1").
+func NewPolicyMatrix(prefix string, policies []RoutePolicy) *PolicyMatrix {
+	return &PolicyMatrix{
+		prefix:      strings.TrimSuffix(prefix, "/"),
+		policies:    policies,
+		crossTenant: application.CrossTenantNotFound,
+	}
+}
+
+// WithCrossTenantDenial sets how a WorkspaceParam naming another workspace is
+// answered, as the services answer cross-workspace requests. Defaults to
+// application.CrossTenantNotFound.
+func (m *PolicyMatrix) WithCrossTenantDenial(denial application.CrossTenantDenial) *PolicyMatrix {
+	m.crossTenant = denial
+	return m
+}
+
+// Lookup finds the policy for method and path, returning the extracted path
+// parameters. When several patterns match, the one with the most literal
+// segments wins so "/admin/users/invite" beats "/admin/users/:id".
+func (m *PolicyMatrix) Lookup(method, path string) (RoutePolicy, map[string]string, bool) {
+	if method == fiber.MethodHead {
+		method = fiber.MethodGet
+	}
+
+	relative, ok := strings.CutPrefix(path, m.prefix)
+	if !ok {
+		return RoutePolicy{}, nil, false
+	}
+	segments := splitPath(relative)
+
+	var (
+		best       RoutePolicy
+		bestParams map[string]string
+		bestScore  = -1
+	)
+	for _, policy := range m.policies {
+		if policy.Method != method {
+			continue
+		}
+		params, score, ok := matchPattern(splitPath(policy.Path), segments)
+		if ok && score > bestScore {
+			best, bestParams, bestScore = policy, params, score
+		}
+	}
+
+	return best, bestParams, bestScore >= 0
+}
+
+// Authorize returns a Fiber middleware that enforces the matrix. It must run
+// after RequireAuth. Routes without a declared policy are denied.
+func Authorize(matrix *PolicyMatrix) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims, ok := GetClaims(c)
+		if !ok {
+			return domainerrors.Unauthorized("missing claims")
+		}
+
+		policy, params, found := matrix.Lookup(c.Method(), c.Path())
+		if !found {
+			return domainerrors.Forbidden(c.Path(), c.Method())
+		}
+
+		if !domain.Role(claims.Role).IsAtLeast(policy.MinRole) {
+			return domainerrors.Forbidden(c.Path(), c.Method())
+		}
+
+		if workspaceID := params[policy.WorkspaceParam]; policy.WorkspaceParam != "" && workspaceID != claims.WorkspaceID {
+			return matrix.crossTenant.Deny(claims, "Workspace", workspaceID, workspaceID, "workspace in path is not the caller's")
+		}
+
+		return c.Next()
+	}
+}
+
+func splitPath(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
+
+// matchPattern matches path segments against pattern segments, where ":name"
+// matches any single non-empty segment. The score is the number of literal matches.
+func matchPattern(pattern, segments []string) (map[string]string, int, bool) {
+	if len(pattern) != len(segments) {
+		return nil, 0, false
+	}
+
+	params := make(map[string]string)
+	score := 0
+	for i, part := range pattern {
+		if name, isParam := strings.CutPrefix(part, ":"); isParam {
+			if segments[i] == "" {
+				return nil, 0, false
+			}
+			params[name] = segments[i]
+			continue
+		}
+		if part != segments[i] {
+			return nil, 0, false
+		}
+		score++
+	}
+
+	return params, score, true
+}
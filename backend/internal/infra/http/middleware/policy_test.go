@@ -0,0 +1,233 @@
+package middleware_test
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"backend/internal/application"
+	handlererrors "backend/internal/application/errors"
+	"backend/internal/infra/http/handlers"
+	"backend/internal/infra/http/middleware"
+	"backend/pkg/jwt"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const policyTestSecret = "this-is-a-very-secure-secret-key-for-testing-purposes"
+
+func setupPolicyTestApp(t *testing.T) (*fiber.App, *jwt.Service) {
+	t.Helper()
+	return setupPolicyTestAppWithDenial(t, application.CrossTenantNotFound)
+}
+
+func setupPolicyTestAppWithDenial(t *testing.T, denial application.CrossTenantDenial) (*fiber.App, *jwt.Service) {
+	t.Helper()
+	jwtService, err := jwt.NewService(policyTestSecret)
+	if err != nil {
+		t.Fatalf("failed to create jwt service: %v", err)
+	}
+
+	app := fiber.New(fiber.Config{
+		ErrorHandler: handlererrors.ErrorHandler(),
+	})
+
+	api := app.Group("/api/v1")
+	protected := api.Group("",
+		middleware.RequireAuth(jwtService, jwt.DefaultCookieConfig()),
+		middleware.Authorize(middleware.NewPolicyMatrix("/api/v1", middleware.RoutePolicies()).WithCrossTenantDenial(denial)),
+	)
+	protected.All("/*", func(c *fiber.Ctx) error {
+		return c.SendString("ok")
+	})
+
+	return app, jwtService
+}
+
+func doPolicyRequest(t *testing.T, app *fiber.App, jwtService *jwt.Service, method, path, role, workspaceID string) int {
+	t.Helper()
+	token, err := jwtService.GenerateToken("user-1", "Test User", role, workspaceID)
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+
+	req := httptest.NewRequest(method, path, nil)
+	req.AddCookie(&http.Cookie{Name: "access_token", Value: token})
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("failed to execute request: %v", err)
+	}
+	return resp.StatusCode
+}
+
+func TestAuthorize_RoleMatrix(t *testing.T) {
+	app, jwtService := setupPolicyTestApp(t)
+
+	tests := []struct {
+		method string
+		path   string
+		role   string
+		want   int
+	}{
+		// Admin-only routes
+		{fiber.MethodGet, "/api/v1/admin/users", "user", fiber.StatusForbidden},
+		{fiber.MethodGet, "/api/v1/admin/users", "editor", fiber.StatusForbidden},
+		{fiber.MethodGet, "/api/v1/admin/users", "admin", fiber.StatusOK},
+		{fiber.MethodGet, "/api/v1/admin/init-attempts", "editor", fiber.StatusForbidden},
+		{fiber.MethodGet, "/api/v1/admin/init-attempts", "admin", fiber.StatusOK},
+		{fiber.MethodPost, "/api/v1/admin/users/invite", "user", fiber.StatusForbidden},
+		{fiber.MethodPost, "/api/v1/admin/users/invite", "admin", fiber.StatusOK},
+		{fiber.MethodDelete, "/api/v1/admin/users/abc", "editor", fiber.StatusForbidden},
+		{fiber.MethodDelete, "/api/v1/admin/users/abc", "admin", fiber.StatusOK},
+		{fiber.MethodPost, "/api/v1/users/abc/move", "editor", fiber.StatusForbidden},
+		{fiber.MethodPost, "/api/v1/users/abc/move", "admin", fiber.StatusOK},
+		{fiber.MethodGet, "/api/v1/groups", "user", fiber.StatusForbidden},
+		{fiber.MethodGet, "/api/v1/groups", "admin", fiber.StatusOK},
+		{fiber.MethodDelete, "/api/v1/groups/g1/members/u1", "editor", fiber.StatusForbidden},
+		{fiber.MethodDelete, "/api/v1/groups/g1/members/u1", "admin", fiber.StatusOK},
+		{fiber.MethodGet, "/api/v1/debug/info", "user", fiber.StatusForbidden},
+		{fiber.MethodGet, "/api/v1/debug/info", "admin", fiber.StatusOK},
+		{fiber.MethodPost, "/api/v1/workspaces/workspace-1/rotate-secret", "editor", fiber.StatusForbidden},
+		{fiber.MethodPost, "/api/v1/workspaces/workspace-1/rotate-secret", "admin", fiber.StatusOK},
+		{fiber.MethodPost, "/api/v1/workspaces/workspace-1/revoke-sessions", "editor", fiber.StatusForbidden},
+		{fiber.MethodPost, "/api/v1/workspaces/workspace-1/revoke-sessions", "admin", fiber.StatusOK},
+		{fiber.MethodGet, "/api/v1/admin/workspaces/purge-report", "editor", fiber.StatusForbidden},
+		{fiber.MethodGet, "/api/v1/admin/workspaces/purge-report", "admin", fiber.StatusOK},
+		{fiber.MethodGet, "/api/v1/workspaces/workspace-1/api-keys", "editor", fiber.StatusForbidden},
+		{fiber.MethodGet, "/api/v1/workspaces/workspace-1/api-keys", "admin", fiber.StatusOK},
+		{fiber.MethodDelete, "/api/v1/workspaces/workspace-1/api-keys/k1", "user", fiber.StatusForbidden},
+		{fiber.MethodDelete, "/api/v1/workspaces/workspace-1/api-keys/k1", "admin", fiber.StatusOK},
+
+		// Editor-write routes
+		{fiber.MethodGet, "/api/v1/workspaces", "user", fiber.StatusOK},
+		{fiber.MethodPost, "/api/v1/workspaces", "user", fiber.StatusForbidden},
+		{fiber.MethodPost, "/api/v1/workspaces", "editor", fiber.StatusOK},
+		{fiber.MethodPut, "/api/v1/templates/t1", "user", fiber.StatusForbidden},
+		{fiber.MethodPut, "/api/v1/templates/t1", "editor", fiber.StatusOK},
+		{fiber.MethodGet, "/api/v1/templates/t1/files/content", "user", fiber.StatusOK},
+		{fiber.MethodGet, "/api/v1/templates/t1/diff/t2", "user", fiber.StatusOK},
+		{fiber.MethodGet, "/api/v1/templates/t1/versions", "user", fiber.StatusOK},
+		{fiber.MethodPost, "/api/v1/templates/t1/versions/1/restore", "user", fiber.StatusForbidden},
+		{fiber.MethodPost, "/api/v1/templates/t1/versions/1/restore", "editor", fiber.StatusOK},
+		{fiber.MethodPost, "/api/v1/templates/t1/variables/parse", "user", fiber.StatusForbidden},
+		{fiber.MethodPost, "/api/v1/templates/t1/variables/parse", "admin", fiber.StatusOK},
+
+		// All-roles routes
+		{fiber.MethodGet, "/api/v1/me", "user", fiber.StatusOK},
+		{fiber.MethodGet, "/api/v1/users/u1", "user", fiber.StatusOK},
+		{fiber.MethodPost, "/api/v1/environments/e1/apply", "user", fiber.StatusOK},
+		{fiber.MethodPost, "/api/v1/environments/e1/restore", "user", fiber.StatusOK},
+		{fiber.MethodHead, "/api/v1/environments", "user", fiber.StatusOK},
+
+		// Undeclared routes are denied
+		{fiber.MethodGet, "/api/v1/unknown", "admin", fiber.StatusForbidden},
+		{fiber.MethodPatch, "/api/v1/workspaces/w1", "admin", fiber.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path+" as "+tt.role, func(t *testing.T) {
+			got := doPolicyRequest(t, app, jwtService, tt.method, tt.path, tt.role, "workspace-1")
+			if got != tt.want {
+				t.Errorf("expected %d, got %d", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestAuthorize_WorkspaceOwnership(t *testing.T) {
+	for _, tc := range []struct {
+		denial application.CrossTenantDenial
+		want   int
+	}{
+		{application.CrossTenantNotFound, fiber.StatusNotFound},
+		{application.CrossTenantForbidden, fiber.StatusForbidden},
+	} {
+		t.Run(string(tc.denial), func(t *testing.T) {
+			app, jwtService := setupPolicyTestAppWithDenial(t, tc.denial)
+
+			own := []struct{ method, path, role string }{
+				{fiber.MethodGet, "/api/v1/templates/workspace/workspace-1", "user"},
+				{fiber.MethodGet, "/api/v1/workspaces/workspace-1/template-stats", "user"},
+			}
+			for _, r := range own {
+				if got := doPolicyRequest(t, app, jwtService, r.method, r.path, r.role, "workspace-1"); got != fiber.StatusOK {
+					t.Errorf("%s %s: expected 200 for own workspace, got %d", r.method, r.path, got)
+				}
+			}
+
+			other := []struct{ method, path string }{
+				{fiber.MethodGet, "/api/v1/templates/workspace/workspace-2"},
+				{fiber.MethodPost, "/api/v1/workspaces/workspace-2/rotate-secret"},
+				{fiber.MethodPost, "/api/v1/workspaces/workspace-2/revoke-sessions"},
+				{fiber.MethodGet, "/api/v1/workspaces/workspace-2/api-keys"},
+				{fiber.MethodGet, "/api/v1/workspaces/workspace-2/template-stats"},
+				{fiber.MethodGet, "/api/v1/workspaces/workspace-2/template-schemes"},
+			}
+			for _, r := range other {
+				if got := doPolicyRequest(t, app, jwtService, r.method, r.path, "admin", "workspace-1"); got != tc.want {
+					t.Errorf("%s %s: expected %d for another workspace, got %d", r.method, r.path, tc.want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestAuthorize_WorkspaceOwnershipLogsDenial(t *testing.T) {
+	var logs bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&logs, nil)))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	app, jwtService := setupPolicyTestApp(t)
+	doPolicyRequest(t, app, jwtService, fiber.MethodGet, "/api/v1/workspaces/workspace-2/api-keys", "admin", "workspace-1")
+
+	if !strings.Contains(logs.String(), `"msg":"authorization denied"`) || !strings.Contains(logs.String(), `"resource_workspace_id":"workspace-2"`) {
+		t.Errorf("expected an authorization denied log naming workspace-2, got %s", logs.String())
+	}
+}
+
+func TestNewPolicyMatrix_LiteralSegmentsWin(t *testing.T) {
+	matrix := middleware.NewPolicyMatrix("/api/v1", middleware.RoutePolicies())
+
+	policy, _, ok := matrix.Lookup(fiber.MethodGet, "/api/v1/workspaces/admin/a1")
+	if !ok || policy.Path != "/workspaces/admin/:admin_id" {
+		t.Errorf("expected /workspaces/admin/:admin_id, got %+v", policy)
+	}
+
+	policy, params, ok := matrix.Lookup(fiber.MethodGet, "/api/v1/workspaces/w1")
+	if !ok || policy.Path != "/workspaces/:id" || params["id"] != "w1" {
+		t.Errorf("expected /workspaces/:id with id=w1, got %+v %v", policy, params)
+	}
+}
+
+// TestRoutePolicies_CoverEveryProtectedRoute guards against registering a route
+// without declaring its policy.
+func TestRoutePolicies_CoverEveryProtectedRoute(t *testing.T) {
+	app := fiber.New()
+	api := app.Group("/api/v1")
+	handlers.NewUserHandler(nil, nil).RegisterProtectedRoutes(api)
+	handlers.NewEnvironmentHandler(nil).RegisterRoutes(api)
+	handlers.NewEnvironmentVariableValueHandler(nil).RegisterRoutes(api)
+	handlers.NewWorkspaceHandler(nil).RegisterRoutes(api)
+	handlers.NewTemplateHandler(nil).RegisterRoutes(api)
+	handlers.NewTemplateVariableHandler(nil).RegisterRoutes(api)
+	handlers.NewAdminHandler(nil, nil, "").RegisterAdminRoutes(api)
+	handlers.NewGroupHandler(nil).RegisterRoutes(api)
+	handlers.NewAPIKeyHandler(nil).RegisterRoutes(api)
+	handlers.NewDebugHandler(time.Now()).RegisterRoutes(api)
+
+	matrix := middleware.NewPolicyMatrix("/api/v1", middleware.RoutePolicies())
+	for _, route := range app.GetRoutes(true) {
+		if route.Method == fiber.MethodHead || !strings.HasPrefix(route.Path, "/api/v1/") {
+			continue
+		}
+		policy, _, ok := matrix.Lookup(route.Method, route.Path)
+		if !ok || "/api/v1"+policy.Path != route.Path {
+			t.Errorf("route %s %s has no matching policy", route.Method, route.Path)
+		}
+	}
+}
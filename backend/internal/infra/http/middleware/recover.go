@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"fmt"
+
+	"backend/pkg/errors"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Recover returns a Fiber middleware that turns panics in later handlers into
+// a critical INTERNAL error carrying the panic value in its metadata and the
+// panicking frames in its stack. The ErrorHandler logs both once; the client
+// only sees the standard error response.
+func Recover() fiber.Handler {
+	return func(c *fiber.Ctx) (err error) {
+		defer func() {
+			r := recover()
+			if r == nil {
+				return
+			}
+
+			// Created inside the deferred call so the captured stack still
+			// includes the frames that panicked.
+			err = errors.WithCode(errors.CodeInternal, "internal server error").
+				WithSeverity(errors.SeverityCritical).
+				WithMetadata(errors.MetadataPanic, fmt.Sprint(r))
+		}()
+
+		return c.Next()
+	}
+}
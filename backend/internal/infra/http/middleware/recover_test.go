@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	handlererrors "backend/internal/application/errors"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestRecover_PanicReturnsStandardErrorResponse(t *testing.T) {
+	var logs bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&logs, nil)))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	app := fiber.New(fiber.Config{
+		ErrorHandler: handlererrors.ErrorHandler(),
+	})
+	app.Use(Recover())
+	app.Get("/boom", func(c *fiber.Ctx) error {
+		panic("something went badly wrong")
+	})
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/boom", nil))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("expected status 500, got %d", resp.StatusCode)
+	}
+
+	var body handlererrors.ErrorResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+	if body.Error.Code != "INTERNAL" {
+		t.Errorf("expected code INTERNAL, got %q", body.Error.Code)
+	}
+	if strings.Contains(body.Error.Message, "badly wrong") || body.Error.Metadata["panic"] != nil {
+		t.Errorf("panic value leaked to the client: %+v", body.Error)
+	}
+
+	var logged []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(logs.String()), "\n") {
+		var entry map[string]any
+		if err := json.Unmarshal([]byte(line), &entry); err == nil {
+			logged = append(logged, entry)
+		}
+	}
+	if len(logged) != 1 {
+		t.Fatalf("expected the panic to be logged once, got:\n%s", logs.String())
+	}
+	if logged[0]["path"] != "/boom" {
+		t.Errorf("expected the request path to be logged, got %v", logged[0]["path"])
+	}
+
+	loggedErr, _ := logged[0]["error"].(map[string]any)
+	if loggedErr["severity"] != "CRITICAL" {
+		t.Errorf("expected severity CRITICAL, got %v", loggedErr["severity"])
+	}
+	if metadata, _ := loggedErr["metadata"].(map[string]any); metadata["panic"] != "something went badly wrong" {
+		t.Errorf("expected panic value to be logged, got %v", loggedErr["metadata"])
+	}
+	stack, _ := loggedErr["stack_trace"].(map[string]any)
+	if len(stack) == 0 {
+		t.Fatal("expected a stack trace in the log entry")
+	}
+	if !strings.Contains(logs.String(), "TestRecover_PanicReturnsStandardErrorResponse") {
+		t.Error("expected the stack trace to include the panicking handler")
+	}
+}
@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"backend/internal/domain"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RoutePolicies is the authorization matrix for every authenticated /api/v1 route.
+// Adding a protected route means declaring its policy here; undeclared routes are denied.
+// Finer-grained ownership checks (e.g. a template belonging to the caller's workspace)
+// still happen in the application services once the resource is loaded.
+func RoutePolicies() []RoutePolicy {
+	return []RoutePolicy{
+		// Current user
+		{Method: fiber.MethodGet, Path: "/me", MinRole: domain.RoleUser},
+		{Method: fiber.MethodGet, Path: "/users/:id", MinRole: domain.RoleUser},
+
+		// Environments — all roles can read and write
+		{Method: fiber.MethodPost, Path: "/environments", MinRole: domain.RoleUser},
+		{Method: fiber.MethodGet, Path: "/environments", MinRole: domain.RoleUser},
+		{Method: fiber.MethodGet, Path: "/environments/:id", MinRole: domain.RoleUser},
+		{Method: fiber.MethodGet, Path: "/environments/:id/outputs", MinRole: domain.RoleUser},
+		{Method: fiber.MethodPost, Path: "/environments/:id/plan", MinRole: domain.RoleUser},
+		{Method: fiber.MethodPost, Path: "/environments/:id/apply", MinRole: domain.RoleUser},
+		{Method: fiber.MethodPost, Path: "/environments/:id/destroy", MinRole: domain.RoleUser},
+		{Method: fiber.MethodDelete, Path: "/environments/:id", MinRole: domain.RoleUser},
+		{Method: fiber.MethodPost, Path: "/environments/:id/restore", MinRole: domain.RoleUser},
+		{Method: fiber.MethodPut, Path: "/environments/:id/variables", MinRole: domain.RoleUser},
+		{Method: fiber.MethodGet, Path: "/environments/:id/variables", MinRole: domain.RoleUser},
+
+		// Workspaces — editor and admin can write, all can read
+		{Method: fiber.MethodPost, Path: "/workspaces", MinRole: domain.RoleEditor},
+		{Method: fiber.MethodGet, Path: "/workspaces", MinRole: domain.RoleUser},
+		{Method: fiber.MethodGet, Path: "/workspaces/admin/:admin_id", MinRole: domain.RoleUser},
+		{Method: fiber.MethodGet, Path: "/workspaces/batch", MinRole: domain.RoleUser},
+		{Method: fiber.MethodGet, Path: "/workspaces/:id", MinRole: domain.RoleUser},
+		{Method: fiber.MethodPut, Path: "/workspaces/:id", MinRole: domain.RoleEditor},
+		{Method: fiber.MethodDelete, Path: "/workspaces/:id", MinRole: domain.RoleEditor},
+		{Method: fiber.MethodPost, Path: "/workspaces/:id/rotate-secret", MinRole: domain.RoleAdmin, WorkspaceParam: "id"},
+		{Method: fiber.MethodPost, Path: "/workspaces/:id/revoke-sessions", MinRole: domain.RoleAdmin, WorkspaceParam: "id"},
+
+		// Workspace API keys — admin only, own workspace
+		{Method: fiber.MethodPost, Path: "/workspaces/:id/api-keys", MinRole: domain.RoleAdmin, WorkspaceParam: "id"},
+		{Method: fiber.MethodGet, Path: "/workspaces/:id/api-keys", MinRole: domain.RoleAdmin, WorkspaceParam: "id"},
+		{Method: fiber.MethodDelete, Path: "/workspaces/:id/api-keys/:key_id", MinRole: domain.RoleAdmin, WorkspaceParam: "id"},
+
+		// Templates — editor and admin can write, all can read
+		{Method: fiber.MethodPost, Path: "/templates", MinRole: domain.RoleEditor},
+		{Method: fiber.MethodGet, Path: "/templates", MinRole: domain.RoleUser},
+		{Method: fiber.MethodGet, Path: "/templates/workspace/:workspace_id", MinRole: domain.RoleUser, WorkspaceParam: "workspace_id"},
+		{Method: fiber.MethodGet, Path: "/templates/:id", MinRole: domain.RoleUser},
+		{Method: fiber.MethodPut, Path: "/templates/:id", MinRole: domain.RoleEditor},
+		{Method: fiber.MethodDelete, Path: "/templates/:id", MinRole: domain.RoleEditor},
+		{Method: fiber.MethodGet, Path: "/templates/:id/files", MinRole: domain.RoleUser},
+		{Method: fiber.MethodGet, Path: "/templates/:id/files/content", MinRole: domain.RoleUser},
+		{Method: fiber.MethodGet, Path: "/templates/:id/diff/:other_id", MinRole: domain.RoleUser},
+		{Method: fiber.MethodGet, Path: "/templates/:id/versions", MinRole: domain.RoleUser},
+		{Method: fiber.MethodPost, Path: "/templates/:id/versions/:version/restore", MinRole: domain.RoleEditor},
+		{Method: fiber.MethodPost, Path: "/templates/:id/validate-path", MinRole: domain.RoleEditor},
+		{Method: fiber.MethodGet, Path: "/workspaces/:id/template-stats", MinRole: domain.RoleUser, WorkspaceParam: "id"},
+		{Method: fiber.MethodGet, Path: "/workspaces/:id/template-schemes", MinRole: domain.RoleUser, WorkspaceParam: "id"},
+
+		// Template variables — editor and admin can write, all can read
+		{Method: fiber.MethodPost, Path: "/templates/:id/variables", MinRole: domain.RoleEditor},
+		{Method: fiber.MethodGet, Path: "/templates/:id/variables", MinRole: domain.RoleUser},
+		{Method: fiber.MethodPut, Path: "/templates/:id/variables/:varId", MinRole: domain.RoleEditor},
+		{Method: fiber.MethodDelete, Path: "/templates/:id/variables/:varId", MinRole: domain.RoleEditor},
+		{Method: fiber.MethodPost, Path: "/templates/:id/variables/parse", MinRole: domain.RoleEditor},
+
+		// Admin user management — admin only
+		{Method: fiber.MethodGet, Path: "/admin/init-attempts", MinRole: domain.RoleAdmin},
+		{Method: fiber.MethodGet, Path: "/admin/users", MinRole: domain.RoleAdmin},
+		{Method: fiber.MethodGet, Path: "/admin/users/by-email", MinRole: domain.RoleAdmin},
+		{Method: fiber.MethodPost, Path: "/admin/users/invite", MinRole: domain.RoleAdmin},
+		{Method: fiber.MethodPost, Path: "/admin/users/invite/batch", MinRole: domain.RoleAdmin},
+		{Method: fiber.MethodPost, Path: "/admin/users/:id/reset-password", MinRole: domain.RoleAdmin},
+		{Method: fiber.MethodDelete, Path: "/admin/users/:id", MinRole: domain.RoleAdmin},
+		{Method: fiber.MethodPost, Path: "/users/:id/move", MinRole: domain.RoleAdmin},
+
+		// Workspace recovery — admin only
+		{Method: fiber.MethodGet, Path: "/admin/workspaces/deleted", MinRole: domain.RoleAdmin},
+		{Method: fiber.MethodGet, Path: "/admin/workspaces/purge-report", MinRole: domain.RoleAdmin},
+		{Method: fiber.MethodPost, Path: "/admin/workspaces/:id/restore", MinRole: domain.RoleAdmin},
+
+		// Groups — admin only
+		{Method: fiber.MethodPost, Path: "/groups", MinRole: domain.RoleAdmin},
+		{Method: fiber.MethodGet, Path: "/groups", MinRole: domain.RoleAdmin},
+		{Method: fiber.MethodGet, Path: "/groups/:id", MinRole: domain.RoleAdmin},
+		{Method: fiber.MethodPut, Path: "/groups/:id", MinRole: domain.RoleAdmin},
+		{Method: fiber.MethodDelete, Path: "/groups/:id", MinRole: domain.RoleAdmin},
+		{Method: fiber.MethodPost, Path: "/groups/:id/members", MinRole: domain.RoleAdmin},
+		{Method: fiber.MethodGet, Path: "/groups/:id/members", MinRole: domain.RoleAdmin},
+		{Method: fiber.MethodDelete, Path: "/groups/:id/members/:user_id", MinRole: domain.RoleAdmin},
+		{Method: fiber.MethodPost, Path: "/groups/:id/templates", MinRole: domain.RoleAdmin},
+		{Method: fiber.MethodGet, Path: "/groups/:id/templates", MinRole: domain.RoleAdmin},
+		{Method: fiber.MethodDelete, Path: "/groups/:id/templates/:template_id", MinRole: domain.RoleAdmin},
+
+		// Diagnostics — admin only
+		{Method: fiber.MethodGet, Path: "/debug/info", MinRole: domain.RoleAdmin},
+	}
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	domainerrors "backend/internal/domain/errors"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RequireScope returns a Fiber middleware that requires the token scope on
+// mutating requests. Safe methods (GET, HEAD, OPTIONS) are allowed for every
+// scope, so applying RequireScope(jwt.ScopeWrite) to a group leaves read-only
+// tokens able to read but not change anything. It must run after RequireAuth.
+func RequireScope(scope string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		switch c.Method() {
+		case fiber.MethodGet, fiber.MethodHead, fiber.MethodOptions:
+			return c.Next()
+		}
+
+		claims, ok := GetClaims(c)
+		if !ok {
+			return domainerrors.Unauthorized("missing claims")
+		}
+
+		if !claims.HasScope(scope) {
+			return domainerrors.Forbidden(c.Path(), c.Method())
+		}
+
+		return c.Next()
+	}
+}
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"context"
+
+	"backend/pkg/errors"
+	"backend/pkg/jwt"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// TokenRevocationChecker decides whether otherwise valid claims have been revoked.
+type TokenRevocationChecker interface {
+	Check(ctx context.Context, claims *jwt.Claims) *errors.Error
+}
+
+// RejectRevokedTokens returns a Fiber middleware that fails requests whose
+// claims checker reports as revoked. It must run after RequireAuth.
+func RejectRevokedTokens(checker TokenRevocationChecker) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims, ok := GetClaims(c)
+		if !ok {
+			return c.Next()
+		}
+
+		if err := checker.Check(c.Context(), claims); err != nil {
+			return err
+		}
+
+		return c.Next()
+	}
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"log/slog"
+
+	apphandlers "backend/internal/application/handlers"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// AuditTransactions returns a Fiber middleware that hands each request a
+// TxTracker and warns when the request ends with a transaction still open,
+// i.e. a Begin that never met its Commit or Rollback. The request itself is
+// not failed.
+func AuditTransactions() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		tracker := &apphandlers.TxTracker{}
+		c.Locals(apphandlers.TxTrackerKey, tracker)
+
+		err := c.Next()
+
+		if open := tracker.Finish(); open > 0 {
+			slog.Warn("request ended with open transactions",
+				"method", c.Method(),
+				"path", c.Path(),
+				"open", open,
+			)
+		}
+		return err
+	}
+}
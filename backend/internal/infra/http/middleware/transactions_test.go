@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	apphandlers "backend/internal/application/handlers"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestAuditTransactions_WarnsWhenRequestLeavesTransactionOpen(t *testing.T) {
+	var logs bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&logs, nil)))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	app := fiber.New()
+	app.Use(AuditTransactions())
+	app.Get("/balanced", func(c *fiber.Ctx) error {
+		tracker, ok := apphandlers.TxTrackerFromContext(c.Context())
+		if !ok {
+			t.Fatal("expected a tracker in the request context")
+		}
+		tracker.Opened()
+		tracker.Closed()
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+	app.Get("/leaky", func(c *fiber.Ctx) error {
+		tracker, _ := apphandlers.TxTrackerFromContext(c.Context())
+		tracker.Opened()
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	_, leftOpenBefore := apphandlers.TxAnomalies()
+
+	if _, err := app.Test(httptest.NewRequest(http.MethodGet, "/balanced", nil)); err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if strings.Contains(logs.String(), "open transactions") {
+		t.Errorf("expected no warning for a balanced request, got:\n%s", logs.String())
+	}
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/leaky", nil))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusNoContent {
+		t.Errorf("expected the request itself to succeed, got %d", resp.StatusCode)
+	}
+	if !strings.Contains(logs.String(), "request ended with open transactions") || !strings.Contains(logs.String(), `"path":"/leaky"`) {
+		t.Errorf("expected a warning naming /leaky, got:\n%s", logs.String())
+	}
+	if _, leftOpen := apphandlers.TxAnomalies(); leftOpen != leftOpenBefore+1 {
+		t.Errorf("expected the left-open counter to grow by 1, got %d -> %d", leftOpenBefore, leftOpen)
+	}
+}
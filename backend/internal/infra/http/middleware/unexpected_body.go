@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"backend/pkg/errors"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RejectUnexpectedBody returns a Fiber middleware that answers 400 to a GET or
+// DELETE request carrying a non-empty body. No route reads such a body, and
+// caches and proxies may drop or mishandle it, so it is almost always a client
+// bug. With warnOnly the request is logged and let through instead.
+func RejectUnexpectedBody(warnOnly bool) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		method := c.Method()
+		if method != fiber.MethodGet && method != fiber.MethodDelete {
+			return c.Next()
+		}
+		if len(c.Body()) == 0 {
+			return c.Next()
+		}
+
+		if warnOnly {
+			slog.Warn("request body sent with a bodyless method",
+				"method", method,
+				"path", c.Path(),
+				"body_bytes", len(c.Body()),
+			)
+			return c.Next()
+		}
+
+		return errors.WithCode(errors.CodeInvalidInput, fmt.Sprintf("%s requests must not have a body", method)).
+			WithMetadata(errors.MetadataReason, "unexpected_body").
+			WithHTTPStatus(http.StatusBadRequest).
+			WithSeverity(errors.SeverityWarning)
+	}
+}
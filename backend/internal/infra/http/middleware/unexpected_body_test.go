@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	handlererrors "backend/internal/application/errors"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func newUnexpectedBodyApp(warnOnly bool) *fiber.App {
+	app := fiber.New(fiber.Config{ErrorHandler: handlererrors.ErrorHandler()})
+	app.Use(RejectUnexpectedBody(warnOnly))
+	ok := func(c *fiber.Ctx) error { return c.SendString("ok") }
+	app.Get("/resource", ok)
+	app.Delete("/resource", ok)
+	app.Post("/resource", ok)
+	return app
+}
+
+func sendWithBody(t *testing.T, app *fiber.App, method, body string) *http.Response {
+	t.Helper()
+	req := httptest.NewRequest(method, "/resource", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	return resp
+}
+
+func TestRejectUnexpectedBody_Strict(t *testing.T) {
+	app := newUnexpectedBodyApp(false)
+
+	tests := []struct {
+		method string
+		body   string
+		want   int
+	}{
+		{http.MethodGet, `{"name":"x"}`, http.StatusBadRequest},
+		{http.MethodDelete, `{"name":"x"}`, http.StatusBadRequest},
+		{http.MethodGet, "", http.StatusOK},
+		{http.MethodDelete, "", http.StatusOK},
+		{http.MethodPost, `{"name":"x"}`, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.body, func(t *testing.T) {
+			resp := sendWithBody(t, app, tt.method, tt.body)
+			defer resp.Body.Close()
+			if resp.StatusCode != tt.want {
+				t.Fatalf("expected %d, got %d", tt.want, resp.StatusCode)
+			}
+			if tt.want != http.StatusBadRequest {
+				return
+			}
+
+			var body struct {
+				Error struct {
+					Code     string         `json:"code"`
+					Message  string         `json:"message"`
+					Metadata map[string]any `json:"metadata"`
+				} `json:"error"`
+			}
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatalf("failed to decode error response: %v", err)
+			}
+			if body.Error.Code != "INVALID_INPUT" || !strings.Contains(body.Error.Message, tt.method) {
+				t.Errorf("unexpected error: %+v", body.Error)
+			}
+			if body.Error.Metadata["reason"] != "unexpected_body" {
+				t.Errorf("expected reason unexpected_body, got %v", body.Error.Metadata["reason"])
+			}
+		})
+	}
+}
+
+func TestRejectUnexpectedBody_WarnOnly(t *testing.T) {
+	var logs bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&logs, nil)))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	app := newUnexpectedBodyApp(true)
+	resp := sendWithBody(t, app, http.MethodGet, `{"name":"x"}`)
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200 in warn-only mode, got %d", resp.StatusCode)
+	}
+	if !strings.Contains(logs.String(), "request body sent with a bodyless method") || !strings.Contains(logs.String(), `"path":"/resource"`) {
+		t.Errorf("expected the request to be logged, got %s", logs.String())
+	}
+}
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+const WorkspaceScopeKey contextKeyType = "workspace_scope"
+
+// ScopeToWorkspace returns a Fiber middleware that confines the request's
+// scoped repository reads to the workspace in its claims, as a backstop for
+// services that forget their own workspace check. It must run after
+// RequireAuth; ContextWithClaims hands the scope to the application layer.
+// Claims without a parseable workspace are left unscoped for the services to
+// reject.
+func ScopeToWorkspace() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims, ok := GetClaims(c)
+		if !ok {
+			return c.Next()
+		}
+
+		if workspaceID, err := uuid.Parse(claims.WorkspaceID); err == nil {
+			c.Locals(WorkspaceScopeKey, workspaceID)
+		}
+		return c.Next()
+	}
+}
@@ -0,0 +1,20 @@
+// Package migrations holds the SQL migrations, compiled into the binaries so
+// the server knows the schema version it was built for.
+package migrations
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed sqlite/*.sql
+var sqliteFiles embed.FS
+
+// SQLite returns the SQLite migrations, with the files at its root.
+func SQLite() fs.FS {
+	files, err := fs.Sub(sqliteFiles, "sqlite")
+	if err != nil {
+		panic(err) // the directory is fixed at compile time
+	}
+	return files
+}
@@ -0,0 +1 @@
+ALTER TABLE templates ADD COLUMN repo_url TEXT;
@@ -0,0 +1,9 @@
+-- Single-row marker written by admin init. The fixed primary key makes a second
+-- concurrent init fail on insert instead of racing a user count. The row goes
+-- away with the initial workspace, which is when init becomes possible again.
+CREATE TABLE IF NOT EXISTS system_init (
+    id INTEGER PRIMARY KEY CHECK (id = 1),
+    workspace_id TEXT NOT NULL,
+    initialized_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%S', 'now')),
+    CONSTRAINT fk_workspace FOREIGN KEY (workspace_id) REFERENCES workspaces(id) ON DELETE CASCADE
+);
@@ -0,0 +1,2 @@
+ALTER TABLE workspaces DROP COLUMN secret_rotated_at;
+ALTER TABLE workspaces DROP COLUMN secret_hash;
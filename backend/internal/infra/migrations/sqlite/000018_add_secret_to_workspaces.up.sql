@@ -0,0 +1,2 @@
+ALTER TABLE workspaces ADD COLUMN secret_hash TEXT;
+ALTER TABLE workspaces ADD COLUMN secret_rotated_at TEXT;
@@ -0,0 +1,3 @@
+-- Tokens carry the epoch current at issue; bumping it revokes every token
+-- issued to the user before the bump.
+ALTER TABLE users ADD COLUMN token_epoch INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,2 @@
+-- Keys created before scopes existed keep full access.
+ALTER TABLE api_keys ADD COLUMN scope TEXT NOT NULL DEFAULT 'write' CHECK (scope IN ('read', 'write'));
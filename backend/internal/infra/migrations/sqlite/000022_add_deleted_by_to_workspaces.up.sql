@@ -0,0 +1,2 @@
+-- Records who soft-deleted a workspace; NULL for deletions made before this existed.
+ALTER TABLE workspaces ADD COLUMN deleted_by TEXT;
@@ -0,0 +1,2 @@
+-- Incremented on every update; clients send it back to detect lost updates.
+ALTER TABLE workspaces ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
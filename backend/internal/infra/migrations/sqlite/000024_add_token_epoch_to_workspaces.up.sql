@@ -0,0 +1,3 @@
+-- Bumped to sign out everyone in the workspace; tokens carrying an older
+-- workspace epoch are rejected.
+ALTER TABLE workspaces ADD COLUMN token_epoch INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,2 @@
+-- Deleted environments are kept, hidden from reads, until they are restored.
+ALTER TABLE environments ADD COLUMN deleted_at TEXT;
@@ -0,0 +1,13 @@
+-- Domain events written in the same transaction as the change they describe
+-- and dispatched afterwards. aggregate_id names the entity the event is about
+-- and carries no foreign key, so events outlive it.
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id TEXT PRIMARY KEY,
+    type TEXT NOT NULL,
+    aggregate_id TEXT NOT NULL,
+    payload TEXT NOT NULL DEFAULT '{}',
+    created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%S', 'now')),
+    processed_at TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_events_pending ON outbox_events(processed_at, created_at);
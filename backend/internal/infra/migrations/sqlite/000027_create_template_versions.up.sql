@@ -0,0 +1,15 @@
+-- Snapshots of a template taken before each change. files holds a JSON object
+-- mapping each file's path within the template to its content.
+CREATE TABLE IF NOT EXISTS template_versions (
+    id TEXT PRIMARY KEY,
+    template_id TEXT NOT NULL,
+    workspace_id TEXT NOT NULL,
+    version INTEGER NOT NULL,
+    name TEXT NOT NULL,
+    path TEXT NOT NULL,
+    repo_url TEXT,
+    files TEXT NOT NULL DEFAULT '{}',
+    created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%S', 'now')),
+    FOREIGN KEY (template_id) REFERENCES templates(id) ON DELETE CASCADE,
+    UNIQUE(template_id, version)
+);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_admin_init_attempts_attempted_at;
+DROP TABLE IF EXISTS admin_init_attempts;
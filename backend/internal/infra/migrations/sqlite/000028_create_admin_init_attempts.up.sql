@@ -0,0 +1,11 @@
+-- Every request to POST /admin/init that reaches the handler, so takeover
+-- attempts on a fresh deployment can be spotted. outcome is one of success,
+-- conflict, unauthorized, invalid or error.
+CREATE TABLE IF NOT EXISTS admin_init_attempts (
+    id TEXT PRIMARY KEY,
+    outcome TEXT NOT NULL,
+    ip TEXT NOT NULL,
+    attempted_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%S', 'now'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_admin_init_attempts_attempted_at ON admin_init_attempts(attempted_at);
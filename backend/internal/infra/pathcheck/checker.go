@@ -0,0 +1,253 @@
+package pathcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"backend/internal/domain/storage"
+)
+
+const (
+	// DefaultTimeout bounds a single reachability check.
+	DefaultTimeout = 5 * time.Second
+
+	SchemeStorage = "storage"
+	SchemeFile    = "file"
+	SchemeHTTP    = "http"
+	SchemeHTTPS   = "https"
+
+	// maxRedirects is how many redirects a HEAD check follows; each hop is
+	// checked against the host allowlist and dialed through the same filter.
+	maxRedirects = 5
+)
+
+var (
+	errForbiddenAddress = errors.New("destination address is not allowed")
+	errForbiddenHost    = errors.New("host is not in the allowlist")
+	errTooManyRedirects = errors.New("too many redirects")
+)
+
+// nonPublicPrefixes are ranges outside the ones netip already classifies as
+// private, loopback or link-local that must not be reached from the server:
+// "this network", carrier-grade NAT, IETF protocol assignments, benchmarking,
+// reserved space and NAT64, which can embed any IPv4 address.
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+}
+
+// Checker resolves template locations by scheme:
+//   - no scheme: a path relative to the template storage root (always allowed)
+//   - file://: an absolute path, only when it lies under an allowlisted root
+//   - http(s)://: a HEAD request; any status below 400 counts as reachable.
+//     Only public addresses are dialed, checked after DNS resolution so a
+//     hostname cannot point the server at its own network, and when a host
+//     allowlist is set only those hosts are contacted.
+type Checker struct {
+	client      *http.Client
+	storageRoot string
+	fileRoots   []string
+	hosts       map[string]bool
+	timeout     time.Duration
+	// allowPrivate lets tests reach servers on loopback.
+	allowPrivate bool
+}
+
+func NewChecker(storageRoot string, fileAllowlist []string, timeout time.Duration) *Checker {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	roots := make([]string, 0, len(fileAllowlist))
+	for _, root := range fileAllowlist {
+		if root = strings.TrimSpace(root); root != "" {
+			roots = append(roots, filepath.Clean(root))
+		}
+	}
+
+	c := &Checker{
+		storageRoot: storageRoot,
+		fileRoots:   roots,
+		timeout:     timeout,
+	}
+	dialer := &net.Dialer{Timeout: timeout, Control: c.controlDial}
+	c.client = &http.Client{
+		Timeout: timeout,
+		// No proxy: the dial filter must see the target's address, not a proxy's
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: timeout,
+		},
+		CheckRedirect: c.checkRedirect,
+	}
+	return c
+}
+
+// WithHostAllowlist restricts http(s) checks, including redirect hops, to the
+// given hostnames. An empty list allows any host with a public address.
+func (c *Checker) WithHostAllowlist(hosts []string) *Checker {
+	c.hosts = nil
+	for _, host := range hosts {
+		if host = strings.ToLower(strings.TrimSpace(host)); host != "" {
+			if c.hosts == nil {
+				c.hosts = make(map[string]bool)
+			}
+			c.hosts[host] = true
+		}
+	}
+	return c
+}
+
+func (c *Checker) Check(ctx context.Context, target string) storage.PathCheck {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	result := c.check(ctx, target)
+	result.Target = target
+	return result
+}
+
+func (c *Checker) check(ctx context.Context, target string) storage.PathCheck {
+	u, err := url.Parse(target)
+	if err != nil {
+		return storage.PathCheck{Detail: "invalid path"}
+	}
+
+	switch strings.ToLower(u.Scheme) {
+	case "":
+		if !filepath.IsLocal(target) {
+			return storage.PathCheck{Scheme: SchemeStorage, Detail: "storage path must be relative to the storage root"}
+		}
+		return statPath(SchemeStorage, filepath.Join(c.storageRoot, target))
+	case SchemeFile:
+		path := filepath.Clean(u.Path)
+		if !filepath.IsAbs(path) {
+			return storage.PathCheck{Scheme: SchemeFile, Detail: "file path must be absolute"}
+		}
+		if !c.fileAllowed(path) {
+			return storage.PathCheck{Scheme: SchemeFile, Detail: "file path is not in the allowlist"}
+		}
+		return statPath(SchemeFile, path)
+	case SchemeHTTP, SchemeHTTPS:
+		return c.head(ctx, u)
+	default:
+		return storage.PathCheck{Scheme: u.Scheme, Detail: fmt.Sprintf("unsupported scheme %q", u.Scheme)}
+	}
+}
+
+func (c *Checker) fileAllowed(path string) bool {
+	for _, root := range c.fileRoots {
+		rel, err := filepath.Rel(root, path)
+		if err == nil && filepath.IsLocal(rel) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Checker) hostAllowed(host string) bool {
+	return c.hosts == nil || c.hosts[strings.ToLower(host)]
+}
+
+// checkRedirect applies the host allowlist to every hop and caps the chain.
+// The dial filter covers the addresses each hop resolves to.
+func (c *Checker) checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxRedirects {
+		return errTooManyRedirects
+	}
+	if scheme := strings.ToLower(req.URL.Scheme); scheme != SchemeHTTP && scheme != SchemeHTTPS {
+		return errForbiddenAddress
+	}
+	if !c.hostAllowed(req.URL.Hostname()) {
+		return errForbiddenHost
+	}
+	return nil
+}
+
+// controlDial runs after DNS resolution with the address about to be
+// connected to, and refuses anything that is not a public address.
+func (c *Checker) controlDial(_, address string, _ syscall.RawConn) error {
+	if c.allowPrivate {
+		return nil
+	}
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return errForbiddenAddress
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil || !publicAddr(addr) {
+		return errForbiddenAddress
+	}
+	return nil
+}
+
+// publicAddr reports whether addr is a globally routable unicast address.
+func publicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return false
+	}
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *Checker) head(ctx context.Context, u *url.URL) storage.PathCheck {
+	scheme := strings.ToLower(u.Scheme)
+
+	if !c.hostAllowed(u.Hostname()) {
+		return storage.PathCheck{Scheme: scheme, Detail: errForbiddenHost.Error()}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, u.String(), nil)
+	if err != nil {
+		return storage.PathCheck{Scheme: scheme, Detail: "invalid URL"}
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		for _, refused := range []error{errForbiddenAddress, errForbiddenHost, errTooManyRedirects} {
+			if errors.Is(err, refused) {
+				return storage.PathCheck{Scheme: scheme, Detail: refused.Error()}
+			}
+		}
+		if ctx.Err() != nil {
+			return storage.PathCheck{Scheme: scheme, Detail: "request timed out"}
+		}
+		return storage.PathCheck{Scheme: scheme, Detail: "request failed"}
+	}
+	resp.Body.Close()
+
+	result := storage.PathCheck{Scheme: scheme, StatusCode: resp.StatusCode, Reachable: resp.StatusCode < http.StatusBadRequest}
+	if !result.Reachable {
+		result.Detail = fmt.Sprintf("unexpected status %d", resp.StatusCode)
+	}
+	return result
+}
+
+func statPath(scheme, path string) storage.PathCheck {
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return storage.PathCheck{Scheme: scheme, Detail: "path does not exist"}
+		}
+		return storage.PathCheck{Scheme: scheme, Detail: "path is not accessible"}
+	}
+	return storage.PathCheck{Scheme: scheme, Reachable: true}
+}
@@ -0,0 +1,228 @@
+package pathcheck
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCheck_StoragePath(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "ws", "tmpl"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	c := NewChecker(root, nil, time.Second)
+
+	tests := []struct {
+		name          string
+		target        string
+		wantReachable bool
+	}{
+		{"existing", "ws/tmpl", true},
+		{"missing", "ws/other", false},
+		{"traversal", "../outside", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := c.Check(context.Background(), tt.target)
+			if result.Reachable != tt.wantReachable {
+				t.Errorf("Check(%q).Reachable = %v, want %v (detail %q)", tt.target, result.Reachable, tt.wantReachable, result.Detail)
+			}
+			if result.Scheme != SchemeStorage {
+				t.Errorf("Check(%q).Scheme = %q, want %q", tt.target, result.Scheme, SchemeStorage)
+			}
+		})
+	}
+}
+
+func TestCheck_FileSchemeAllowlist(t *testing.T) {
+	allowed := t.TempDir()
+	denied := t.TempDir()
+	c := NewChecker(t.TempDir(), []string{allowed}, time.Second)
+
+	tests := []struct {
+		name          string
+		target        string
+		wantReachable bool
+		wantDetail    string
+	}{
+		{"allowed root", "file://" + allowed, true, ""},
+		{"allowed missing", "file://" + filepath.Join(allowed, "missing"), false, "path does not exist"},
+		{"outside allowlist", "file://" + denied, false, "file path is not in the allowlist"},
+		{"escapes allowlist", "file://" + filepath.Join(allowed, "..", filepath.Base(denied)), false, "file path is not in the allowlist"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := c.Check(context.Background(), tt.target)
+			if result.Reachable != tt.wantReachable || result.Detail != tt.wantDetail {
+				t.Errorf("Check(%q) = {Reachable: %v, Detail: %q}, want {%v, %q}", tt.target, result.Reachable, result.Detail, tt.wantReachable, tt.wantDetail)
+			}
+		})
+	}
+}
+
+func TestCheck_FileSchemeDisabledByDefault(t *testing.T) {
+	c := NewChecker(t.TempDir(), nil, time.Second)
+
+	result := c.Check(context.Background(), "file://"+t.TempDir())
+	if result.Reachable {
+		t.Error("expected file scheme to be unreachable without an allowlist")
+	}
+}
+
+// newLoopbackChecker returns a checker allowed to reach httptest servers.
+func newLoopbackChecker(t *testing.T, timeout time.Duration) *Checker {
+	c := NewChecker(t.TempDir(), nil, timeout)
+	c.allowPrivate = true
+	return c
+}
+
+func TestCheck_HTTP(t *testing.T) {
+	ok := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			t.Errorf("expected HEAD request, got %s", r.Method)
+		}
+	}))
+	defer ok.Close()
+
+	notFound := httptest.NewServer(http.NotFoundHandler())
+	defer notFound.Close()
+
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+
+	c := newLoopbackChecker(t, time.Second)
+
+	tests := []struct {
+		name           string
+		target         string
+		wantReachable  bool
+		wantStatusCode int
+	}{
+		{"ok", ok.URL, true, http.StatusOK},
+		{"not found", notFound.URL, false, http.StatusNotFound},
+		{"connection refused", down.URL, false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := c.Check(context.Background(), tt.target)
+			if result.Reachable != tt.wantReachable || result.StatusCode != tt.wantStatusCode {
+				t.Errorf("Check(%q) = {Reachable: %v, StatusCode: %d}, want {%v, %d}", tt.target, result.Reachable, result.StatusCode, tt.wantReachable, tt.wantStatusCode)
+			}
+		})
+	}
+}
+
+func TestCheck_HTTPTimeout(t *testing.T) {
+	release := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer slow.Close()
+	defer close(release)
+
+	c := newLoopbackChecker(t, 50*time.Millisecond)
+
+	result := c.Check(context.Background(), slow.URL)
+	if result.Reachable || result.Detail != "request timed out" {
+		t.Errorf("expected timeout, got {Reachable: %v, Detail: %q}", result.Reachable, result.Detail)
+	}
+}
+
+func TestCheck_UnsupportedScheme(t *testing.T) {
+	c := NewChecker(t.TempDir(), nil, time.Second)
+
+	result := c.Check(context.Background(), "ssh://git@example.com/repo.git")
+	if result.Reachable || result.Scheme != "ssh" {
+		t.Errorf("expected unsupported ssh scheme, got %+v", result)
+	}
+}
+
+func TestCheck_HTTPRefusesNonPublicAddresses(t *testing.T) {
+	hit := false
+	local := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hit = true
+	}))
+	defer local.Close()
+
+	c := NewChecker(t.TempDir(), nil, time.Second)
+
+	for _, target := range []string{local.URL, "http://169.254.169.254/latest/meta-data/", "http://[::1]:9/"} {
+		result := c.Check(context.Background(), target)
+		if result.Reachable || result.Detail != "destination address is not allowed" {
+			t.Errorf("Check(%q) = {Reachable: %v, Detail: %q}, want refused", target, result.Reachable, result.Detail)
+		}
+	}
+	if hit {
+		t.Error("expected the loopback server never to be contacted")
+	}
+}
+
+func TestCheck_HTTPHostAllowlist(t *testing.T) {
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer target.Close()
+	u, _ := url.Parse(target.URL)
+
+	// Redirects to the same server under a hostname that is not allowlisted
+	redirect := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://localhost:"+u.Port()+"/", http.StatusFound)
+	}))
+	defer redirect.Close()
+
+	c := newLoopbackChecker(t, time.Second).WithHostAllowlist([]string{"127.0.0.1"})
+
+	tests := []struct {
+		name          string
+		target        string
+		wantReachable bool
+		wantDetail    string
+	}{
+		{"allowed host", target.URL, true, ""},
+		{"host not allowed", "http://localhost:" + u.Port() + "/", false, "host is not in the allowlist"},
+		{"redirect to host not allowed", redirect.URL, false, "host is not in the allowlist"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := c.Check(context.Background(), tt.target)
+			if result.Reachable != tt.wantReachable || result.Detail != tt.wantDetail {
+				t.Errorf("Check(%q) = {Reachable: %v, Detail: %q}, want {%v, %q}", tt.target, result.Reachable, result.Detail, tt.wantReachable, tt.wantDetail)
+			}
+		})
+	}
+}
+
+func TestPublicAddr(t *testing.T) {
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"127.0.0.1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"100.64.0.1", false},
+		{"0.0.0.0", false},
+		{"::1", false},
+		{"fe80::1", false},
+		{"fd00::1", false},
+		{"::ffff:127.0.0.1", false},
+		{"64:ff9b::a9fe:a9fe", false},
+	}
+	for _, tt := range tests {
+		if got := publicAddr(netip.MustParseAddr(tt.addr)); got != tt.want {
+			t.Errorf("publicAddr(%s) = %v, want %v", tt.addr, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+
+	"backend/internal/domain"
+	domainerrors "backend/internal/domain/errors"
+	"backend/internal/domain/repository"
+	infraerrors "backend/internal/infra/errors"
+	pkgerrors "backend/pkg/errors"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+var apiKeyCols = []string{"k.id", "k.workspace_id", "k.name", "k.role", "k.scope", "k.key_prefix", "k.key_hash", "k.created_by", "k.created_at"}
+
+type apiKeyRepository struct {
+	uow *UnitOfWork
+}
+
+func newAPIKeyRepository(uow *UnitOfWork) repository.APIKeyRepository {
+	return &apiKeyRepository{uow: uow}
+}
+
+func (r *apiKeyRepository) Create(ctx context.Context, key *domain.APIKey) *pkgerrors.Error {
+	query, args, err := builder.
+		Insert("api_keys").
+		Columns("id", "workspace_id", "name", "role", "scope", "key_prefix", "key_hash", "created_by").
+		Values(key.ID, key.WorkspaceID, key.Name, string(key.Role), key.Scope, key.KeyPrefix, key.KeyHash, key.CreatedBy).
+		Suffix("RETURNING created_at").
+		ToSql()
+	if err != nil {
+		return infraerrors.WrapQueryBuildError(err, "create_api_key")
+	}
+
+	var cat TimestampDest
+	if err := r.uow.Querier().QueryRowContext(ctx, query, args...).Scan(&cat); err != nil {
+		return infraerrors.WrapSQLiteError(err, "create_api_key")
+	}
+	key.CreatedAt = cat.Time()
+
+	return nil
+}
+
+func (r *apiKeyRepository) GetActiveByHash(ctx context.Context, keyHash string) (*domain.APIKey, *pkgerrors.Error) {
+	query, args, err := builder.
+		Select(apiKeyCols...).
+		From("api_keys k").
+		Join("workspaces w ON w.id = k.workspace_id").
+		Where(sq.Eq{"k.key_hash": keyHash}).
+		Where("k.revoked_at IS NULL").
+		Where("w.deleted_at IS NULL").
+		ToSql()
+	if err != nil {
+		return nil, infraerrors.WrapQueryBuildError(err, "get_api_key")
+	}
+
+	key, scanErr := scanAPIKey(r.uow.Querier().QueryRowContext(ctx, query, args...))
+	if scanErr != nil {
+		if scanErr == sql.ErrNoRows {
+			return nil, domainerrors.NotFoundByField("APIKey", "key_hash", keyHash)
+		}
+		return nil, infraerrors.WrapSQLiteError(scanErr, "get_api_key")
+	}
+
+	return key, nil
+}
+
+func (r *apiKeyRepository) ListByWorkspace(ctx context.Context, workspaceID uuid.UUID) ([]*domain.APIKey, *pkgerrors.Error) {
+	query, args, err := builder.
+		Select(apiKeyCols...).
+		From("api_keys k").
+		Where(sq.Eq{"k.workspace_id": workspaceID}).
+		Where("k.revoked_at IS NULL").
+		OrderBy("k.created_at DESC", "k.id").
+		ToSql()
+	if err != nil {
+		return nil, infraerrors.WrapQueryBuildError(err, "list_api_keys")
+	}
+
+	rows, err := r.uow.Querier().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, infraerrors.WrapSQLiteError(err, "list_api_keys")
+	}
+	defer rows.Close()
+
+	keys := []*domain.APIKey{}
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, infraerrors.WrapSQLiteError(err, "scan_api_key")
+		}
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, infraerrors.WrapSQLiteError(err, "iterate_api_keys")
+	}
+
+	return keys, nil
+}
+
+func (r *apiKeyRepository) Revoke(ctx context.Context, workspaceID uuid.UUID, id uuid.UUID) *pkgerrors.Error {
+	query, args, err := builder.
+		Update("api_keys").
+		Set("revoked_at", sq.Expr("CURRENT_TIMESTAMP")).
+		Where(sq.Eq{"id": id, "workspace_id": workspaceID}).
+		Where("revoked_at IS NULL").
+		ToSql()
+	if err != nil {
+		return infraerrors.WrapQueryBuildError(err, "revoke_api_key")
+	}
+
+	result, err := r.uow.Querier().ExecContext(ctx, query, args...)
+	if err != nil {
+		return infraerrors.WrapSQLiteError(err, "revoke_api_key")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return infraerrors.WrapSQLiteError(err, "get_rows_affected")
+	}
+	if rowsAffected == 0 {
+		return domainerrors.NotFound("APIKey", id.String())
+	}
+
+	return nil
+}
+
+func scanAPIKey(row interface{ Scan(dest ...any) error }) (*domain.APIKey, error) {
+	var key domain.APIKey
+	var role string
+	var cat TimestampDest
+	if err := row.Scan(&key.ID, &key.WorkspaceID, &key.Name, &role, &key.Scope, &key.KeyPrefix, &key.KeyHash, &key.CreatedBy, &cat); err != nil {
+		return nil, err
+	}
+	key.Role = domain.Role(role)
+	key.CreatedAt = cat.Time()
+	return &key, nil
+}
@@ -0,0 +1,14 @@
+package sqlite
+
+import sq "github.com/Masterminds/squirrel"
+
+// placeholderFormat is the bind parameter style SQLite expects (?). It is the
+// single place the format is configured for this backend.
+var placeholderFormat = sq.Question
+
+// builder is the Squirrel statement builder every repository in this package
+// must start from. Squirrel's package-level constructors (sq.Select,
+// sq.Insert, ...) and a bare sq.StatementBuilder bypass placeholderFormat, so a
+// query built with them would keep its own placeholder style if the format
+// here ever changed; builder_test.go rejects such uses.
+var builder = sq.StatementBuilder.PlaceholderFormat(placeholderFormat)
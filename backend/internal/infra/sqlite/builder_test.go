@@ -0,0 +1,136 @@
+package sqlite
+
+import (
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	infraerrors "backend/internal/infra/errors"
+	pkgerrors "backend/pkg/errors"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+func TestBuilder_UsesQuestionPlaceholders(t *testing.T) {
+	queries := map[string]sq.Sqlizer{
+		"select": builder.Select("id").From("users").Where(sq.Eq{"id": 1}).Where("name = ?", "a"),
+		"insert": builder.Insert("users").Columns("id", "name").Values(1, "a"),
+		"update": builder.Update("users").Set("name", "a").Where(sq.Eq{"id": 1}),
+		"delete": builder.Delete("users").Where(sq.Eq{"id": 1}),
+	}
+
+	for name, q := range queries {
+		t.Run(name, func(t *testing.T) {
+			query, args, err := q.ToSql()
+			if err != nil {
+				t.Fatalf("failed to build SQL: %v", err)
+			}
+			if got := strings.Count(query, "?"); got != len(args) {
+				t.Errorf("expected %d ? placeholders, got %d in %q", len(args), got, query)
+			}
+			if strings.Contains(query, "$1") {
+				t.Errorf("unexpected dollar placeholder in %q", query)
+			}
+		})
+	}
+}
+
+// TestRepositories_BuildQueriesWithBuilder fails when a file in this package
+// builds a statement without going through builder.
+func TestRepositories_BuildQueriesWithBuilder(t *testing.T) {
+	forbidden := map[string]bool{
+		"StatementBuilder": true,
+		"Select":           true,
+		"Insert":           true,
+		"Update":           true,
+		"Delete":           true,
+		"Replace":          true,
+	}
+
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatalf("failed to list package files: %v", err)
+	}
+
+	fset := token.NewFileSet()
+	for _, file := range files {
+		if file == "builder.go" || strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		parsed, err := parser.ParseFile(fset, file, nil, 0)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", file, err)
+		}
+		ast.Inspect(parsed, func(n ast.Node) bool {
+			sel, ok := n.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			if pkg, ok := sel.X.(*ast.Ident); ok && pkg.Name == "sq" && forbidden[sel.Sel.Name] {
+				t.Errorf("%s: use builder instead of sq.%s", fset.Position(sel.Pos()), sel.Sel.Name)
+			}
+			return true
+		})
+	}
+}
+
+func TestWrapQueryBuildError_UpdateWithoutSet(t *testing.T) {
+	_, _, buildErr := builder.Update("users").Where(sq.Eq{"id": 1}).ToSql()
+	if buildErr == nil {
+		t.Fatal("expected an update without a Set clause to fail to build")
+	}
+
+	err := infraerrors.WrapQueryBuildError(buildErr, "update_user")
+	if err.Code() != pkgerrors.CodeInternal {
+		t.Errorf("expected code %s, got %s", pkgerrors.CodeInternal, err.Code())
+	}
+	if err.HTTPStatus() != http.StatusInternalServerError {
+		t.Errorf("expected status 500, got %d", err.HTTPStatus())
+	}
+	if op := err.GetMetadata()[pkgerrors.MetadataOperation]; op != "update_user" {
+		t.Errorf("expected operation update_user, got %v", op)
+	}
+	if !errors.Is(err, buildErr) {
+		t.Error("expected the build error to be kept as the cause")
+	}
+}
+
+// TestRepositories_WrapBuildErrorsUniformly fails when a ToSql error in this
+// package is handled by anything but WrapQueryBuildError.
+func TestRepositories_WrapBuildErrorsUniformly(t *testing.T) {
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatalf("failed to list package files: %v", err)
+	}
+
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		source, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", file, err)
+		}
+		lines := strings.Split(string(source), "\n")
+		for i, line := range lines {
+			if !strings.Contains(line, "ToSql()") {
+				continue
+			}
+			// The error check follows the call; its return must use the helper.
+			for _, next := range lines[i+1 : min(i+4, len(lines))] {
+				if strings.Contains(strings.TrimSpace(next), "return ") {
+					if !strings.Contains(next, "infraerrors.WrapQueryBuildError(") {
+						t.Errorf("%s:%d: wrap ToSql errors with infraerrors.WrapQueryBuildError", file, i+1)
+					}
+					break
+				}
+			}
+		}
+	}
+}
@@ -3,6 +3,7 @@ package sqlite
 import (
 	"database/sql"
 	"fmt"
+	"strings"
 
 	_ "modernc.org/sqlite"
 )
@@ -39,3 +40,23 @@ func NewDB(cfg Config) (*sql.DB, error) {
 
 	return db, nil
 }
+
+// NewReplicaDB opens a read-only pool on a replica of the database, e.g. one
+// kept in sync by a replication tool. url is a file path, optionally with a
+// "file:" prefix. Unlike the primary the pool is not limited to a single
+// connection, since readers do not contend for SQLite's write lock.
+func NewReplicaDB(url string) (*sql.DB, error) {
+	dsn := fmt.Sprintf("file:%s?mode=ro&_pragma=query_only(1)", strings.TrimPrefix(url, "file:"))
+
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sqlite replica: %w", err)
+	}
+
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to ping sqlite replica: %w", err)
+	}
+
+	return db, nil
+}
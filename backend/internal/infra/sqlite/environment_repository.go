@@ -3,7 +3,7 @@ package sqlite
 import (
 	"context"
 	"database/sql"
-	"fmt"
+	"time"
 
 	"backend/internal/domain"
 	domainerrors "backend/internal/domain/errors"
@@ -22,12 +22,19 @@ var envColumns = []string{
 	"last_operation", "last_error", "ttl_seconds", "updated_at",
 }
 
+// environmentFilterColumns lists the columns ListOptions.Filters may reference for environments.
+var (
+	environmentFilterColumns = []string{"id", "workspace_id", "template_id", "created_by", "status"}
+	environmentSortColumns   = []string{"name", "status", "created_at", "updated_at"}
+)
+
 type environmentRepository struct {
 	uow *UnitOfWork
+	ids domain.IDGenerator
 }
 
-func newEnvironmentRepository(uow *UnitOfWork) repository.EnvironmentRepository {
-	return &environmentRepository{uow: uow}
+func newEnvironmentRepository(uow *UnitOfWork, ids domain.IDGenerator) repository.EnvironmentRepository {
+	return &environmentRepository{uow: uow, ids: ids}
 }
 
 // scanEnvironment scans a row into a domain.Environment using the standard column order.
@@ -141,7 +148,7 @@ func scanEnrichedEnviormentResponse(scanner interface{ Scan(dest ...any) error }
 
 func (r *environmentRepository) Create(ctx context.Context, env *domain.Environment) *pkgerrors.Error {
 	if env.ID == uuid.Nil {
-		env.ID = uuid.New()
+		env.ID = r.ids.NewID()
 	}
 
 	query, args, err := builder.
@@ -151,7 +158,7 @@ func (r *environmentRepository) Create(ctx context.Context, env *domain.Environm
 		Suffix("RETURNING created_at, updated_at").
 		ToSql()
 	if err != nil {
-		return infraerrors.WrapSQLiteError(err, "create_environment")
+		return infraerrors.WrapQueryBuildError(err, "create_environment")
 	}
 
 	var cat, uat TimestampDest
@@ -171,9 +178,10 @@ func (r *environmentRepository) GetByID(ctx context.Context, id uuid.UUID) (*dom
 		Select(envColumns...).
 		From("environments").
 		Where(sq.Eq{"id": id}).
+		Where("deleted_at IS NULL").
 		ToSql()
 	if err != nil {
-		return nil, infraerrors.WrapSQLiteError(err, "get_environment")
+		return nil, infraerrors.WrapQueryBuildError(err, "get_environment")
 	}
 
 	env, scanErr := scanEnvironment(r.uow.Querier().QueryRowContext(ctx, query, args...))
@@ -190,7 +198,7 @@ func (r *environmentRepository) GetByID(ctx context.Context, id uuid.UUID) (*dom
 func (r *environmentRepository) queryMany(ctx context.Context, qb sq.SelectBuilder, op string) ([]*domain.Environment, *pkgerrors.Error) {
 	query, args, err := qb.ToSql()
 	if err != nil {
-		return nil, infraerrors.WrapSQLiteError(err, op)
+		return nil, infraerrors.WrapQueryBuildError(err, op)
 	}
 
 	rows, err := r.uow.Querier().QueryContext(ctx, query, args...)
@@ -199,7 +207,7 @@ func (r *environmentRepository) queryMany(ctx context.Context, qb sq.SelectBuild
 	}
 	defer rows.Close()
 
-	var environments []*domain.Environment
+	environments := []*domain.Environment{}
 	for rows.Next() {
 		env, scanErr := scanEnvironment(rows)
 		if scanErr != nil {
@@ -220,7 +228,8 @@ func (r *environmentRepository) GetByWorkspaceID(ctx context.Context, workspaceI
 		Select(envColumns...).
 		From("environments").
 		Where(sq.Eq{"workspace_id": workspaceID}).
-		OrderBy("created_at DESC"),
+		Where("deleted_at IS NULL").
+		OrderBy("created_at DESC", "id DESC"),
 		"get_environments_by_workspace",
 	)
 }
@@ -230,7 +239,8 @@ func (r *environmentRepository) GetByCreatedBy(ctx context.Context, userID uuid.
 		Select(envColumns...).
 		From("environments").
 		Where(sq.Eq{"created_by": userID}).
-		OrderBy("created_at DESC"),
+		Where("deleted_at IS NULL").
+		OrderBy("created_at DESC", "id DESC"),
 		"get_environments_by_creator",
 	)
 }
@@ -240,7 +250,8 @@ func (r *environmentRepository) GetByTemplateID(ctx context.Context, templateID
 		Select(envColumns...).
 		From("environments").
 		Where(sq.Eq{"template_id": templateID}).
-		OrderBy("created_at DESC"),
+		Where("deleted_at IS NULL").
+		OrderBy("created_at DESC", "id DESC"),
 		"get_environments_by_template",
 	)
 }
@@ -267,7 +278,7 @@ func (r *environmentRepository) Update(ctx context.Context, env *domain.Environm
 
 	query, args, err := qb.ToSql()
 	if err != nil {
-		return infraerrors.WrapSQLiteError(err, "update_environment")
+		return infraerrors.WrapQueryBuildError(err, "update_environment")
 	}
 
 	var uat TimestampDest
@@ -284,13 +295,17 @@ func (r *environmentRepository) Update(ctx context.Context, env *domain.Environm
 	return nil
 }
 
+// Delete soft-deletes the environment; it stays in the table, hidden from
+// every read, until Restore brings it back.
 func (r *environmentRepository) Delete(ctx context.Context, id uuid.UUID) *pkgerrors.Error {
 	query, args, err := builder.
-		Delete("environments").
+		Update("environments").
+		Set("deleted_at", sq.Expr("CURRENT_TIMESTAMP")).
 		Where(sq.Eq{"id": id}).
+		Where("deleted_at IS NULL").
 		ToSql()
 	if err != nil {
-		return infraerrors.WrapSQLiteError(err, "delete_environment")
+		return infraerrors.WrapQueryBuildError(err, "delete_environment")
 	}
 
 	result, err := r.uow.Querier().ExecContext(ctx, query, args...)
@@ -310,24 +325,90 @@ func (r *environmentRepository) Delete(ctx context.Context, id uuid.UUID) *pkger
 	return nil
 }
 
+func (r *environmentRepository) Restore(ctx context.Context, id uuid.UUID, workspaceID uuid.UUID) *pkgerrors.Error {
+	query, args, err := builder.
+		Update("environments").
+		Set("deleted_at", nil).
+		Set("updated_at", sq.Expr("CURRENT_TIMESTAMP")).
+		Where(sq.Eq{"id": id, "workspace_id": workspaceID}).
+		Where("deleted_at IS NOT NULL").
+		ToSql()
+	if err != nil {
+		return infraerrors.WrapQueryBuildError(err, "restore_environment")
+	}
+
+	result, err := r.uow.Querier().ExecContext(ctx, query, args...)
+	if err != nil {
+		return infraerrors.WrapSQLiteError(err, "restore_environment")
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return infraerrors.WrapSQLiteError(err, "get_rows_affected")
+	}
+
+	if affected == 0 {
+		return domainerrors.NotFound("Environment", id.String())
+	}
+
+	return nil
+}
+
+func (r *environmentRepository) ListDeletedBefore(ctx context.Context, before time.Time) ([]*domain.Environment, *pkgerrors.Error) {
+	return r.queryMany(ctx, builder.
+		Select(envColumns...).
+		From("environments").
+		Where("deleted_at IS NOT NULL").
+		Where(sq.Lt{"deleted_at": before.UTC().Format(timestampFormat)}).
+		OrderBy("deleted_at ASC", "id ASC"),
+		"list_environments_deleted_before",
+	)
+}
+
+func (r *environmentRepository) Purge(ctx context.Context, id uuid.UUID, before time.Time) (bool, *pkgerrors.Error) {
+	query, args, err := builder.
+		Delete("environments").
+		Where(sq.Eq{"id": id}).
+		Where("deleted_at IS NOT NULL").
+		Where(sq.Lt{"deleted_at": before.UTC().Format(timestampFormat)}).
+		ToSql()
+	if err != nil {
+		return false, infraerrors.WrapQueryBuildError(err, "purge_environment")
+	}
+
+	result, err := r.uow.Querier().ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, infraerrors.WrapSQLiteError(err, "purge_environment")
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, infraerrors.WrapSQLiteError(err, "get_rows_affected")
+	}
+	return rows > 0, nil
+}
+
 func (r *environmentRepository) List(ctx context.Context, opts repository.ListOptions) ([]*domain.Environment, *pkgerrors.Error) {
 	opts.ApplyDefaults()
 	if err := opts.Validate(); err != nil {
 		return nil, err
 	}
+	if err := opts.ValidateFilters(environmentFilterColumns...); err != nil {
+		return nil, err
+	}
 
 	qb := builder.
 		Select(envColumns...).
-		From("environments")
-	for col, val := range opts.FilterBy {
-		qb = qb.Where(sq.Eq{col: val})
-	}
-	return r.queryMany(ctx, qb.
-		OrderBy(fmt.Sprintf("%s %s", opts.SortBy, opts.Order)).
-		Limit(uint64(opts.Limit)).
-		Offset(uint64(opts.Offset)),
-		"list_environments",
-	)
+		From("environments").
+		Where("deleted_at IS NULL")
+	if len(opts.Filters) > 0 {
+		qb = qb.Where(sq.Eq(opts.Filters))
+	}
+	qb, pageErr := paginate(qb, opts, environmentSortColumns)
+	if pageErr != nil {
+		return nil, pageErr
+	}
+	return r.queryMany(ctx, qb, "list_environments")
 }
 
 // AcquireOperation atomically transitions the environment to newStatus only if
@@ -347,11 +428,12 @@ func (r *environmentRepository) AcquireOperation(ctx context.Context, id uuid.UU
 		Set("last_error", nil).
 		Set("updated_at", sq.Expr("CURRENT_TIMESTAMP")).
 		Where(sq.Eq{"id": id}).
+		Where("deleted_at IS NULL").
 		Where(sq.NotEq{"status": blocking}).
 		Suffix("RETURNING " + joinColumns(envColumns)).
 		ToSql()
 	if err != nil {
-		return nil, infraerrors.WrapSQLiteError(err, "acquire_operation")
+		return nil, infraerrors.WrapQueryBuildError(err, "acquire_operation")
 	}
 
 	env, scanErr := scanEnvironment(r.uow.Querier().QueryRowContext(ctx, query, args...))
@@ -370,13 +452,11 @@ func (r *environmentRepository) AcquireOperation(ctx context.Context, id uuid.UU
 	return env, nil
 }
 
-func (r *environmentRepository) ListFiltered(ctx context.Context, opts repository.EnvironmentListOptions) ([]*contracts.EnvironmentResponse, *pkgerrors.Error) {
-	qb := builder.
-		Select(enrichedEnviormentColumns...).
-		From("environments e").
-		Join("users u ON e.created_by = u.id").
-		LeftJoin("templates t ON e.template_id = t.id").
-		Where(sq.Eq{"e.workspace_id": opts.WorkspaceID})
+// filterEnvironments applies the filters of opts to a query over
+// "environments e", always scoped to the workspace and excluding deleted
+// environments.
+func filterEnvironments(qb sq.SelectBuilder, opts repository.EnvironmentListOptions) sq.SelectBuilder {
+	qb = qb.Where(sq.Eq{"e.workspace_id": opts.WorkspaceID}).Where("e.deleted_at IS NULL")
 
 	if len(opts.CreatorIDs) > 0 {
 		qb = qb.Where(sq.Eq{"e.created_by": opts.CreatorIDs})
@@ -390,26 +470,26 @@ func (r *environmentRepository) ListFiltered(ctx context.Context, opts repositor
 	if opts.Search != "" {
 		qb = qb.Where(sq.Like{"e.name": "%" + opts.Search + "%"})
 	}
+	return qb
+}
 
-	sortBy := opts.SortBy
-	if sortBy == "" {
-		sortBy = "created_at"
-	}
-	order := opts.Order
-	if order == "" {
-		order = "DESC"
-	}
-	qb = qb.OrderBy(fmt.Sprintf("e.%s %s", sortBy, order))
+func (r *environmentRepository) ListFiltered(ctx context.Context, opts repository.EnvironmentListOptions) ([]*contracts.EnvironmentResponse, *pkgerrors.Error) {
+	qb := filterEnvironments(builder.
+		Select(enrichedEnviormentColumns...).
+		From("environments e").
+		Join("users u ON e.created_by = u.id").
+		LeftJoin("templates t ON e.template_id = t.id"), opts)
 
-	limit := opts.Limit
-	if limit <= 0 {
-		limit = 50
+	page := repository.ListOptions{SortBy: opts.SortBy, Order: opts.Order, Limit: opts.Limit, Offset: opts.Offset}
+	page.ApplyDefaults()
+	qb, pageErr := paginateQualified(qb, page, "e", environmentSortColumns)
+	if pageErr != nil {
+		return nil, pageErr
 	}
-	qb = qb.Limit(uint64(limit)).Offset(uint64(opts.Offset))
 
 	query, args, err := qb.ToSql()
 	if err != nil {
-		return nil, infraerrors.WrapSQLiteError(err, "list_filtered_environments")
+		return nil, infraerrors.WrapQueryBuildError(err, "list_filtered_environments")
 	}
 
 	rows, err := r.uow.Querier().QueryContext(ctx, query, args...)
@@ -418,7 +498,7 @@ func (r *environmentRepository) ListFiltered(ctx context.Context, opts repositor
 	}
 	defer rows.Close()
 
-	var results []*contracts.EnvironmentResponse
+	results := []*contracts.EnvironmentResponse{}
 	for rows.Next() {
 		resp, scanErr := scanEnrichedEnviormentResponse(rows)
 		if scanErr != nil {
@@ -433,3 +513,21 @@ func (r *environmentRepository) ListFiltered(ctx context.Context, opts repositor
 
 	return results, nil
 }
+
+func (r *environmentRepository) CountFiltered(ctx context.Context, opts repository.EnvironmentListOptions) (int, *pkgerrors.Error) {
+	query, args, err := filterEnvironments(builder.
+		Select("COUNT(*)").
+		From("environments e").
+		Join("users u ON e.created_by = u.id"), opts).
+		ToSql()
+	if err != nil {
+		return 0, infraerrors.WrapQueryBuildError(err, "count_filtered_environments")
+	}
+
+	var count int
+	if err := r.uow.Querier().QueryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return 0, infraerrors.WrapSQLiteError(err, "count_filtered_environments")
+	}
+
+	return count, nil
+}
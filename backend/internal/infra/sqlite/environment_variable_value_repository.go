@@ -28,7 +28,7 @@ func (r *environmentVariableValueRepository) Create(ctx context.Context, value d
 		Suffix("RETURNING created_at, updated_at").
 		ToSql()
 	if err != nil {
-		return infraerrors.WrapSQLiteError(err, "create_env_var_value")
+		return infraerrors.WrapQueryBuildError(err, "create_env_var_value")
 	}
 
 	var cat, uat TimestampDest
@@ -50,7 +50,7 @@ func (r *environmentVariableValueRepository) GetByEnvironmentID(ctx context.Cont
 		Where(sq.Eq{"environment_id": environmentID}).
 		ToSql()
 	if err != nil {
-		return nil, infraerrors.WrapSQLiteError(err, "get_env_var_values")
+		return nil, infraerrors.WrapQueryBuildError(err, "get_env_var_values")
 	}
 
 	rows, err := r.uow.Querier().QueryContext(ctx, query, args...)
@@ -59,7 +59,7 @@ func (r *environmentVariableValueRepository) GetByEnvironmentID(ctx context.Cont
 	}
 	defer rows.Close()
 
-	var values []*domain.EnvironmentVariableValue
+	values := []*domain.EnvironmentVariableValue{}
 	for rows.Next() {
 		var v domain.EnvironmentVariableValue
 		var cat, uat TimestampDest
@@ -102,7 +102,7 @@ func (r *environmentVariableValueRepository) DeleteByEnvironmentID(ctx context.C
 		Where(sq.Eq{"environment_id": environmentID}).
 		ToSql()
 	if err != nil {
-		return infraerrors.WrapSQLiteError(err, "delete_env_var_values")
+		return infraerrors.WrapQueryBuildError(err, "delete_env_var_values")
 	}
 
 	_, err = r.uow.Querier().ExecContext(ctx, query, args...)
@@ -32,7 +32,7 @@ func (r *groupRepository) Create(ctx context.Context, group *domain.Group) *pkge
 		Suffix("RETURNING created_at, updated_at").
 		ToSql()
 	if err != nil {
-		return infraerrors.WrapSQLiteError(err, "create_group")
+		return infraerrors.WrapQueryBuildError(err, "create_group")
 	}
 
 	var cat, uat TimestampDest
@@ -54,7 +54,7 @@ func (r *groupRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Gr
 		Where(sq.Eq{"id": id}).
 		ToSql()
 	if err != nil {
-		return nil, infraerrors.WrapSQLiteError(err, "get_group")
+		return nil, infraerrors.WrapQueryBuildError(err, "get_group")
 	}
 
 	group, scanErr := r.scanGroup(r.uow.Querier().QueryRowContext(ctx, query, args...))
@@ -73,10 +73,10 @@ func (r *groupRepository) GetByWorkspaceID(ctx context.Context, workspaceID uuid
 		Select("id", "name", "description", "workspace_id", "access_all_templates", "created_at", "updated_at").
 		From("groups").
 		Where(sq.Eq{"workspace_id": workspaceID}).
-		OrderBy("created_at DESC").
+		OrderBy("created_at DESC", "id DESC").
 		ToSql()
 	if err != nil {
-		return nil, infraerrors.WrapSQLiteError(err, "get_groups_by_workspace")
+		return nil, infraerrors.WrapQueryBuildError(err, "get_groups_by_workspace")
 	}
 
 	rows, err := r.uow.Querier().QueryContext(ctx, query, args...)
@@ -85,7 +85,7 @@ func (r *groupRepository) GetByWorkspaceID(ctx context.Context, workspaceID uuid
 	}
 	defer rows.Close()
 
-	var groups []*domain.Group
+	groups := []*domain.Group{}
 	for rows.Next() {
 		var g domain.Group
 		var accessAll int
@@ -118,7 +118,7 @@ func (r *groupRepository) Update(ctx context.Context, group *domain.Group) *pkge
 		Suffix("RETURNING updated_at").
 		ToSql()
 	if err != nil {
-		return infraerrors.WrapSQLiteError(err, "update_group")
+		return infraerrors.WrapQueryBuildError(err, "update_group")
 	}
 
 	var uat TimestampDest
@@ -141,7 +141,7 @@ func (r *groupRepository) Delete(ctx context.Context, id uuid.UUID) *pkgerrors.E
 		Where(sq.Eq{"id": id}).
 		ToSql()
 	if err != nil {
-		return infraerrors.WrapSQLiteError(err, "delete_group")
+		return infraerrors.WrapQueryBuildError(err, "delete_group")
 	}
 
 	result, err := r.uow.Querier().ExecContext(ctx, query, args...)
@@ -175,7 +175,7 @@ func (r *groupRepository) AddMembers(ctx context.Context, groupID uuid.UUID, use
 	// Ignore duplicates — already a member
 	query, args, err := ins.Suffix("ON CONFLICT (group_id, user_id) DO NOTHING").ToSql()
 	if err != nil {
-		return infraerrors.WrapSQLiteError(err, "add_group_members")
+		return infraerrors.WrapQueryBuildError(err, "add_group_members")
 	}
 
 	_, err = r.uow.Querier().ExecContext(ctx, query, args...)
@@ -192,7 +192,7 @@ func (r *groupRepository) RemoveMember(ctx context.Context, groupID uuid.UUID, u
 		Where(sq.Eq{"group_id": groupID, "user_id": userID}).
 		ToSql()
 	if err != nil {
-		return infraerrors.WrapSQLiteError(err, "remove_group_member")
+		return infraerrors.WrapQueryBuildError(err, "remove_group_member")
 	}
 
 	result, err := r.uow.Querier().ExecContext(ctx, query, args...)
@@ -217,10 +217,10 @@ func (r *groupRepository) GetMembers(ctx context.Context, groupID uuid.UUID) ([]
 		Select("user_id").
 		From("group_memberships").
 		Where(sq.Eq{"group_id": groupID}).
-		OrderBy("created_at ASC").
+		OrderBy("created_at ASC", "user_id ASC").
 		ToSql()
 	if err != nil {
-		return nil, infraerrors.WrapSQLiteError(err, "get_group_members")
+		return nil, infraerrors.WrapQueryBuildError(err, "get_group_members")
 	}
 
 	return r.queryUUIDs(ctx, query, args, "get_group_members")
@@ -233,7 +233,7 @@ func (r *groupRepository) GetGroupIDsForUser(ctx context.Context, userID uuid.UU
 		Where(sq.Eq{"user_id": userID}).
 		ToSql()
 	if err != nil {
-		return nil, infraerrors.WrapSQLiteError(err, "get_groups_for_user")
+		return nil, infraerrors.WrapQueryBuildError(err, "get_groups_for_user")
 	}
 
 	return r.queryUUIDs(ctx, query, args, "get_groups_for_user")
@@ -252,7 +252,7 @@ func (r *groupRepository) AddTemplateAccess(ctx context.Context, groupID uuid.UU
 	}
 	query, args, err := ins.Suffix("ON CONFLICT (group_id, template_id) DO NOTHING").ToSql()
 	if err != nil {
-		return infraerrors.WrapSQLiteError(err, "add_group_template_access")
+		return infraerrors.WrapQueryBuildError(err, "add_group_template_access")
 	}
 
 	_, err = r.uow.Querier().ExecContext(ctx, query, args...)
@@ -269,7 +269,7 @@ func (r *groupRepository) RemoveTemplateAccess(ctx context.Context, groupID uuid
 		Where(sq.Eq{"group_id": groupID, "template_id": templateID}).
 		ToSql()
 	if err != nil {
-		return infraerrors.WrapSQLiteError(err, "remove_group_template_access")
+		return infraerrors.WrapQueryBuildError(err, "remove_group_template_access")
 	}
 
 	result, err := r.uow.Querier().ExecContext(ctx, query, args...)
@@ -294,10 +294,10 @@ func (r *groupRepository) GetTemplateAccess(ctx context.Context, groupID uuid.UU
 		Select("template_id").
 		From("group_template_access").
 		Where(sq.Eq{"group_id": groupID}).
-		OrderBy("created_at ASC").
+		OrderBy("created_at ASC", "template_id ASC").
 		ToSql()
 	if err != nil {
-		return nil, infraerrors.WrapSQLiteError(err, "get_group_template_access")
+		return nil, infraerrors.WrapQueryBuildError(err, "get_group_template_access")
 	}
 
 	return r.queryUUIDs(ctx, query, args, "get_group_template_access")
@@ -338,7 +338,7 @@ func (r *groupRepository) GetAccessibleTemplateIDs(ctx context.Context, userID u
 	}
 	defer rows.Close()
 
-	var ids []uuid.UUID
+	ids := []uuid.UUID{}
 	for rows.Next() {
 		var id uuid.UUID
 		if err := rows.Scan(&id); err != nil {
@@ -390,7 +390,7 @@ func (r *groupRepository) queryUUIDs(ctx context.Context, query string, args []i
 	}
 	defer rows.Close()
 
-	var ids []uuid.UUID
+	ids := []uuid.UUID{}
 	for rows.Next() {
 		var id uuid.UUID
 		if err := rows.Scan(&id); err != nil {
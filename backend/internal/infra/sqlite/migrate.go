@@ -0,0 +1,124 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/sqlite"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/google/uuid"
+)
+
+const (
+	// migrationLockTTL is how long a lock row is honoured. A holder that
+	// crashed mid-migration is taken over once its lock is older than this;
+	// a live holder refreshes it every migrationLockHeartbeat.
+	migrationLockTTL       = 10 * time.Minute
+	migrationLockHeartbeat = migrationLockTTL / 4
+
+	migrationLockPollInterval = 100 * time.Millisecond
+)
+
+// MigrateUp applies all pending migrations from migrationsPath to the database
+// at dbPath. A single row in migration_lock serializes concurrent callers, e.g.
+// replicas starting together: one instance migrates while the others wait and
+// then find nothing left to apply. golang-migrate's own sqlite lock only guards
+// a single process, hence the row.
+func MigrateUp(ctx context.Context, dbPath, migrationsPath string) (err error) {
+	db, err := sql.Open("sqlite", fmt.Sprintf("file:%s?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)", dbPath))
+	if err != nil {
+		return fmt.Errorf("failed to open sqlite database: %w", err)
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	owner := uuid.NewString()
+	if err := acquireMigrationLock(ctx, db, owner); err != nil {
+		return err
+	}
+	defer func() {
+		if releaseErr := releaseMigrationLock(db, owner); releaseErr != nil && err == nil {
+			err = releaseErr
+		}
+	}()
+	stopHeartbeat := heartbeatMigrationLock(db, owner, migrationLockHeartbeat)
+	defer stopHeartbeat()
+
+	m, err := migrate.New("file://"+migrationsPath, "sqlite://"+dbPath)
+	if err != nil {
+		return fmt.Errorf("failed to initialize migrations: %w", err)
+	}
+	defer m.Close()
+
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("failed to apply migrations: %w", err)
+	}
+	return nil
+}
+
+func acquireMigrationLock(ctx context.Context, db *sql.DB, owner string) error {
+	if _, err := db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS migration_lock (
+		id INTEGER PRIMARY KEY CHECK (id = 1),
+		owner TEXT NOT NULL,
+		acquired_at TEXT NOT NULL
+	)`); err != nil {
+		return fmt.Errorf("failed to create migration lock table: %w", err)
+	}
+
+	for {
+		result, err := db.ExecContext(ctx, `INSERT INTO migration_lock (id, owner, acquired_at)
+			VALUES (1, ?, datetime('now'))
+			ON CONFLICT(id) DO UPDATE SET owner = excluded.owner, acquired_at = excluded.acquired_at
+			WHERE migration_lock.acquired_at < datetime('now', ?)`,
+			owner, fmt.Sprintf("-%d seconds", int(migrationLockTTL.Seconds())),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to acquire migration lock: %w", err)
+		}
+		if n, err := result.RowsAffected(); err == nil && n == 1 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for migration lock: %w", ctx.Err())
+		case <-time.After(migrationLockPollInterval):
+		}
+	}
+}
+
+// heartbeatMigrationLock refreshes owner's lock every interval until the
+// returned function is called, so a migration outlasting migrationLockTTL is
+// not taken over. A failed refresh is retried on the next tick.
+func heartbeatMigrationLock(db *sql.DB, owner string, interval time.Duration) (stop func()) {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				db.Exec("UPDATE migration_lock SET acquired_at = datetime('now') WHERE owner = ?", owner)
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+func releaseMigrationLock(db *sql.DB, owner string) error {
+	if _, err := db.Exec("DELETE FROM migration_lock WHERE owner = ?", owner); err != nil {
+		return fmt.Errorf("failed to release migration lock: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testMigrationsPath = "../migrations/sqlite"
+
+func TestMigrateUp_ConcurrentCallers(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "devshare.db")
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	const callers = 4
+	var wg sync.WaitGroup
+	errs := make([]error, callers)
+	for i := range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = MigrateUp(ctx, dbPath, testMigrationsPath)
+		}()
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("caller %d: MigrateUp: %v", i, err)
+		}
+	}
+
+	ups, err := filepath.Glob(filepath.Join(testMigrationsPath, "*.up.sql"))
+	if err != nil || len(ups) == 0 {
+		t.Fatalf("failed to list migrations: %v", err)
+	}
+	latest := len(ups)
+
+	db, err := NewDB(Config{FilePath: dbPath})
+	if err != nil {
+		t.Fatalf("NewDB: %v", err)
+	}
+	defer db.Close()
+
+	var version int
+	var dirty bool
+	if err := db.QueryRow("SELECT version, dirty FROM schema_migrations").Scan(&version, &dirty); err != nil {
+		t.Fatalf("read schema_migrations: %v", err)
+	}
+	if version != latest || dirty {
+		t.Errorf("schema version = %d (dirty=%v), want %d (clean)", version, dirty, latest)
+	}
+
+	var locks int
+	if err := db.QueryRow("SELECT COUNT(*) FROM migration_lock").Scan(&locks); err != nil {
+		t.Fatalf("read migration_lock: %v", err)
+	}
+	if locks != 0 {
+		t.Errorf("expected migration lock to be released, found %d rows", locks)
+	}
+}
+
+func TestMigrateUp_WaitsForHeldLock(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "devshare.db")
+	if err := MigrateUp(context.Background(), dbPath, testMigrationsPath); err != nil {
+		t.Fatalf("MigrateUp: %v", err)
+	}
+
+	db, err := NewDB(Config{FilePath: dbPath})
+	if err != nil {
+		t.Fatalf("NewDB: %v", err)
+	}
+	defer db.Close()
+	if _, err := db.Exec("INSERT INTO migration_lock (id, owner, acquired_at) VALUES (1, 'other', datetime('now'))"); err != nil {
+		t.Fatalf("hold lock: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	if err := MigrateUp(ctx, dbPath, testMigrationsPath); err == nil {
+		t.Fatal("expected MigrateUp to wait for the held lock and time out")
+	}
+}
+
+func TestHeartbeatMigrationLock_RefreshesHeldLock(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "devshare.db")
+	if err := MigrateUp(context.Background(), dbPath, testMigrationsPath); err != nil {
+		t.Fatalf("MigrateUp: %v", err)
+	}
+
+	db, err := NewDB(Config{FilePath: dbPath})
+	if err != nil {
+		t.Fatalf("NewDB: %v", err)
+	}
+	defer db.Close()
+	if _, err := db.Exec("INSERT INTO migration_lock (id, owner, acquired_at) VALUES (1, 'holder', datetime('now', '-1 hour'))"); err != nil {
+		t.Fatalf("hold lock: %v", err)
+	}
+
+	stop := heartbeatMigrationLock(db, "holder", 10*time.Millisecond)
+	time.Sleep(100 * time.Millisecond)
+	stop()
+
+	var stale bool
+	if err := db.QueryRow("SELECT acquired_at < datetime('now', '-1 minute') FROM migration_lock WHERE owner = 'holder'").Scan(&stale); err != nil {
+		t.Fatalf("read migration_lock: %v", err)
+	}
+	if stale {
+		t.Error("expected the heartbeat to refresh the held lock")
+	}
+}
+
+func TestReleaseMigrationLock_ReturnsError(t *testing.T) {
+	db, err := NewDB(Config{FilePath: filepath.Join(t.TempDir(), "devshare.db")})
+	if err != nil {
+		t.Fatalf("NewDB: %v", err)
+	}
+	db.Close()
+
+	if err := releaseMigrationLock(db, "holder"); err == nil {
+		t.Error("expected an error releasing the lock on a closed database")
+	}
+}
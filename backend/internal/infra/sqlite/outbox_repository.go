@@ -0,0 +1,94 @@
+package sqlite
+
+import (
+	"context"
+	"time"
+
+	"backend/internal/domain"
+	"backend/internal/domain/repository"
+	infraerrors "backend/internal/infra/errors"
+	pkgerrors "backend/pkg/errors"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+type outboxRepository struct {
+	uow *UnitOfWork
+}
+
+func newOutboxRepository(uow *UnitOfWork) repository.OutboxRepository {
+	return &outboxRepository{uow: uow}
+}
+
+func (r *outboxRepository) Add(ctx context.Context, event *domain.OutboxEvent) *pkgerrors.Error {
+	query, args, err := builder.
+		Insert("outbox_events").
+		Columns("id", "type", "aggregate_id", "payload", "created_at").
+		Values(event.ID, event.Type, event.AggregateID, string(event.Payload), event.CreatedAt.UTC().Format(timestampFormat)).
+		ToSql()
+	if err != nil {
+		return infraerrors.WrapQueryBuildError(err, "add_outbox_event")
+	}
+
+	if _, err := r.uow.Querier().ExecContext(ctx, query, args...); err != nil {
+		return infraerrors.WrapSQLiteError(err, "add_outbox_event")
+	}
+
+	return nil
+}
+
+func (r *outboxRepository) FindPending(ctx context.Context, limit int) ([]*domain.OutboxEvent, *pkgerrors.Error) {
+	query, args, err := builder.
+		Select("id", "type", "aggregate_id", "payload", "created_at").
+		From("outbox_events").
+		Where(sq.Eq{"processed_at": nil}).
+		OrderBy("created_at ASC", "id ASC").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, infraerrors.WrapQueryBuildError(err, "find_pending_outbox_events")
+	}
+
+	rows, err := r.uow.Querier().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, infraerrors.WrapSQLiteError(err, "find_pending_outbox_events")
+	}
+	defer rows.Close()
+
+	events := []*domain.OutboxEvent{}
+	for rows.Next() {
+		var event domain.OutboxEvent
+		var payload string
+		var cat TimestampDest
+		if err := rows.Scan(&event.ID, &event.Type, &event.AggregateID, &payload, &cat); err != nil {
+			return nil, infraerrors.WrapSQLiteError(err, "scan_outbox_event")
+		}
+		event.Payload = []byte(payload)
+		event.CreatedAt = cat.Time()
+		events = append(events, &event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, infraerrors.WrapSQLiteError(err, "iterate_outbox_events")
+	}
+
+	return events, nil
+}
+
+func (r *outboxRepository) MarkProcessed(ctx context.Context, id uuid.UUID, at time.Time) *pkgerrors.Error {
+	query, args, err := builder.
+		Update("outbox_events").
+		Set("processed_at", at.UTC().Format(timestampFormat)).
+		Where(sq.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return infraerrors.WrapQueryBuildError(err, "mark_outbox_event_processed")
+	}
+
+	if _, err := r.uow.Querier().ExecContext(ctx, query, args...); err != nil {
+		return infraerrors.WrapSQLiteError(err, "mark_outbox_event_processed")
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package sqlite
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	domainerrors "backend/internal/domain/errors"
+	"backend/internal/domain/repository"
+	pkgerrors "backend/pkg/errors"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// paginate applies opts' ordering, limit and offset to qb. ORDER BY cannot be
+// parameterized, so SortBy must be one of sortable and Order ASC or DESC before
+// either is written into the query. opts is expected to have had ApplyDefaults.
+// With opts.Unpaged only the ordering is applied.
+//
+// Rows are also ordered by id, so rows sharing a sort value (timestamps only
+// have second precision) keep the same order from page to page.
+func paginate(qb sq.SelectBuilder, opts repository.ListOptions, sortable []string) (sq.SelectBuilder, *pkgerrors.Error) {
+	return paginateQualified(qb, opts, "", sortable)
+}
+
+// paginateQualified is paginate for joined queries, qualifying the sort column
+// with table (e.g. "e" for "e.created_at").
+func paginateQualified(qb sq.SelectBuilder, opts repository.ListOptions, table string, sortable []string) (sq.SelectBuilder, *pkgerrors.Error) {
+	if !slices.Contains(sortable, opts.SortBy) {
+		return qb, domainerrors.InvalidInput("sort_by", fmt.Sprintf("cannot sort by %q (allowed: %s)", opts.SortBy, strings.Join(sortable, ", ")))
+	}
+	if opts.Order != "ASC" && opts.Order != "DESC" {
+		return qb, domainerrors.InvalidInput("order", fmt.Sprintf("order must be ASC or DESC, got %q", opts.Order))
+	}
+
+	column, id := opts.SortBy, "id"
+	if table != "" {
+		column, id = table+"."+column, table+".id"
+	}
+	orderBy := []string{column + " " + opts.Order}
+	if opts.SortBy != "id" {
+		orderBy = append(orderBy, id+" "+opts.Order)
+	}
+	qb = qb.OrderBy(orderBy...)
+	if opts.Unpaged {
+		return qb, nil
+	}
+	return qb.
+		Limit(uint64(opts.Limit)).
+		Offset(uint64(opts.Offset)), nil
+}
@@ -0,0 +1,134 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"backend/internal/domain/repository"
+	pkgerrors "backend/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+func TestPaginate_ProducesOrderLimitOffset(t *testing.T) {
+	opts := repository.ListOptions{SortBy: "name", Order: "ASC", Limit: 20, Offset: 40}
+
+	qb, err := paginate(builder.Select("id").From("templates"), opts, templateSortColumns)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	query, args, sqlErr := qb.ToSql()
+	if sqlErr != nil {
+		t.Fatalf("failed to build SQL: %v", sqlErr)
+	}
+	if want := "SELECT id FROM templates ORDER BY name ASC, id ASC LIMIT 20 OFFSET 40"; query != want {
+		t.Errorf("expected %q, got %q", want, query)
+	}
+	if len(args) != 0 {
+		t.Errorf("expected no args, got %v", args)
+	}
+}
+
+func TestPaginate_AppliesDefaults(t *testing.T) {
+	opts := repository.ListOptions{}
+	opts.ApplyDefaults()
+
+	qb, err := paginate(builder.Select("id").From("workspaces"), opts, workspaceSortColumns)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	query, _, _ := qb.ToSql()
+	if want := "SELECT id FROM workspaces ORDER BY created_at DESC, id DESC LIMIT 50 OFFSET 0"; query != want {
+		t.Errorf("expected %q, got %q", want, query)
+	}
+}
+
+func TestPaginate_UnpagedOnlyOrders(t *testing.T) {
+	opts := repository.ListOptions{SortBy: "name", Order: "ASC", Limit: 20, Offset: 40, Unpaged: true}
+
+	qb, err := paginate(builder.Select("id").From("templates"), opts, templateSortColumns)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	query, _, _ := qb.ToSql()
+	if want := "SELECT id FROM templates ORDER BY name ASC, id ASC"; query != want {
+		t.Errorf("expected %q, got %q", want, query)
+	}
+}
+
+func TestPaginateQualified_PrefixesSortColumn(t *testing.T) {
+	opts := repository.ListOptions{SortBy: "status", Order: "DESC", Limit: 10}
+
+	qb, err := paginateQualified(builder.Select("e.id").From("environments e"), opts, "e", environmentSortColumns)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	query, _, _ := qb.ToSql()
+	if want := "SELECT e.id FROM environments e ORDER BY e.status DESC, e.id DESC LIMIT 10 OFFSET 0"; query != want {
+		t.Errorf("expected %q, got %q", want, query)
+	}
+}
+
+func TestPaginate_RejectsUnsafeOrdering(t *testing.T) {
+	tests := []struct {
+		name string
+		opts repository.ListOptions
+	}{
+		{"column outside allow-list", repository.ListOptions{SortBy: "path", Order: "ASC", Limit: 10}},
+		{"injected sort column", repository.ListOptions{SortBy: "name; DROP TABLE templates", Order: "ASC", Limit: 10}},
+		{"injected order", repository.ListOptions{SortBy: "name", Order: "ASC, (SELECT 1)", Limit: 10}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := paginate(builder.Select("id").From("templates"), tt.opts, templateSortColumns)
+			if err == nil {
+				t.Fatal("expected an error, got nil")
+			}
+			if err.Code() != pkgerrors.CodeInvalidInput {
+				t.Errorf("expected code %s, got %s", pkgerrors.CodeInvalidInput, err.Code())
+			}
+		})
+	}
+}
+
+func TestPaginate_TiedTimestampsPageWithoutDuplicatesOrGaps(t *testing.T) {
+	ctx := context.Background()
+	uow := newMigratedUnitOfWork(t)
+
+	const total = 7
+	for i := 0; i < total; i++ {
+		if _, err := uow.db.Exec(
+			"INSERT INTO workspaces (id, name, description, created_at, updated_at) VALUES (?, ?, '', '2024-05-17 09:30:00', '2024-05-17 09:30:00')",
+			uuid.New(), "Tied",
+		); err != nil {
+			t.Fatalf("failed to seed workspace: %v", err)
+		}
+	}
+
+	repo := NewRepositoryFactory().CreateWorkspaceRepository(uow)
+	for _, sortBy := range []string{"created_at", "name"} {
+		for _, order := range []string{"ASC", "DESC"} {
+			seen := map[uuid.UUID]bool{}
+			for offset := 0; offset < total; offset += 2 {
+				page, err := repo.List(ctx, repository.ListOptions{SortBy: sortBy, Order: order, Limit: 2, Offset: offset})
+				if err != nil {
+					t.Fatalf("failed to list page at offset %d: %v", offset, err)
+				}
+				for _, workspace := range page {
+					if seen[workspace.ID] {
+						t.Errorf("%s %s: workspace %s appeared on more than one page", sortBy, order, workspace.ID)
+					}
+					seen[workspace.ID] = true
+				}
+			}
+			if len(seen) != total {
+				t.Errorf("%s %s: expected %d workspaces across pages, got %d", sortBy, order, total, len(seen))
+			}
+		}
+	}
+}
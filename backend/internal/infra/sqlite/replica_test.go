@@ -0,0 +1,158 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"backend/internal/domain"
+	"backend/internal/domain/repository"
+	"backend/pkg/errors"
+	"backend/pkg/validation"
+
+	"github.com/google/uuid"
+)
+
+// spyQuerier counts the queries sent to the Querier it wraps.
+type spyQuerier struct {
+	Querier
+	calls atomic.Int64
+}
+
+func (s *spyQuerier) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	s.calls.Add(1)
+	return s.Querier.ExecContext(ctx, query, args...)
+}
+
+func (s *spyQuerier) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	s.calls.Add(1)
+	return s.Querier.QueryContext(ctx, query, args...)
+}
+
+func (s *spyQuerier) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	s.calls.Add(1)
+	return s.Querier.QueryRowContext(ctx, query, args...)
+}
+
+// newReplicaPair returns a migrated primary and a read-only replica pool whose
+// database holds one workspace the primary does not have.
+func newReplicaPair(t *testing.T) (primary *sql.DB, replica *spyQuerier, replicaOnlyID uuid.UUID) {
+	t.Helper()
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	primaryPath := filepath.Join(dir, "primary.db")
+	replicaPath := filepath.Join(dir, "replica.db")
+	for _, path := range []string{primaryPath, replicaPath} {
+		if err := MigrateUp(ctx, path, testMigrationsPath); err != nil {
+			t.Fatalf("failed to migrate %s: %v", path, err)
+		}
+	}
+
+	seed, err := NewDB(Config{FilePath: replicaPath})
+	if err != nil {
+		t.Fatalf("failed to open replica for seeding: %v", err)
+	}
+	replicaOnlyID = uuid.New()
+	if _, err := seed.Exec("INSERT INTO workspaces (id, name, description) VALUES (?, 'Replica Only', '')", replicaOnlyID); err != nil {
+		t.Fatalf("failed to seed replica: %v", err)
+	}
+	seed.Close()
+
+	primary, err = NewDB(Config{FilePath: primaryPath})
+	if err != nil {
+		t.Fatalf("failed to open primary: %v", err)
+	}
+	t.Cleanup(func() { primary.Close() })
+
+	replicaDB, err := NewReplicaDB("file:" + replicaPath)
+	if err != nil {
+		t.Fatalf("failed to open replica: %v", err)
+	}
+	t.Cleanup(func() { replicaDB.Close() })
+
+	return primary, &spyQuerier{Querier: replicaDB}, replicaOnlyID
+}
+
+func TestReplicaReads_ReadsHitReplicaAndWritesHitPrimary(t *testing.T) {
+	ctx := context.Background()
+	primary, replica, replicaOnlyID := newReplicaPair(t)
+	uow := NewUnitOfWorkWithReplica(primary, replica)
+	repo := NewRepositoryFactory(WithReplicaReads()).CreateWorkspaceRepository(uow)
+
+	if _, err := repo.GetByID(ctx, replicaOnlyID); err != nil {
+		t.Fatalf("expected GetByID to be served by the replica, got %v", err)
+	}
+	listed, err := repo.List(ctx, repository.ListOptions{})
+	if err != nil {
+		t.Fatalf("failed to list workspaces: %v", err)
+	}
+	if len(listed) != 1 || listed[0].ID != replicaOnlyID {
+		t.Errorf("expected List to return the replica's workspace, got %d workspaces", len(listed))
+	}
+	if replica.calls.Load() != 2 {
+		t.Errorf("expected 2 queries on the replica, got %d", replica.calls.Load())
+	}
+
+	workspace, wsErr := domain.NewWorkspace(domain.RandomIDGenerator{}, validation.DefaultContentPolicy(), "Primary Write", "", nil)
+	if wsErr != nil {
+		t.Fatalf("failed to build workspace: %v", wsErr)
+	}
+	before := replica.calls.Load()
+	if err := repo.Create(ctx, workspace); err != nil {
+		t.Fatalf("failed to create workspace: %v", err)
+	}
+	if replica.calls.Load() != before {
+		t.Error("expected the write not to touch the replica")
+	}
+	var count int
+	if err := primary.QueryRow("SELECT COUNT(*) FROM workspaces WHERE id = ?", workspace.ID).Scan(&count); err != nil || count != 1 {
+		t.Errorf("expected the workspace on the primary, got count %d (err %v)", count, err)
+	}
+}
+
+func TestReplicaReads_TransactionReadsUsePrimary(t *testing.T) {
+	ctx := context.Background()
+	primary, replica, _ := newReplicaPair(t)
+	uow := NewUnitOfWorkWithReplica(primary, replica)
+	repo := NewRepositoryFactory(WithReplicaReads()).CreateWorkspaceRepository(uow)
+
+	workspace, _ := domain.NewWorkspace(domain.RandomIDGenerator{}, validation.DefaultContentPolicy(), "In Transaction", "", nil)
+	if err := uow.Begin(ctx); err != nil {
+		t.Fatalf("failed to begin: %v", err)
+	}
+	defer uow.Rollback()
+	if err := repo.Create(ctx, workspace); err != nil {
+		t.Fatalf("failed to create workspace: %v", err)
+	}
+	if _, err := repo.GetByID(ctx, workspace.ID); err != nil {
+		t.Errorf("expected the transaction to see its own write, got %v", err)
+	}
+	if replica.calls.Load() != 0 {
+		t.Errorf("expected no replica queries inside a transaction, got %d", replica.calls.Load())
+	}
+}
+
+func TestReplicaReads_FallsBackToPrimary(t *testing.T) {
+	ctx := context.Background()
+	primary, replica, replicaOnlyID := newReplicaPair(t)
+
+	t.Run("no replica configured", func(t *testing.T) {
+		repo := NewRepositoryFactory(WithReplicaReads()).CreateWorkspaceRepository(NewUnitOfWork(primary))
+		if _, err := repo.GetByID(ctx, replicaOnlyID); err == nil || err.Code() != errors.CodeNotFound {
+			t.Errorf("expected the primary to be read, got %v", err)
+		}
+	})
+
+	t.Run("flag off", func(t *testing.T) {
+		repo := NewRepositoryFactory().CreateTemplateRepository(NewUnitOfWorkWithReplica(primary, replica))
+		if _, err := repo.List(ctx, repository.ListOptions{}); err != nil {
+			t.Fatalf("failed to list templates: %v", err)
+		}
+		if replica.calls.Load() != 0 {
+			t.Errorf("expected no replica queries without WithReplicaReads, got %d", replica.calls.Load())
+		}
+	})
+}
@@ -2,29 +2,57 @@ package sqlite
 
 import (
 	apphandlers "backend/internal/application/handlers"
+	"backend/internal/domain"
 	"backend/internal/domain/repository"
 )
 
-type repositoryFactory struct{}
+type repositoryFactory struct {
+	replicaReads bool
+	ids          domain.IDGenerator
+}
+
+// RepositoryFactoryOption configures NewRepositoryFactory.
+type RepositoryFactoryOption func(*repositoryFactory)
+
+// WithReplicaReads routes the read-only lookups and listings of the workspace
+// and template repositories to the unit of work's replica when it has one.
+// Reads inside a transaction still use the transaction.
+func WithReplicaReads() RepositoryFactoryOption {
+	return func(f *repositoryFactory) { f.replicaReads = true }
+}
 
-func NewRepositoryFactory() apphandlers.RepositoryFactory {
-	return &repositoryFactory{}
+// WithIDGenerator sets the generator for IDs that repositories assign to
+// entities created without one. The default generates random UUIDs.
+func WithIDGenerator(ids domain.IDGenerator) RepositoryFactoryOption {
+	return func(f *repositoryFactory) { f.ids = ids }
+}
+
+func NewRepositoryFactory(opts ...RepositoryFactoryOption) apphandlers.RepositoryFactory {
+	f := &repositoryFactory{ids: domain.RandomIDGenerator{}}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
 }
 
 func (f *repositoryFactory) CreateUserRepository(uow apphandlers.UnitOfWork) repository.UserRepository {
-	return newUserRepository(uow.(*UnitOfWork))
+	return newUserRepository(uow.(*UnitOfWork), f.ids)
 }
 
 func (f *repositoryFactory) CreateWorkspaceRepository(uow apphandlers.UnitOfWork) repository.WorkspaceRepository {
-	return newWorkspaceRepository(uow.(*UnitOfWork))
+	return newWorkspaceRepository(uow.(*UnitOfWork), f.replicaReads)
 }
 
 func (f *repositoryFactory) CreateTemplateRepository(uow apphandlers.UnitOfWork) repository.TemplateRepository {
-	return newTemplateRepository(uow.(*UnitOfWork))
+	return newTemplateRepository(uow.(*UnitOfWork), f.replicaReads)
+}
+
+func (f *repositoryFactory) CreateTemplateVersionRepository(uow apphandlers.UnitOfWork) repository.TemplateVersionRepository {
+	return newTemplateVersionRepository(uow.(*UnitOfWork))
 }
 
 func (f *repositoryFactory) CreateEnvironmentRepository(uow apphandlers.UnitOfWork) repository.EnvironmentRepository {
-	return newEnvironmentRepository(uow.(*UnitOfWork))
+	return newEnvironmentRepository(uow.(*UnitOfWork), f.ids)
 }
 
 func (f *repositoryFactory) CreateTemplateVariableRepository(uow apphandlers.UnitOfWork) repository.TemplateVariableRepository {
@@ -39,6 +67,18 @@ func (f *repositoryFactory) CreateTeardownQueueRepository(uow apphandlers.UnitOf
 	return newTeardownQueueRepository(uow.(*UnitOfWork))
 }
 
+func (f *repositoryFactory) CreateSystemInitRepository(uow apphandlers.UnitOfWork) repository.SystemInitRepository {
+	return newSystemInitRepository(uow.(*UnitOfWork))
+}
+
 func (f *repositoryFactory) CreateGroupRepository(uow apphandlers.UnitOfWork) repository.GroupRepository {
 	return newGroupRepository(uow.(*UnitOfWork))
 }
+
+func (f *repositoryFactory) CreateAPIKeyRepository(uow apphandlers.UnitOfWork) repository.APIKeyRepository {
+	return newAPIKeyRepository(uow.(*UnitOfWork))
+}
+
+func (f *repositoryFactory) CreateOutboxRepository(uow apphandlers.UnitOfWork) repository.OutboxRepository {
+	return newOutboxRepository(uow.(*UnitOfWork))
+}
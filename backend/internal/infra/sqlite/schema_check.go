@@ -0,0 +1,67 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/golang-migrate/migrate/v4/source"
+)
+
+var (
+	// ErrSchemaDirty reports a database whose last migration did not finish.
+	ErrSchemaDirty = errors.New("database schema is dirty")
+	// ErrSchemaVersionMismatch reports a database at another schema version
+	// than the one the server was built for.
+	ErrSchemaVersionMismatch = errors.New("database schema version mismatch")
+)
+
+// LatestMigrationVersion returns the highest version among the up migrations
+// at the root of migrations.
+func LatestMigrationVersion(migrations fs.FS) (uint, error) {
+	entries, err := fs.ReadDir(migrations, ".")
+	if err != nil {
+		return 0, fmt.Errorf("failed to list migrations: %w", err)
+	}
+
+	var latest uint
+	for _, entry := range entries {
+		m, err := source.Parse(entry.Name())
+		if err != nil || m.Direction != source.Up {
+			continue
+		}
+		latest = max(latest, m.Version)
+	}
+	if latest == 0 {
+		return 0, errors.New("no migrations found")
+	}
+	return latest, nil
+}
+
+// CheckSchemaVersion verifies that the migrations recorded in db finished at
+// version want. Serving against a schema another instance is still migrating,
+// or one a failed migration left half applied, would fail in ways that are
+// hard to trace back, so the server refuses to start instead.
+func CheckSchemaVersion(ctx context.Context, db *sql.DB, want uint) error {
+	var (
+		version uint
+		dirty   bool
+	)
+	err := db.QueryRowContext(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%w: no migrations applied, expected version %d", ErrSchemaVersionMismatch, want)
+		}
+		return fmt.Errorf("failed to read schema version (have migrations been run?): %w", err)
+	}
+
+	if dirty {
+		return fmt.Errorf("%w at version %d: a migration failed or is still running", ErrSchemaDirty, version)
+	}
+	if version != want {
+		return fmt.Errorf("%w: database is at version %d, server expects %d", ErrSchemaVersionMismatch, version, want)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"backend/internal/infra/migrations"
+)
+
+func embeddedSchemaVersion(t *testing.T) uint {
+	t.Helper()
+	version, err := LatestMigrationVersion(migrations.SQLite())
+	if err != nil {
+		t.Fatalf("LatestMigrationVersion: %v", err)
+	}
+	return version
+}
+
+func TestCheckSchemaVersion_MigratedDatabasePasses(t *testing.T) {
+	uow := newMigratedUnitOfWork(t)
+	if err := CheckSchemaVersion(context.Background(), uow.db, embeddedSchemaVersion(t)); err != nil {
+		t.Fatalf("expected a fully migrated database to pass, got %v", err)
+	}
+}
+
+func TestCheckSchemaVersion_RefusesUnreadySchema(t *testing.T) {
+	tests := []struct {
+		name    string
+		tamper  string
+		wantErr error
+		wantMsg string
+	}{
+		{"dirty", "UPDATE schema_migrations SET dirty = 1", ErrSchemaDirty, "a migration failed or is still running"},
+		{"behind", "UPDATE schema_migrations SET version = version - 1", ErrSchemaVersionMismatch, "server expects"},
+		{"never migrated", "DELETE FROM schema_migrations", ErrSchemaVersionMismatch, "no migrations applied"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uow := newMigratedUnitOfWork(t)
+			if _, err := uow.db.Exec(tt.tamper); err != nil {
+				t.Fatalf("tamper with schema_migrations: %v", err)
+			}
+
+			err := CheckSchemaVersion(context.Background(), uow.db, embeddedSchemaVersion(t))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+			if !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("expected the error to explain %q, got %q", tt.wantMsg, err)
+			}
+		})
+	}
+}
+
+func TestCheckSchemaVersion_NoMigrationTable(t *testing.T) {
+	db, err := NewDB(Config{FilePath: filepath.Join(t.TempDir(), "devshare.db")})
+	if err != nil {
+		t.Fatalf("NewDB: %v", err)
+	}
+	defer db.Close()
+
+	if err := CheckSchemaVersion(context.Background(), db, embeddedSchemaVersion(t)); err == nil {
+		t.Fatal("expected a database without schema_migrations to be refused")
+	}
+}
@@ -0,0 +1,150 @@
+package sqlite
+
+import (
+	"context"
+	"time"
+
+	"backend/internal/domain"
+	domainerrors "backend/internal/domain/errors"
+	"backend/internal/domain/repository"
+	infraerrors "backend/internal/infra/errors"
+	pkgerrors "backend/pkg/errors"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+// systemInitRowID is the only id the system_init table accepts.
+const systemInitRowID = 1
+
+type systemInitRepository struct {
+	uow *UnitOfWork
+}
+
+func newSystemInitRepository(uow *UnitOfWork) repository.SystemInitRepository {
+	return &systemInitRepository{uow: uow}
+}
+
+func (r *systemInitRepository) MarkInitialized(ctx context.Context, workspaceID uuid.UUID) *pkgerrors.Error {
+	query, args, err := builder.
+		Insert("system_init").
+		Columns("id", "workspace_id").
+		Values(systemInitRowID, workspaceID).
+		ToSql()
+	if err != nil {
+		return infraerrors.WrapQueryBuildError(err, "mark_system_initialized")
+	}
+
+	if _, err := r.uow.Querier().ExecContext(ctx, query, args...); err != nil {
+		wrapped := infraerrors.WrapSQLiteError(err, "mark_system_initialized")
+		if wrapped.Code() == pkgerrors.CodeConflict {
+			return domainerrors.ErrSystemAlreadyInitialized
+		}
+		return wrapped
+	}
+	return nil
+}
+
+// RecordAttempt checks for a recent duplicate and inserts in one statement,
+// so concurrent requests cannot both pass the check.
+func (r *systemInitRepository) RecordAttempt(ctx context.Context, attempt *domain.AdminInitAttempt, since time.Time) (bool, *pkgerrors.Error) {
+	query, args, err := builder.
+		Insert("admin_init_attempts").
+		Columns("id", "outcome", "ip", "attempted_at").
+		Select(builder.
+			Select().
+			Column("?", attempt.ID).
+			Column("?", attempt.Outcome).
+			Column("?", attempt.IP).
+			Column("?", attempt.AttemptedAt.UTC().Format(timestampFormat)).
+			Where(sq.Expr(
+				"NOT EXISTS (SELECT 1 FROM admin_init_attempts WHERE ip = ? AND outcome = ? AND attempted_at >= ?)",
+				attempt.IP, attempt.Outcome, since.UTC().Format(timestampFormat),
+			))).
+		ToSql()
+	if err != nil {
+		return false, infraerrors.WrapQueryBuildError(err, "record_admin_init_attempt")
+	}
+
+	result, err := r.uow.Querier().ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, infraerrors.WrapSQLiteError(err, "record_admin_init_attempt")
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, infraerrors.WrapSQLiteError(err, "get_rows_affected")
+	}
+	return rows > 0, nil
+}
+
+func (r *systemInitRepository) PruneAttempts(ctx context.Context, keep int) *pkgerrors.Error {
+	query, args, err := builder.
+		Delete("admin_init_attempts").
+		Where(sq.Expr(
+			"rowid NOT IN (SELECT rowid FROM admin_init_attempts ORDER BY attempted_at DESC, rowid DESC LIMIT ?)",
+			keep,
+		)).
+		ToSql()
+	if err != nil {
+		return infraerrors.WrapQueryBuildError(err, "prune_admin_init_attempts")
+	}
+
+	if _, err := r.uow.Querier().ExecContext(ctx, query, args...); err != nil {
+		return infraerrors.WrapSQLiteError(err, "prune_admin_init_attempts")
+	}
+	return nil
+}
+
+// ListAttempts breaks ties between attempts in the same second by insertion
+// order.
+func (r *systemInitRepository) ListAttempts(ctx context.Context, limit, offset int) ([]*domain.AdminInitAttempt, *pkgerrors.Error) {
+	query, args, err := builder.
+		Select("id", "outcome", "ip", "attempted_at").
+		From("admin_init_attempts").
+		OrderBy("attempted_at DESC", "rowid DESC").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).
+		ToSql()
+	if err != nil {
+		return nil, infraerrors.WrapQueryBuildError(err, "list_admin_init_attempts")
+	}
+
+	rows, err := r.uow.Querier().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, infraerrors.WrapSQLiteError(err, "list_admin_init_attempts")
+	}
+	defer rows.Close()
+
+	attempts := []*domain.AdminInitAttempt{}
+	for rows.Next() {
+		var attempt domain.AdminInitAttempt
+		var at TimestampDest
+		if err := rows.Scan(&attempt.ID, &attempt.Outcome, &attempt.IP, &at); err != nil {
+			return nil, infraerrors.WrapSQLiteError(err, "scan_admin_init_attempt")
+		}
+		attempt.AttemptedAt = at.Time()
+		attempts = append(attempts, &attempt)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, infraerrors.WrapSQLiteError(err, "iterate_admin_init_attempts")
+	}
+
+	return attempts, nil
+}
+
+func (r *systemInitRepository) CountAttempts(ctx context.Context) (int, *pkgerrors.Error) {
+	query, args, err := builder.
+		Select("COUNT(*)").
+		From("admin_init_attempts").
+		ToSql()
+	if err != nil {
+		return 0, infraerrors.WrapQueryBuildError(err, "count_admin_init_attempts")
+	}
+
+	var count int
+	if err := r.uow.Querier().QueryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return 0, infraerrors.WrapSQLiteError(err, "count_admin_init_attempts")
+	}
+	return count, nil
+}
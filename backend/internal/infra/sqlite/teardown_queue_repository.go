@@ -32,7 +32,7 @@ func (r *teardownQueueRepository) Enqueue(ctx context.Context, entry *domain.Tea
 		Suffix("RETURNING created_at, updated_at").
 		ToSql()
 	if err != nil {
-		return infraerrors.WrapSQLiteError(err, "enqueue_teardown")
+		return infraerrors.WrapQueryBuildError(err, "enqueue_teardown")
 	}
 
 	var cat, uat TimestampDest
@@ -47,17 +47,38 @@ func (r *teardownQueueRepository) Enqueue(ctx context.Context, entry *domain.Tea
 	return nil
 }
 
+func (r *teardownQueueRepository) Schedule(ctx context.Context, envID uuid.UUID, at time.Time) *pkgerrors.Error {
+	query, args, err := builder.
+		Insert("teardown_queue").
+		Columns("environment_id", "teardown_at", "status").
+		Values(envID, at.UTC().Format(timestampFormat), string(domain.TeardownStatusPending)).
+		Suffix(`ON CONFLICT(environment_id) DO UPDATE SET
+			teardown_at = MIN(teardown_queue.teardown_at, excluded.teardown_at),
+			status = CASE WHEN teardown_queue.status = ? THEN teardown_queue.status ELSE excluded.status END,
+			updated_at = CURRENT_TIMESTAMP`, string(domain.TeardownStatusProcessing)).
+		ToSql()
+	if err != nil {
+		return infraerrors.WrapQueryBuildError(err, "schedule_teardown")
+	}
+
+	if _, err := r.uow.Querier().ExecContext(ctx, query, args...); err != nil {
+		return infraerrors.WrapSQLiteError(err, "schedule_teardown")
+	}
+
+	return nil
+}
+
 func (r *teardownQueueRepository) FindDue(ctx context.Context, now time.Time) (*domain.TeardownEntry, *pkgerrors.Error) {
 	query, args, err := builder.
 		Select("environment_id", "teardown_at", "status", "created_at", "updated_at").
 		From("teardown_queue").
 		Where(sq.Eq{"status": string(domain.TeardownStatusPending)}).
 		Where(sq.LtOrEq{"teardown_at": now.UTC().Format(timestampFormat)}).
-		OrderBy("teardown_at ASC").
+		OrderBy("teardown_at ASC", "environment_id ASC").
 		Limit(1).
 		ToSql()
 	if err != nil {
-		return nil, infraerrors.WrapSQLiteError(err, "find_due_teardown")
+		return nil, infraerrors.WrapQueryBuildError(err, "find_due_teardown")
 	}
 
 	var entry domain.TeardownEntry
@@ -94,7 +115,7 @@ func (r *teardownQueueRepository) UpdateStatus(ctx context.Context, envID uuid.U
 		Where(sq.Eq{"environment_id": envID}).
 		ToSql()
 	if err != nil {
-		return infraerrors.WrapSQLiteError(err, "update_teardown_status")
+		return infraerrors.WrapQueryBuildError(err, "update_teardown_status")
 	}
 
 	_, err = r.uow.Querier().ExecContext(ctx, query, args...)
@@ -113,7 +134,7 @@ func (r *teardownQueueRepository) ResetProcessing(ctx context.Context) *pkgerror
 		Where(sq.Eq{"status": string(domain.TeardownStatusProcessing)}).
 		ToSql()
 	if err != nil {
-		return infraerrors.WrapSQLiteError(err, "reset_processing_teardowns")
+		return infraerrors.WrapQueryBuildError(err, "reset_processing_teardowns")
 	}
 
 	_, err = r.uow.Querier().ExecContext(ctx, query, args...)
@@ -4,34 +4,75 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"slices"
+	"time"
 
 	"backend/internal/domain"
 	domainerrors "backend/internal/domain/errors"
 	"backend/internal/domain/repository"
 	infraerrors "backend/internal/infra/errors"
+	"backend/pkg/contracts"
 	pkgerrors "backend/pkg/errors"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
 )
 
+// templateFilterColumns lists the columns ListOptions.Filters may reference for templates.
+var (
+	templateFilterColumns = []string{"id", "workspace_id", "name", "repo_url"}
+	templateSortColumns   = []string{"name", "created_at", "updated_at"}
+)
+
 type templateRepository struct {
-	uow *UnitOfWork
+	uow          *UnitOfWork
+	replicaReads bool
+}
+
+func newTemplateRepository(uow *UnitOfWork, replicaReads bool) repository.TemplateRepository {
+	return &templateRepository{uow: uow, replicaReads: replicaReads}
+}
+
+// reader is the Querier for read-only lookups and listings.
+// GetByWorkspaceAndName always reads the primary since it backs the name
+// uniqueness check.
+func (r *templateRepository) reader() Querier {
+	return r.uow.reader(r.replicaReads)
 }
 
-func newTemplateRepository(uow *UnitOfWork) repository.TemplateRepository {
-	return &templateRepository{uow: uow}
+var templateCols = []string{"id", "name", "workspace_id", "path", "repo_url", "created_at", "updated_at"}
+
+func (r *templateRepository) scanTemplate(row interface{ Scan(dest ...any) error }) (*domain.Template, error) {
+	var template domain.Template
+	var cat, uat TimestampDest
+	var repoURL sql.NullString
+	err := row.Scan(
+		&template.ID,
+		&template.Name,
+		&template.WorkspaceID,
+		&template.Path,
+		&repoURL,
+		&cat,
+		&uat,
+	)
+	if err != nil {
+		return nil, err
+	}
+	template.RepoURL = repoURL.String
+	template.CreatedAt = cat.Time()
+	template.UpdatedAt = uat.Time()
+	return &template, nil
 }
 
 func (r *templateRepository) Create(ctx context.Context, template domain.Template) *pkgerrors.Error {
 	query, args, err := builder.
 		Insert("templates").
-		Columns("id", "name", "workspace_id", "path").
-		Values(template.ID, template.Name, template.WorkspaceID, template.Path).
+		Columns("id", "name", "workspace_id", "path", "repo_url").
+		Values(template.ID, template.Name, template.WorkspaceID, template.Path, nullString(template.RepoURL)).
 		Suffix("RETURNING created_at, updated_at").
 		ToSql()
 	if err != nil {
-		return infraerrors.WrapSQLiteError(err, "create_template")
+		return infraerrors.WrapQueryBuildError(err, "create_template")
 	}
 
 	var cat, uat TimestampDest
@@ -47,25 +88,16 @@ func (r *templateRepository) Create(ctx context.Context, template domain.Templat
 }
 
 func (r *templateRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Template, *pkgerrors.Error) {
-	query, args, err := builder.
-		Select("id", "name", "workspace_id", "path", "created_at", "updated_at").
+	query, args, err := scopeToWorkspace(ctx, builder.
+		Select(templateCols...).
 		From("templates").
-		Where(sq.Eq{"id": id}).
+		Where(sq.Eq{"id": id}), "workspace_id").
 		ToSql()
 	if err != nil {
-		return nil, infraerrors.WrapSQLiteError(err, "get_template")
+		return nil, infraerrors.WrapQueryBuildError(err, "get_template")
 	}
 
-	var template domain.Template
-	var cat, uat TimestampDest
-	err = r.uow.Querier().QueryRowContext(ctx, query, args...).Scan(
-		&template.ID,
-		&template.Name,
-		&template.WorkspaceID,
-		&template.Path,
-		&cat,
-		&uat,
-	)
+	template, err := r.scanTemplate(r.reader().QueryRowContext(ctx, query, args...))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, domainerrors.NotFound("Template", id.String())
@@ -73,47 +105,74 @@ func (r *templateRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain
 		return nil, infraerrors.WrapSQLiteError(err, "get_template")
 	}
 
-	template.CreatedAt = cat.Time()
-	template.UpdatedAt = uat.Time()
+	return template, nil
+}
 
-	return &template, nil
+func (r *templateRepository) WorkspaceOf(ctx context.Context, id uuid.UUID) (uuid.UUID, *pkgerrors.Error) {
+	query, args, err := builder.
+		Select("workspace_id").
+		From("templates").
+		Where(sq.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return uuid.Nil, infraerrors.WrapQueryBuildError(err, "get_template_workspace")
+	}
+
+	var workspaceID uuid.UUID
+	if err := r.reader().QueryRowContext(ctx, query, args...).Scan(&workspaceID); err != nil {
+		if err == sql.ErrNoRows {
+			return uuid.Nil, domainerrors.NotFound("Template", id.String())
+		}
+		return uuid.Nil, infraerrors.WrapSQLiteError(err, "get_template_workspace")
+	}
+	return workspaceID, nil
+}
+
+func (r *templateRepository) GetByWorkspaceAndName(ctx context.Context, workspaceID uuid.UUID, name string) (*domain.Template, *pkgerrors.Error) {
+	query, args, err := scopeToWorkspace(ctx, builder.
+		Select(templateCols...).
+		From("templates").
+		Where(sq.Eq{"workspace_id": workspaceID, "name": name}), "workspace_id").
+		ToSql()
+	if err != nil {
+		return nil, infraerrors.WrapQueryBuildError(err, "get_template_by_name")
+	}
+
+	template, err := r.scanTemplate(r.uow.Querier().QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainerrors.NotFoundByField("Template", "name", name)
+		}
+		return nil, infraerrors.WrapSQLiteError(err, "get_template_by_name")
+	}
+
+	return template, nil
 }
 
 func (r *templateRepository) GetByWorkspaceID(ctx context.Context, workspaceID uuid.UUID) ([]*domain.Template, *pkgerrors.Error) {
-	query, args, err := builder.
-		Select("id", "name", "workspace_id", "path", "created_at", "updated_at").
+	query, args, err := scopeToWorkspace(ctx, builder.
+		Select(templateCols...).
 		From("templates").
-		Where(sq.Eq{"workspace_id": workspaceID}).
-		OrderBy("created_at DESC").
+		Where(sq.Eq{"workspace_id": workspaceID}), "workspace_id").
+		OrderBy("created_at DESC", "id DESC").
 		ToSql()
 	if err != nil {
-		return nil, infraerrors.WrapSQLiteError(err, "get_templates_by_workspace")
+		return nil, infraerrors.WrapQueryBuildError(err, "get_templates_by_workspace")
 	}
 
-	rows, err := r.uow.Querier().QueryContext(ctx, query, args...)
+	rows, err := r.reader().QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, infraerrors.WrapSQLiteError(err, "get_templates_by_workspace")
 	}
 	defer rows.Close()
 
-	var templates []*domain.Template
+	templates := []*domain.Template{}
 	for rows.Next() {
-		var template domain.Template
-		var cat, uat TimestampDest
-		err := rows.Scan(
-			&template.ID,
-			&template.Name,
-			&template.WorkspaceID,
-			&template.Path,
-			&cat,
-			&uat,
-		)
+		template, err := r.scanTemplate(rows)
 		if err != nil {
 			return nil, infraerrors.WrapSQLiteError(err, "scan_template")
 		}
-		template.CreatedAt = cat.Time()
-		template.UpdatedAt = uat.Time()
-		templates = append(templates, &template)
+		templates = append(templates, template)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -123,17 +182,167 @@ func (r *templateRepository) GetByWorkspaceID(ctx context.Context, workspaceID u
 	return templates, nil
 }
 
+func (r *templateRepository) CountByWorkspaceID(ctx context.Context, workspaceID uuid.UUID, filters map[string]any) (int, *pkgerrors.Error) {
+	opts := repository.ListOptions{Filters: filters}
+	if err := opts.ValidateFilters(templateFilterColumns...); err != nil {
+		return 0, err
+	}
+
+	conditions := sq.Eq{}
+	for key, value := range filters {
+		conditions[key] = value
+	}
+	conditions["workspace_id"] = workspaceID
+
+	query, args, err := scopeToWorkspace(ctx, builder.
+		Select("COUNT(*)").
+		From("templates").
+		Where(conditions), "workspace_id").
+		ToSql()
+	if err != nil {
+		return 0, infraerrors.WrapQueryBuildError(err, "count_templates_by_workspace")
+	}
+
+	var count int
+	if err := r.reader().QueryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return 0, infraerrors.WrapSQLiteError(err, "count_templates_by_workspace")
+	}
+
+	return count, nil
+}
+
+func (r *templateRepository) Stats(ctx context.Context, workspaceID uuid.UUID, filters map[string]any, since time.Time, recent int) (*repository.TemplateStats, *pkgerrors.Error) {
+	opts := repository.ListOptions{Filters: filters}
+	if err := opts.ValidateFilters(templateFilterColumns...); err != nil {
+		return nil, err
+	}
+
+	conditions := sq.Eq{}
+	for key, value := range filters {
+		conditions[key] = value
+	}
+	conditions["workspace_id"] = workspaceID
+
+	query, args, err := scopeToWorkspace(ctx, builder.
+		Select("COUNT(*)").
+		Column(sq.Expr("COALESCE(SUM(CASE WHEN updated_at >= ? THEN 1 ELSE 0 END), 0)", since.UTC().Format(timestampFormat))).
+		Column("MAX(updated_at)").
+		From("templates").
+		Where(conditions), "workspace_id").
+		ToSql()
+	if err != nil {
+		return nil, infraerrors.WrapQueryBuildError(err, "template_stats")
+	}
+
+	stats := &repository.TemplateStats{MostRecentlyUpdated: []domain.Template{}}
+	var lastUpdated NullableTimestamp
+	if err := r.reader().QueryRowContext(ctx, query, args...).Scan(&stats.Total, &stats.UpdatedSince, &lastUpdated); err != nil {
+		return nil, infraerrors.WrapSQLiteError(err, "template_stats")
+	}
+	if lastUpdated.valid {
+		t := lastUpdated.t
+		stats.LastUpdatedAt = &t
+	}
+	if recent == 0 || stats.Total == 0 {
+		return stats, nil
+	}
+
+	query, args, err = scopeToWorkspace(ctx, builder.
+		Select("id", "name", "updated_at").
+		From("templates").
+		Where(conditions), "workspace_id").
+		OrderBy("updated_at DESC", "id DESC").
+		Limit(uint64(recent)).
+		ToSql()
+	if err != nil {
+		return nil, infraerrors.WrapQueryBuildError(err, "template_stats_recent")
+	}
+
+	rows, err := r.reader().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, infraerrors.WrapSQLiteError(err, "template_stats_recent")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var template domain.Template
+		var uat TimestampDest
+		if err := rows.Scan(&template.ID, &template.Name, &uat); err != nil {
+			return nil, infraerrors.WrapSQLiteError(err, "scan_template_stats_recent")
+		}
+		template.UpdatedAt = uat.Time()
+		stats.MostRecentlyUpdated = append(stats.MostRecentlyUpdated, template)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, infraerrors.WrapSQLiteError(err, "iterate_template_stats_recent")
+	}
+
+	return stats, nil
+}
+
+// templateSchemeExpr derives a template's source scheme: the lowercased scheme
+// of repo_url, or "storage" when there is none.
+const templateSchemeExpr = `CASE
+	WHEN repo_url IS NULL OR instr(repo_url, '://') = 0 THEN 'storage'
+	ELSE lower(substr(repo_url, 1, instr(repo_url, '://') - 1))
+END`
+
+func (r *templateRepository) CountBySourceScheme(ctx context.Context, workspaceID uuid.UUID, filters map[string]any) ([]repository.SchemeCount, *pkgerrors.Error) {
+	opts := repository.ListOptions{Filters: filters}
+	if err := opts.ValidateFilters(templateFilterColumns...); err != nil {
+		return nil, err
+	}
+
+	conditions := sq.Eq{}
+	for key, value := range filters {
+		conditions[key] = value
+	}
+	conditions["workspace_id"] = workspaceID
+
+	query, args, err := scopeToWorkspace(ctx, builder.
+		Select(templateSchemeExpr+" AS scheme", "COUNT(*) AS count").
+		From("templates").
+		Where(conditions), "workspace_id").
+		GroupBy("scheme").
+		OrderBy("count DESC", "scheme ASC").
+		ToSql()
+	if err != nil {
+		return nil, infraerrors.WrapQueryBuildError(err, "count_templates_by_scheme")
+	}
+
+	rows, err := r.reader().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, infraerrors.WrapSQLiteError(err, "count_templates_by_scheme")
+	}
+	defer rows.Close()
+
+	counts := []repository.SchemeCount{}
+	for rows.Next() {
+		var count repository.SchemeCount
+		if err := rows.Scan(&count.Scheme, &count.Count); err != nil {
+			return nil, infraerrors.WrapSQLiteError(err, "scan_template_scheme_count")
+		}
+		counts = append(counts, count)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, infraerrors.WrapSQLiteError(err, "iterate_template_scheme_counts")
+	}
+
+	return counts, nil
+}
+
 func (r *templateRepository) Update(ctx context.Context, template domain.Template) *pkgerrors.Error {
 	query, args, err := builder.
 		Update("templates").
 		Set("name", template.Name).
 		Set("path", template.Path).
+		Set("repo_url", nullString(template.RepoURL)).
 		Set("updated_at", sq.Expr("CURRENT_TIMESTAMP")).
 		Where(sq.Eq{"id": template.ID}).
 		Suffix("RETURNING updated_at").
 		ToSql()
 	if err != nil {
-		return infraerrors.WrapSQLiteError(err, "update_template")
+		return infraerrors.WrapQueryBuildError(err, "update_template")
 	}
 
 	var uat TimestampDest
@@ -156,7 +365,7 @@ func (r *templateRepository) Delete(ctx context.Context, id uuid.UUID) *pkgerror
 		Where(sq.Eq{"id": id}).
 		ToSql()
 	if err != nil {
-		return infraerrors.WrapSQLiteError(err, "delete_template")
+		return infraerrors.WrapQueryBuildError(err, "delete_template")
 	}
 
 	result, err := r.uow.Querier().ExecContext(ctx, query, args...)
@@ -177,50 +386,63 @@ func (r *templateRepository) Delete(ctx context.Context, id uuid.UUID) *pkgerror
 }
 
 func (r *templateRepository) List(ctx context.Context, opts repository.ListOptions) ([]*domain.Template, *pkgerrors.Error) {
+	templates := []*domain.Template{}
+	err := r.ListEach(ctx, opts, func(template *domain.Template) error {
+		templates = append(templates, template)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return templates, nil
+}
+
+func (r *templateRepository) ListFields(ctx context.Context, opts repository.ListOptions, fields []string) ([]*contracts.PartialTemplate, *pkgerrors.Error) {
 	opts.ApplyDefaults()
 	if err := opts.Validate(); err != nil {
 		return nil, err
 	}
+	if err := opts.ValidateFilters(templateFilterColumns...); err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, domainerrors.InvalidInput("fields", "at least one field is required")
+	}
+	for _, field := range fields {
+		if !slices.Contains(templateCols, field) {
+			return nil, domainerrors.InvalidInput("fields", fmt.Sprintf("unknown field %q", field))
+		}
+	}
 
-	qb := builder.
-		Select("id", "name", "workspace_id", "path", "created_at", "updated_at").
-		From("templates")
-	for col, val := range opts.FilterBy {
-		qb = qb.Where(sq.Eq{col: val})
+	qb := scopeToWorkspace(ctx, builder.
+		Select(fields...).
+		From("templates"), "workspace_id")
+	if len(opts.Filters) > 0 {
+		qb = qb.Where(sq.Eq(opts.Filters))
 	}
-	query, args, err := qb.
-		OrderBy(fmt.Sprintf("%s %s", opts.SortBy, opts.Order)).
-		Limit(uint64(opts.Limit)).
-		Offset(uint64(opts.Offset)).
-		ToSql()
+	qb, pageErr := paginate(qb, opts, templateSortColumns)
+	if pageErr != nil {
+		return nil, pageErr
+	}
+	query, args, err := qb.ToSql()
 	if err != nil {
-		return nil, infraerrors.WrapSQLiteError(err, "list_templates")
+		return nil, infraerrors.WrapQueryBuildError(err, "list_template_fields")
 	}
 
-	rows, err := r.uow.Querier().QueryContext(ctx, query, args...)
+	rows, err := r.reader().QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, infraerrors.WrapSQLiteError(err, "list_templates")
+		return nil, infraerrors.WrapSQLiteError(err, "list_template_fields")
 	}
 	defer rows.Close()
 
-	var templates []*domain.Template
+	templates := []*contracts.PartialTemplate{}
 	for rows.Next() {
-		var template domain.Template
-		var cat, uat TimestampDest
-		err := rows.Scan(
-			&template.ID,
-			&template.Name,
-			&template.WorkspaceID,
-			&template.Path,
-			&cat,
-			&uat,
-		)
+		template, err := scanPartialTemplate(rows, fields)
 		if err != nil {
 			return nil, infraerrors.WrapSQLiteError(err, "scan_template")
 		}
-		template.CreatedAt = cat.Time()
-		template.UpdatedAt = uat.Time()
-		templates = append(templates, &template)
+		templates = append(templates, template)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -229,3 +451,108 @@ func (r *templateRepository) List(ctx context.Context, opts repository.ListOptio
 
 	return templates, nil
 }
+
+// scanPartialTemplate scans a row holding the given template columns, in
+// order, setting only the matching fields.
+func scanPartialTemplate(row interface{ Scan(dest ...any) error }, fields []string) (*contracts.PartialTemplate, error) {
+	var template contracts.PartialTemplate
+	var id, workspaceID uuid.UUID
+	var name, path string
+	var repoURL sql.NullString
+	var cat, uat TimestampDest
+
+	dests := make([]any, len(fields))
+	for i, field := range fields {
+		switch field {
+		case "id":
+			dests[i] = &id
+		case "name":
+			dests[i] = &name
+		case "workspace_id":
+			dests[i] = &workspaceID
+		case "path":
+			dests[i] = &path
+		case "repo_url":
+			dests[i] = &repoURL
+		case "created_at":
+			dests[i] = &cat
+		case "updated_at":
+			dests[i] = &uat
+		}
+	}
+	if err := row.Scan(dests...); err != nil {
+		return nil, err
+	}
+
+	for _, field := range fields {
+		switch field {
+		case "id":
+			template.ID = &id
+		case "name":
+			template.Name = &name
+		case "workspace_id":
+			template.WorkspaceID = &workspaceID
+		case "path":
+			template.Path = &path
+		case "repo_url":
+			// Matches Template, which omits an unset repo_url
+			if repoURL.String != "" {
+				template.RepoURL = &repoURL.String
+			}
+		case "created_at":
+			createdAt := cat.Time()
+			template.CreatedAt = &createdAt
+		case "updated_at":
+			updatedAt := uat.Time()
+			template.UpdatedAt = &updatedAt
+		}
+	}
+	return &template, nil
+}
+
+func (r *templateRepository) ListEach(ctx context.Context, opts repository.ListOptions, fn func(*domain.Template) error) *pkgerrors.Error {
+	opts.ApplyDefaults()
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+	if err := opts.ValidateFilters(templateFilterColumns...); err != nil {
+		return err
+	}
+
+	qb := scopeToWorkspace(ctx, builder.
+		Select(templateCols...).
+		From("templates"), "workspace_id")
+	if len(opts.Filters) > 0 {
+		qb = qb.Where(sq.Eq(opts.Filters))
+	}
+	qb, pageErr := paginate(qb, opts, templateSortColumns)
+	if pageErr != nil {
+		return pageErr
+	}
+	query, args, err := qb.ToSql()
+	if err != nil {
+		return infraerrors.WrapQueryBuildError(err, "list_templates")
+	}
+
+	rows, err := r.reader().QueryContext(ctx, query, args...)
+	if err != nil {
+		return infraerrors.WrapSQLiteError(err, "list_templates")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		template, err := r.scanTemplate(rows)
+		if err != nil {
+			return infraerrors.WrapSQLiteError(err, "scan_template")
+		}
+		if err := fn(template); err != nil {
+			return pkgerrors.Wrap(err, "list_templates: consumer failed")
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return infraerrors.WrapSQLiteError(err, "iterate_templates")
+	}
+
+	return nil
+}
@@ -61,7 +61,7 @@ func (r *templateVariableRepository) Create(ctx context.Context, variable domain
 		Suffix("RETURNING created_at, updated_at").
 		ToSql()
 	if err != nil {
-		return infraerrors.WrapSQLiteError(err, "create_template_variable")
+		return infraerrors.WrapQueryBuildError(err, "create_template_variable")
 	}
 
 	var cat, uat TimestampDest
@@ -95,7 +95,7 @@ func (r *templateVariableRepository) CreateBatch(ctx context.Context, variables
 
 	query, args, err := q.ToSql()
 	if err != nil {
-		return infraerrors.WrapSQLiteError(err, "create_batch_template_variables")
+		return infraerrors.WrapQueryBuildError(err, "create_batch_template_variables")
 	}
 
 	_, err = r.uow.Querier().ExecContext(ctx, query, args...)
@@ -125,7 +125,7 @@ func (r *templateVariableRepository) UpdateBatch(ctx context.Context, variables
 			Where(sq.Eq{"id": v.ID}).
 			ToSql()
 		if err != nil {
-			return infraerrors.WrapSQLiteError(err, "update_batch_template_variables")
+			return infraerrors.WrapQueryBuildError(err, "update_batch_template_variables")
 		}
 
 		_, err = r.uow.Querier().ExecContext(ctx, query, args...)
@@ -144,7 +144,7 @@ func (r *templateVariableRepository) GetByID(ctx context.Context, id uuid.UUID)
 		Where(sq.Eq{"id": id}).
 		ToSql()
 	if err != nil {
-		return nil, infraerrors.WrapSQLiteError(err, "get_template_variable")
+		return nil, infraerrors.WrapQueryBuildError(err, "get_template_variable")
 	}
 
 	v, scanErr := r.scanVariable(r.uow.Querier().QueryRowContext(ctx, query, args...))
@@ -166,7 +166,7 @@ func (r *templateVariableRepository) GetByTemplateID(ctx context.Context, templa
 		OrderBy("display_order ASC", "key ASC").
 		ToSql()
 	if err != nil {
-		return nil, infraerrors.WrapSQLiteError(err, "get_template_variables_by_template")
+		return nil, infraerrors.WrapQueryBuildError(err, "get_template_variables_by_template")
 	}
 
 	rows, err := r.uow.Querier().QueryContext(ctx, query, args...)
@@ -175,7 +175,7 @@ func (r *templateVariableRepository) GetByTemplateID(ctx context.Context, templa
 	}
 	defer rows.Close()
 
-	var variables []*domain.TemplateVariable
+	variables := []*domain.TemplateVariable{}
 	for rows.Next() {
 		v, scanErr := r.scanVariable(rows)
 		if scanErr != nil {
@@ -198,7 +198,7 @@ func (r *templateVariableRepository) GetByTemplateIDAndKey(ctx context.Context,
 		Where(sq.Eq{"template_id": templateID, "key": key}).
 		ToSql()
 	if err != nil {
-		return nil, infraerrors.WrapSQLiteError(err, "get_template_variable_by_key")
+		return nil, infraerrors.WrapQueryBuildError(err, "get_template_variable_by_key")
 	}
 
 	v, scanErr := r.scanVariable(r.uow.Querier().QueryRowContext(ctx, query, args...))
@@ -227,7 +227,7 @@ func (r *templateVariableRepository) Update(ctx context.Context, variable domain
 		Suffix("RETURNING updated_at").
 		ToSql()
 	if err != nil {
-		return infraerrors.WrapSQLiteError(err, "update_template_variable")
+		return infraerrors.WrapQueryBuildError(err, "update_template_variable")
 	}
 
 	var uat TimestampDest
@@ -250,7 +250,7 @@ func (r *templateVariableRepository) Delete(ctx context.Context, id uuid.UUID) *
 		Where(sq.Eq{"id": id}).
 		ToSql()
 	if err != nil {
-		return infraerrors.WrapSQLiteError(err, "delete_template_variable")
+		return infraerrors.WrapQueryBuildError(err, "delete_template_variable")
 	}
 
 	result, err := r.uow.Querier().ExecContext(ctx, query, args...)
@@ -280,7 +280,7 @@ func (r *templateVariableRepository) DeleteByTemplateIDAndKeys(ctx context.Conte
 		Where(sq.Eq{"template_id": templateID, "key": keys}).
 		ToSql()
 	if err != nil {
-		return infraerrors.WrapSQLiteError(err, "delete_template_variables_by_keys")
+		return infraerrors.WrapQueryBuildError(err, "delete_template_variables_by_keys")
 	}
 
 	_, err = r.uow.Querier().ExecContext(ctx, query, args...)
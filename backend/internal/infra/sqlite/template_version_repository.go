@@ -0,0 +1,137 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strconv"
+
+	"backend/internal/domain"
+	domainerrors "backend/internal/domain/errors"
+	"backend/internal/domain/repository"
+	infraerrors "backend/internal/infra/errors"
+	pkgerrors "backend/pkg/errors"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+var templateVersionCols = []string{"id", "template_id", "workspace_id", "version", "name", "path", "repo_url", "created_at"}
+
+type templateVersionRepository struct {
+	uow *UnitOfWork
+}
+
+func newTemplateVersionRepository(uow *UnitOfWork) repository.TemplateVersionRepository {
+	return &templateVersionRepository{uow: uow}
+}
+
+func (r *templateVersionRepository) Create(ctx context.Context, version *domain.TemplateVersion) *pkgerrors.Error {
+	files, err := json.Marshal(version.Files)
+	if err != nil {
+		return infraerrors.WrapSQLiteError(err, "create_template_version")
+	}
+
+	query, args, err := builder.
+		Insert("template_versions").
+		Columns("id", "template_id", "workspace_id", "version", "name", "path", "repo_url", "files").
+		Values(
+			version.ID,
+			version.TemplateID,
+			version.WorkspaceID,
+			sq.Expr("(SELECT COALESCE(MAX(version), 0) + 1 FROM template_versions WHERE template_id = ?)", version.TemplateID),
+			version.Name,
+			version.Path,
+			nullString(version.RepoURL),
+			string(files),
+		).
+		Suffix("RETURNING version, created_at").
+		ToSql()
+	if err != nil {
+		return infraerrors.WrapQueryBuildError(err, "create_template_version")
+	}
+
+	var cat TimestampDest
+	if err := r.uow.Querier().QueryRowContext(ctx, query, args...).Scan(&version.Version, &cat); err != nil {
+		return infraerrors.WrapSQLiteError(err, "create_template_version")
+	}
+	version.CreatedAt = cat.Time()
+
+	return nil
+}
+
+func (r *templateVersionRepository) ListByTemplateID(ctx context.Context, templateID uuid.UUID) ([]*domain.TemplateVersion, *pkgerrors.Error) {
+	query, args, err := scopeToWorkspace(ctx, builder.
+		Select(templateVersionCols...).
+		From("template_versions").
+		Where(sq.Eq{"template_id": templateID}).
+		OrderBy("version DESC"), "workspace_id").
+		ToSql()
+	if err != nil {
+		return nil, infraerrors.WrapQueryBuildError(err, "list_template_versions")
+	}
+
+	rows, err := r.uow.Querier().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, infraerrors.WrapSQLiteError(err, "list_template_versions")
+	}
+	defer rows.Close()
+
+	versions := []*domain.TemplateVersion{}
+	for rows.Next() {
+		version, err := scanTemplateVersion(rows)
+		if err != nil {
+			return nil, infraerrors.WrapSQLiteError(err, "scan_template_version")
+		}
+		versions = append(versions, version)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, infraerrors.WrapSQLiteError(err, "iterate_template_versions")
+	}
+
+	return versions, nil
+}
+
+func (r *templateVersionRepository) GetByTemplateAndVersion(ctx context.Context, templateID uuid.UUID, number int) (*domain.TemplateVersion, *pkgerrors.Error) {
+	query, args, err := scopeToWorkspace(ctx, builder.
+		Select(append(templateVersionCols, "files")...).
+		From("template_versions").
+		Where(sq.Eq{"template_id": templateID, "version": number}), "workspace_id").
+		ToSql()
+	if err != nil {
+		return nil, infraerrors.WrapQueryBuildError(err, "get_template_version")
+	}
+
+	var files string
+	version, err := scanTemplateVersion(r.uow.Querier().QueryRowContext(ctx, query, args...), &files)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainerrors.NotFoundByField("TemplateVersion", "version", strconv.Itoa(number))
+		}
+		return nil, infraerrors.WrapSQLiteError(err, "get_template_version")
+	}
+
+	if err := json.Unmarshal([]byte(files), &version.Files); err != nil {
+		return nil, infraerrors.WrapSQLiteError(err, "decode_template_version_files")
+	}
+
+	return version, nil
+}
+
+// scanTemplateVersion scans templateVersionCols followed by extra.
+func scanTemplateVersion(row interface{ Scan(dest ...any) error }, extra ...any) (*domain.TemplateVersion, error) {
+	var version domain.TemplateVersion
+	var repoURL sql.NullString
+	var cat TimestampDest
+	dest := append([]any{
+		&version.ID, &version.TemplateID, &version.WorkspaceID, &version.Version,
+		&version.Name, &version.Path, &repoURL, &cat,
+	}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	version.RepoURL = repoURL.String
+	version.CreatedAt = cat.Time()
+	return &version, nil
+}
@@ -3,13 +3,8 @@ package sqlite
 import (
 	"fmt"
 	"time"
-
-	sq "github.com/Masterminds/squirrel"
 )
 
-// builder is the Squirrel statement builder configured for SQLite (? placeholders).
-var builder = sq.StatementBuilder.PlaceholderFormat(sq.Question)
-
 // TimestampDest is a sql.Scanner that handles timestamps from SQLite (stored as
 // TEXT "2006-01-02 15:04:05") and, as a safety net, time.Time from any driver.
 type TimestampDest struct{ t time.Time }
@@ -3,15 +3,43 @@ package sqlite
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
+	"log/slog"
+	"runtime"
+	"time"
 
+	apphandlers "backend/internal/application/handlers"
 	"backend/pkg/errors"
 )
 
 type UnitOfWork struct {
-	db     *sql.DB
-	tx     *sql.Tx
-	depth  int
-	failed bool
+	db       *sql.DB
+	replica  Querier
+	tx       *sql.Tx
+	conn     *sql.Conn // pinned connection of a read-only transaction
+	depth    int
+	failed   bool
+	readOnly bool
+
+	// timeout bounds how long a transaction opened by Begin may stay open;
+	// zero leaves it bound only to the caller's ctx.
+	timeout  time.Duration
+	txCtx    context.Context
+	txCancel context.CancelFunc
+
+	// tracker is the request's TxTracker while a transaction is open.
+	tracker *apphandlers.TxTracker
+}
+
+// UnitOfWorkOption configures a UnitOfWork.
+type UnitOfWorkOption func(*UnitOfWork)
+
+// WithTransactionTimeout rolls back a transaction opened by Begin once it has
+// been open for d, releasing SQLite's write lock even if its caller is stuck.
+// Statements after the deadline and the final Commit then fail. Zero or a
+// negative d disables the deadline.
+func WithTransactionTimeout(d time.Duration) UnitOfWorkOption {
+	return func(u *UnitOfWork) { u.timeout = d }
 }
 
 type Querier interface {
@@ -20,26 +48,109 @@ type Querier interface {
 	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
 }
 
-func NewUnitOfWork(db *sql.DB) *UnitOfWork {
-	return &UnitOfWork{db: db}
+func NewUnitOfWork(db *sql.DB, opts ...UnitOfWorkOption) *UnitOfWork {
+	u := &UnitOfWork{db: db}
+	for _, opt := range opts {
+		opt(u)
+	}
+	return u
+}
+
+// NewUnitOfWorkWithReplica is NewUnitOfWork with a read replica that
+// repositories may use for reads made outside a transaction.
+func NewUnitOfWorkWithReplica(db *sql.DB, replica Querier, opts ...UnitOfWorkOption) *UnitOfWork {
+	u := NewUnitOfWork(db, opts...)
+	u.replica = replica
+	return u
 }
 
-func (u *UnitOfWork) Begin() *errors.Error {
+// Begin starts a transaction bound to ctx, or joins the open one. A ctx that
+// is already done fails without taking a connection, and cancelling ctx while
+// the transaction is open rolls it back, as does reaching the transaction
+// timeout when one is configured. A joined transaction keeps the options it
+// was started with.
+func (u *UnitOfWork) Begin(ctx context.Context, opts ...apphandlers.TxOption) *errors.Error {
+	if err := ctx.Err(); err != nil {
+		return errors.Wrap(err, "failed to begin transaction").
+			WithCode(errors.CodeInternal).
+			WithHTTPStatus(500)
+	}
 	if u.depth == 0 {
-		tx, err := u.db.Begin()
+		txCtx, cancel := ctx, context.CancelFunc(func() {})
+		if u.timeout > 0 {
+			txCtx, cancel = context.WithTimeout(ctx, u.timeout)
+		}
+		options := apphandlers.NewTxOptions(opts...)
+		tx, err := u.db.BeginTx(txCtx, &sql.TxOptions{Isolation: options.Isolation})
 		if err != nil {
+			cancel()
 			return errors.Wrap(err, "failed to begin transaction").
 				WithCode(errors.CodeInternal).
 				WithHTTPStatus(500)
 		}
 		u.tx = tx
+		u.txCtx, u.txCancel = txCtx, cancel
+		u.track(ctx)
 	}
 	u.depth++
 	return nil
 }
 
-func (u *UnitOfWork) Commit() *errors.Error {
+// BeginReadOnly starts a deferred transaction with query_only set on its
+// connection. SQLite takes the read snapshot at the first read and keeps it
+// until the transaction ends, and query_only turns any write into an error.
+// The transaction runs on a dedicated connection so that one can be discarded
+// if query_only cannot be cleared again.
+//
+// The transaction itself is not bound to ctx: an automatic rollback on
+// cancellation would return the connection to the pool with query_only still
+// on; the same goes for the transaction timeout. A done ctx still fails here
+// and in Commit, and the queries inside use their own contexts.
+func (u *UnitOfWork) BeginReadOnly(ctx context.Context) *errors.Error {
+	if err := ctx.Err(); err != nil {
+		return errors.Wrap(err, "failed to begin read-only transaction").
+			WithCode(errors.CodeInternal).
+			WithHTTPStatus(500)
+	}
+	if u.depth > 0 {
+		u.depth++
+		return nil
+	}
+	conn, err := u.db.Conn(context.WithoutCancel(ctx))
+	if err != nil {
+		return errors.Wrap(err, "failed to begin read-only transaction").
+			WithCode(errors.CodeInternal).
+			WithHTTPStatus(500)
+	}
+	tx, err := conn.BeginTx(context.WithoutCancel(ctx), &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		conn.Close()
+		return errors.Wrap(err, "failed to begin read-only transaction").
+			WithCode(errors.CodeInternal).
+			WithHTTPStatus(500)
+	}
+	if _, err := tx.Exec("PRAGMA query_only = ON"); err != nil {
+		tx.Rollback()
+		discardConn(conn, err)
+		return errors.Wrap(err, "failed to begin read-only transaction").
+			WithCode(errors.CodeInternal).
+			WithHTTPStatus(500)
+	}
+	u.tx = tx
+	u.conn = conn
+	u.readOnly = true
+	u.track(ctx)
+	u.depth++
+	return nil
+}
+
+// Commit commits the transaction once the outermost caller commits. If ctx is
+// done by then the transaction is rolled back instead and an error returned.
+func (u *UnitOfWork) Commit(ctx context.Context) *errors.Error {
 	if u.depth == 0 {
+		apphandlers.RecordCommitWithoutBegin()
+		_, file, line, _ := runtime.Caller(1)
+		slog.Warn("unit of work committed without an open transaction", "file", file, "line", line)
 		return errors.New("no active transaction").
 			WithCode(errors.CodeInternal).
 			WithHTTPStatus(500)
@@ -51,8 +162,23 @@ func (u *UnitOfWork) Commit() *errors.Error {
 	if u.failed {
 		return u.doRollback()
 	}
+	if err := ctx.Err(); err != nil {
+		u.doRollback()
+		return errors.Wrap(err, "failed to commit transaction").
+			WithCode(errors.CodeInternal).
+			WithHTTPStatus(500)
+	}
+	if u.txCtx != nil && u.txCtx.Err() != nil {
+		err := u.txCtx.Err()
+		u.doRollback()
+		return errors.Wrap(err, "failed to commit transaction: transaction timeout exceeded").
+			WithCode(errors.CodeInternal).
+			WithHTTPStatus(500)
+	}
+	resetErr := u.resetReadOnly()
 	err := u.tx.Commit()
-	u.tx = nil
+	u.endTx()
+	u.releaseConn(resetErr)
 	if err != nil {
 		return errors.Wrap(err, "failed to commit transaction").
 			WithCode(errors.CodeInternal).
@@ -71,8 +197,10 @@ func (u *UnitOfWork) Rollback() *errors.Error {
 }
 
 func (u *UnitOfWork) doRollback() *errors.Error {
+	resetErr := u.resetReadOnly()
 	err := u.tx.Rollback()
-	u.tx = nil
+	u.endTx()
+	u.releaseConn(resetErr)
 	u.failed = false
 	if err != nil {
 		return errors.Wrap(err, "failed to rollback transaction").
@@ -82,9 +210,75 @@ func (u *UnitOfWork) doRollback() *errors.Error {
 	return nil
 }
 
+// track reports the new transaction to the request's TxTracker in ctx, if any.
+func (u *UnitOfWork) track(ctx context.Context) {
+	if tracker, ok := apphandlers.TxTrackerFromContext(ctx); ok {
+		tracker.Opened()
+		u.tracker = tracker
+	}
+}
+
+// endTx forgets the finished transaction, stops its timeout and reports it
+// closed.
+func (u *UnitOfWork) endTx() {
+	u.tx = nil
+	if u.txCancel != nil {
+		u.txCancel()
+	}
+	u.txCtx, u.txCancel = nil, nil
+	if u.tracker != nil {
+		u.tracker.Closed()
+		u.tracker = nil
+	}
+}
+
+// resetReadOnly clears query_only before a read-only transaction ends, while
+// the transaction still pins the connection the pragma was set on.
+func (u *UnitOfWork) resetReadOnly() error {
+	if !u.readOnly {
+		return nil
+	}
+	u.readOnly = false
+	_, err := u.tx.Exec("PRAGMA query_only = OFF")
+	return err
+}
+
+// releaseConn returns a read-only transaction's connection to the pool once
+// the transaction has ended. If resetErr is set query_only may still be on,
+// so the connection is discarded instead of being handed to a writer.
+func (u *UnitOfWork) releaseConn(resetErr error) {
+	if u.conn == nil {
+		return
+	}
+	if resetErr != nil {
+		discardConn(u.conn, resetErr)
+	} else {
+		u.conn.Close()
+	}
+	u.conn = nil
+}
+
+// discardConn closes conn's underlying driver connection rather than
+// returning it to the pool.
+func discardConn(conn *sql.Conn, cause error) {
+	slog.Error("discarding database connection left read-only", "error", cause)
+	conn.Raw(func(any) error { return driver.ErrBadConn })
+	conn.Close()
+}
+
 func (u *UnitOfWork) Querier() Querier {
 	if u.tx != nil {
 		return u.tx
 	}
 	return u.db
 }
+
+// reader returns the Querier for a read. An open transaction always wins so
+// reads see its own writes; otherwise the replica is used when useReplica is
+// set and one is configured, and the primary when not.
+func (u *UnitOfWork) reader(useReplica bool) Querier {
+	if u.tx == nil && useReplica && u.replica != nil {
+		return u.replica
+	}
+	return u.Querier()
+}
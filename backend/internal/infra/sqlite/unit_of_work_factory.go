@@ -6,12 +6,26 @@ import (
 	apphandlers "backend/internal/application/handlers"
 )
 
-type unitOfWorkFactory struct{ db *sql.DB }
+type unitOfWorkFactory struct {
+	db      *sql.DB
+	replica *sql.DB
+	opts    []UnitOfWorkOption
+}
+
+func NewUnitOfWorkFactory(db *sql.DB, opts ...UnitOfWorkOption) apphandlers.UnitOfWorkFactory {
+	return &unitOfWorkFactory{db: db, opts: opts}
+}
 
-func NewUnitOfWorkFactory(db *sql.DB) apphandlers.UnitOfWorkFactory {
-	return &unitOfWorkFactory{db: db}
+// NewUnitOfWorkFactoryWithReplica creates units of work that carry the
+// replica pool alongside the primary. A nil replica behaves like
+// NewUnitOfWorkFactory.
+func NewUnitOfWorkFactoryWithReplica(db, replica *sql.DB, opts ...UnitOfWorkOption) apphandlers.UnitOfWorkFactory {
+	return &unitOfWorkFactory{db: db, replica: replica, opts: opts}
 }
 
 func (f *unitOfWorkFactory) Create() apphandlers.UnitOfWork {
-	return NewUnitOfWork(f.db)
+	if f.replica != nil {
+		return NewUnitOfWorkWithReplica(f.db, f.replica, f.opts...)
+	}
+	return NewUnitOfWork(f.db, f.opts...)
 }
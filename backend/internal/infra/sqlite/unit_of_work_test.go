@@ -0,0 +1,245 @@
+package sqlite
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	apphandlers "backend/internal/application/handlers"
+)
+
+func newTestDB(t *testing.T) *UnitOfWork {
+	t.Helper()
+	db, err := NewDB(Config{FilePath: filepath.Join(t.TempDir(), "uow.db")})
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return NewUnitOfWork(db)
+}
+
+// assertConnectionFree fails unless the pool's only connection can be taken
+// promptly, i.e. nothing leaked it.
+func assertConnectionFree(t *testing.T, uow *UnitOfWork) {
+	t.Helper()
+	if inUse := uow.db.Stats().InUse; inUse != 0 {
+		t.Errorf("expected no connection in use, got %d", inUse)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := uow.Begin(ctx); err != nil {
+		t.Fatalf("expected a fresh transaction to begin, got %v", err)
+	}
+	if err := uow.Commit(ctx); err != nil {
+		t.Fatalf("expected the fresh transaction to commit, got %v", err)
+	}
+}
+
+func TestUnitOfWork_BeginWithCancelledContext(t *testing.T) {
+	uow := newTestDB(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := uow.Begin(ctx); err == nil {
+		t.Fatal("expected Begin to fail with a cancelled context")
+	}
+	if err := uow.BeginReadOnly(ctx); err == nil {
+		t.Fatal("expected BeginReadOnly to fail with a cancelled context")
+	}
+	if err := uow.Rollback(); err != nil {
+		t.Errorf("expected Rollback after a failed Begin to be a no-op, got %v", err)
+	}
+
+	assertConnectionFree(t, uow)
+}
+
+func TestUnitOfWork_CommitAfterCancelRollsBack(t *testing.T) {
+	uow := newTestDB(t)
+	if _, err := uow.db.Exec("CREATE TABLE items (name TEXT)"); err != nil {
+		t.Fatalf("failed to create table: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := uow.Begin(ctx); err != nil {
+		t.Fatalf("failed to begin: %v", err)
+	}
+	if _, err := uow.Querier().ExecContext(ctx, "INSERT INTO items (name) VALUES ('lost')"); err != nil {
+		t.Fatalf("failed to insert: %v", err)
+	}
+	cancel()
+
+	if err := uow.Commit(ctx); err == nil {
+		t.Fatal("expected Commit to fail once the context is cancelled")
+	}
+	uow.Rollback()
+
+	var count int
+	if err := uow.db.QueryRow("SELECT COUNT(*) FROM items").Scan(&count); err != nil {
+		t.Fatalf("failed to count: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("expected the insert to be rolled back, found %d rows", count)
+	}
+
+	assertConnectionFree(t, uow)
+}
+
+func TestUnitOfWork_ReadOnlyCancelResetsQueryOnly(t *testing.T) {
+	uow := newTestDB(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := uow.BeginReadOnly(ctx); err != nil {
+		t.Fatalf("failed to begin read-only transaction: %v", err)
+	}
+	cancel()
+
+	if err := uow.Commit(ctx); err == nil {
+		t.Fatal("expected Commit to fail once the context is cancelled")
+	}
+
+	// The pool's single connection must be writable again
+	if _, err := uow.db.Exec("CREATE TABLE after_read_only (id INTEGER)"); err != nil {
+		t.Errorf("expected writes to work after a cancelled read-only transaction, got %v", err)
+	}
+
+	assertConnectionFree(t, uow)
+}
+
+func TestUnitOfWork_ReadOnlyResetFailureDiscardsConnection(t *testing.T) {
+	uow := newTestDB(t)
+
+	ctx := context.Background()
+	if err := uow.BeginReadOnly(ctx); err != nil {
+		t.Fatalf("failed to begin read-only transaction: %v", err)
+	}
+	// Ending the transaction underneath the unit of work makes clearing
+	// query_only fail, leaving the pragma set on the connection
+	uow.tx.Rollback()
+
+	if err := uow.Commit(ctx); err == nil {
+		t.Fatal("expected Commit to fail on an ended transaction")
+	}
+
+	if _, err := uow.db.Exec("CREATE TABLE after_failed_reset (id INTEGER)"); err != nil {
+		t.Errorf("expected the read-only connection to be discarded, got %v", err)
+	}
+
+	assertConnectionFree(t, uow)
+}
+
+func TestUnitOfWork_TransactionTimeoutAbortsStuckTransaction(t *testing.T) {
+	uow := newTestDB(t)
+	WithTransactionTimeout(50 * time.Millisecond)(uow)
+	if _, err := uow.db.Exec("CREATE TABLE items (name TEXT)"); err != nil {
+		t.Fatalf("failed to create table: %v", err)
+	}
+
+	ctx := context.Background()
+	if err := uow.Begin(ctx); err != nil {
+		t.Fatalf("failed to begin: %v", err)
+	}
+	if _, err := uow.Querier().ExecContext(ctx, "INSERT INTO items (name) VALUES ('slow')"); err != nil {
+		t.Fatalf("failed to insert: %v", err)
+	}
+
+	// A slow caller holds the transaction past its deadline
+	time.Sleep(150 * time.Millisecond)
+
+	if _, err := uow.Querier().ExecContext(ctx, "INSERT INTO items (name) VALUES ('late')"); err == nil {
+		t.Error("expected a statement after the deadline to fail")
+	}
+	if err := uow.Commit(ctx); err == nil {
+		t.Fatal("expected Commit to fail after the deadline")
+	}
+	uow.Rollback()
+
+	var count int
+	if err := uow.db.QueryRow("SELECT COUNT(*) FROM items").Scan(&count); err != nil {
+		t.Fatalf("failed to count: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("expected the timed-out transaction to be rolled back, found %d rows", count)
+	}
+
+	assertConnectionFree(t, uow)
+}
+
+func TestUnitOfWork_TransactionTimeoutAllowsPromptCommit(t *testing.T) {
+	uow := newTestDB(t)
+	WithTransactionTimeout(time.Second)(uow)
+	if _, err := uow.db.Exec("CREATE TABLE items (name TEXT)"); err != nil {
+		t.Fatalf("failed to create table: %v", err)
+	}
+
+	ctx := context.Background()
+	if err := uow.Begin(ctx); err != nil {
+		t.Fatalf("failed to begin: %v", err)
+	}
+	if _, err := uow.Querier().ExecContext(ctx, "INSERT INTO items (name) VALUES ('quick')"); err != nil {
+		t.Fatalf("failed to insert: %v", err)
+	}
+	if err := uow.Commit(ctx); err != nil {
+		t.Fatalf("expected a transaction within its deadline to commit, got %v", err)
+	}
+
+	assertConnectionFree(t, uow)
+}
+
+func TestUnitOfWork_CommitWithoutBeginIsReported(t *testing.T) {
+	var logs bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&logs, nil)))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	uow := newTestDB(t)
+	before, _ := apphandlers.TxAnomalies()
+
+	if err := uow.Commit(context.Background()); err == nil {
+		t.Fatal("expected Commit without Begin to fail")
+	}
+	if !strings.Contains(logs.String(), "committed without an open transaction") {
+		t.Errorf("expected a warning, got:\n%s", logs.String())
+	}
+	if after, _ := apphandlers.TxAnomalies(); after != before+1 {
+		t.Errorf("expected the commit-without-begin counter to grow by 1, got %d -> %d", before, after)
+	}
+}
+
+func TestUnitOfWork_ReportsTransactionsToTracker(t *testing.T) {
+	uow := newTestDB(t)
+	tracker := &apphandlers.TxTracker{}
+	ctx := apphandlers.WithTxTracker(context.Background(), tracker)
+
+	if err := uow.Begin(ctx); err != nil {
+		t.Fatalf("failed to begin: %v", err)
+	}
+	if err := uow.Begin(ctx); err != nil {
+		t.Fatalf("failed to begin nested: %v", err)
+	}
+	if err := uow.Commit(ctx); err != nil {
+		t.Fatalf("failed to commit nested: %v", err)
+	}
+	if open := tracker.Open(); open != 1 {
+		t.Errorf("expected one open transaction while the outer Begin is pending, got %d", open)
+	}
+
+	if err := uow.Commit(ctx); err != nil {
+		t.Fatalf("failed to commit: %v", err)
+	}
+	if open := tracker.Open(); open != 0 {
+		t.Errorf("expected no open transaction after the outer Commit, got %d", open)
+	}
+
+	if err := uow.BeginReadOnly(ctx); err != nil {
+		t.Fatalf("failed to begin read-only: %v", err)
+	}
+	uow.Rollback()
+	if open := tracker.Open(); open != 0 {
+		t.Errorf("expected no open transaction after Rollback, got %d", open)
+	}
+}
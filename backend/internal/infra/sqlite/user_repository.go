@@ -3,7 +3,7 @@ package sqlite
 import (
 	"context"
 	"database/sql"
-	"fmt"
+	"strings"
 	"time"
 
 	"backend/internal/domain"
@@ -16,12 +16,22 @@ import (
 	"github.com/google/uuid"
 )
 
+// userCols is the column list expected by scanUser and scanUserFromRows.
+var userCols = []string{"id", "oauth_provider", "oauth_id", "password", "name", "email", "role", "workspace_id", "created_at", "updated_at", "last_active_at", "token_epoch"}
+
+// userFilterColumns lists the columns ListOptions.Filters may reference for users.
+var (
+	userFilterColumns = []string{"id", "email", "role", "workspace_id"}
+	userSortColumns   = []string{"name", "email", "role", "created_at", "updated_at", "last_active_at"}
+)
+
 type userRepository struct {
 	uow *UnitOfWork
+	ids domain.IDGenerator
 }
 
-func newUserRepository(uow *UnitOfWork) repository.UserRepository {
-	return &userRepository{uow: uow}
+func newUserRepository(uow *UnitOfWork, ids domain.IDGenerator) repository.UserRepository {
+	return &userRepository{uow: uow, ids: ids}
 }
 
 func (r *userRepository) Create(ctx context.Context, user domain.UserAggregate) *pkgerrors.Error {
@@ -38,7 +48,7 @@ func (r *userRepository) Create(ctx context.Context, user domain.UserAggregate)
 	}
 
 	if user.BaseUser.ID == uuid.Nil {
-		user.BaseUser.ID = uuid.New()
+		user.BaseUser.ID = r.ids.NewID()
 	}
 
 	query, args, err := builder.
@@ -48,12 +58,16 @@ func (r *userRepository) Create(ctx context.Context, user domain.UserAggregate)
 		Suffix("RETURNING created_at, updated_at").
 		ToSql()
 	if err != nil {
-		return infraerrors.WrapSQLiteError(err, "create_user")
+		return infraerrors.WrapQueryBuildError(err, "create_user")
 	}
 
 	var cat, uat TimestampDest
 	err = r.uow.Querier().QueryRowContext(ctx, query, args...).Scan(&cat, &uat)
 	if err != nil {
+		// The unique_oauth_user constraint catches an identity linked concurrently
+		if user.ThirdPartyUser != nil && strings.Contains(err.Error(), "users.oauth_id") {
+			return domainerrors.Conflict("User", "oauth_id", user.ThirdPartyUser.OauthID)
+		}
 		return infraerrors.WrapSQLiteError(err, "create_user")
 	}
 
@@ -65,12 +79,12 @@ func (r *userRepository) Create(ctx context.Context, user domain.UserAggregate)
 
 func (r *userRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.UserAggregate, *pkgerrors.Error) {
 	query, args, err := builder.
-		Select("id", "oauth_provider", "oauth_id", "password", "name", "email", "role", "workspace_id", "created_at", "updated_at").
+		Select(userCols...).
 		From("users").
 		Where(sq.Eq{"id": id}).
 		ToSql()
 	if err != nil {
-		return nil, infraerrors.WrapSQLiteError(err, "get_user")
+		return nil, infraerrors.WrapQueryBuildError(err, "get_user")
 	}
 
 	user, err := r.scanUser(r.uow.Querier().QueryRowContext(ctx, query, args...))
@@ -84,9 +98,72 @@ func (r *userRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Use
 	return user, nil
 }
 
+func (r *userRepository) GetByIDWithWorkspace(ctx context.Context, id uuid.UUID) (*domain.UserWithWorkspace, *pkgerrors.Error) {
+	cols := make([]string, 0, len(userCols)+2)
+	for _, col := range userCols {
+		cols = append(cols, "u."+col)
+	}
+	cols = append(cols, "w.name", "w.deleted_at")
+
+	query, args, err := builder.
+		Select(cols...).
+		From("users u").
+		Join("workspaces w ON w.id = u.workspace_id").
+		Where(sq.Eq{"u.id": id}).
+		ToSql()
+	if err != nil {
+		return nil, infraerrors.WrapQueryBuildError(err, "get_user_with_workspace")
+	}
+
+	var (
+		userID                           uuid.UUID
+		oauthProvider, oauthID, password sql.NullString
+		name, email                      string
+		role                             string
+		workspaceID                      uuid.UUID
+		cat, uat                         TimestampDest
+		lastActive                       NullableTimestamp
+		tokenEpoch                       int64
+		workspaceName                    string
+		workspaceDeleted                 NullableTimestamp
+	)
+	err = r.uow.Querier().QueryRowContext(ctx, query, args...).Scan(
+		&userID,
+		&oauthProvider,
+		&oauthID,
+		&password,
+		&name,
+		&email,
+		&role,
+		&workspaceID,
+		&cat,
+		&uat,
+		&lastActive,
+		&tokenEpoch,
+		&workspaceName,
+		&workspaceDeleted,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainerrors.NotFound("User", id.String())
+		}
+		return nil, infraerrors.WrapSQLiteError(err, "get_user_with_workspace")
+	}
+
+	result := &domain.UserWithWorkspace{
+		User:          *buildUserAggregate(userID, oauthProvider, oauthID, password, name, email, role, workspaceID, cat.Time(), uat.Time(), lastActive, tokenEpoch),
+		WorkspaceName: workspaceName,
+	}
+	if workspaceDeleted.valid {
+		t := workspaceDeleted.t
+		result.WorkspaceDeletedAt = &t
+	}
+	return result, nil
+}
+
 func (r *userRepository) GetByOAuthID(ctx context.Context, provider domain.OauthProvider, oauthID string) (*domain.UserAggregate, *pkgerrors.Error) {
 	query, args, err := builder.
-		Select("id", "oauth_provider", "oauth_id", "password", "name", "email", "role", "workspace_id", "created_at", "updated_at").
+		Select(userCols...).
 		From("users").
 		Where(sq.Eq{
 			"oauth_provider": provider,
@@ -94,7 +171,7 @@ func (r *userRepository) GetByOAuthID(ctx context.Context, provider domain.Oauth
 		}).
 		ToSql()
 	if err != nil {
-		return nil, infraerrors.WrapSQLiteError(err, "get_user_by_oauth")
+		return nil, infraerrors.WrapQueryBuildError(err, "get_user_by_oauth")
 	}
 
 	user, err := r.scanUser(r.uow.Querier().QueryRowContext(ctx, query, args...))
@@ -110,12 +187,12 @@ func (r *userRepository) GetByOAuthID(ctx context.Context, provider domain.Oauth
 
 func (r *userRepository) GetByEmail(ctx context.Context, email string) (*domain.UserAggregate, *pkgerrors.Error) {
 	query, args, err := builder.
-		Select("id", "oauth_provider", "oauth_id", "password", "name", "email", "role", "workspace_id", "created_at", "updated_at").
+		Select(userCols...).
 		From("users").
 		Where(sq.Eq{"email": email}).
 		ToSql()
 	if err != nil {
-		return nil, infraerrors.WrapSQLiteError(err, "get_user_by_email")
+		return nil, infraerrors.WrapQueryBuildError(err, "get_user_by_email")
 	}
 
 	user, err := r.scanUser(r.uow.Querier().QueryRowContext(ctx, query, args...))
@@ -131,13 +208,13 @@ func (r *userRepository) GetByEmail(ctx context.Context, email string) (*domain.
 
 func (r *userRepository) GetByWorkspaceID(ctx context.Context, workspaceID uuid.UUID) ([]*domain.UserAggregate, *pkgerrors.Error) {
 	query, args, err := builder.
-		Select("id", "oauth_provider", "oauth_id", "password", "name", "email", "role", "workspace_id", "created_at", "updated_at").
+		Select(userCols...).
 		From("users").
 		Where(sq.Eq{"workspace_id": workspaceID}).
-		OrderBy("created_at DESC").
+		OrderBy("created_at DESC", "id DESC").
 		ToSql()
 	if err != nil {
-		return nil, infraerrors.WrapSQLiteError(err, "get_users_by_workspace")
+		return nil, infraerrors.WrapQueryBuildError(err, "get_users_by_workspace")
 	}
 
 	rows, err := r.uow.Querier().QueryContext(ctx, query, args...)
@@ -146,7 +223,7 @@ func (r *userRepository) GetByWorkspaceID(ctx context.Context, workspaceID uuid.
 	}
 	defer rows.Close()
 
-	var users []*domain.UserAggregate
+	users := []*domain.UserAggregate{}
 	for rows.Next() {
 		user, err := r.scanUserFromRows(rows)
 		if err != nil {
@@ -188,7 +265,7 @@ func (r *userRepository) Update(ctx context.Context, user domain.UserAggregate)
 		Suffix("RETURNING updated_at").
 		ToSql()
 	if err != nil {
-		return infraerrors.WrapSQLiteError(err, "update_user")
+		return infraerrors.WrapQueryBuildError(err, "update_user")
 	}
 
 	var uat TimestampDest
@@ -211,7 +288,7 @@ func (r *userRepository) Delete(ctx context.Context, id uuid.UUID) *pkgerrors.Er
 		Where(sq.Eq{"id": id}).
 		ToSql()
 	if err != nil {
-		return infraerrors.WrapSQLiteError(err, "delete_user")
+		return infraerrors.WrapQueryBuildError(err, "delete_user")
 	}
 
 	result, err := r.uow.Querier().ExecContext(ctx, query, args...)
@@ -236,20 +313,23 @@ func (r *userRepository) List(ctx context.Context, opts repository.ListOptions)
 	if err := opts.Validate(); err != nil {
 		return nil, err
 	}
+	if err := opts.ValidateFilters(userFilterColumns...); err != nil {
+		return nil, err
+	}
 
 	qb := builder.
-		Select("id", "oauth_provider", "oauth_id", "password", "name", "email", "role", "workspace_id", "created_at", "updated_at").
+		Select(userCols...).
 		From("users")
-	for col, val := range opts.FilterBy {
-		qb = qb.Where(sq.Eq{col: val})
+	if len(opts.Filters) > 0 {
+		qb = qb.Where(sq.Eq(opts.Filters))
 	}
-	query, args, err := qb.
-		OrderBy(fmt.Sprintf("%s %s", opts.SortBy, opts.Order)).
-		Limit(uint64(opts.Limit)).
-		Offset(uint64(opts.Offset)).
-		ToSql()
+	qb, pageErr := paginate(qb, opts, userSortColumns)
+	if pageErr != nil {
+		return nil, pageErr
+	}
+	query, args, err := qb.ToSql()
 	if err != nil {
-		return nil, infraerrors.WrapSQLiteError(err, "list_users")
+		return nil, infraerrors.WrapQueryBuildError(err, "list_users")
 	}
 
 	rows, err := r.uow.Querier().QueryContext(ctx, query, args...)
@@ -258,7 +338,55 @@ func (r *userRepository) List(ctx context.Context, opts repository.ListOptions)
 	}
 	defer rows.Close()
 
-	var users []*domain.UserAggregate
+	users := []*domain.UserAggregate{}
+	for rows.Next() {
+		user, err := r.scanUserFromRows(rows)
+		if err != nil {
+			return nil, infraerrors.WrapSQLiteError(err, "scan_user")
+		}
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, infraerrors.WrapSQLiteError(err, "iterate_users")
+	}
+
+	return users, nil
+}
+
+// inactiveSince matches the workspace's users not active since since,
+// including those never active.
+func inactiveSince(workspaceID uuid.UUID, since time.Time) sq.And {
+	return sq.And{
+		sq.Eq{"workspace_id": workspaceID},
+		sq.Or{
+			sq.Eq{"last_active_at": nil},
+			sq.Lt{"last_active_at": since.UTC().Format(time.DateTime)},
+		},
+	}
+}
+
+func (r *userRepository) ListInactiveSince(ctx context.Context, workspaceID uuid.UUID, since time.Time, limit, offset int) ([]*domain.UserAggregate, *pkgerrors.Error) {
+	query, args, err := builder.
+		Select(userCols...).
+		From("users").
+		Where(inactiveSince(workspaceID, since)).
+		// SQLite sorts NULL first in ascending order
+		OrderBy("last_active_at ASC", "id ASC").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).
+		ToSql()
+	if err != nil {
+		return nil, infraerrors.WrapQueryBuildError(err, "list_inactive_users")
+	}
+
+	rows, err := r.uow.Querier().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, infraerrors.WrapSQLiteError(err, "list_inactive_users")
+	}
+	defer rows.Close()
+
+	users := []*domain.UserAggregate{}
 	for rows.Next() {
 		user, err := r.scanUserFromRows(rows)
 		if err != nil {
@@ -274,13 +402,30 @@ func (r *userRepository) List(ctx context.Context, opts repository.ListOptions)
 	return users, nil
 }
 
+func (r *userRepository) CountInactiveSince(ctx context.Context, workspaceID uuid.UUID, since time.Time) (int, *pkgerrors.Error) {
+	query, args, err := builder.
+		Select("COUNT(*)").
+		From("users").
+		Where(inactiveSince(workspaceID, since)).
+		ToSql()
+	if err != nil {
+		return 0, infraerrors.WrapQueryBuildError(err, "count_inactive_users")
+	}
+
+	var count int
+	if err := r.uow.Querier().QueryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return 0, infraerrors.WrapSQLiteError(err, "count_inactive_users")
+	}
+	return count, nil
+}
+
 func (r *userRepository) Count(ctx context.Context) (int, *pkgerrors.Error) {
 	query, args, err := builder.
 		Select("COUNT(*)").
 		From("users").
 		ToSql()
 	if err != nil {
-		return 0, infraerrors.WrapSQLiteError(err, "count_users")
+		return 0, infraerrors.WrapQueryBuildError(err, "count_users")
 	}
 
 	var count int
@@ -292,6 +437,97 @@ func (r *userRepository) Count(ctx context.Context) (int, *pkgerrors.Error) {
 	return count, nil
 }
 
+func (r *userRepository) ExistsByEmail(ctx context.Context, email string) (bool, *pkgerrors.Error) {
+	query, args, err := builder.
+		Select("1").
+		From("users").
+		Where(sq.Eq{"email": email}).
+		Prefix("SELECT EXISTS(").
+		Suffix(")").
+		ToSql()
+	if err != nil {
+		return false, infraerrors.WrapQueryBuildError(err, "user_exists_by_email")
+	}
+
+	var exists bool
+	if err := r.uow.Querier().QueryRowContext(ctx, query, args...).Scan(&exists); err != nil {
+		return false, infraerrors.WrapSQLiteError(err, "user_exists_by_email")
+	}
+	return exists, nil
+}
+
+func (r *userRepository) TouchLastActive(ctx context.Context, id uuid.UUID, at, notBefore time.Time) (bool, *pkgerrors.Error) {
+	query, args, err := builder.
+		Update("users").
+		Set("last_active_at", at.UTC().Format(time.DateTime)).
+		Where(sq.Eq{"id": id}).
+		Where(sq.Or{
+			sq.Eq{"last_active_at": nil},
+			sq.Lt{"last_active_at": notBefore.UTC().Format(time.DateTime)},
+		}).
+		ToSql()
+	if err != nil {
+		return false, infraerrors.WrapQueryBuildError(err, "touch_user_last_active")
+	}
+
+	result, err := r.uow.Querier().ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, infraerrors.WrapSQLiteError(err, "touch_user_last_active")
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, infraerrors.WrapSQLiteError(err, "get_rows_affected")
+	}
+
+	return rows > 0, nil
+}
+
+func (r *userRepository) GetTokenEpoch(ctx context.Context, id uuid.UUID) (int64, *pkgerrors.Error) {
+	query, args, err := builder.
+		Select("token_epoch").
+		From("users").
+		Where(sq.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return 0, infraerrors.WrapQueryBuildError(err, "get_token_epoch")
+	}
+
+	var epoch int64
+	if err := r.uow.Querier().QueryRowContext(ctx, query, args...).Scan(&epoch); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, domainerrors.NotFound("User", id.String())
+		}
+		return 0, infraerrors.WrapSQLiteError(err, "get_token_epoch")
+	}
+	return epoch, nil
+}
+
+func (r *userRepository) BumpTokenEpoch(ctx context.Context, id uuid.UUID) *pkgerrors.Error {
+	query, args, err := builder.
+		Update("users").
+		Set("token_epoch", sq.Expr("token_epoch + 1")).
+		Where(sq.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return infraerrors.WrapQueryBuildError(err, "bump_token_epoch")
+	}
+
+	result, err := r.uow.Querier().ExecContext(ctx, query, args...)
+	if err != nil {
+		return infraerrors.WrapSQLiteError(err, "bump_token_epoch")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return infraerrors.WrapSQLiteError(err, "get_rows_affected")
+	}
+	if rowsAffected == 0 {
+		return domainerrors.NotFound("User", id.String())
+	}
+	return nil
+}
+
 func (r *userRepository) scanUser(row *sql.Row) (*domain.UserAggregate, error) {
 	var (
 		id                               uuid.UUID
@@ -300,6 +536,8 @@ func (r *userRepository) scanUser(row *sql.Row) (*domain.UserAggregate, error) {
 		role                             string
 		workspaceID                      uuid.UUID
 		cat, uat                         TimestampDest
+		lastActive                       NullableTimestamp
+		tokenEpoch                       int64
 	)
 
 	err := row.Scan(
@@ -313,12 +551,14 @@ func (r *userRepository) scanUser(row *sql.Row) (*domain.UserAggregate, error) {
 		&workspaceID,
 		&cat,
 		&uat,
+		&lastActive,
+		&tokenEpoch,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	return buildUserAggregate(id, oauthProvider, oauthID, password, name, email, role, workspaceID, cat.Time(), uat.Time()), nil
+	return buildUserAggregate(id, oauthProvider, oauthID, password, name, email, role, workspaceID, cat.Time(), uat.Time(), lastActive, tokenEpoch), nil
 }
 
 func (r *userRepository) scanUserFromRows(rows *sql.Rows) (*domain.UserAggregate, error) {
@@ -329,6 +569,8 @@ func (r *userRepository) scanUserFromRows(rows *sql.Rows) (*domain.UserAggregate
 		role                             string
 		workspaceID                      uuid.UUID
 		cat, uat                         TimestampDest
+		lastActive                       NullableTimestamp
+		tokenEpoch                       int64
 	)
 
 	err := rows.Scan(
@@ -342,12 +584,14 @@ func (r *userRepository) scanUserFromRows(rows *sql.Rows) (*domain.UserAggregate
 		&workspaceID,
 		&cat,
 		&uat,
+		&lastActive,
+		&tokenEpoch,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	return buildUserAggregate(id, oauthProvider, oauthID, password, name, email, role, workspaceID, cat.Time(), uat.Time()), nil
+	return buildUserAggregate(id, oauthProvider, oauthID, password, name, email, role, workspaceID, cat.Time(), uat.Time(), lastActive, tokenEpoch), nil
 }
 
 func buildUserAggregate(
@@ -357,6 +601,8 @@ func buildUserAggregate(
 	role string,
 	workspaceID uuid.UUID,
 	createdAt, updatedAt time.Time,
+	lastActiveAt NullableTimestamp,
+	tokenEpoch int64,
 ) *domain.UserAggregate {
 	user := &domain.UserAggregate{
 		BaseUser: domain.BaseUser{
@@ -367,9 +613,15 @@ func buildUserAggregate(
 			WorkspaceID: workspaceID,
 			CreatedAt:   createdAt,
 			UpdatedAt:   updatedAt,
+			TokenEpoch:  tokenEpoch,
 		},
 	}
 
+	if lastActiveAt.valid {
+		t := lastActiveAt.t
+		user.BaseUser.LastActiveAt = &t
+	}
+
 	if oauthProvider.Valid && oauthID.Valid {
 		user.ThirdPartyUser = &domain.ThirdPartyUser{
 			OauthProvider: domain.OauthProvider(oauthProvider.String),
@@ -0,0 +1,170 @@
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"backend/internal/domain"
+	"backend/pkg/errors"
+	"backend/pkg/validation"
+
+	"github.com/google/uuid"
+)
+
+// newMigratedUnitOfWork returns a unit of work over a freshly migrated database.
+func newMigratedUnitOfWork(t *testing.T) *UnitOfWork {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "devshare.db")
+	if err := MigrateUp(context.Background(), path, testMigrationsPath); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	db, err := NewDB(Config{FilePath: path})
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return NewUnitOfWork(db)
+}
+
+// seedUserInWorkspace creates a workspace and a local user in it.
+func seedUserInWorkspace(t *testing.T, uow *UnitOfWork, workspaceName string) (*domain.Workspace, domain.UserAggregate) {
+	t.Helper()
+	ctx := context.Background()
+	factory := NewRepositoryFactory()
+
+	workspace, wsErr := domain.NewWorkspace(domain.RandomIDGenerator{}, validation.DefaultContentPolicy(), workspaceName, "", nil)
+	if wsErr != nil {
+		t.Fatalf("failed to build workspace: %v", wsErr)
+	}
+	if err := factory.CreateWorkspaceRepository(uow).Create(ctx, workspace); err != nil {
+		t.Fatalf("failed to create workspace: %v", err)
+	}
+
+	user := domain.UserAggregate{
+		BaseUser:  domain.NewBaseUser(domain.RandomIDGenerator{}, "Joined User", "joined@example.com", domain.RoleEditor, workspace.ID),
+		LocalUser: &domain.LocalUser{Password: "not-a-real-hash"},
+	}
+	user.BaseUser.ID = uuid.New()
+	if err := factory.CreateUserRepository(uow).Create(ctx, user); err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	return workspace, user
+}
+
+func TestUserRepository_GetByIDWithWorkspace(t *testing.T) {
+	ctx := context.Background()
+	uow := newMigratedUnitOfWork(t)
+	workspace, user := seedUserInWorkspace(t, uow, "Joined Workspace")
+
+	got, err := NewRepositoryFactory().CreateUserRepository(uow).GetByIDWithWorkspace(ctx, user.BaseUser.ID)
+	if err != nil {
+		t.Fatalf("GetByIDWithWorkspace failed: %v", err)
+	}
+	if got.User.BaseUser.ID != user.BaseUser.ID || got.User.BaseUser.Email != user.BaseUser.Email {
+		t.Errorf("expected user %s (%s), got %s (%s)", user.BaseUser.ID, user.BaseUser.Email, got.User.BaseUser.ID, got.User.BaseUser.Email)
+	}
+	if got.User.BaseUser.WorkspaceID != workspace.ID {
+		t.Errorf("expected workspace_id %s, got %s", workspace.ID, got.User.BaseUser.WorkspaceID)
+	}
+	if got.User.LocalUser == nil || got.User.LocalUser.Password != "not-a-real-hash" {
+		t.Error("expected the local user's password hash to be loaded")
+	}
+	if got.WorkspaceName != "Joined Workspace" {
+		t.Errorf("expected workspace name %q, got %q", "Joined Workspace", got.WorkspaceName)
+	}
+	if got.WorkspaceDeletedAt != nil {
+		t.Errorf("expected a live workspace, got deleted at %v", got.WorkspaceDeletedAt)
+	}
+}
+
+func TestUserRepository_GetByIDWithWorkspace_SoftDeletedWorkspace(t *testing.T) {
+	ctx := context.Background()
+	uow := newMigratedUnitOfWork(t)
+	workspace, user := seedUserInWorkspace(t, uow, "Deleted Workspace")
+
+	if err := NewRepositoryFactory().CreateWorkspaceRepository(uow).Delete(ctx, workspace.ID, nil); err != nil {
+		t.Fatalf("failed to soft-delete workspace: %v", err)
+	}
+
+	got, err := NewRepositoryFactory().CreateUserRepository(uow).GetByIDWithWorkspace(ctx, user.BaseUser.ID)
+	if err != nil {
+		t.Fatalf("GetByIDWithWorkspace failed: %v", err)
+	}
+	if got.WorkspaceName != "Deleted Workspace" {
+		t.Errorf("expected workspace name %q, got %q", "Deleted Workspace", got.WorkspaceName)
+	}
+	if got.WorkspaceDeletedAt == nil {
+		t.Error("expected the workspace's deletion time to be set")
+	}
+}
+
+func TestUserRepository_GetByIDWithWorkspace_NotFound(t *testing.T) {
+	uow := newMigratedUnitOfWork(t)
+
+	_, err := NewRepositoryFactory().CreateUserRepository(uow).GetByIDWithWorkspace(context.Background(), uuid.New())
+	if err == nil || err.Code() != errors.CodeNotFound {
+		t.Errorf("expected NOT_FOUND, got %v", err)
+	}
+}
+
+func TestUserRepository_Create_DuplicateOAuthIdentity(t *testing.T) {
+	ctx := context.Background()
+	uow := newMigratedUnitOfWork(t)
+	workspace, _ := seedUserInWorkspace(t, uow, "OAuth Workspace")
+	repo := NewRepositoryFactory().CreateUserRepository(uow)
+
+	linked := func(email string, provider domain.OauthProvider) domain.UserAggregate {
+		user := domain.UserAggregate{
+			BaseUser:       domain.NewBaseUser(domain.RandomIDGenerator{}, "OAuth User", email, domain.RoleUser, workspace.ID),
+			ThirdPartyUser: &domain.ThirdPartyUser{OauthProvider: provider, OauthID: "12345"},
+		}
+		user.BaseUser.ID = uuid.New()
+		return user
+	}
+
+	if err := repo.Create(ctx, linked("first@example.com", domain.OauthProviderGitHub)); err != nil {
+		t.Fatalf("failed to create first user: %v", err)
+	}
+
+	err := repo.Create(ctx, linked("second@example.com", domain.OauthProviderGitHub))
+	if err == nil {
+		t.Fatal("expected linking an already linked identity to fail")
+	}
+	if err.Code() != errors.CodeConflict || err.HTTPStatus() != 409 {
+		t.Errorf("expected a 409 conflict, got %s (%d)", err.Code(), err.HTTPStatus())
+	}
+	if err.GetMetadata()[errors.MetadataField] != "oauth_id" {
+		t.Errorf("expected the conflict to name oauth_id, got %v", err.GetMetadata())
+	}
+
+	// The same id at another provider is a different identity
+	if err := repo.Create(ctx, linked("third@example.com", domain.OauthProviderGoogle)); err != nil {
+		t.Errorf("expected the same id at another provider to be accepted, got %v", err)
+	}
+}
+
+func TestUserRepository_CreateAssignsIDFromInjectedGenerator(t *testing.T) {
+	ctx := context.Background()
+	uow := newMigratedUnitOfWork(t)
+	workspace, _ := seedUserInWorkspace(t, uow, "Generated IDs")
+
+	want := uuid.MustParse("11111111-2222-4333-8444-555555555555")
+	repo := NewRepositoryFactory(WithIDGenerator(domain.IDGeneratorFunc(func() uuid.UUID { return want }))).CreateUserRepository(uow)
+
+	user := domain.UserAggregate{
+		BaseUser:  domain.BaseUser{Name: "Unsaved", Email: "unsaved@example.com", Role: domain.RoleUser, WorkspaceID: workspace.ID},
+		LocalUser: &domain.LocalUser{Password: "not-a-real-hash"},
+	}
+	if err := repo.Create(ctx, user); err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+
+	got, err := repo.GetByEmail(ctx, "unsaved@example.com")
+	if err != nil {
+		t.Fatalf("failed to read user back: %v", err)
+	}
+	if got.BaseUser.ID != want {
+		t.Errorf("expected the injected ID %s, got %s", want, got.BaseUser.ID)
+	}
+}
@@ -3,7 +3,7 @@ package sqlite
 import (
 	"context"
 	"database/sql"
-	"fmt"
+	"time"
 
 	"backend/internal/domain"
 	domainerrors "backend/internal/domain/errors"
@@ -15,12 +15,27 @@ import (
 	"github.com/google/uuid"
 )
 
+// workspaceFilterColumns lists the columns ListOptions.Filters may reference for workspaces.
+var (
+	workspaceFilterColumns      = []string{"id", "name", "admin_id"}
+	workspaceSortColumns        = []string{"name", "created_at", "updated_at"}
+	deletedWorkspaceSortColumns = []string{"name", "created_at", "deleted_at"}
+)
+
 type workspaceRepository struct {
-	uow *UnitOfWork
+	uow          *UnitOfWork
+	replicaReads bool
+}
+
+func newWorkspaceRepository(uow *UnitOfWork, replicaReads bool) repository.WorkspaceRepository {
+	return &workspaceRepository{uow: uow, replicaReads: replicaReads}
 }
 
-func newWorkspaceRepository(uow *UnitOfWork) repository.WorkspaceRepository {
-	return &workspaceRepository{uow: uow}
+// reader is the Querier for read-only lookups and listings. Exists and the
+// secret hash always read the primary: they gate writes and authentication,
+// where replica lag would be visible.
+func (r *workspaceRepository) reader() Querier {
+	return r.uow.reader(r.replicaReads)
 }
 
 func (r *workspaceRepository) Create(ctx context.Context, workspace *domain.Workspace) *pkgerrors.Error {
@@ -31,7 +46,7 @@ func (r *workspaceRepository) Create(ctx context.Context, workspace *domain.Work
 		Suffix("RETURNING created_at, updated_at").
 		ToSql()
 	if err != nil {
-		return infraerrors.WrapSQLiteError(err, "create_workspace")
+		return infraerrors.WrapQueryBuildError(err, "create_workspace")
 	}
 
 	var cat, uat TimestampDest
@@ -48,24 +63,25 @@ func (r *workspaceRepository) Create(ctx context.Context, workspace *domain.Work
 
 func (r *workspaceRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Workspace, *pkgerrors.Error) {
 	query, args, err := builder.
-		Select("id", "name", "description", "admin_id", "created_at", "updated_at").
+		Select("id", "name", "description", "admin_id", "created_at", "updated_at", "version").
 		From("workspaces").
 		Where(sq.Eq{"id": id}).
 		Where("deleted_at IS NULL").
 		ToSql()
 	if err != nil {
-		return nil, infraerrors.WrapSQLiteError(err, "get_workspace")
+		return nil, infraerrors.WrapQueryBuildError(err, "get_workspace")
 	}
 
 	var workspace domain.Workspace
 	var cat, uat TimestampDest
-	err = r.uow.Querier().QueryRowContext(ctx, query, args...).Scan(
+	err = r.reader().QueryRowContext(ctx, query, args...).Scan(
 		&workspace.ID,
 		&workspace.Name,
 		&workspace.Description,
 		&workspace.AdminID,
 		&cat,
 		&uat,
+		&workspace.Version,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -80,25 +96,95 @@ func (r *workspaceRepository) GetByID(ctx context.Context, id uuid.UUID) (*domai
 	return &workspace, nil
 }
 
+func (r *workspaceRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.Workspace, *pkgerrors.Error) {
+	workspaces := []*domain.Workspace{}
+	if len(ids) == 0 {
+		return workspaces, nil
+	}
+
+	query, args, err := builder.
+		Select("id", "name", "description", "admin_id", "created_at", "updated_at", "version").
+		From("workspaces").
+		Where(sq.Eq{"id": ids}).
+		Where("deleted_at IS NULL").
+		OrderBy("created_at DESC", "id DESC").
+		ToSql()
+	if err != nil {
+		return nil, infraerrors.WrapQueryBuildError(err, "get_workspaces_by_ids")
+	}
+
+	rows, err := r.reader().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, infraerrors.WrapSQLiteError(err, "get_workspaces_by_ids")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var workspace domain.Workspace
+		var cat, uat TimestampDest
+		err := rows.Scan(
+			&workspace.ID,
+			&workspace.Name,
+			&workspace.Description,
+			&workspace.AdminID,
+			&cat,
+			&uat,
+			&workspace.Version,
+		)
+		if err != nil {
+			return nil, infraerrors.WrapSQLiteError(err, "scan_workspace")
+		}
+		workspace.CreatedAt = cat.Time()
+		workspace.UpdatedAt = uat.Time()
+		workspaces = append(workspaces, &workspace)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, infraerrors.WrapSQLiteError(err, "iterate_workspaces")
+	}
+
+	return workspaces, nil
+}
+
+func (r *workspaceRepository) Exists(ctx context.Context, id uuid.UUID) (bool, *pkgerrors.Error) {
+	query, args, err := builder.
+		Select("1").
+		From("workspaces").
+		Where(sq.Eq{"id": id}).
+		Where("deleted_at IS NULL").
+		Prefix("SELECT EXISTS(").
+		Suffix(")").
+		ToSql()
+	if err != nil {
+		return false, infraerrors.WrapQueryBuildError(err, "workspace_exists")
+	}
+
+	var exists bool
+	if err := r.uow.Querier().QueryRowContext(ctx, query, args...).Scan(&exists); err != nil {
+		return false, infraerrors.WrapSQLiteError(err, "workspace_exists")
+	}
+	return exists, nil
+}
+
 func (r *workspaceRepository) GetByAdminID(ctx context.Context, adminID uuid.UUID) ([]*domain.Workspace, *pkgerrors.Error) {
 	query, args, err := builder.
-		Select("id", "name", "description", "admin_id", "created_at", "updated_at").
+		Select("id", "name", "description", "admin_id", "created_at", "updated_at", "version").
 		From("workspaces").
 		Where(sq.Eq{"admin_id": adminID}).
 		Where("deleted_at IS NULL").
-		OrderBy("created_at DESC").
+		OrderBy("created_at DESC", "id DESC").
 		ToSql()
 	if err != nil {
-		return nil, infraerrors.WrapSQLiteError(err, "get_workspaces_by_admin")
+		return nil, infraerrors.WrapQueryBuildError(err, "get_workspaces_by_admin")
 	}
 
-	rows, err := r.uow.Querier().QueryContext(ctx, query, args...)
+	rows, err := r.reader().QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, infraerrors.WrapSQLiteError(err, "get_workspaces_by_admin")
 	}
 	defer rows.Close()
 
-	var workspaces []*domain.Workspace
+	workspaces := []*domain.Workspace{}
 	for rows.Next() {
 		var workspace domain.Workspace
 		var cat, uat TimestampDest
@@ -109,6 +195,7 @@ func (r *workspaceRepository) GetByAdminID(ctx context.Context, adminID uuid.UUI
 			&workspace.AdminID,
 			&cat,
 			&uat,
+			&workspace.Version,
 		)
 		if err != nil {
 			return nil, infraerrors.WrapSQLiteError(err, "scan_workspace")
@@ -132,18 +219,19 @@ func (r *workspaceRepository) Update(ctx context.Context, workspace *domain.Work
 		Set("description", workspace.Description).
 		Set("admin_id", workspace.AdminID).
 		Set("updated_at", sq.Expr("CURRENT_TIMESTAMP")).
-		Where(sq.Eq{"id": workspace.ID}).
-		Suffix("RETURNING updated_at").
+		Set("version", sq.Expr("version + 1")).
+		Where(sq.Eq{"id": workspace.ID, "version": workspace.Version}).
+		Suffix("RETURNING updated_at, version").
 		ToSql()
 	if err != nil {
-		return infraerrors.WrapSQLiteError(err, "update_workspace")
+		return infraerrors.WrapQueryBuildError(err, "update_workspace")
 	}
 
 	var uat TimestampDest
-	err = r.uow.Querier().QueryRowContext(ctx, query, args...).Scan(&uat)
+	err = r.uow.Querier().QueryRowContext(ctx, query, args...).Scan(&uat, &workspace.Version)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return domainerrors.NotFound("Workspace", workspace.ID.String())
+			return r.versionConflictOrNotFound(ctx, workspace.ID)
 		}
 		return infraerrors.WrapSQLiteError(err, "update_workspace")
 	}
@@ -153,14 +241,38 @@ func (r *workspaceRepository) Update(ctx context.Context, workspace *domain.Work
 	return nil
 }
 
-func (r *workspaceRepository) Delete(ctx context.Context, id uuid.UUID) *pkgerrors.Error {
+// versionConflictOrNotFound explains why a versioned update matched no row:
+// either the workspace is gone or someone else updated it first.
+func (r *workspaceRepository) versionConflictOrNotFound(ctx context.Context, id uuid.UUID) *pkgerrors.Error {
+	query, args, err := builder.
+		Select("version", "updated_at").
+		From("workspaces").
+		Where(sq.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return infraerrors.WrapQueryBuildError(err, "get_workspace_version")
+	}
+
+	var version int
+	var uat TimestampDest
+	if err := r.uow.Querier().QueryRowContext(ctx, query, args...).Scan(&version, &uat); err != nil {
+		if err == sql.ErrNoRows {
+			return domainerrors.NotFound("Workspace", id.String())
+		}
+		return infraerrors.WrapSQLiteError(err, "get_workspace_version")
+	}
+	return domainerrors.VersionConflict("Workspace", id.String(), version, uat.Time())
+}
+
+func (r *workspaceRepository) Delete(ctx context.Context, id uuid.UUID, deletedBy *uuid.UUID) *pkgerrors.Error {
 	query, args, err := builder.
 		Update("workspaces").
 		Set("deleted_at", sq.Expr("CURRENT_TIMESTAMP")).
+		Set("deleted_by", deletedBy).
 		Where(sq.Eq{"id": id}).
 		ToSql()
 	if err != nil {
-		return infraerrors.WrapSQLiteError(err, "delete_workspace")
+		return infraerrors.WrapQueryBuildError(err, "delete_workspace")
 	}
 
 	result, err := r.uow.Querier().ExecContext(ctx, query, args...)
@@ -180,38 +292,36 @@ func (r *workspaceRepository) Delete(ctx context.Context, id uuid.UUID) *pkgerro
 	return nil
 }
 
-func (r *workspaceRepository) List(ctx context.Context, opts repository.ListOptions) ([]*domain.Workspace, *pkgerrors.Error) {
+func (r *workspaceRepository) ListDeleted(ctx context.Context, adminID uuid.UUID, opts repository.ListOptions) ([]*domain.Workspace, *pkgerrors.Error) {
 	opts.ApplyDefaults()
 	if err := opts.Validate(); err != nil {
 		return nil, err
 	}
 
 	qb := builder.
-		Select("id", "name", "description", "admin_id", "created_at", "updated_at").
+		Select("id", "name", "description", "admin_id", "created_at", "updated_at", "version", "deleted_at", "deleted_by").
 		From("workspaces").
-		Where("deleted_at IS NULL")
-	for col, val := range opts.FilterBy {
-		qb = qb.Where(sq.Eq{col: val})
-	}
-	query, args, err := qb.
-		OrderBy(fmt.Sprintf("%s %s", opts.SortBy, opts.Order)).
-		Limit(uint64(opts.Limit)).
-		Offset(uint64(opts.Offset)).
-		ToSql()
+		Where("deleted_at IS NOT NULL").
+		Where(sq.Eq{"admin_id": adminID})
+	qb, pageErr := paginate(qb, opts, deletedWorkspaceSortColumns)
+	if pageErr != nil {
+		return nil, pageErr
+	}
+	query, args, err := qb.ToSql()
 	if err != nil {
-		return nil, infraerrors.WrapSQLiteError(err, "list_workspaces")
+		return nil, infraerrors.WrapQueryBuildError(err, "list_deleted_workspaces")
 	}
 
-	rows, err := r.uow.Querier().QueryContext(ctx, query, args...)
+	rows, err := r.reader().QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, infraerrors.WrapSQLiteError(err, "list_workspaces")
+		return nil, infraerrors.WrapSQLiteError(err, "list_deleted_workspaces")
 	}
 	defer rows.Close()
 
-	var workspaces []*domain.Workspace
+	workspaces := []*domain.Workspace{}
 	for rows.Next() {
 		var workspace domain.Workspace
-		var cat, uat TimestampDest
+		var cat, uat, dat TimestampDest
 		err := rows.Scan(
 			&workspace.ID,
 			&workspace.Name,
@@ -219,12 +329,17 @@ func (r *workspaceRepository) List(ctx context.Context, opts repository.ListOpti
 			&workspace.AdminID,
 			&cat,
 			&uat,
+			&workspace.Version,
+			&dat,
+			&workspace.DeletedBy,
 		)
 		if err != nil {
 			return nil, infraerrors.WrapSQLiteError(err, "scan_workspace")
 		}
 		workspace.CreatedAt = cat.Time()
 		workspace.UpdatedAt = uat.Time()
+		deletedAt := dat.Time()
+		workspace.DeletedAt = &deletedAt
 		workspaces = append(workspaces, &workspace)
 	}
 
@@ -235,15 +350,207 @@ func (r *workspaceRepository) List(ctx context.Context, opts repository.ListOpti
 	return workspaces, nil
 }
 
+func (r *workspaceRepository) CountDeleted(ctx context.Context, adminID uuid.UUID) (int, *pkgerrors.Error) {
+	query, args, err := builder.
+		Select("COUNT(*)").
+		From("workspaces").
+		Where("deleted_at IS NOT NULL").
+		Where(sq.Eq{"admin_id": adminID}).
+		ToSql()
+	if err != nil {
+		return 0, infraerrors.WrapQueryBuildError(err, "count_deleted_workspaces")
+	}
+
+	var count int
+	if err := r.reader().QueryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return 0, infraerrors.WrapSQLiteError(err, "count_deleted_workspaces")
+	}
+	return count, nil
+}
+
+func (r *workspaceRepository) Restore(ctx context.Context, id uuid.UUID, adminID uuid.UUID) *pkgerrors.Error {
+	query, args, err := builder.
+		Update("workspaces").
+		Set("deleted_at", nil).
+		Set("deleted_by", nil).
+		Set("updated_at", sq.Expr("CURRENT_TIMESTAMP")).
+		Where(sq.Eq{"id": id, "admin_id": adminID}).
+		Where("deleted_at IS NOT NULL").
+		ToSql()
+	if err != nil {
+		return infraerrors.WrapQueryBuildError(err, "restore_workspace")
+	}
+
+	result, err := r.uow.Querier().ExecContext(ctx, query, args...)
+	if err != nil {
+		return infraerrors.WrapSQLiteError(err, "restore_workspace")
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return infraerrors.WrapSQLiteError(err, "get_rows_affected")
+	}
+
+	if rows == 0 {
+		return domainerrors.NotFound("Workspace", id.String())
+	}
+
+	return nil
+}
+
+func (r *workspaceRepository) ListDeletedBefore(ctx context.Context, before time.Time, adminID *uuid.UUID) ([]uuid.UUID, *pkgerrors.Error) {
+	qb := builder.
+		Select("id").
+		From("workspaces").
+		Where("deleted_at IS NOT NULL").
+		Where(sq.Lt{"deleted_at": before.UTC().Format(timestampFormat)}).
+		OrderBy("deleted_at ASC", "id ASC")
+	if adminID != nil {
+		qb = qb.Where(sq.Eq{"admin_id": *adminID})
+	}
+	query, args, err := qb.ToSql()
+	if err != nil {
+		return nil, infraerrors.WrapQueryBuildError(err, "list_workspaces_deleted_before")
+	}
+
+	rows, err := r.uow.Querier().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, infraerrors.WrapSQLiteError(err, "list_workspaces_deleted_before")
+	}
+	defer rows.Close()
+
+	ids := []uuid.UUID{}
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, infraerrors.WrapSQLiteError(err, "scan_workspace_id")
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, infraerrors.WrapSQLiteError(err, "iterate_workspace_ids")
+	}
+
+	return ids, nil
+}
+
+func (r *workspaceRepository) Purge(ctx context.Context, id uuid.UUID, before time.Time) (bool, *pkgerrors.Error) {
+	query, args, err := builder.
+		Delete("workspaces").
+		Where(sq.Eq{"id": id}).
+		Where("deleted_at IS NOT NULL").
+		Where(sq.Lt{"deleted_at": before.UTC().Format(timestampFormat)}).
+		ToSql()
+	if err != nil {
+		return false, infraerrors.WrapQueryBuildError(err, "purge_workspace")
+	}
+
+	result, err := r.uow.Querier().ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, infraerrors.WrapSQLiteError(err, "purge_workspace")
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, infraerrors.WrapSQLiteError(err, "get_rows_affected")
+	}
+	return rows > 0, nil
+}
+
+func (r *workspaceRepository) List(ctx context.Context, opts repository.ListOptions) ([]*domain.Workspace, *pkgerrors.Error) {
+	workspaces := []*domain.Workspace{}
+	err := r.ListEach(ctx, opts, func(workspace *domain.Workspace) error {
+		workspaces = append(workspaces, workspace)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return workspaces, nil
+}
+
+func (r *workspaceRepository) ListEach(ctx context.Context, opts repository.ListOptions, fn func(*domain.Workspace) error) *pkgerrors.Error {
+	opts.ApplyDefaults()
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+	if err := opts.ValidateFilters(workspaceFilterColumns...); err != nil {
+		return err
+	}
+
+	qb := builder.
+		Select("id", "name", "description", "admin_id", "created_at", "updated_at", "version", "deleted_at", "deleted_by").
+		From("workspaces")
+	if opts.IncludeDeleted {
+		qb = qb.Where(sq.Or{sq.Expr("deleted_at IS NULL"), sq.Eq{"admin_id": opts.DeletedAdminID}})
+	} else {
+		qb = qb.Where("deleted_at IS NULL")
+	}
+	if len(opts.Filters) > 0 {
+		qb = qb.Where(sq.Eq(opts.Filters))
+	}
+	qb, pageErr := paginate(qb, opts, workspaceSortColumns)
+	if pageErr != nil {
+		return pageErr
+	}
+	query, args, err := qb.ToSql()
+	if err != nil {
+		return infraerrors.WrapQueryBuildError(err, "list_workspaces")
+	}
+
+	rows, err := r.reader().QueryContext(ctx, query, args...)
+	if err != nil {
+		return infraerrors.WrapSQLiteError(err, "list_workspaces")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var workspace domain.Workspace
+		var cat, uat TimestampDest
+		var dat NullableTimestamp
+		err := rows.Scan(
+			&workspace.ID,
+			&workspace.Name,
+			&workspace.Description,
+			&workspace.AdminID,
+			&cat,
+			&uat,
+			&workspace.Version,
+			&dat,
+			&workspace.DeletedBy,
+		)
+		if err != nil {
+			return infraerrors.WrapSQLiteError(err, "scan_workspace")
+		}
+		workspace.CreatedAt = cat.Time()
+		workspace.UpdatedAt = uat.Time()
+		if dat.valid {
+			deletedAt := dat.t
+			workspace.DeletedAt = &deletedAt
+		}
+		if err := fn(&workspace); err != nil {
+			return pkgerrors.Wrap(err, "list_workspaces: consumer failed")
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return infraerrors.WrapSQLiteError(err, "iterate_workspaces")
+	}
+
+	return nil
+}
+
 func (r *workspaceRepository) UpdateAdminID(ctx context.Context, workspaceID uuid.UUID, adminID uuid.UUID) *pkgerrors.Error {
 	query, args, err := builder.
 		Update("workspaces").
 		Set("admin_id", adminID).
 		Set("updated_at", sq.Expr("CURRENT_TIMESTAMP")).
+		Set("version", sq.Expr("version + 1")).
 		Where(sq.Eq{"id": workspaceID}).
 		ToSql()
 	if err != nil {
-		return infraerrors.WrapSQLiteError(err, "update_workspace_admin")
+		return infraerrors.WrapQueryBuildError(err, "update_workspace_admin")
 	}
 
 	result, err := r.uow.Querier().ExecContext(ctx, query, args...)
@@ -262,3 +569,90 @@ func (r *workspaceRepository) UpdateAdminID(ctx context.Context, workspaceID uui
 
 	return nil
 }
+
+func (r *workspaceRepository) SetSecretHash(ctx context.Context, workspaceID uuid.UUID, secretHash string) (time.Time, *pkgerrors.Error) {
+	query, args, err := builder.
+		Update("workspaces").
+		Set("secret_hash", secretHash).
+		Set("secret_rotated_at", sq.Expr("CURRENT_TIMESTAMP")).
+		Where(sq.Eq{"id": workspaceID}).
+		Where("deleted_at IS NULL").
+		Suffix("RETURNING secret_rotated_at").
+		ToSql()
+	if err != nil {
+		return time.Time{}, infraerrors.WrapQueryBuildError(err, "set_workspace_secret_hash")
+	}
+
+	var rotatedAt TimestampDest
+	if err := r.uow.Querier().QueryRowContext(ctx, query, args...).Scan(&rotatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return time.Time{}, domainerrors.NotFound("Workspace", workspaceID.String())
+		}
+		return time.Time{}, infraerrors.WrapSQLiteError(err, "set_workspace_secret_hash")
+	}
+	return rotatedAt.Time(), nil
+}
+
+func (r *workspaceRepository) GetSecretHash(ctx context.Context, workspaceID uuid.UUID) (string, *pkgerrors.Error) {
+	query, args, err := builder.
+		Select("secret_hash").
+		From("workspaces").
+		Where(sq.Eq{"id": workspaceID}).
+		Where("deleted_at IS NULL").
+		ToSql()
+	if err != nil {
+		return "", infraerrors.WrapQueryBuildError(err, "get_workspace_secret_hash")
+	}
+
+	var secretHash sql.NullString
+	if err := r.uow.Querier().QueryRowContext(ctx, query, args...).Scan(&secretHash); err != nil {
+		if err == sql.ErrNoRows {
+			return "", domainerrors.NotFound("Workspace", workspaceID.String())
+		}
+		return "", infraerrors.WrapSQLiteError(err, "get_workspace_secret_hash")
+	}
+	return secretHash.String, nil
+}
+
+func (r *workspaceRepository) GetTokenEpoch(ctx context.Context, workspaceID uuid.UUID) (int64, *pkgerrors.Error) {
+	query, args, err := builder.
+		Select("token_epoch").
+		From("workspaces").
+		Where(sq.Eq{"id": workspaceID}).
+		Where("deleted_at IS NULL").
+		ToSql()
+	if err != nil {
+		return 0, infraerrors.WrapQueryBuildError(err, "get_workspace_token_epoch")
+	}
+
+	var epoch int64
+	if err := r.uow.Querier().QueryRowContext(ctx, query, args...).Scan(&epoch); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, domainerrors.NotFound("Workspace", workspaceID.String())
+		}
+		return 0, infraerrors.WrapSQLiteError(err, "get_workspace_token_epoch")
+	}
+	return epoch, nil
+}
+
+func (r *workspaceRepository) BumpTokenEpoch(ctx context.Context, workspaceID uuid.UUID) (int64, *pkgerrors.Error) {
+	query, args, err := builder.
+		Update("workspaces").
+		Set("token_epoch", sq.Expr("token_epoch + 1")).
+		Where(sq.Eq{"id": workspaceID}).
+		Where("deleted_at IS NULL").
+		Suffix("RETURNING token_epoch").
+		ToSql()
+	if err != nil {
+		return 0, infraerrors.WrapQueryBuildError(err, "bump_workspace_token_epoch")
+	}
+
+	var epoch int64
+	if err := r.uow.Querier().QueryRowContext(ctx, query, args...).Scan(&epoch); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, domainerrors.NotFound("Workspace", workspaceID.String())
+		}
+		return 0, infraerrors.WrapSQLiteError(err, "bump_workspace_token_epoch")
+	}
+	return epoch, nil
+}
@@ -0,0 +1,19 @@
+package sqlite
+
+import (
+	"context"
+
+	"backend/internal/domain/repository"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// scopeToWorkspace restricts qb to the workspace carried by ctx, if any, by
+// matching column. Repositories apply it to reads that must never cross
+// workspaces; see repository.WithWorkspaceScope.
+func scopeToWorkspace(ctx context.Context, qb sq.SelectBuilder, column string) sq.SelectBuilder {
+	if workspaceID, ok := repository.WorkspaceScopeFromContext(ctx); ok {
+		qb = qb.Where(sq.Eq{column: workspaceID})
+	}
+	return qb
+}
@@ -0,0 +1,11 @@
+// Package buildinfo exposes build metadata injected at link time, e.g.
+//
+//	go build -ldflags "-X backend/pkg/buildinfo.Version=v1.2.3 -X backend/pkg/buildinfo.Commit=abc1234" ./cmd/server
+package buildinfo
+
+var (
+	// Version is the released version of the binary.
+	Version = "dev"
+	// Commit is the VCS revision the binary was built from.
+	Commit = "unknown"
+)
@@ -3,25 +3,51 @@ package config
 import (
 	"encoding/hex"
 	"fmt"
+	"log/slog"
 	"os"
 	"strconv"
 	"strings"
+	"time"
+
+	"backend/pkg/jwt"
+	"backend/pkg/validation"
 
 	"github.com/go-playground/validator/v10"
 )
 
+// Deployment environments accepted in ENV.
+const (
+	EnvDevelopment = "development"
+	EnvProduction  = "production"
+)
+
 // Config holds all application configuration loaded from environment variables.
 type Config struct {
+	// Env is the deployment environment; production requires HTTPS and
+	// secure cookies
+	Env string `validate:"required,oneof=development production"`
+
 	// Server
 	Port           string `validate:"required"`
 	BodyLimitBytes int    `validate:"gt=0"`
+	// IPs or CIDR ranges of the reverse proxies whose X-Forwarded-* headers
+	// are honoured; empty trusts no proxy
+	TrustedProxies []string
 
 	// Database
 	DBFilePath string `validate:"required"`
+	// DBReplicaURL optionally points at a read replica used for list and get
+	// queries; empty means every query goes to the primary.
+	DBReplicaURL string
 
 	// Auth
-	JWTSecret      string `validate:"required,min=32"`
+	JWTSecret string `validate:"required,min=32"`
+	// JWTClockSkew is the leeway applied to token exp/nbf/iat checks
+	JWTClockSkew   time.Duration `validate:"gte=0"`
 	AdminInitToken string
+	// CookieName names the auth cookie, so several apps on one domain don't
+	// overwrite each other's sessions
+	CookieName string `validate:"required"`
 
 	// Encryption
 	EncryptionKey []byte `validate:"required"`
@@ -30,6 +56,11 @@ type Config struct {
 	TemplateStoragePath string `validate:"required"`
 	EnvExecutionPath    string `validate:"required"`
 
+	// Template path checks: absolute roots that file:// template paths may resolve under
+	TemplatePathFileAllowlist []string
+	// Hostnames http(s) template paths may be checked against; empty allows any public host
+	TemplatePathHostAllowlist []string
+
 	// Terraform
 	TFPluginCacheDir string
 
@@ -39,6 +70,50 @@ type Config struct {
 	// Role-based secret access (valid values: "admin", "editor", "user")
 	MinRoleViewSecrets string `validate:"required,oneof=admin editor user"`
 	MinRoleEditSecrets string `validate:"required,oneof=admin editor user"`
+
+	// Activity tracking: minimum seconds between last_active_at writes per user
+	ActivityIntervalSeconds int `validate:"gt=0"`
+
+	// Longest a write transaction may stay open before it is rolled back; 0 disables
+	TxTimeoutSeconds int `validate:"gte=0"`
+
+	// Soft-deleted workspace purge: seconds between runs (0 disables) and days
+	// a deleted workspace is kept before it is hard-deleted
+	PurgeIntervalSeconds int `validate:"gte=0"`
+	PurgeRetentionDays   int `validate:"gt=0"`
+
+	// Token introspection: requests per minute allowed from one client IP
+	IntrospectRateLimit int `validate:"gt=0"`
+
+	// /admin/init backoff: wrong-token attempts one client IP may make before
+	// it is blocked, and the first block in seconds, doubling per further failure
+	AdminInitMaxAttempts    int `validate:"gt=0"`
+	AdminInitBackoffSeconds int `validate:"gt=0"`
+
+	// Content policy: character limits on names and descriptions
+	NameMinLength        int `validate:"gt=0"`
+	NameMaxLength        int `validate:"gtefield=NameMinLength"`
+	DescriptionMaxLength int `validate:"gte=0"`
+
+	// Password policy: minimum length in characters and which character
+	// classes are required, each toggled independently
+	PasswordMinLength      int `validate:"gt=0"`
+	PasswordRequireUpper   bool
+	PasswordRequireLower   bool
+	PasswordRequireDigit   bool
+	PasswordRequireSpecial bool
+
+	// Wrap successful JSON response bodies as {"data": ...}
+	ResponseEnvelope bool
+
+	// GET/DELETE requests with a body: answer 400 ("reject") or log them ("warn")
+	UnexpectedBodyPolicy string `validate:"required,oneof=reject warn"`
+
+	// ID generation strategy for new entities ("uuidv4" or time-ordered "uuidv7")
+	IDStrategy string `validate:"required,oneof=uuidv4 uuidv7"`
+
+	// Cross-workspace access: answer with 404 ("not_found") or 403 ("forbidden")
+	CrossTenantDenial string `validate:"required,oneof=not_found forbidden"`
 }
 
 // Load reads configuration from environment variables and returns a validated Config.
@@ -60,6 +135,91 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("BODY_LIMIT_BYTES must be a valid integer: %w", err)
 	}
 
+	activityInterval, err := strconv.Atoi(getEnv("ACTIVITY_INTERVAL_SECONDS", "300"))
+	if err != nil {
+		return nil, fmt.Errorf("ACTIVITY_INTERVAL_SECONDS must be a valid integer: %w", err)
+	}
+
+	clockSkew, err := time.ParseDuration(getEnv("JWT_CLOCK_SKEW", jwt.DefaultClockSkew.String()))
+	if err != nil {
+		return nil, fmt.Errorf("JWT_CLOCK_SKEW must be a valid duration: %w", err)
+	}
+
+	txTimeout, err := strconv.Atoi(getEnv("TX_TIMEOUT_SECONDS", "30"))
+	if err != nil {
+		return nil, fmt.Errorf("TX_TIMEOUT_SECONDS must be a valid integer: %w", err)
+	}
+
+	purgeInterval, err := strconv.Atoi(getEnv("PURGE_INTERVAL_SECONDS", "3600"))
+	if err != nil {
+		return nil, fmt.Errorf("PURGE_INTERVAL_SECONDS must be a valid integer: %w", err)
+	}
+
+	purgeRetention, err := strconv.Atoi(getEnv("PURGE_RETENTION_DAYS", "30"))
+	if err != nil {
+		return nil, fmt.Errorf("PURGE_RETENTION_DAYS must be a valid integer: %w", err)
+	}
+
+	introspectRateLimit, err := strconv.Atoi(getEnv("INTROSPECT_RATE_LIMIT", "60"))
+	if err != nil {
+		return nil, fmt.Errorf("INTROSPECT_RATE_LIMIT must be a valid integer: %w", err)
+	}
+
+	adminInitMaxAttempts, err := strconv.Atoi(getEnv("ADMIN_INIT_MAX_ATTEMPTS", "5"))
+	if err != nil {
+		return nil, fmt.Errorf("ADMIN_INIT_MAX_ATTEMPTS must be a valid integer: %w", err)
+	}
+
+	adminInitBackoff, err := strconv.Atoi(getEnv("ADMIN_INIT_BACKOFF_SECONDS", "30"))
+	if err != nil {
+		return nil, fmt.Errorf("ADMIN_INIT_BACKOFF_SECONDS must be a valid integer: %w", err)
+	}
+
+	nameMinLength, err := strconv.Atoi(getEnv("NAME_MIN_LENGTH", strconv.Itoa(validation.MinNameLength)))
+	if err != nil {
+		return nil, fmt.Errorf("NAME_MIN_LENGTH must be a valid integer: %w", err)
+	}
+
+	nameMaxLength, err := strconv.Atoi(getEnv("NAME_MAX_LENGTH", strconv.Itoa(validation.MaxNameLength)))
+	if err != nil {
+		return nil, fmt.Errorf("NAME_MAX_LENGTH must be a valid integer: %w", err)
+	}
+
+	descriptionMaxLength, err := strconv.Atoi(getEnv("DESCRIPTION_MAX_LENGTH", strconv.Itoa(validation.MaxDescriptionLength)))
+	if err != nil {
+		return nil, fmt.Errorf("DESCRIPTION_MAX_LENGTH must be a valid integer: %w", err)
+	}
+
+	passwordMinLength, err := strconv.Atoi(getEnv("PASSWORD_MIN_LENGTH", strconv.Itoa(validation.DefaultPasswordMinLength)))
+	if err != nil {
+		return nil, fmt.Errorf("PASSWORD_MIN_LENGTH must be a valid integer: %w", err)
+	}
+
+	passwordRequireUpper, err := strconv.ParseBool(getEnv("PASSWORD_REQUIRE_UPPERCASE", "true"))
+	if err != nil {
+		return nil, fmt.Errorf("PASSWORD_REQUIRE_UPPERCASE must be a valid boolean: %w", err)
+	}
+
+	passwordRequireLower, err := strconv.ParseBool(getEnv("PASSWORD_REQUIRE_LOWERCASE", "true"))
+	if err != nil {
+		return nil, fmt.Errorf("PASSWORD_REQUIRE_LOWERCASE must be a valid boolean: %w", err)
+	}
+
+	passwordRequireDigit, err := strconv.ParseBool(getEnv("PASSWORD_REQUIRE_DIGIT", "true"))
+	if err != nil {
+		return nil, fmt.Errorf("PASSWORD_REQUIRE_DIGIT must be a valid boolean: %w", err)
+	}
+
+	passwordRequireSpecial, err := strconv.ParseBool(getEnv("PASSWORD_REQUIRE_SPECIAL", "true"))
+	if err != nil {
+		return nil, fmt.Errorf("PASSWORD_REQUIRE_SPECIAL must be a valid boolean: %w", err)
+	}
+
+	responseEnvelope, err := strconv.ParseBool(getEnv("RESPONSE_ENVELOPE", "false"))
+	if err != nil {
+		return nil, fmt.Errorf("RESPONSE_ENVELOPE must be a valid boolean: %w", err)
+	}
+
 	jwtSecret, err := getEnvOrFile("JWT_SECRET", "")
 	if err != nil {
 		return nil, err
@@ -70,11 +230,15 @@ func Load() (*Config, error) {
 	}
 
 	cfg := &Config{
+		Env:                 getEnv("ENV", EnvDevelopment),
 		Port:                getEnv("PORT", "8080"),
 		BodyLimitBytes:      bodyLimit,
 		DBFilePath:          getEnv("DB_FILE_PATH", "./devshare.db"),
+		DBReplicaURL:        getEnv("DB_REPLICA_URL", ""),
 		JWTSecret:           jwtSecret,
+		JWTClockSkew:        clockSkew,
 		AdminInitToken:      adminInitToken,
+		CookieName:          getEnv("COOKIE_NAME", jwt.DefaultCookieName),
 		EncryptionKey:       encryptionKey,
 		TemplateStoragePath: getEnv("TEMPLATE_STORAGE_PATH", "./template_storage"),
 		EnvExecutionPath:    getEnv("ENV_EXECUTION_PATH", "./env_executions"),
@@ -82,16 +246,125 @@ func Load() (*Config, error) {
 		CORSAllowOrigins:    getEnv("CORS_ALLOW_ORIGINS", "http://localhost:5173,http://localhost:3000"),
 		MinRoleViewSecrets:  getEnv("MIN_ROLE_VIEW_SECRETS", "admin"),
 		MinRoleEditSecrets:  getEnv("MIN_ROLE_EDIT_SECRETS", "admin"),
+		CrossTenantDenial:   getEnv("CROSS_TENANT_DENIAL", "not_found"),
+		IDStrategy:          getEnv("ID_STRATEGY", "uuidv4"),
+
+		ActivityIntervalSeconds:   activityInterval,
+		TxTimeoutSeconds:          txTimeout,
+		PurgeIntervalSeconds:      purgeInterval,
+		PurgeRetentionDays:        purgeRetention,
+		IntrospectRateLimit:       introspectRateLimit,
+		AdminInitMaxAttempts:      adminInitMaxAttempts,
+		AdminInitBackoffSeconds:   adminInitBackoff,
+		NameMinLength:             nameMinLength,
+		NameMaxLength:             nameMaxLength,
+		DescriptionMaxLength:      descriptionMaxLength,
+		PasswordMinLength:         passwordMinLength,
+		PasswordRequireUpper:      passwordRequireUpper,
+		PasswordRequireLower:      passwordRequireLower,
+		PasswordRequireDigit:      passwordRequireDigit,
+		PasswordRequireSpecial:    passwordRequireSpecial,
+		ResponseEnvelope:          responseEnvelope,
+		UnexpectedBodyPolicy:      getEnv("UNEXPECTED_BODY_POLICY", "reject"),
+		TemplatePathFileAllowlist: splitList(getEnv("TEMPLATE_PATH_FILE_ALLOWLIST", "")),
+		TemplatePathHostAllowlist: splitList(getEnv("TEMPLATE_PATH_HOST_ALLOWLIST", "")),
+		TrustedProxies:            splitList(getEnv("TRUSTED_PROXIES", "")),
 	}
 
 	v := validator.New()
 	if err := v.Struct(cfg); err != nil {
 		return nil, fmt.Errorf("invalid configuration: %w", err)
 	}
+	if !validCookieName(cfg.CookieName) {
+		return nil, fmt.Errorf("COOKIE_NAME %q is not a valid cookie name", cfg.CookieName)
+	}
 
 	return cfg, nil
 }
 
+// Production reports whether the server runs in the production environment.
+func (c *Config) Production() bool {
+	return c.Env == EnvProduction
+}
+
+// ContentPolicy returns the configured limits on names and descriptions.
+func (c *Config) ContentPolicy() validation.ContentPolicy {
+	return validation.ContentPolicy{
+		NameMinLength:        c.NameMinLength,
+		NameMaxLength:        c.NameMaxLength,
+		DescriptionMaxLength: c.DescriptionMaxLength,
+	}
+}
+
+// PasswordPolicy returns the configured password length and character class
+// requirements.
+func (c *Config) PasswordPolicy() validation.PasswordPolicy {
+	return validation.PasswordPolicy{
+		MinLength:      c.PasswordMinLength,
+		RequireUpper:   c.PasswordRequireUpper,
+		RequireLower:   c.PasswordRequireLower,
+		RequireDigit:   c.PasswordRequireDigit,
+		RequireSpecial: c.PasswordRequireSpecial,
+	}
+}
+
+// redacted stands in for secret values in logs.
+const redacted = "[redacted]"
+
+// LogValue renders the effective configuration for a structured log line, keyed
+// by environment variable name. Secrets only show whether they are set, so the
+// whole Config can be logged as is: slog.Info("...", "config", cfg).
+func (c *Config) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("ENV", c.Env),
+		slog.String("PORT", c.Port),
+		slog.Int("BODY_LIMIT_BYTES", c.BodyLimitBytes),
+		slog.Any("TRUSTED_PROXIES", c.TrustedProxies),
+		slog.String("DB_FILE_PATH", c.DBFilePath),
+		slog.String("DB_REPLICA_URL", c.DBReplicaURL),
+		slog.String("JWT_SECRET", mask(c.JWTSecret != "")),
+		slog.Duration("JWT_CLOCK_SKEW", c.JWTClockSkew),
+		slog.String("ADMIN_INIT_TOKEN", mask(c.AdminInitToken != "")),
+		slog.String("COOKIE_NAME", c.CookieName),
+		slog.String("ENCRYPTION_KEY", mask(len(c.EncryptionKey) > 0)),
+		slog.String("TEMPLATE_STORAGE_PATH", c.TemplateStoragePath),
+		slog.String("ENV_EXECUTION_PATH", c.EnvExecutionPath),
+		slog.Any("TEMPLATE_PATH_FILE_ALLOWLIST", c.TemplatePathFileAllowlist),
+		slog.Any("TEMPLATE_PATH_HOST_ALLOWLIST", c.TemplatePathHostAllowlist),
+		slog.String("TF_PLUGIN_CACHE_DIR", c.TFPluginCacheDir),
+		slog.String("CORS_ALLOW_ORIGINS", c.CORSAllowOrigins),
+		slog.String("MIN_ROLE_VIEW_SECRETS", c.MinRoleViewSecrets),
+		slog.String("MIN_ROLE_EDIT_SECRETS", c.MinRoleEditSecrets),
+		slog.Int("ACTIVITY_INTERVAL_SECONDS", c.ActivityIntervalSeconds),
+		slog.Int("TX_TIMEOUT_SECONDS", c.TxTimeoutSeconds),
+		slog.Int("PURGE_INTERVAL_SECONDS", c.PurgeIntervalSeconds),
+		slog.Int("PURGE_RETENTION_DAYS", c.PurgeRetentionDays),
+		slog.Int("INTROSPECT_RATE_LIMIT", c.IntrospectRateLimit),
+		slog.Int("ADMIN_INIT_MAX_ATTEMPTS", c.AdminInitMaxAttempts),
+		slog.Int("ADMIN_INIT_BACKOFF_SECONDS", c.AdminInitBackoffSeconds),
+		slog.Int("NAME_MIN_LENGTH", c.NameMinLength),
+		slog.Int("NAME_MAX_LENGTH", c.NameMaxLength),
+		slog.Int("DESCRIPTION_MAX_LENGTH", c.DescriptionMaxLength),
+		slog.Int("PASSWORD_MIN_LENGTH", c.PasswordMinLength),
+		slog.Bool("PASSWORD_REQUIRE_UPPERCASE", c.PasswordRequireUpper),
+		slog.Bool("PASSWORD_REQUIRE_LOWERCASE", c.PasswordRequireLower),
+		slog.Bool("PASSWORD_REQUIRE_DIGIT", c.PasswordRequireDigit),
+		slog.Bool("PASSWORD_REQUIRE_SPECIAL", c.PasswordRequireSpecial),
+		slog.Bool("RESPONSE_ENVELOPE", c.ResponseEnvelope),
+		slog.String("UNEXPECTED_BODY_POLICY", c.UnexpectedBodyPolicy),
+		slog.String("ID_STRATEGY", c.IDStrategy),
+		slog.String("CROSS_TENANT_DENIAL", c.CrossTenantDenial),
+	)
+}
+
+// mask reports a secret as redacted when set and empty when not.
+func mask(set bool) string {
+	if set {
+		return redacted
+	}
+	return ""
+}
+
 func getEnv(key, defaultValue string) string {
 	value := os.Getenv(key)
 	if value == "" {
@@ -100,6 +373,28 @@ func getEnv(key, defaultValue string) string {
 	return value
 }
 
+// validCookieName reports whether name is an RFC 6265 cookie name: printable
+// ASCII without whitespace or separators.
+func validCookieName(name string) bool {
+	for _, r := range name {
+		if r <= ' ' || r >= 0x7f || strings.ContainsRune(`()<>@,;:\"/[]?={}`, r) {
+			return false
+		}
+	}
+	return true
+}
+
+// splitList parses a comma-separated value, dropping empty entries.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 // getEnvOrFile reads a secret from either ${key}_FILE (a file path, whose
 // contents are read and whitespace-trimmed) or ${key} (a literal value). The
 // _FILE form takes precedence when set — it's how Docker secrets, tmpfs-backed
@@ -1,8 +1,12 @@
 package config
 
 import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -88,3 +92,61 @@ func TestGetEnvOrFile_EmptyFileReturnsEmpty(t *testing.T) {
 		t.Errorf("want empty string, got %q", got)
 	}
 }
+
+func TestConfigLogValue_SummarizesAndMasksSecrets(t *testing.T) {
+	const jwtSecret = "a-very-long-jwt-secret-that-must-not-leak"
+	t.Setenv("JWT_SECRET", jwtSecret)
+	t.Setenv("ENCRYPTION_KEY", strings.Repeat("ab", 32))
+	t.Setenv("DB_FILE_PATH", "/data/test.db")
+	t.Setenv("PORT", "9090")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	var buf bytes.Buffer
+	slog.New(slog.NewJSONHandler(&buf, nil)).Info("configuration loaded", "config", cfg)
+
+	if strings.Contains(buf.String(), jwtSecret) || strings.Contains(buf.String(), strings.Repeat("ab", 32)) {
+		t.Fatalf("expected secrets to be masked, got %s", buf.String())
+	}
+
+	var line struct {
+		Config map[string]any `json:"config"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+		t.Fatalf("failed to decode log line: %v", err)
+	}
+	want := map[string]any{
+		"PORT":             "9090",
+		"DB_FILE_PATH":     "/data/test.db",
+		"JWT_SECRET":       redacted,
+		"ENCRYPTION_KEY":   redacted,
+		"ADMIN_INIT_TOKEN": "",
+		"ID_STRATEGY":      "uuidv4",
+	}
+	for key, value := range want {
+		if line.Config[key] != value {
+			t.Errorf("%s: want %v, got %v", key, value, line.Config[key])
+		}
+	}
+	if _, ok := line.Config["CORS_ALLOW_ORIGINS"]; !ok {
+		t.Error("expected CORS_ALLOW_ORIGINS in the summary")
+	}
+}
+
+func TestValidCookieName(t *testing.T) {
+	for name, want := range map[string]bool{
+		"access_token":     true,
+		"devshare-session": true,
+		"has space":        false,
+		"semi;colon":       false,
+		"equals=sign":      false,
+		"caf\u00e9":        false,
+	} {
+		if got := validCookieName(name); got != want {
+			t.Errorf("validCookieName(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
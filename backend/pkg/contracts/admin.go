@@ -7,11 +7,11 @@ import (
 )
 
 type AdminInit struct {
-	AdminName            string `json:"admin_name" validate:"required,min=2,max=100"`
+	AdminName            string `json:"admin_name" validate:"required" content:"name"`
 	AdminEmail           string `json:"admin_email" validate:"required,email"`
-	AdminPassword        string `json:"admin_password" validate:"required,min=8,strongpassword"`
-	WorkspaceName        string `json:"workspace_name" validate:"required,min=3,max=100"`
-	WorkspaceDescription string `json:"workspace_description" validate:"max=500"`
+	AdminPassword        string `json:"admin_password" validate:"required,strongpassword"`
+	WorkspaceName        string `json:"workspace_name" validate:"required,min=3" content:"name"`
+	WorkspaceDescription string `json:"workspace_description" content:"description"`
 }
 
 type AdminInitResponse struct {
@@ -21,12 +21,23 @@ type AdminInitResponse struct {
 	UserName    string    `json:"admin_user_name"`
 }
 
+// ListAdminInitAttempts pages through recorded /admin/init requests, most
+// recent first.
+type ListAdminInitAttempts struct {
+	Limit  int `json:"limit" validate:"omitempty,min=1,max=100"`
+	Offset int `json:"offset" validate:"omitempty,min=0"`
+}
+
 type InviteUser struct {
-	Name  string `json:"name" validate:"required,min=2,max=100"`
+	Name  string `json:"name" validate:"required" content:"name"`
 	Email string `json:"email" validate:"required,email"`
 	Role  string `json:"role" validate:"required,oneof=admin editor user"`
 }
 
+type InviteUsers struct {
+	Users []InviteUser `json:"users" validate:"required,min=1,max=100"`
+}
+
 type InviteUserResponse struct {
 	UserID   uuid.UUID `json:"user_id"`
 	Name     string    `json:"name"`
@@ -35,8 +46,26 @@ type InviteUserResponse struct {
 	Password string    `json:"password"`
 }
 
+// ListUsers filters the admin user list. InactiveSince (RFC 3339) keeps only
+// users not active since that instant, including those never active, oldest
+// activity first; that listing is paged by Limit and Offset.
+type ListUsers struct {
+	InactiveSince string `json:"inactive_since" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	Limit         int    `json:"limit" validate:"omitempty,min=1,max=100"`
+	Offset        int    `json:"offset" validate:"omitempty,min=0"`
+}
+
+type GetUserByEmail struct {
+	Email string `json:"email" query:"email" validate:"required,email"`
+}
+
+type MoveUser struct {
+	UserID      uuid.UUID `json:"user_id" validate:"required,uuid"`
+	WorkspaceID uuid.UUID `json:"workspace_id" validate:"required,uuid"`
+}
+
 type ResetPassword struct {
-	UserID uuid.UUID `json:"user_id" validate:"required,uuid4"`
+	UserID uuid.UUID `json:"user_id" validate:"required,uuid"`
 }
 
 type ResetPasswordResponse struct {
@@ -45,11 +74,12 @@ type ResetPasswordResponse struct {
 }
 
 type AdminUserResponse struct {
-	ID          uuid.UUID `json:"id"`
-	Name        string    `json:"name"`
-	Email       string    `json:"email"`
-	Role        string    `json:"role"`
-	WorkspaceID uuid.UUID `json:"workspace_id"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID           uuid.UUID  `json:"id"`
+	Name         string     `json:"name"`
+	Email        string     `json:"email"`
+	Role         string     `json:"role"`
+	WorkspaceID  uuid.UUID  `json:"workspace_id"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+	LastActiveAt *time.Time `json:"last_active_at,omitempty"`
 }
@@ -0,0 +1,33 @@
+package contracts
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreateAPIKey struct {
+	WorkspaceID uuid.UUID `json:"workspace_id" validate:"required,uuid"`
+	Name        string    `json:"name" validate:"required,min=3" content:"name"`
+	Role        string    `json:"role" validate:"required,oneof=admin editor user"`
+	// Scope optionally limits the key to reads; defaults to "write"
+	Scope string `json:"scope" validate:"omitempty,oneof=read write"`
+}
+
+// APIKeyResponse describes an API key without its secret value.
+type APIKeyResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	WorkspaceID uuid.UUID  `json:"workspace_id"`
+	Name        string     `json:"name"`
+	Role        string     `json:"role"`
+	Scope       string     `json:"scope"`
+	KeyPrefix   string     `json:"key_prefix"`
+	CreatedBy   *uuid.UUID `json:"created_by,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// CreateAPIKeyResponse is the only response that carries the plaintext key.
+type CreateAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
@@ -0,0 +1,74 @@
+package contracts
+
+import (
+	"net/http"
+
+	"backend/pkg/errors"
+)
+
+// ErrorDetail is the error as clients see it, the same shape as the "error"
+// object of a failed request.
+type ErrorDetail struct {
+	Code     string                 `json:"code"`
+	Message  string                 `json:"message"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// BatchItemResult reports the outcome of one item in a batch request. Index is
+// the item's position in the request; exactly one of Data and Error is set.
+type BatchItemResult[T any] struct {
+	Index  int          `json:"index"`
+	Status int          `json:"status"`
+	Data   *T           `json:"data,omitempty"`
+	Error  *ErrorDetail `json:"error,omitempty"`
+
+	err *errors.Error
+}
+
+// BatchResponse is the multi-status response shape shared by batch endpoints.
+// Items are processed independently, so one failure does not undo the others.
+type BatchResponse[T any] struct {
+	Results   []BatchItemResult[T] `json:"results"`
+	Succeeded int                  `json:"succeeded"`
+	Failed    int                  `json:"failed"`
+}
+
+// AddSuccess records that the item at index succeeded with status.
+func (r *BatchResponse[T]) AddSuccess(index, status int, data *T) {
+	r.Results = append(r.Results, BatchItemResult[T]{Index: index, Status: status, Data: data})
+	r.Succeeded++
+}
+
+// AddFailure records that the item at index failed with err. The error is
+// only rendered for the client by FormatErrors.
+func (r *BatchResponse[T]) AddFailure(index int, err *errors.Error) {
+	r.Results = append(r.Results, BatchItemResult[T]{Index: index, Status: err.HTTPStatus(), err: err})
+	r.Failed++
+}
+
+// FormatErrors renders the error of every failed item with format, which
+// should be the formatting used for the error of a failed request, so item
+// errors reach clients in the same shape.
+func (r *BatchResponse[T]) FormatErrors(format func(*errors.Error) ErrorDetail) {
+	for i := range r.Results {
+		if err := r.Results[i].err; err != nil {
+			detail := format(err)
+			r.Results[i].Error = &detail
+		}
+	}
+}
+
+// HTTPStatus returns the status shared by every item when outcomes are
+// uniform, and 207 Multi-Status when they differ.
+func (r *BatchResponse[T]) HTTPStatus() int {
+	if len(r.Results) == 0 {
+		return http.StatusOK
+	}
+	status := r.Results[0].Status
+	for _, result := range r.Results[1:] {
+		if result.Status != status {
+			return http.StatusMultiStatus
+		}
+	}
+	return status
+}
@@ -0,0 +1,13 @@
+package contracts
+
+type DebugInfoResponse struct {
+	Version       string  `json:"version"`
+	Commit        string  `json:"commit"`
+	GoVersion     string  `json:"go_version"`
+	UptimeSeconds float64 `json:"uptime_seconds"`
+	Goroutines    int     `json:"goroutines"`
+	// CommitsWithoutBegin and TransactionsLeftOpen count unit-of-work misuse
+	// since startup; both should stay zero.
+	CommitsWithoutBegin  int64 `json:"commits_without_begin"`
+	TransactionsLeftOpen int64 `json:"transactions_left_open"`
+}
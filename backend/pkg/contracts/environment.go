@@ -8,16 +8,18 @@ import (
 
 type (
 	CreateEnvironment struct {
-		Name        string    `json:"name" validate:"required,min=3,max=255"`
-		Description string    `json:"description" validate:"omitempty,max=1000"`
-		TemplateID  uuid.UUID `json:"template_id" validate:"required,uuid4"`
+		Name        string    `json:"name" validate:"required,min=3" content:"name"`
+		Description string    `json:"description" content:"description"`
+		TemplateID  uuid.UUID `json:"template_id" validate:"required,uuid"`
 		TTLSeconds  *int      `json:"ttl_seconds" validate:"omitempty,min=60"`
 	}
 
 	GetEnvironment struct {
-		ID uuid.UUID `json:"id" validate:"required,uuid4"`
+		ID uuid.UUID `json:"id" validate:"required,uuid"`
 	}
 
+	// ListEnvironments lists the caller's environments. Without a limit the
+	// legacy array is returned; with one, a PagedResponse carrying the total.
 	ListEnvironments struct {
 		Scope      string `query:"scope" validate:"omitempty,oneof=user all"`
 		Status     string `query:"status" validate:"omitempty"`
@@ -31,23 +33,27 @@ type (
 	}
 
 	ApplyEnvironment struct {
-		ID uuid.UUID `json:"id" validate:"required,uuid4"`
+		ID uuid.UUID `json:"id" validate:"required,uuid"`
 	}
 
 	PlanEnvironment struct {
-		ID uuid.UUID `json:"id" validate:"required,uuid4"`
+		ID uuid.UUID `json:"id" validate:"required,uuid"`
 	}
 
 	DestroyEnvironment struct {
-		ID uuid.UUID `json:"id" validate:"required,uuid4"`
+		ID uuid.UUID `json:"id" validate:"required,uuid"`
 	}
 
 	DeleteEnvironment struct {
-		ID uuid.UUID `json:"id" validate:"required,uuid4"`
+		ID uuid.UUID `json:"id" validate:"required,uuid"`
+	}
+
+	RestoreEnvironment struct {
+		ID uuid.UUID `json:"id" validate:"required,uuid"`
 	}
 
 	GetEnvironmentOutputs struct {
-		ID uuid.UUID `json:"id" validate:"required,uuid4"`
+		ID uuid.UUID `json:"id" validate:"required,uuid"`
 	}
 
 	TerraformOutput struct {
@@ -0,0 +1,7 @@
+package contracts
+
+// LimitsResponse publishes input limits enforced by the API so clients can
+// validate before submitting.
+type LimitsResponse struct {
+	MaxNameLength int `json:"max_name_length"`
+}
@@ -0,0 +1,10 @@
+package contracts
+
+// PagedResponse is the envelope for paginated listings. Total counts every
+// item the query matches, not just those in Items.
+type PagedResponse[T any] struct {
+	Items  []T `json:"items"`
+	Total  int `json:"total"`
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+}
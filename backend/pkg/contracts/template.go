@@ -1,43 +1,155 @@
 package contracts
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TemplateFields are the field names ListTemplates.Fields may request.
+var TemplateFields = []string{"id", "name", "workspace_id", "path", "repo_url", "created_at", "updated_at"}
 
 type (
 	CreateTemplate struct {
-		Name        string    `form:"name" validate:"required,min=3,max=255"`
-		WorkspaceID uuid.UUID `form:"workspace_id" validate:"required,uuid4"`
+		Name        string    `form:"name" validate:"required,min=3" content:"name"`
+		WorkspaceID uuid.UUID `form:"workspace_id" validate:"required,uuid"`
+		RepoURL     string    `form:"repo_url" validate:"omitempty,max=2048,httpurl"`
 	}
 
+	// UpdateTemplate changes the fields that are set. A nil RepoURL keeps
+	// the current one; an empty one clears it.
 	UpdateTemplate struct {
-		ID   uuid.UUID `form:"id" validate:"required,uuid4"`
-		Name string    `form:"name" validate:"omitempty,min=3,max=255"`
+		ID      uuid.UUID `form:"id" validate:"required,uuid"`
+		Name    string    `form:"name" validate:"omitempty,min=3" content:"name"`
+		RepoURL *string   `form:"repo_url" validate:"omitempty,max=2048,httpurl"`
 	}
 
 	GetTemplate struct {
-		ID uuid.UUID `json:"id" validate:"required,uuid4"`
+		ID uuid.UUID `json:"id" validate:"required,uuid"`
 	}
 
+	// GetTemplatesByWorkspace lists a workspace's templates. Without a limit
+	// the legacy unpaginated array is returned; with one, a PagedResponse.
 	GetTemplatesByWorkspace struct {
-		WorkspaceID uuid.UUID `json:"workspace_id" validate:"required,uuid4"`
+		WorkspaceID uuid.UUID `json:"workspace_id" query:"-" validate:"required,uuid"`
+		Limit       int       `json:"limit" validate:"omitempty,min=1,max=100"`
+		Offset      int       `json:"offset" validate:"omitempty,min=0"`
+		SortBy      string    `json:"sort_by" query:"sort_by" validate:"omitempty,oneof=name created_at updated_at"`
+		Order       string    `json:"order" validate:"omitempty,oneof=ASC DESC"`
 	}
 
+	// ListTemplates is bound from query parameters; default tags give the
+	// value used when a parameter is omitted, so every bound is checked
+	// without omitempty and invalid values never reach the repository.
 	ListTemplates struct {
-		Limit  int    `json:"limit" validate:"omitempty,min=1,max=100"`
-		Offset int    `json:"offset" validate:"omitempty,min=0"`
-		SortBy string `json:"sort_by" query:"sort_by" validate:"omitempty,oneof=name created_at updated_at"`
-		Order  string `json:"order" validate:"omitempty,oneof=ASC DESC"`
+		Limit  int    `json:"limit" default:"50" validate:"gte=1,lte=100"`
+		Offset int    `json:"offset" default:"0" validate:"gte=0"`
+		SortBy string `json:"sort_by" query:"sort_by" default:"created_at" validate:"oneof=name created_at updated_at"`
+		Order  string `json:"order" default:"DESC" validate:"oneof=ASC DESC"`
+		// Fields optionally limits each template to a comma-separated subset
+		// of TemplateFields, e.g. "id,name,updated_at"
+		Fields string `json:"fields"`
+		// Filters holds equality filters from filter.<column>=<value> query parameters
+		Filters map[string]string `json:"-" query:"-"`
+	}
+
+	// PartialTemplate is a template listed with ?fields=; only the requested
+	// fields are set, the rest are omitted from the JSON.
+	PartialTemplate struct {
+		ID          *uuid.UUID `json:"id,omitempty"`
+		Name        *string    `json:"name,omitempty"`
+		WorkspaceID *uuid.UUID `json:"workspace_id,omitempty"`
+		Path        *string    `json:"path,omitempty"`
+		RepoURL     *string    `json:"repo_url,omitempty"`
+		CreatedAt   *time.Time `json:"created_at,omitempty"`
+		UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+	}
+
+	// GetTemplateStats is bound from query parameters: RecentDays is the
+	// window updated_recently counts over and Recent how many of the most
+	// recently updated templates to return.
+	GetTemplateStats struct {
+		WorkspaceID uuid.UUID `json:"workspace_id" query:"-" validate:"required,uuid"`
+		RecentDays  int       `json:"recent_days" query:"recent_days" default:"7" validate:"gte=1,lte=365"`
+		Recent      int       `json:"recent" default:"5" validate:"gte=0,lte=50"`
+	}
+
+	TemplateStatsEntry struct {
+		ID        uuid.UUID `json:"id"`
+		Name      string    `json:"name"`
+		UpdatedAt time.Time `json:"updated_at"`
+	}
+
+	// TemplateStatsResponse counts the templates the caller can access in a
+	// workspace.
+	TemplateStatsResponse struct {
+		WorkspaceID         uuid.UUID            `json:"workspace_id"`
+		Total               int                  `json:"total"`
+		RecentDays          int                  `json:"recent_days"`
+		UpdatedRecently     int                  `json:"updated_recently"`
+		LastUpdatedAt       *time.Time           `json:"last_updated_at"`
+		MostRecentlyUpdated []TemplateStatsEntry `json:"most_recently_updated"`
+	}
+
+	GetTemplateSchemes struct {
+		WorkspaceID uuid.UUID `json:"workspace_id" validate:"required,uuid"`
+	}
+
+	TemplateSchemeCount struct {
+		Scheme string `json:"scheme"`
+		Count  int    `json:"count"`
+	}
+
+	// TemplateSchemesResponse groups the templates the caller can access by
+	// where their source lives: the repo_url scheme, or "storage".
+	TemplateSchemesResponse struct {
+		WorkspaceID uuid.UUID             `json:"workspace_id"`
+		Schemes     []TemplateSchemeCount `json:"schemes"`
 	}
 
 	DeleteTemplate struct {
-		ID uuid.UUID `json:"id" validate:"required,uuid4"`
+		ID uuid.UUID `json:"id" validate:"required,uuid"`
 	}
 
 	ListTemplateFiles struct {
-		ID uuid.UUID `json:"id" validate:"required,uuid4"`
+		ID uuid.UUID `json:"id" validate:"required,uuid"`
+	}
+
+	ListTemplateVersions struct {
+		ID uuid.UUID `json:"id" validate:"required,uuid"`
+	}
+
+	RestoreTemplateVersion struct {
+		ID      uuid.UUID `json:"id" validate:"required,uuid"`
+		Version int       `json:"version" validate:"required,min=1"`
+	}
+
+	// DiffTemplates compares the files of template ID (the old side) with
+	// those of template OtherID (the new side).
+	DiffTemplates struct {
+		ID      uuid.UUID `json:"id" validate:"required,uuid"`
+		OtherID uuid.UUID `json:"other_id" validate:"required,uuid"`
+	}
+
+	TemplateDiff struct {
+		ID      uuid.UUID `json:"id"`
+		OtherID uuid.UUID `json:"other_id"`
+		// Files holds the files that differ, sorted by path; identical files
+		// are left out
+		Files []TemplateFileDiff `json:"files"`
+	}
+
+	// TemplateFileDiff is one differing file. Status is "added" (only in the
+	// other template), "removed" (only in the first) or "modified"; Diff is
+	// a unified diff from the first template's file to the other's.
+	TemplateFileDiff struct {
+		Path   string `json:"path"`
+		Status string `json:"status"`
+		Diff   string `json:"diff"`
 	}
 
 	GetTemplateFileContent struct {
-		ID       uuid.UUID `json:"id" validate:"required,uuid4"`
+		ID       uuid.UUID `json:"id" validate:"required,uuid"`
 		Filename string    `json:"filename" validate:"required,filepath"`
 	}
 
@@ -45,4 +157,30 @@ type (
 		Name string `json:"name"`
 		Size int64  `json:"size"`
 	}
+
+	// TemplateFileContent is a template file with the language to highlight
+	// it as, detected from its extension.
+	TemplateFileContent struct {
+		Path     string `json:"path"`
+		Language string `json:"language"`
+		Content  string `json:"content"`
+	}
+
+	ValidateTemplatePath struct {
+		ID uuid.UUID `json:"id" validate:"required,uuid"`
+	}
+
+	PathCheckResult struct {
+		Target     string `json:"target"`
+		Scheme     string `json:"scheme"`
+		Reachable  bool   `json:"reachable"`
+		StatusCode int    `json:"status_code,omitempty"`
+		Detail     string `json:"detail,omitempty"`
+	}
+
+	ValidateTemplatePathResponse struct {
+		TemplateID uuid.UUID         `json:"template_id"`
+		Reachable  bool              `json:"reachable"`
+		Checks     []PathCheckResult `json:"checks"`
+	}
 )
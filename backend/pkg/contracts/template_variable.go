@@ -4,9 +4,9 @@ import "github.com/google/uuid"
 
 type (
 	CreateTemplateVariable struct {
-		TemplateID      uuid.UUID `json:"template_id" validate:"required,uuid4"`
+		TemplateID      uuid.UUID `json:"template_id" validate:"required,uuid"`
 		Key             string    `json:"key" validate:"required,min=1,max=255"`
-		Description     string    `json:"description" validate:"max=1000"`
+		Description     string    `json:"description" content:"description"`
 		VarType         string    `json:"var_type" validate:"omitempty,max=100"`
 		DefaultValue    string    `json:"default_value"`
 		IsSensitive     bool      `json:"is_sensitive"`
@@ -15,12 +15,12 @@ type (
 	}
 
 	GetTemplateVariables struct {
-		TemplateID uuid.UUID `json:"template_id" validate:"required,uuid4"`
+		TemplateID uuid.UUID `json:"template_id" validate:"required,uuid"`
 	}
 
 	UpdateTemplateVariable struct {
-		ID              uuid.UUID `json:"id" validate:"required,uuid4"`
-		Description     *string   `json:"description" validate:"omitempty,max=1000"`
+		ID              uuid.UUID `json:"id" validate:"required,uuid"`
+		Description     *string   `json:"description" content:"description"`
 		VarType         *string   `json:"var_type" validate:"omitempty,max=100"`
 		DefaultValue    *string   `json:"default_value"`
 		IsSensitive     *bool     `json:"is_sensitive"`
@@ -30,10 +30,10 @@ type (
 	}
 
 	DeleteTemplateVariable struct {
-		ID uuid.UUID `json:"id" validate:"required,uuid4"`
+		ID uuid.UUID `json:"id" validate:"required,uuid"`
 	}
 
 	ParseTemplateVariables struct {
-		TemplateID uuid.UUID `json:"template_id" validate:"required,uuid4"`
+		TemplateID uuid.UUID `json:"template_id" validate:"required,uuid"`
 	}
 )
@@ -3,16 +3,30 @@ package contracts
 import "github.com/google/uuid"
 
 type (
+	// CreateUser creates a user authenticated either by password or by an
+	// OAuth provider. Supplying both is rejected rather than silently
+	// dropping one of them.
+	CreateUser struct {
+		Name          string     `json:"name" validate:"required" content:"name"`
+		Email         string     `json:"email" validate:"required,email"`
+		Password      *string    `json:"password" validate:"omitempty,strongpassword"`
+		OauthProvider *string    `json:"oauth_provider" validate:"omitempty,oneof=github google"`
+		OauthID       *uuid.UUID `json:"oauth_id" validate:"omitempty,uuid"`
+		WorkspaceID   uuid.UUID  `json:"workspace_id" validate:"required,uuid"`
+	}
+
 	CreateLocalUser struct {
-		Name        string    `json:"name" validate:"required,min=2,max=100"`
+		Name        string    `json:"name" validate:"required" content:"name"`
 		Email       string    `json:"email" validate:"required,email"`
-		Password    string    `json:"password" validate:"required,min=8,strongpassword"`
-		WorkspaceID uuid.UUID `json:"workspace_id" validate:"required,uuid4"`
+		Password    string    `json:"password" validate:"required,strongpassword"`
+		WorkspaceID uuid.UUID `json:"workspace_id" validate:"required,uuid"`
 	}
 
 	LoginLocalUser struct {
 		Email    string `json:"email" validate:"required,email"`
 		Password string `json:"password" validate:"required"`
+		// Scope optionally limits the issued token; defaults to "write"
+		Scope string `json:"scope" validate:"omitempty,oneof=read write"`
 	}
 
 	LoginResponse struct {
@@ -20,5 +34,25 @@ type (
 		Name        string    `json:"name"`
 		Role        string    `json:"role"`
 		WorkspaceID uuid.UUID `json:"workspace_id"`
+		Scope       string    `json:"scope"`
+		TokenEpoch  int64     `json:"-"`
+		// WorkspaceTokenEpoch is the workspace's token epoch, embedded in the
+		// issued token.
+		WorkspaceTokenEpoch int64 `json:"-"`
+	}
+
+	// IntrospectToken asks whether a token is currently valid. The token may
+	// instead be sent as "Authorization: Bearer <token>".
+	IntrospectToken struct {
+		Token string `json:"token"`
+	}
+
+	// TokenIntrospection describes a token. Only Active is set for a token that
+	// is invalid or expired; Exp is in seconds since the Unix epoch.
+	TokenIntrospection struct {
+		Active      bool   `json:"active"`
+		UserID      string `json:"user_id,omitempty"`
+		WorkspaceID string `json:"workspace_id,omitempty"`
+		Exp         int64  `json:"exp,omitempty"`
 	}
 )
@@ -0,0 +1,55 @@
+package contracts
+
+import "github.com/go-playground/validator/v10"
+
+// StructValidator is the part of validation.Service the contracts register
+// their struct-level rules with.
+type StructValidator interface {
+	RegisterStructValidation(fn validator.StructLevelFunc, types ...interface{})
+	ValidateContentFields(sl validator.StructLevel)
+}
+
+// RegisterValidations registers the rules spanning several fields of a
+// contract: the content policy on content-tagged fields, and CreateUser's
+// authentication method. Call it after RegisterDefaultCustomValidations.
+func RegisterValidations(v StructValidator) {
+	v.RegisterStructValidation(v.ValidateContentFields,
+		CreateLocalUser{},
+		AdminInit{},
+		InviteUser{},
+		CreateAPIKey{},
+		CreateWorkspace{},
+		UpdateWorkspace{},
+		CreateGroup{},
+		UpdateGroup{},
+		CreateTemplate{},
+		UpdateTemplate{},
+		CreateTemplateVariable{},
+		UpdateTemplateVariable{},
+		CreateEnvironment{},
+	)
+	// A type takes a single struct-level validation, so CreateUser's also
+	// checks its content fields
+	v.RegisterStructValidation(func(sl validator.StructLevel) {
+		validateCreateUserAuth(sl)
+		v.ValidateContentFields(sl)
+	}, CreateUser{})
+}
+
+// validateCreateUserAuth requires exactly one authentication method on a
+// user creation request: a password, or an OAuth provider and ID.
+func validateCreateUserAuth(sl validator.StructLevel) {
+	req := sl.Current().Interface().(CreateUser)
+	hasOAuth := req.OauthProvider != nil || req.OauthID != nil
+
+	switch {
+	case req.Password != nil && hasOAuth:
+		sl.ReportError(req.Password, "password", "Password", "authexclusive", "")
+	case req.Password == nil && !hasOAuth:
+		sl.ReportError(req.Password, "password", "Password", "required", "")
+	case hasOAuth && req.OauthProvider == nil:
+		sl.ReportError(req.OauthProvider, "oauth_provider", "OauthProvider", "required", "")
+	case hasOAuth && req.OauthID == nil:
+		sl.ReportError(req.OauthID, "oauth_id", "OauthID", "required", "")
+	}
+}
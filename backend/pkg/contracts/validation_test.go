@@ -0,0 +1,71 @@
+package contracts
+
+import (
+	"strings"
+	"testing"
+
+	"backend/pkg/validation"
+
+	"github.com/google/uuid"
+)
+
+func TestRegisterValidations_CreateUserAuthExclusive(t *testing.T) {
+	validator := validation.New()
+	if err := validator.RegisterDefaultCustomValidations(); err != nil {
+		t.Fatalf("Failed to register custom validations: %v", err)
+	}
+	RegisterValidations(validator)
+
+	password := "SecurePass123!"
+	provider := "github"
+	oauthID := uuid.New()
+
+	tests := []struct {
+		name       string
+		password   *string
+		provider   *string
+		oauthID    *uuid.UUID
+		wantField  string
+		wantSubstr string
+	}{
+		{"valid - password only", &password, nil, nil, "", ""},
+		{"valid - oauth only", nil, &provider, &oauthID, "", ""},
+		{"invalid - both", &password, &provider, &oauthID, "password", "cannot be combined"},
+		{"invalid - neither", nil, nil, nil, "password", "required"},
+		{"invalid - oauth id without provider", nil, nil, &oauthID, "oauth_provider", "required"},
+		{"invalid - name too short", &password, nil, nil, "name", "at least"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name := "John Doe"
+			if tt.wantField == "name" {
+				name = "J"
+			}
+			err := validator.Validate(CreateUser{
+				Name:          name,
+				Email:         "john@example.com",
+				Password:      tt.password,
+				OauthProvider: tt.provider,
+				OauthID:       tt.oauthID,
+				WorkspaceID:   uuid.New(),
+			})
+			if tt.wantField == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("Expected validation error")
+			}
+			if err.HTTPStatus() != 400 {
+				t.Errorf("Expected status 400, got %d", err.HTTPStatus())
+			}
+			fields, _ := err.GetMetadata()["fields"].(map[string]string)
+			if !strings.Contains(fields[tt.wantField], tt.wantSubstr) {
+				t.Errorf("Expected %q error containing %q, got fields: %v", tt.wantField, tt.wantSubstr, fields)
+			}
+		})
+	}
+}
@@ -1,36 +1,118 @@
 package contracts
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WorkspaceExpandAdmin is the GetWorkspace expand value that embeds the
+// workspace's admin user.
+const WorkspaceExpandAdmin = "admin"
 
 type (
 	CreateWorkspace struct {
-		Name        string    `json:"name" validate:"required,min=3,max=100"`
-		Description string    `json:"description" validate:"max=500"`
-		AdminID     uuid.UUID `json:"admin_id" validate:"required,uuid4"`
+		Name        string    `json:"name" validate:"required,min=3" content:"name"`
+		Description string    `json:"description" content:"description"`
+		AdminID     uuid.UUID `json:"admin_id" validate:"required,uuid"`
 	}
 
 	UpdateWorkspace struct {
-		ID          uuid.UUID `json:"id" validate:"required,uuid4"`
-		Name        string    `json:"name" validate:"omitempty,min=3,max=100"`
-		Description string    `json:"description" validate:"max=500"`
+		ID          uuid.UUID `json:"id" validate:"required,uuid"`
+		Name        string    `json:"name" validate:"omitempty,min=3" content:"name"`
+		Description string    `json:"description" content:"description"`
+		// AdminID, when set, hands the workspace to another of its users.
+		AdminID *uuid.UUID `json:"admin_id" validate:"omitempty,uuid"`
+		// Version, when set, must match the workspace's current version or
+		// the update is rejected with 409 instead of overwriting newer changes.
+		Version *int `json:"version" validate:"omitempty,min=1"`
 	}
 
 	GetWorkspace struct {
-		ID uuid.UUID `json:"id" validate:"required,uuid4"`
+		ID uuid.UUID `json:"id" validate:"required,uuid"`
+		// Expand names a related resource to embed in the response; only
+		// WorkspaceExpandAdmin is supported.
+		Expand string `json:"expand" validate:"omitempty,oneof=admin"`
+	}
+
+	// WorkspaceAdmin is the admin user embedded in a workspace fetched with
+	// expand=admin.
+	WorkspaceAdmin struct {
+		ID    uuid.UUID `json:"id"`
+		Name  string    `json:"name"`
+		Email string    `json:"email"`
+	}
+
+	// GetWorkspacesByIDs fetches several workspaces in one call.
+	GetWorkspacesByIDs struct {
+		IDs []uuid.UUID `json:"ids" validate:"required,min=1,max=100,dive,uuid"`
 	}
 
 	GetWorkspacesByAdmin struct {
-		AdminID uuid.UUID `json:"admin_id" validate:"required,uuid4"`
+		AdminID uuid.UUID `json:"admin_id" validate:"required,uuid"`
 	}
 
+	// ListWorkspaces is bound from query parameters; default tags give the
+	// value used when a parameter is omitted, so every bound is checked
+	// without omitempty and invalid values never reach the repository.
 	ListWorkspaces struct {
-		Limit  int    `json:"limit" validate:"omitempty,min=1,max=100"`
-		Offset int    `json:"offset" validate:"omitempty,min=0"`
-		SortBy string `json:"sort_by" query:"sort_by" validate:"omitempty,oneof=name created_at updated_at"`
-		Order  string `json:"order" validate:"omitempty,oneof=ASC DESC"`
+		Limit  int    `json:"limit" default:"50" validate:"gte=1,lte=100"`
+		Offset int    `json:"offset" default:"0" validate:"gte=0"`
+		SortBy string `json:"sort_by" query:"sort_by" default:"created_at" validate:"oneof=name created_at updated_at"`
+		Order  string `json:"order" default:"DESC" validate:"oneof=ASC DESC"`
+		// IncludeDeleted also lists soft-deleted workspaces; admins only.
+		IncludeDeleted bool `json:"include_deleted"`
+	}
+
+	// ListDeletedWorkspaces pages through soft-deleted workspaces, most
+	// recently deleted first.
+	ListDeletedWorkspaces struct {
+		Limit  int `json:"limit" validate:"omitempty,min=1,max=100"`
+		Offset int `json:"offset" validate:"omitempty,min=0"`
+	}
+
+	// PurgeReport asks which soft-deleted workspaces a purge would remove.
+	// OlderThanDays of 0 uses the purge job's retention window.
+	PurgeReport struct {
+		OlderThanDays int `json:"older_than_days" query:"older_than_days" validate:"gte=0,lte=3650"`
+	}
+
+	// PurgeReportResponse lists the purge candidates without deleting them.
+	PurgeReportResponse struct {
+		OlderThanDays  int         `json:"older_than_days"`
+		Cutoff         time.Time   `json:"cutoff"`
+		WorkspaceCount int         `json:"workspace_count"`
+		WorkspaceIDs   []uuid.UUID `json:"workspace_ids"`
+	}
+
+	RestoreWorkspace struct {
+		ID uuid.UUID `json:"id" validate:"required,uuid"`
 	}
 
 	DeleteWorkspace struct {
-		ID uuid.UUID `json:"id" validate:"required,uuid4"`
+		ID uuid.UUID `json:"id" validate:"required,uuid"`
+	}
+
+	RotateWorkspaceSecret struct {
+		ID uuid.UUID `json:"id" validate:"required,uuid"`
+	}
+
+	// WorkspaceSecretResponse carries a freshly rotated secret. It is the only
+	// time the plaintext is available; only its hash is stored.
+	WorkspaceSecretResponse struct {
+		WorkspaceID uuid.UUID `json:"workspace_id"`
+		Secret      string    `json:"secret"`
+		RotatedAt   time.Time `json:"rotated_at"`
+	}
+
+	RevokeWorkspaceSessions struct {
+		ID uuid.UUID `json:"id" validate:"required,uuid"`
+	}
+
+	// RevokeWorkspaceSessionsResponse reports the workspace's new token epoch;
+	// tokens issued before it, including the caller's, no longer validate.
+	RevokeWorkspaceSessionsResponse struct {
+		WorkspaceID uuid.UUID `json:"workspace_id"`
+		TokenEpoch  int64     `json:"token_epoch"`
 	}
 )
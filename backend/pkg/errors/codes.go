@@ -48,6 +48,31 @@ func (c Code) HTTPStatus() int {
 	}
 }
 
+// CodeFromHTTPStatus maps an HTTP status to the closest error code. It is the
+// inverse of HTTPStatus for the statuses HTTPStatus produces.
+func CodeFromHTTPStatus(status int) Code {
+	switch {
+	case status == http.StatusBadRequest:
+		return CodeInvalidInput
+	case status == http.StatusUnprocessableEntity:
+		return CodeValidation
+	case status == http.StatusUnauthorized:
+		return CodeUnauthorized
+	case status == http.StatusForbidden:
+		return CodeForbidden
+	case status == http.StatusNotFound, status == http.StatusGone:
+		return CodeNotFound
+	case status == http.StatusConflict:
+		return CodeConflict
+	case status >= 400 && status < 500:
+		return CodeInvalidInput
+	case status >= 500 && status < 600:
+		return CodeInternal
+	default:
+		return CodeUnknown
+	}
+}
+
 // String returns the string representation of the error code
 func (c Code) String() string {
 	return string(c)
@@ -1,11 +1,26 @@
 package errors
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"time"
+	"unicode/utf8"
+)
+
+const (
+	// MaxMetadataValueLength caps the length in bytes of string metadata values
+	// exposed through GetMetadata, MarshalJSON and LogValue. Longer values are
+	// cut and end in MetadataTruncationMarker. The error itself keeps the full value.
+	MaxMetadataValueLength = 1024
+
+	// MaxMetadataDepth caps how many levels of nested maps are exposed; deeper
+	// maps are replaced by MetadataTruncationMarker.
+	MaxMetadataDepth = 4
+
+	MetadataTruncationMarker = "…[truncated]"
 )
 
 // Error represents a rich error with metadata, severity, and optional stack trace
@@ -18,6 +33,9 @@ type Error struct {
 	timestamp  time.Time
 	stack      []uintptr
 	httpStatus int
+	// kind names a specific error within its code (e.g. "token_expired"
+	// under CodeUnauthorized). Is matches a target with a kind by kind.
+	kind string
 }
 
 // New creates a new error with the given message
@@ -57,6 +75,7 @@ func Wrap(err error, message string) *Error {
 		return &Error{
 			message:    message,
 			code:       appErr.code,
+			kind:       appErr.kind,
 			severity:   appErr.severity,
 			cause:      appErr,
 			metadata:   copyMetadata(appErr.metadata),
@@ -111,6 +130,23 @@ func WithCode(code Code, message string) *Error {
 	}
 }
 
+// NewSentinel creates a package-level sentinel error identified by kind.
+// Unlike plain errors, which match any error with the same code, a sentinel
+// only matches errors of the same kind (directly or anywhere in a wrapped
+// chain), so sentinels sharing a code stay distinguishable with errors.Is.
+func NewSentinel(code Code, kind, message string) *Error {
+	return WithCode(code, message).WithKind(kind)
+}
+
+// FromHTTPStatus translates an HTTP status received from an external service
+// (OAuth provider, webhook, ...) into an error. Code, severity and the status
+// we respond with are derived from the mapped code; the original status is
+// kept in the "upstream_status" metadata.
+func FromHTTPStatus(status int, message string) *Error {
+	return WithCode(CodeFromHTTPStatus(status), message).
+		WithMetadata(MetadataUpstreamStatus, status)
+}
+
 // WithCodef creates a new error with a specific error code and formatted message
 func WithCodef(code Code, format string, args ...interface{}) *Error {
 	return WithCode(code, fmt.Sprintf(format, args...))
@@ -129,12 +165,17 @@ func (e *Error) Unwrap() error {
 	return e.cause
 }
 
-// Is implements error comparison for errors.Is()
+// Is implements error comparison for errors.Is(). A target with a kind (see
+// NewSentinel) matches only errors of the same code and kind; any other
+// target matches by code.
 func (e *Error) Is(target error) bool {
 	t, ok := target.(*Error)
 	if !ok {
 		return false
 	}
+	if t.kind != "" {
+		return e.code == t.code && e.kind == t.kind
+	}
 	return e.code == t.code
 }
 
@@ -156,6 +197,19 @@ func (e *Error) WithMetadata(key string, value interface{}) *Error {
 	return e
 }
 
+// MergeMetadata merges m into the error's metadata. Unlike WithMetadata, when both
+// the existing and the new value for a key are maps they are merged recursively
+// (new entries win on conflicting keys) instead of the new map replacing the old one.
+func (e *Error) MergeMetadata(m map[string]interface{}) *Error {
+	if e.metadata == nil {
+		e.metadata = make(map[string]interface{}, len(m))
+	}
+	for k, v := range m {
+		e.metadata[k] = mergeValue(e.metadata[k], v)
+	}
+	return e
+}
+
 // WithHTTPStatus sets the HTTP status code
 func (e *Error) WithHTTPStatus(status int) *Error {
 	e.httpStatus = status
@@ -184,11 +238,22 @@ func (e *Error) WithCode(code Code) *Error {
 	return e
 }
 
+// WithKind sets the kind that distinguishes this error from others sharing its code
+func (e *Error) WithKind(kind string) *Error {
+	e.kind = kind
+	return e
+}
+
 // Code returns the error code
 func (e *Error) Code() Code {
 	return e.code
 }
 
+// Kind returns the error kind, or "" for errors identified by code alone
+func (e *Error) Kind() string {
+	return e.kind
+}
+
 // Severity returns the severity level
 func (e *Error) Severity() Severity {
 	return e.severity
@@ -199,9 +264,9 @@ func (e *Error) HTTPStatus() int {
 	return e.httpStatus
 }
 
-// GetMetadata returns a copy of the metadata
+// GetMetadata returns a copy of the metadata with oversized values truncated
 func (e *Error) GetMetadata() map[string]interface{} {
-	return copyMetadata(e.metadata)
+	return boundedMetadata(e.metadata)
 }
 
 // StackTrace returns the formatted stack trace
@@ -223,9 +288,9 @@ func (e *Error) LogValue() slog.Value {
 		slog.Time("timestamp", e.timestamp),
 	}
 
-	if len(e.metadata) > 0 {
-		metadataAttrs := make([]slog.Attr, 0, len(e.metadata))
-		for k, v := range e.metadata {
+	if metadata := publicMetadata(e.metadata); len(metadata) > 0 {
+		metadataAttrs := make([]slog.Attr, 0, len(metadata))
+		for k, v := range boundedMetadata(metadata) {
 			metadataAttrs = append(metadataAttrs, slog.Any(k, v))
 		}
 		attrs = append(attrs, slog.Any("metadata", slog.GroupValue(metadataAttrs...)))
@@ -253,6 +318,40 @@ func (e *Error) LogValue() slog.Value {
 	return slog.GroupValue(attrs...)
 }
 
+// errorJSON is the wire form of Error. Stack and cause are deliberately left
+// out so serialized errors never leak internals.
+type errorJSON struct {
+	Code     Code                   `json:"code"`
+	Message  string                 `json:"message"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler as {code, message, metadata}
+func (e *Error) MarshalJSON() ([]byte, error) {
+	return json.Marshal(errorJSON{
+		Code:     e.code,
+		Message:  e.message,
+		Metadata: boundedMetadata(publicMetadata(e.metadata)),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Severity and HTTP status are
+// derived from the code, as with WithCode.
+func (e *Error) UnmarshalJSON(data []byte) error {
+	var v errorJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	decoded := WithCode(v.Code, v.Message)
+	decoded.stack = nil // the decoding site says nothing about the error
+	if v.Metadata != nil {
+		decoded.metadata = v.Metadata
+	}
+	*e = *decoded
+	return nil
+}
+
 // copyMetadata creates a copy of the metadata map
 func copyMetadata(m map[string]interface{}) map[string]interface{} {
 	if m == nil {
@@ -265,6 +364,130 @@ func copyMetadata(m map[string]interface{}) map[string]interface{} {
 	return copy
 }
 
+// boundedMetadata copies m, truncating long strings and maps nested deeper
+// than MaxMetadataDepth so a single oversized value cannot bloat a response or
+// log line. Values of other types are passed through unchanged.
+// publicMetadata returns m without its InternalMetadata values.
+func publicMetadata(m map[string]interface{}) map[string]interface{} {
+	var public map[string]interface{}
+	for k, v := range m {
+		if _, ok := v.(InternalMetadata); ok {
+			continue
+		}
+		if public == nil {
+			public = make(map[string]interface{}, len(m))
+		}
+		public[k] = v
+	}
+	return public
+}
+
+func boundedMetadata(m map[string]interface{}) map[string]interface{} {
+	if m == nil {
+		return nil
+	}
+	bounded := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		bounded[k] = boundedValue(v, 1)
+	}
+	return bounded
+}
+
+func boundedValue(v interface{}, depth int) interface{} {
+	switch value := v.(type) {
+	case string:
+		return truncateMetadataString(value)
+	case []string:
+		bounded := make([]string, len(value))
+		for i, s := range value {
+			bounded[i] = truncateMetadataString(s)
+		}
+		return bounded
+	case map[string]string:
+		if depth >= MaxMetadataDepth {
+			return MetadataTruncationMarker
+		}
+		bounded := make(map[string]string, len(value))
+		for k, s := range value {
+			bounded[k] = truncateMetadataString(s)
+		}
+		return bounded
+	case map[string]interface{}:
+		if depth >= MaxMetadataDepth {
+			return MetadataTruncationMarker
+		}
+		bounded := make(map[string]interface{}, len(value))
+		for k, nested := range value {
+			bounded[k] = boundedValue(nested, depth+1)
+		}
+		return bounded
+	default:
+		return v
+	}
+}
+
+// truncateMetadataString cuts s to MaxMetadataValueLength bytes on a rune
+// boundary and appends MetadataTruncationMarker.
+func truncateMetadataString(s string) string {
+	if len(s) <= MaxMetadataValueLength {
+		return s
+	}
+	cut := MaxMetadataValueLength
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + MetadataTruncationMarker
+}
+
+// mergeValue deep-merges src into dst when both are maps, without mutating either.
+// map[string]string pairs stay map[string]string; any other combination of maps
+// yields map[string]interface{}. Non-map values are replaced by src.
+func mergeValue(dst, src interface{}) interface{} {
+	if dstStrings, ok := dst.(map[string]string); ok {
+		if srcStrings, ok := src.(map[string]string); ok {
+			merged := make(map[string]string, len(dstStrings)+len(srcStrings))
+			for k, v := range dstStrings {
+				merged[k] = v
+			}
+			for k, v := range srcStrings {
+				merged[k] = v
+			}
+			return merged
+		}
+	}
+
+	dstMap, dstOK := asGenericMap(dst)
+	srcMap, srcOK := asGenericMap(src)
+	if !dstOK || !srcOK {
+		return src
+	}
+
+	merged := make(map[string]interface{}, len(dstMap)+len(srcMap))
+	for k, v := range dstMap {
+		merged[k] = v
+	}
+	for k, v := range srcMap {
+		merged[k] = mergeValue(merged[k], v)
+	}
+	return merged
+}
+
+// asGenericMap views the supported metadata map types as map[string]interface{}
+func asGenericMap(v interface{}) (map[string]interface{}, bool) {
+	switch m := v.(type) {
+	case map[string]interface{}:
+		return m, true
+	case map[string]string:
+		generic := make(map[string]interface{}, len(m))
+		for k, val := range m {
+			generic[k] = val
+		}
+		return generic, true
+	default:
+		return nil, false
+	}
+}
+
 // IsNotFound checks if an error is a not found error
 func IsNotFound(err error) bool {
 	var appErr *Error
@@ -0,0 +1,330 @@
+package errors
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestMergeMetadata_MergesFieldsMaps(t *testing.T) {
+	err := WithCode(CodeValidation, "validation failed").
+		WithMetadata("fields", map[string]string{"name": "name is required"})
+
+	err.MergeMetadata(map[string]interface{}{
+		"fields": map[string]string{"email": "email is taken"},
+	})
+
+	fields, ok := err.GetMetadata()["fields"].(map[string]string)
+	if !ok {
+		t.Fatalf("expected fields to stay map[string]string, got %T", err.GetMetadata()["fields"])
+	}
+	if fields["name"] != "name is required" {
+		t.Errorf("expected original name entry preserved, got %q", fields["name"])
+	}
+	if fields["email"] != "email is taken" {
+		t.Errorf("expected merged email entry, got %q", fields["email"])
+	}
+}
+
+func TestMergeMetadata_NewValueWinsOnConflict(t *testing.T) {
+	err := New("boom").
+		WithMetadata("fields", map[string]string{"name": "old"})
+
+	err.MergeMetadata(map[string]interface{}{
+		"fields": map[string]string{"name": "new"},
+	})
+
+	fields := err.GetMetadata()["fields"].(map[string]string)
+	if fields["name"] != "new" {
+		t.Errorf("expected conflicting key to take new value, got %q", fields["name"])
+	}
+}
+
+func TestMergeMetadata_DeepMergesNestedMaps(t *testing.T) {
+	err := New("boom").WithMetadata("details", map[string]interface{}{
+		"fields": map[string]string{"name": "name is required"},
+		"source": "validator",
+	})
+
+	err.MergeMetadata(map[string]interface{}{
+		"details": map[string]interface{}{
+			"fields": map[string]interface{}{"email": "email is taken"},
+		},
+	})
+
+	details, ok := err.GetMetadata()["details"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected details map, got %T", err.GetMetadata()["details"])
+	}
+	if details["source"] != "validator" {
+		t.Errorf("expected sibling key preserved, got %v", details["source"])
+	}
+	fields, ok := details["fields"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected nested fields map, got %T", details["fields"])
+	}
+	if fields["name"] != "name is required" || fields["email"] != "email is taken" {
+		t.Errorf("expected both nested entries, got %v", fields)
+	}
+}
+
+func TestMergeMetadata_DoesNotMutateInputs(t *testing.T) {
+	original := map[string]string{"name": "name is required"}
+	incoming := map[string]string{"email": "email is taken"}
+
+	err := New("boom").WithMetadata("fields", original)
+	err.MergeMetadata(map[string]interface{}{"fields": incoming})
+
+	if len(original) != 1 || len(incoming) != 1 {
+		t.Errorf("expected input maps untouched, got original=%v incoming=%v", original, incoming)
+	}
+}
+
+func TestMergeMetadata_NonMapValuesReplaced(t *testing.T) {
+	err := New("boom").WithMetadata("operation", "create_user")
+
+	err.MergeMetadata(map[string]interface{}{"operation": "update_user"})
+
+	if got := err.GetMetadata()["operation"]; got != "update_user" {
+		t.Errorf("expected scalar to be replaced, got %v", got)
+	}
+}
+
+func TestIs_SentinelMatchesOnlyItself(t *testing.T) {
+	errA := NewSentinel(CodeUnauthorized, "kind_a", "a")
+	errB := NewSentinel(CodeUnauthorized, "kind_b", "b")
+
+	if !errors.Is(errA, errA) {
+		t.Error("expected sentinel to match itself")
+	}
+	if errors.Is(errA, errB) {
+		t.Error("expected sentinels sharing a code not to match each other")
+	}
+	if errors.Is(WithCode(CodeUnauthorized, "a"), errA) {
+		t.Error("expected a plain error with the same code not to match a sentinel")
+	}
+}
+
+func TestIs_SentinelThroughWrapChain(t *testing.T) {
+	errA := NewSentinel(CodeUnauthorized, "kind_a", "a")
+	errB := NewSentinel(CodeUnauthorized, "kind_b", "b")
+
+	wrapped := Wrap(Wrap(errA, "service"), "handler")
+	if !errors.Is(wrapped, errA) {
+		t.Error("expected doubly wrapped sentinel to match")
+	}
+	if errors.Is(wrapped, errB) {
+		t.Error("expected wrapped sentinel not to match a different sentinel")
+	}
+}
+
+func TestIs_NonSentinelTargetMatchesByCode(t *testing.T) {
+	sentinel := NewSentinel(CodeUnauthorized, "kind_a", "a")
+
+	if !errors.Is(sentinel, WithCode(CodeUnauthorized, "any")) {
+		t.Error("expected code-only target to match a sentinel with the same code")
+	}
+	if errors.Is(sentinel, WithCode(CodeForbidden, "any")) {
+		t.Error("expected code-only target with a different code not to match")
+	}
+}
+
+func TestIs_KindMatchesAcrossInstances(t *testing.T) {
+	sentinel := NewSentinel(CodeUnauthorized, "kind_a", "a")
+
+	if !errors.Is(WithCode(CodeUnauthorized, "another message").WithKind("kind_a"), sentinel) {
+		t.Error("expected a separately constructed error of the same kind to match")
+	}
+	if errors.Is(WithCode(CodeForbidden, "a").WithKind("kind_a"), sentinel) {
+		t.Error("expected same kind under a different code not to match")
+	}
+	if got := Wrap(sentinel, "wrapped").Kind(); got != "kind_a" {
+		t.Errorf("expected Wrap to preserve kind, got %q", got)
+	}
+}
+
+func TestFromHTTPStatus(t *testing.T) {
+	tests := []struct {
+		status       int
+		wantCode     Code
+		wantStatus   int
+		wantSeverity Severity
+	}{
+		{http.StatusBadRequest, CodeInvalidInput, http.StatusBadRequest, SeverityWarning},
+		{http.StatusUnprocessableEntity, CodeValidation, http.StatusBadRequest, SeverityWarning},
+		{http.StatusUnauthorized, CodeUnauthorized, http.StatusUnauthorized, SeverityWarning},
+		{http.StatusForbidden, CodeForbidden, http.StatusForbidden, SeverityWarning},
+		{http.StatusNotFound, CodeNotFound, http.StatusNotFound, SeverityWarning},
+		{http.StatusGone, CodeNotFound, http.StatusNotFound, SeverityWarning},
+		{http.StatusConflict, CodeConflict, http.StatusConflict, SeverityWarning},
+		{http.StatusTooManyRequests, CodeInvalidInput, http.StatusBadRequest, SeverityWarning},
+		{http.StatusInternalServerError, CodeInternal, http.StatusInternalServerError, SeverityError},
+		{http.StatusBadGateway, CodeInternal, http.StatusInternalServerError, SeverityError},
+		{http.StatusOK, CodeUnknown, http.StatusInternalServerError, SeverityError},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			err := FromHTTPStatus(tt.status, "upstream failed")
+
+			if err.Code() != tt.wantCode {
+				t.Errorf("Code() = %v, want %v", err.Code(), tt.wantCode)
+			}
+			if err.HTTPStatus() != tt.wantStatus {
+				t.Errorf("HTTPStatus() = %d, want %d", err.HTTPStatus(), tt.wantStatus)
+			}
+			if err.Severity() != tt.wantSeverity {
+				t.Errorf("Severity() = %v, want %v", err.Severity(), tt.wantSeverity)
+			}
+			if got := err.GetMetadata()["upstream_status"]; got != tt.status {
+				t.Errorf("upstream_status = %v, want %d", got, tt.status)
+			}
+		})
+	}
+}
+
+func TestCodeFromHTTPStatus_InvertsHTTPStatus(t *testing.T) {
+	for _, code := range []Code{CodeInvalidInput, CodeUnauthorized, CodeForbidden, CodeNotFound, CodeConflict, CodeInternal} {
+		if got := CodeFromHTTPStatus(code.HTTPStatus()); got != code {
+			t.Errorf("CodeFromHTTPStatus(%d) = %v, want %v", code.HTTPStatus(), got, code)
+		}
+	}
+}
+
+func TestMarshalJSON_RoundTrip(t *testing.T) {
+	original := Wrap(WithCode(CodeNotFound, "user not found"), "load profile").
+		WithMetadata("entity", "User").
+		WithMetadata("id", "42")
+
+	data, err := json.Marshal(original)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	var decoded Error
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	if decoded.Code() != CodeNotFound {
+		t.Errorf("Code() = %v, want %v", decoded.Code(), CodeNotFound)
+	}
+	if decoded.Error() != "load profile" {
+		t.Errorf("Error() = %q, want %q", decoded.Error(), "load profile")
+	}
+	if decoded.HTTPStatus() != http.StatusNotFound {
+		t.Errorf("HTTPStatus() = %d, want %d", decoded.HTTPStatus(), http.StatusNotFound)
+	}
+	metadata := decoded.GetMetadata()
+	if metadata["entity"] != "User" || metadata["id"] != "42" {
+		t.Errorf("unexpected metadata after round trip: %v", metadata)
+	}
+	if !errors.Is(&decoded, original) {
+		t.Error("expected decoded error to match the original by code")
+	}
+}
+
+func TestMarshalJSON_OmitsCauseAndStack(t *testing.T) {
+	appErr := Wrap(errors.New("password=hunter2"), "login failed").WithSeverity(SeverityCritical)
+
+	data, err := json.Marshal(appErr)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	for key := range fields {
+		if key != "code" && key != "message" && key != "metadata" {
+			t.Errorf("unexpected key %q in %s", key, data)
+		}
+	}
+	if strings.Contains(string(data), "hunter2") {
+		t.Errorf("expected cause to be omitted, got %s", data)
+	}
+}
+
+func TestGetMetadata_TruncatesOversizedValues(t *testing.T) {
+	huge := strings.Repeat("x", MaxMetadataValueLength*4)
+	err := WithCode(CodeDatabase, "query failed").
+		WithMetadata("detail", huge).
+		WithMetadata("fields", map[string]string{"name": huge}).
+		WithMetadata("short", "kept as is")
+
+	metadata := err.GetMetadata()
+
+	detail := metadata["detail"].(string)
+	if !strings.HasSuffix(detail, MetadataTruncationMarker) {
+		t.Errorf("expected truncated value to end with %q", MetadataTruncationMarker)
+	}
+	if want := MaxMetadataValueLength + len(MetadataTruncationMarker); len(detail) != want {
+		t.Errorf("expected truncated length %d, got %d", want, len(detail))
+	}
+	if fields := metadata["fields"].(map[string]string); !strings.HasSuffix(fields["name"], MetadataTruncationMarker) {
+		t.Error("expected nested field message to be truncated")
+	}
+	if metadata["short"] != "kept as is" {
+		t.Errorf("expected short value untouched, got %v", metadata["short"])
+	}
+
+	data, marshalErr := json.Marshal(err)
+	if marshalErr != nil {
+		t.Fatalf("marshal failed: %v", marshalErr)
+	}
+	if strings.Contains(string(data), huge) {
+		t.Error("expected serialized error to carry the truncated value")
+	}
+}
+
+func TestGetMetadata_CapsNestingDepth(t *testing.T) {
+	nested := map[string]interface{}{"leaf": "value"}
+	for range MaxMetadataDepth {
+		nested = map[string]interface{}{"next": nested}
+	}
+	err := New("deep").WithMetadata("tree", nested)
+
+	var v interface{} = err.GetMetadata()["tree"]
+	for depth := 1; depth < MaxMetadataDepth; depth++ {
+		v = v.(map[string]interface{})["next"]
+	}
+	if v != MetadataTruncationMarker {
+		t.Errorf("expected maps deeper than %d levels to be replaced by the marker, got %v", MaxMetadataDepth, v)
+	}
+}
+
+func TestFormatKey(t *testing.T) {
+	tests := []struct {
+		key     string
+		keyCase KeyCase
+		want    string
+	}{
+		{"entity_type", SnakeCase, "entity_type"},
+		{"entity_type", CamelCase, "entityType"},
+		{"current_version", CamelCase, "currentVersion"},
+		{"reason", CamelCase, "reason"},
+		{"field_workspace_id", CamelCase, "fieldWorkspaceId"},
+	}
+
+	for _, tt := range tests {
+		if got := formatKey(tt.key, tt.keyCase); got != tt.want {
+			t.Errorf("formatKey(%q, %d) = %q, want %q", tt.key, tt.keyCase, got, tt.want)
+		}
+	}
+}
+
+func TestFormatMetadata_UsesConfiguredCase(t *testing.T) {
+	metadata := map[string]interface{}{MetadataEntityType: "Workspace", MetadataFields: map[string]string{"admin_id": "required"}}
+
+	formatted := FormatMetadata(metadata)
+	if _, ok := formatted[FormatMetadataKey(MetadataEntityType)]; !ok {
+		t.Errorf("expected key %q, got %v", FormatMetadataKey(MetadataEntityType), formatted)
+	}
+	fields, _ := formatted[FormatMetadataKey(MetadataFields)].(map[string]string)
+	if _, ok := fields["admin_id"]; !ok {
+		t.Errorf("expected nested field names to be left as is, got %v", fields)
+	}
+}
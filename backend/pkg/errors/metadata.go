@@ -0,0 +1,95 @@
+package errors
+
+import "strings"
+
+// KeyCase is a naming convention for error metadata keys
+type KeyCase int
+
+const (
+	// SnakeCase renders keys as entity_type
+	SnakeCase KeyCase = iota
+	// CamelCase renders keys as entityType
+	CamelCase
+)
+
+// MetadataKeyCase is the convention every metadata key sent to clients
+// follows, whichever layer attached it. Keys are always written in snake_case
+// (use the constants below) and converted when the response is rendered.
+const MetadataKeyCase = SnakeCase
+
+// Metadata keys shared across error sources
+const (
+	// MetadataReason is a machine-readable cause clients can branch on
+	MetadataReason = "reason"
+	// MetadataFields maps request fields to their validation messages
+	MetadataFields = "fields"
+	// MetadataField names the single field an error is about
+	MetadataField = "field"
+	// MetadataValue is the offending value of MetadataField
+	MetadataValue = "value"
+	// MetadataEntityType names the kind of resource involved
+	MetadataEntityType = "entity_type"
+	// MetadataEntityID identifies the resource involved
+	MetadataEntityID = "entity_id"
+	// MetadataCurrentVersion is the stored version on an optimistic-lock conflict
+	MetadataCurrentVersion = "current_version"
+	// MetadataUpdatedAt is when the conflicting resource was last written
+	MetadataUpdatedAt = "updated_at"
+	// MetadataClaim names the token claim that failed to parse
+	MetadataClaim = "claim"
+	// MetadataResource and MetadataAction describe a forbidden operation
+	MetadataResource = "resource"
+	MetadataAction   = "action"
+	// MetadataOperation names the repository operation a database error came from
+	MetadataOperation = "operation"
+	// MetadataSQLiteCode is SQLite's extended result code for a database error
+	MetadataSQLiteCode = "sqlite_code"
+	// MetadataConstraint names the violated database constraint
+	MetadataConstraint = "constraint"
+	// MetadataUpstreamStatus is the HTTP status returned by an external service
+	MetadataUpstreamStatus = "upstream_status"
+	// MetadataKey names the JSON key a malformed request body repeats
+	MetadataKey = "key"
+	// MetadataPanic is the value a recovered panic was raised with. It is
+	// logged with the error but never sent to clients.
+	MetadataPanic = "panic"
+)
+
+// InternalMetadata is implemented by metadata values kept for the server's
+// own use, such as raw validation violations re-rendered per request locale.
+// MarshalJSON and LogValue leave them out; GetMetadata still returns them.
+type InternalMetadata interface {
+	InternalMetadata()
+}
+
+// FormatMetadataKey converts a snake_case key to MetadataKeyCase
+func FormatMetadataKey(key string) string {
+	return formatKey(key, MetadataKeyCase)
+}
+
+// FormatMetadata returns metadata with its top-level keys converted to
+// MetadataKeyCase. Nested values such as the field map are left as is: their
+// keys are request field names, not metadata keys.
+func FormatMetadata(metadata map[string]interface{}) map[string]interface{} {
+	if MetadataKeyCase == SnakeCase || metadata == nil {
+		return metadata
+	}
+	formatted := make(map[string]interface{}, len(metadata))
+	for key, value := range metadata {
+		formatted[FormatMetadataKey(key)] = value
+	}
+	return formatted
+}
+
+func formatKey(key string, keyCase KeyCase) string {
+	if keyCase != CamelCase {
+		return key
+	}
+	parts := strings.Split(key, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
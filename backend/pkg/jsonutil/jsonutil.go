@@ -0,0 +1,226 @@
+// Package jsonutil provides the JSON decoder used for request bodies.
+package jsonutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+var uuidType = reflect.TypeOf(uuid.UUID{})
+
+// DuplicateKeyError reports an object in the body that sets the same key
+// twice. encoding/json would silently keep the last value.
+type DuplicateKeyError struct {
+	// Key is the path of the repeated key, e.g. "name" or "variables[1].key".
+	Key string
+}
+
+func (e *DuplicateKeyError) Error() string {
+	return fmt.Sprintf("duplicate key %q in JSON body", e.Key)
+}
+
+// Unmarshal decodes data into v like encoding/json, but rejects objects that
+// repeat a key with a *DuplicateKeyError and tolerates noisy UUIDs in fields
+// typed as uuid.UUID: surrounding whitespace, embedded quotes, braces and
+// uppercase are normalized before decoding. Values that still do not parse as a
+// UUID are left untouched so the original decode error is returned.
+func Unmarshal(data []byte, v interface{}) error {
+	if err := checkDuplicateKeys(data); err != nil {
+		return err
+	}
+
+	err := json.Unmarshal(data, v)
+	if err == nil {
+		return nil
+	}
+
+	// Only retry when the target has UUID fields that normalization could fix
+	target := reflect.TypeOf(v)
+	if target == nil || !containsUUID(target, map[reflect.Type]bool{}) {
+		return err
+	}
+
+	var raw interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if decodeErr := decoder.Decode(&raw); decodeErr != nil {
+		return err
+	}
+
+	normalized, marshalErr := json.Marshal(normalizeValue(raw, target))
+	if marshalErr != nil {
+		return err
+	}
+
+	if retryErr := json.Unmarshal(normalized, v); retryErr != nil {
+		return err
+	}
+	return nil
+}
+
+// checkDuplicateKeys walks data and returns a *DuplicateKeyError for the first
+// object that repeats a key. Keys are compared exactly. Malformed JSON is left
+// for the decoder to report.
+func checkDuplicateKeys(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var duplicate *DuplicateKeyError
+	if err := walkKeys(decoder, "", &duplicate); err != nil || duplicate == nil {
+		return nil
+	}
+	return duplicate
+}
+
+// walkKeys consumes one JSON value from decoder, recording in duplicate the
+// first repeated key found under path.
+func walkKeys(decoder *json.Decoder, path string, duplicate **DuplicateKeyError) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	delim, ok := token.(json.Delim)
+	if !ok {
+		return nil
+	}
+
+	switch delim {
+	case '{':
+		seen := make(map[string]bool)
+		for decoder.More() {
+			token, err := decoder.Token()
+			if err != nil {
+				return err
+			}
+			key, _ := token.(string)
+			keyPath := key
+			if path != "" {
+				keyPath = path + "." + key
+			}
+			if seen[key] {
+				*duplicate = &DuplicateKeyError{Key: keyPath}
+				return nil
+			}
+			seen[key] = true
+			if err := walkKeys(decoder, keyPath, duplicate); err != nil || *duplicate != nil {
+				return err
+			}
+		}
+	case '[':
+		for i := 0; decoder.More(); i++ {
+			if err := walkKeys(decoder, fmt.Sprintf("%s[%d]", path, i), duplicate); err != nil || *duplicate != nil {
+				return err
+			}
+		}
+	}
+	_, err = decoder.Token()
+	return err
+}
+
+// NormalizeUUID trims whitespace, surrounding quotes and braces from s and
+// parses the result as a UUID
+func NormalizeUUID(s string) (uuid.UUID, error) {
+	s = strings.TrimSpace(s)
+	s = strings.TrimSpace(strings.Trim(s, `"'`))
+	if strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}") {
+		s = strings.TrimSpace(s[1 : len(s)-1])
+	}
+	return uuid.Parse(strings.ToLower(s))
+}
+
+// normalizeValue walks raw JSON alongside the Go type it will be decoded into,
+// rewriting strings destined for uuid.UUID fields into canonical form
+func normalizeValue(raw interface{}, t reflect.Type) interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == uuidType {
+		s, ok := raw.(string)
+		if !ok {
+			return raw
+		}
+		id, err := NormalizeUUID(s)
+		if err != nil {
+			return raw
+		}
+		return id.String()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		obj, ok := raw.(map[string]interface{})
+		if !ok {
+			return raw
+		}
+		for key, value := range obj {
+			if field, ok := fieldForKey(t, key); ok {
+				obj[key] = normalizeValue(value, field.Type)
+			}
+		}
+		return obj
+	case reflect.Slice, reflect.Array:
+		items, ok := raw.([]interface{})
+		if !ok {
+			return raw
+		}
+		for i, item := range items {
+			items[i] = normalizeValue(item, t.Elem())
+		}
+		return items
+	default:
+		return raw
+	}
+}
+
+// fieldForKey finds the struct field a JSON key decodes into, matching
+// encoding/json's case-insensitive fallback
+func fieldForKey(t reflect.Type, key string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if strings.EqualFold(name, key) {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// containsUUID reports whether t (or anything it contains) is a uuid.UUID
+func containsUUID(t reflect.Type, seen map[reflect.Type]bool) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == uuidType {
+		return true
+	}
+	if seen[t] {
+		return false
+	}
+	seen[t] = true
+
+	switch t.Kind() {
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if containsUUID(t.Field(i).Type, seen) {
+				return true
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		return containsUUID(t.Elem(), seen)
+	}
+	return false
+}
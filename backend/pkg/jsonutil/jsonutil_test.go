@@ -0,0 +1,128 @@
+package jsonutil
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+type uuidRequest struct {
+	Name        string      `json:"name"`
+	WorkspaceID uuid.UUID   `json:"workspace_id"`
+	UserIDs     []uuid.UUID `json:"user_ids"`
+}
+
+func TestUnmarshal_NoisyUUIDsAccepted(t *testing.T) {
+	id := uuid.MustParse("3f2504e0-4f89-41d3-9a0c-0305e82c3301")
+
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{"canonical", `"3f2504e0-4f89-41d3-9a0c-0305e82c3301"`},
+		{"uppercase", `"3F2504E0-4F89-41D3-9A0C-0305E82C3301"`},
+		{"braced", `"{3f2504e0-4f89-41d3-9a0c-0305e82c3301}"`},
+		{"whitespace padded", `"  3f2504e0-4f89-41d3-9a0c-0305e82c3301\t"`},
+		{"embedded quotes", `"\"3f2504e0-4f89-41d3-9a0c-0305e82c3301\""`},
+		{"braced uppercase padded", `" {3F2504E0-4F89-41D3-9A0C-0305E82C3301} "`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := `{"name":"  keep  ","workspace_id":` + tt.value + `,"user_ids":[` + tt.value + `]}`
+
+			var req uuidRequest
+			if err := Unmarshal([]byte(body), &req); err != nil {
+				t.Fatalf("expected no error, got: %v", err)
+			}
+			if req.WorkspaceID != id {
+				t.Errorf("expected workspace_id %s, got %s", id, req.WorkspaceID)
+			}
+			if len(req.UserIDs) != 1 || req.UserIDs[0] != id {
+				t.Errorf("expected user_ids [%s], got %v", id, req.UserIDs)
+			}
+			if req.Name != "  keep  " {
+				t.Errorf("expected non-UUID fields untouched, got %q", req.Name)
+			}
+		})
+	}
+}
+
+func TestUnmarshal_GarbageUUIDsRejected(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"garbage string", `{"workspace_id":"not-a-uuid"}`},
+		{"too short", `{"workspace_id":"3f2504e0-4f89-41d3"}`},
+		{"number", `{"workspace_id":12345}`},
+		{"garbage in slice", `{"user_ids":["3f2504e0-4f89-41d3-9a0c-0305e82c3301","nope"]}`},
+		{"unbalanced brace", `{"workspace_id":"{3f2504e0-4f89-41d3-9a0c-0305e82c3301"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var req uuidRequest
+			if err := Unmarshal([]byte(tt.body), &req); err == nil {
+				t.Errorf("expected error for body %s", tt.body)
+			}
+		})
+	}
+}
+
+func TestNormalizeUUID(t *testing.T) {
+	if _, err := NormalizeUUID("  {3F2504E0-4F89-41D3-9A0C-0305E82C3301}  "); err != nil {
+		t.Errorf("expected noisy UUID to normalize, got: %v", err)
+	}
+	if _, err := NormalizeUUID(""); err == nil {
+		t.Error("expected empty string to be rejected")
+	}
+}
+
+func TestUnmarshal_DuplicateKeysRejected(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		key  string
+	}{
+		{"top level", `{"name":"a","name":"b"}`, "name"},
+		{"nested object", `{"meta":{"owner":"a","owner":"b"}}`, "meta.owner"},
+		{"object in array", `{"items":[{"key":"a"},{"key":"b","key":"c"}]}`, "items[1].key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var req map[string]interface{}
+			err := Unmarshal([]byte(tt.body), &req)
+			var duplicate *DuplicateKeyError
+			if !errors.As(err, &duplicate) {
+				t.Fatalf("expected a DuplicateKeyError, got %v", err)
+			}
+			if duplicate.Key != tt.key {
+				t.Errorf("expected duplicate key %q, got %q", tt.key, duplicate.Key)
+			}
+		})
+	}
+}
+
+func TestUnmarshal_DistinctKeysAccepted(t *testing.T) {
+	body := `{"name":"a","workspace_id":"3f2504e0-4f89-41d3-9a0c-0305e82c3301","user_ids":[],"meta":{"name":"nested"}}`
+
+	var req uuidRequest
+	if err := Unmarshal([]byte(body), &req); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if req.Name != "a" {
+		t.Errorf("expected name %q, got %q", "a", req.Name)
+	}
+}
+
+func TestUnmarshal_MalformedBodyKeepsDecodeError(t *testing.T) {
+	var req uuidRequest
+	err := Unmarshal([]byte(`{"name":"a",`), &req)
+	var duplicate *DuplicateKeyError
+	if err == nil || errors.As(err, &duplicate) {
+		t.Errorf("expected a decode error, got %v", err)
+	}
+}
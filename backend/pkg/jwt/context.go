@@ -12,8 +12,8 @@ func WithClaims(ctx context.Context, claims *Claims) context.Context {
 }
 
 // ClaimsFromContext extracts JWT claims stored by WithClaims.
-// Returns (nil, false) if no claims are present.
+// Returns (nil, false) if no claims are present, including a nil *Claims.
 func ClaimsFromContext(ctx context.Context) (*Claims, bool) {
 	claims, ok := ctx.Value(claimsContextKey).(*Claims)
-	return claims, ok
+	return claims, ok && claims != nil
 }
@@ -15,17 +15,35 @@ const (
 
 	// DefaultTokenDuration is the default expiration time for tokens (24 hours)
 	DefaultTokenDuration = 24 * time.Hour
+
+	// DefaultClockSkew is how far exp, nbf and iat may be off before a token
+	// is rejected, absorbing small clock differences between hosts
+	DefaultClockSkew = 30 * time.Second
+
+	// ScopeRead limits a token to read-only (GET) requests
+	ScopeRead = "read"
+
+	// ScopeWrite allows every request the token's role permits
+	ScopeWrite = "write"
 )
 
 var (
-	// ErrInvalidToken is returned when the token is invalid
-	ErrInvalidToken = errors.WithCode(errors.CodeUnauthorized, "invalid token")
+	// ErrInvalidToken is returned when the token is invalid. Its "reason"
+	// metadata tells clients to sign in again.
+	ErrInvalidToken = errors.NewSentinel(errors.CodeUnauthorized, "invalid_token", "invalid token").
+			WithMetadata(errors.MetadataReason, "invalid_token")
 
-	// ErrExpiredToken is returned when the token has expired
-	ErrExpiredToken = errors.WithCode(errors.CodeUnauthorized, "token has expired")
+	// ErrExpiredToken is returned when the token has expired. Its "reason"
+	// metadata tells clients a refresh is enough.
+	ErrExpiredToken = errors.NewSentinel(errors.CodeUnauthorized, "token_expired", "token has expired").
+			WithMetadata(errors.MetadataReason, "token_expired")
+
+	// ErrRevokedToken is returned when a token predates its user's current token epoch
+	ErrRevokedToken = errors.NewSentinel(errors.CodeUnauthorized, "token_revoked", "token has been revoked")
 
 	// ErrInvalidSigningMethod is returned when the signing method is not expected
-	ErrInvalidSigningMethod = errors.WithCode(errors.CodeUnauthorized, "invalid signing method")
+	ErrInvalidSigningMethod = errors.NewSentinel(errors.CodeUnauthorized, "invalid_signing_method", "invalid signing method").
+				WithMetadata(errors.MetadataReason, "invalid_token")
 
 	// ErrMissingSecret is returned when JWT_SECRET environment variable is not set
 	ErrMissingSecret = errors.WithCode(errors.CodeInternal, "JWT_SECRET environment variable is not set")
@@ -40,12 +58,33 @@ type Claims struct {
 	Name        string `json:"name"`
 	Role        string `json:"role"`
 	WorkspaceID string `json:"workspace_id"`
+	// Epoch is the user's token epoch at issue time
+	Epoch int64 `json:"epoch,omitempty"`
+	// WorkspaceEpoch is the workspace's token epoch at issue time
+	WorkspaceEpoch int64 `json:"wepoch,omitempty"`
+	// Scope is ScopeRead or ScopeWrite. Tokens issued before scopes existed
+	// carry none and are treated as ScopeWrite.
+	Scope string `json:"scope,omitempty"`
+	// ViaAPIKey marks claims derived from an API key rather than a signed
+	// token. It is never serialized, so a token cannot claim it.
+	ViaAPIKey bool `json:"-"`
 	jwtlib.RegisteredClaims
 }
 
+// HasScope reports whether the claims grant scope. ScopeWrite includes ScopeRead.
+func (c *Claims) HasScope(scope string) bool {
+	switch c.Scope {
+	case "", ScopeWrite:
+		return true
+	default:
+		return c.Scope == scope
+	}
+}
+
 // Service handles JWT token operations
 type Service struct {
-	secret []byte
+	secret    []byte
+	clockSkew time.Duration
 }
 
 // NewService creates a new JWT service with the provided secret.
@@ -59,13 +98,42 @@ func NewService(secret string) (*Service, error) {
 	}
 
 	return &Service{
-		secret: []byte(secret),
+		secret:    []byte(secret),
+		clockSkew: DefaultClockSkew,
 	}, nil
 }
 
+// WithClockSkew sets the leeway applied to exp, nbf and iat when validating.
+// A negative skew is treated as zero.
+func (s *Service) WithClockSkew(skew time.Duration) *Service {
+	s.clockSkew = max(skew, 0)
+	return s
+}
+
 // GenerateToken creates a new JWT token with the provided claims
 // Returns the signed token string or an error if token generation fails
 func (s *Service) GenerateToken(id, name, role, workspaceID string) (string, error) {
+	return s.GenerateTokenWithEpoch(id, name, role, workspaceID, 0)
+}
+
+// GenerateTokenWithEpoch is like GenerateToken but records the user's current
+// token epoch, so the token stops validating once the epoch is bumped.
+func (s *Service) GenerateTokenWithEpoch(id, name, role, workspaceID string, epoch int64) (string, error) {
+	return s.GenerateScopedToken(id, name, role, workspaceID, epoch, ScopeWrite)
+}
+
+// TokenOption sets optional claims on a generated token.
+type TokenOption func(*Claims)
+
+// WithWorkspaceEpoch records the workspace's current token epoch, so the token
+// stops validating once the workspace's sessions are revoked.
+func WithWorkspaceEpoch(epoch int64) TokenOption {
+	return func(c *Claims) { c.WorkspaceEpoch = epoch }
+}
+
+// GenerateScopedToken is like GenerateTokenWithEpoch but limits the token to
+// scope, e.g. ScopeRead for integrations that only need read access.
+func (s *Service) GenerateScopedToken(id, name, role, workspaceID string, epoch int64, scope string, opts ...TokenOption) (string, error) {
 	now := time.Now()
 
 	claims := Claims{
@@ -73,12 +141,17 @@ func (s *Service) GenerateToken(id, name, role, workspaceID string) (string, err
 		Name:        name,
 		Role:        role,
 		WorkspaceID: workspaceID,
+		Epoch:       epoch,
+		Scope:       scope,
 		RegisteredClaims: jwtlib.RegisteredClaims{
 			ExpiresAt: jwtlib.NewNumericDate(now.Add(DefaultTokenDuration)),
 			IssuedAt:  jwtlib.NewNumericDate(now),
 			NotBefore: jwtlib.NewNumericDate(now),
 		},
 	}
+	for _, opt := range opts {
+		opt(&claims)
+	}
 
 	token := jwtlib.NewWithClaims(jwtlib.SigningMethodHS256, claims)
 	tokenString, err := token.SignedString(s.secret)
@@ -90,7 +163,8 @@ func (s *Service) GenerateToken(id, name, role, workspaceID string) (string, err
 }
 
 // ValidateToken validates and parses a JWT token, returning the claims
-// Returns an error if the token is invalid, expired, or uses an incorrect signing method
+// Returns an error if the token is invalid, expired, or uses an incorrect signing method.
+// exp, nbf and iat are checked with the service's clock skew as leeway.
 func (s *Service) ValidateToken(tokenString string) (*Claims, error) {
 	token, err := jwtlib.ParseWithClaims(tokenString, &Claims{}, func(token *jwtlib.Token) (interface{}, error) {
 		// Verify the signing method to prevent algorithm substitution attacks
@@ -99,13 +173,16 @@ func (s *Service) ValidateToken(tokenString string) (*Claims, error) {
 			return nil, ErrInvalidSigningMethod
 		}
 		return s.secret, nil
-	})
+	}, jwtlib.WithLeeway(s.clockSkew), jwtlib.WithIssuedAt())
 
 	if err != nil {
 		// Check if the error is due to token expiration
 		if stderrors.Is(err, jwtlib.ErrTokenExpired) {
 			return nil, ErrExpiredToken
 		}
+		if stderrors.Is(err, ErrInvalidSigningMethod) {
+			return nil, ErrInvalidSigningMethod
+		}
 		return nil, ErrInvalidToken
 	}
 
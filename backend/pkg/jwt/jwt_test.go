@@ -1,6 +1,7 @@
 package jwt
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -320,15 +321,8 @@ func TestValidateToken(t *testing.T) {
 					t.Error("ValidateToken() expected error, got nil")
 					return
 				}
-				if tt.expectedErr != nil {
-					var appErr *apperrors.Error
-					if !errors.As(err, &appErr) {
-						t.Errorf("ValidateToken() error is not *apperrors.Error: %v", err)
-						return
-					}
-					if appErr.Code() != tt.expectedErr.Code() {
-						t.Errorf("ValidateToken() error code = %v, want %v", appErr.Code(), tt.expectedErr.Code())
-					}
+				if tt.expectedErr != nil && !errors.Is(err, tt.expectedErr) {
+					t.Errorf("ValidateToken() error = %v, want %v", err, tt.expectedErr)
 				}
 				return
 			}
@@ -384,6 +378,65 @@ func TestValidateToken_TokenTampering(t *testing.T) {
 	}
 }
 
+func TestValidateToken_ExpiredIsDistinctFromInvalid(t *testing.T) {
+	service, err := NewService(testSecret)
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+
+	expiredClaims := Claims{
+		ID: testUserID,
+		RegisteredClaims: jwtlib.RegisteredClaims{
+			ExpiresAt: jwtlib.NewNumericDate(time.Now().Add(-time.Hour)),
+		},
+	}
+	expiredTokenString, _ := jwtlib.NewWithClaims(jwtlib.SigningMethodHS256, expiredClaims).SignedString([]byte(testSecret))
+
+	_, err = service.ValidateToken(expiredTokenString)
+	if !errors.Is(err, ErrExpiredToken) {
+		t.Errorf("expected expired token error to match ErrExpiredToken, got %v", err)
+	}
+	if errors.Is(err, ErrInvalidToken) {
+		t.Error("expected expired token error not to match ErrInvalidToken")
+	}
+
+	_, err = service.ValidateToken("not-a-token")
+	if !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("expected malformed token error to match ErrInvalidToken, got %v", err)
+	}
+	if errors.Is(err, ErrExpiredToken) {
+		t.Error("expected malformed token error not to match ErrExpiredToken")
+	}
+
+	// Wrapping across layers keeps the sentinel reachable through the chain.
+	wrapped := apperrors.Wrap(ErrExpiredToken, "authenticate request")
+	if !errors.Is(wrapped, ErrExpiredToken) {
+		t.Error("expected wrapped error to match ErrExpiredToken")
+	}
+	if errors.Is(wrapped, ErrInvalidToken) {
+		t.Error("expected wrapped error not to match ErrInvalidToken")
+	}
+}
+
+func TestSentinels_MatchOnlyThemselves(t *testing.T) {
+	sentinels := map[string]*apperrors.Error{
+		"ErrInvalidToken":         ErrInvalidToken,
+		"ErrExpiredToken":         ErrExpiredToken,
+		"ErrInvalidSigningMethod": ErrInvalidSigningMethod,
+	}
+
+	for name, err := range sentinels {
+		for targetName, target := range sentinels {
+			if got, want := errors.Is(err, target), name == targetName; got != want {
+				t.Errorf("errors.Is(%s, %s) = %v, want %v", name, targetName, got, want)
+			}
+		}
+		if !errors.Is(err, apperrors.WithCode(apperrors.CodeUnauthorized, "any")) {
+			t.Errorf("expected %s to still match a code-only CodeUnauthorized target", name)
+		}
+	}
+}
+
 func TestService_MultipleTokens(t *testing.T) {
 	service, err := NewService(testSecret)
 	if err != nil {
@@ -424,3 +477,131 @@ func TestService_MultipleTokens(t *testing.T) {
 		t.Errorf("Expected 5 unique tokens, got %d", len(tokens))
 	}
 }
+
+func TestClaimsFromContext_NilClaims(t *testing.T) {
+	if _, ok := ClaimsFromContext(context.Background()); ok {
+		t.Error("expected no claims in an empty context")
+	}
+
+	ctx := WithClaims(context.Background(), nil)
+	if claims, ok := ClaimsFromContext(ctx); ok || claims != nil {
+		t.Errorf("expected a nil *Claims to be reported as absent, got (%v, %v)", claims, ok)
+	}
+
+	ctx = WithClaims(context.Background(), &Claims{ID: testUserID})
+	if claims, ok := ClaimsFromContext(ctx); !ok || claims.ID != testUserID {
+		t.Errorf("expected stored claims, got (%v, %v)", claims, ok)
+	}
+}
+
+func TestGenerateTokenWithEpoch(t *testing.T) {
+	service, err := NewService(testSecret)
+	if err != nil {
+		t.Fatalf("failed to create service: %v", err)
+	}
+
+	token, err := service.GenerateTokenWithEpoch(testUserID, testUserName, "user", testWorkspaceID, 3)
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+
+	claims, err := service.ValidateToken(token)
+	if err != nil {
+		t.Fatalf("failed to validate token: %v", err)
+	}
+	if claims.Epoch != 3 {
+		t.Errorf("expected epoch 3, got %d", claims.Epoch)
+	}
+	if claims.ViaAPIKey {
+		t.Error("a signed token must never carry ViaAPIKey")
+	}
+}
+
+func TestValidateToken_ClockSkew(t *testing.T) {
+	service, err := NewService(testSecret)
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+	service.WithClockSkew(30 * time.Second)
+
+	sign := func(claims jwtlib.RegisteredClaims) string {
+		t.Helper()
+		token, err := jwtlib.NewWithClaims(jwtlib.SigningMethodHS256, Claims{ID: testUserID, RegisteredClaims: claims}).
+			SignedString([]byte(testSecret))
+		if err != nil {
+			t.Fatalf("failed to sign token: %v", err)
+		}
+		return token
+	}
+	at := func(offset time.Duration) *jwtlib.NumericDate {
+		return jwtlib.NewNumericDate(time.Now().Add(offset))
+	}
+
+	tests := []struct {
+		name    string
+		claims  jwtlib.RegisteredClaims
+		wantErr error
+	}{
+		{
+			name:   "expired within leeway",
+			claims: jwtlib.RegisteredClaims{ExpiresAt: at(-5 * time.Second), IssuedAt: at(-time.Hour)},
+		},
+		{
+			name:    "expired beyond leeway",
+			claims:  jwtlib.RegisteredClaims{ExpiresAt: at(-time.Minute), IssuedAt: at(-time.Hour)},
+			wantErr: ErrExpiredToken,
+		},
+		{
+			name:   "not yet valid within leeway",
+			claims: jwtlib.RegisteredClaims{ExpiresAt: at(time.Hour), NotBefore: at(5 * time.Second)},
+		},
+		{
+			name:    "not yet valid beyond leeway",
+			claims:  jwtlib.RegisteredClaims{ExpiresAt: at(time.Hour), NotBefore: at(time.Minute)},
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:   "issued in the future within leeway",
+			claims: jwtlib.RegisteredClaims{ExpiresAt: at(time.Hour), IssuedAt: at(5 * time.Second)},
+		},
+		{
+			name:    "issued in the future beyond leeway",
+			claims:  jwtlib.RegisteredClaims{ExpiresAt: at(time.Hour), IssuedAt: at(time.Minute)},
+			wantErr: ErrInvalidToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.ValidateToken(sign(tt.claims))
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Errorf("expected token to validate within the leeway, got %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateToken_ZeroClockSkew(t *testing.T) {
+	service, err := NewService(testSecret)
+	if err != nil {
+		t.Fatalf("Failed to create service: %v", err)
+	}
+	service.WithClockSkew(0)
+
+	token, _ := jwtlib.NewWithClaims(jwtlib.SigningMethodHS256, Claims{
+		ID: testUserID,
+		RegisteredClaims: jwtlib.RegisteredClaims{
+			ExpiresAt: jwtlib.NewNumericDate(time.Now().Add(-5 * time.Second)),
+		},
+	}).SignedString([]byte(testSecret))
+
+	if _, err := service.ValidateToken(token); !errors.Is(err, ErrExpiredToken) {
+		t.Errorf("expected a token expired 5s ago to fail without leeway, got %v", err)
+	}
+}
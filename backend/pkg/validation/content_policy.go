@@ -0,0 +1,116 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Default content limits, in characters, enforced by a Service created
+// without WithContentPolicy.
+const (
+	MinNameLength        = 2
+	MaxNameLength        = 100
+	MaxDescriptionLength = 500
+)
+
+// contentTag marks a string field as a name or a description, so its length
+// is checked against the content policy instead of hard-coded tags.
+const (
+	contentTag         = "content"
+	contentName        = "name"
+	contentDescription = "description"
+)
+
+// ContentPolicy bounds the length, in characters, of user, workspace, API key,
+// group, template and environment names and of workspace, group, environment
+// and template variable descriptions. Fields needing more than the policy's
+// minimum keep their own min tag.
+type ContentPolicy struct {
+	NameMinLength        int
+	NameMaxLength        int
+	DescriptionMaxLength int
+}
+
+func DefaultContentPolicy() ContentPolicy {
+	return ContentPolicy{
+		NameMinLength:        MinNameLength,
+		NameMaxLength:        MaxNameLength,
+		DescriptionMaxLength: MaxDescriptionLength,
+	}
+}
+
+// Validate rejects limits that no input could satisfy.
+func (p ContentPolicy) Validate() error {
+	if p.NameMinLength < 1 {
+		return fmt.Errorf("name minimum length must be at least 1, got %d", p.NameMinLength)
+	}
+	if p.NameMaxLength < p.NameMinLength {
+		return fmt.Errorf("name maximum length %d is below the minimum %d", p.NameMaxLength, p.NameMinLength)
+	}
+	if p.DescriptionMaxLength < 0 {
+		return fmt.Errorf("description maximum length must not be negative, got %d", p.DescriptionMaxLength)
+	}
+	return nil
+}
+
+// WithContentPolicy makes the Service enforce p on names and descriptions
+// instead of DefaultContentPolicy. p must pass Validate.
+func WithContentPolicy(p ContentPolicy) Option {
+	return func(s *Service) { s.contentPolicy = p }
+}
+
+// ContentPolicy returns the policy the Service enforces.
+func (s *Service) ContentPolicy() ContentPolicy {
+	return s.contentPolicy
+}
+
+// ValidateContentFields checks the fields tagged content:"name" or
+// content:"description" against the policy. Empty values are skipped; their
+// presence is up to the required tag. Register it with RegisterStructValidation
+// for every struct carrying content tags.
+func (s *Service) ValidateContentFields(sl validator.StructLevel) {
+	policy := s.contentPolicy
+	current := sl.Current()
+	for i := 0; i < current.NumField(); i++ {
+		field := current.Type().Field(i)
+		kind := field.Tag.Get(contentTag)
+		if kind == "" {
+			continue
+		}
+
+		value := current.Field(i)
+		if value.Kind() == reflect.Pointer {
+			if value.IsNil() {
+				continue
+			}
+			value = value.Elem()
+		}
+		text := value.String()
+		if text == "" {
+			continue
+		}
+
+		length := utf8.RuneCountInString(text)
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "" {
+			name = field.Name
+		}
+		switch kind {
+		case contentName:
+			if length < policy.NameMinLength {
+				sl.ReportError(text, name, field.Name, "min", strconv.Itoa(policy.NameMinLength))
+			} else if length > policy.NameMaxLength {
+				sl.ReportError(text, name, field.Name, "max", strconv.Itoa(policy.NameMaxLength))
+			}
+		case contentDescription:
+			if length > policy.DescriptionMaxLength {
+				sl.ReportError(text, name, field.Name, "max", strconv.Itoa(policy.DescriptionMaxLength))
+			}
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	"backend/pkg/contracts"
+
+	"github.com/google/uuid"
+)
+
+func newContentValidator(t *testing.T, opts ...Option) *Service {
+	t.Helper()
+	validator := New(opts...)
+	if err := validator.RegisterDefaultCustomValidations(); err != nil {
+		t.Fatalf("Failed to register custom validations: %v", err)
+	}
+	contracts.RegisterValidations(validator)
+	return validator
+}
+
+func TestContentPolicy_TightenedRejectsDefaultAccepted(t *testing.T) {
+	validator := newContentValidator(t)
+	workspace := contracts.CreateWorkspace{
+		Name:        strings.Repeat("w", 40),
+		Description: strings.Repeat("d", 200),
+		AdminID:     uuid.New(),
+	}
+	user := contracts.CreateLocalUser{
+		Name:        "Al",
+		Email:       "al@example.com",
+		Password:    "SecurePass123!",
+		WorkspaceID: uuid.New(),
+	}
+	if err := validator.Validate(workspace); err != nil {
+		t.Fatalf("Expected the default policy to accept the workspace, got: %v", err)
+	}
+	if err := validator.Validate(user); err != nil {
+		t.Fatalf("Expected the default policy to accept the user, got: %v", err)
+	}
+
+	validator = newContentValidator(t, WithContentPolicy(ContentPolicy{NameMinLength: 3, NameMaxLength: 30, DescriptionMaxLength: 100}))
+
+	err := validator.Validate(workspace)
+	if err == nil {
+		t.Fatal("Expected the tightened policy to reject the workspace")
+	}
+	fields := err.GetMetadata()["fields"].(map[string]string)
+	if fields["name"] != "name must be at most 30 characters" {
+		t.Errorf("Unexpected name message %q", fields["name"])
+	}
+	if fields["description"] != "description must be at most 100 characters" {
+		t.Errorf("Unexpected description message %q", fields["description"])
+	}
+
+	err = validator.Validate(user)
+	if err == nil {
+		t.Fatal("Expected the tightened policy to reject a two-character name")
+	}
+	if got := err.GetMetadata()["fields"].(map[string]string)["name"]; got != "name must be at least 3 characters" {
+		t.Errorf("Unexpected name message %q", got)
+	}
+}
+
+func TestContentPolicy_LoosenedAcceptsLonger(t *testing.T) {
+	validator := newContentValidator(t)
+	description := strings.Repeat("d", MaxDescriptionLength+100)
+	group := contracts.UpdateGroup{ID: uuid.New(), Description: &description}
+	key := contracts.CreateAPIKey{
+		WorkspaceID: uuid.New(),
+		Name:        strings.Repeat("k", MaxNameLength+50),
+		Role:        "user",
+	}
+	if err := validator.Validate(group); err == nil {
+		t.Fatal("Expected the default policy to reject the long description")
+	}
+	if err := validator.Validate(key); err == nil {
+		t.Fatal("Expected the default policy to reject the long name")
+	}
+
+	validator = newContentValidator(t, WithContentPolicy(ContentPolicy{NameMinLength: 2, NameMaxLength: 200, DescriptionMaxLength: 1000}))
+
+	if err := validator.Validate(group); err != nil {
+		t.Errorf("Expected the loosened policy to accept the long description, got: %v", err)
+	}
+	if err := validator.Validate(key); err != nil {
+		t.Errorf("Expected the loosened policy to accept the long name, got: %v", err)
+	}
+}
+
+func TestContentPolicy_ServicesKeepTheirOwnPolicy(t *testing.T) {
+	strict := newContentValidator(t, WithContentPolicy(ContentPolicy{NameMinLength: 2, NameMaxLength: 10, DescriptionMaxLength: 10}))
+	lenient := newContentValidator(t)
+
+	group := contracts.CreateGroup{Name: strings.Repeat("g", 20)}
+	if err := strict.Validate(group); err == nil {
+		t.Error("Expected the strict service to reject the group name")
+	}
+	if err := lenient.Validate(group); err != nil {
+		t.Errorf("Expected a default service to be unaffected by another's policy, got: %v", err)
+	}
+	if lenient.ContentPolicy() != DefaultContentPolicy() {
+		t.Errorf("Expected the default policy, got %+v", lenient.ContentPolicy())
+	}
+}
+
+func TestContentPolicy_CoversTemplatesAndEnvironments(t *testing.T) {
+	validator := newContentValidator(t, WithContentPolicy(ContentPolicy{NameMinLength: 2, NameMaxLength: 10, DescriptionMaxLength: 5}))
+	long := strings.Repeat("x", 11)
+	description := "too long"
+
+	tests := []struct {
+		name  string
+		data  interface{}
+		field string
+	}{
+		{"template name", contracts.CreateTemplate{Name: long, WorkspaceID: uuid.New()}, "Name"},
+		{"template rename", contracts.UpdateTemplate{ID: uuid.New(), Name: long}, "Name"},
+		{"environment name", contracts.CreateEnvironment{Name: long, TemplateID: uuid.New()}, "name"},
+		{"environment description", contracts.CreateEnvironment{Name: "Env", Description: description, TemplateID: uuid.New()}, "description"},
+		{"group name", contracts.CreateGroup{Name: long}, "name"},
+		{"group rename", contracts.UpdateGroup{ID: uuid.New(), Name: long}, "name"},
+		{"variable description", contracts.CreateTemplateVariable{TemplateID: uuid.New(), Key: "region", Description: description}, "description"},
+		{"variable description update", contracts.UpdateTemplateVariable{ID: uuid.New(), Description: &description}, "description"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.Validate(tt.data)
+			if err == nil {
+				t.Fatal("Expected the content policy to reject the request")
+			}
+			if _, ok := err.GetMetadata()["fields"].(map[string]string)[tt.field]; !ok {
+				t.Errorf("Expected an error on %s, got %v", tt.field, err.GetMetadata()["fields"])
+			}
+		})
+	}
+}
+
+func TestContentPolicy_RejectsImpossibleLimits(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy ContentPolicy
+	}{
+		{"zero minimum", ContentPolicy{NameMinLength: 0, NameMaxLength: 10}},
+		{"maximum below minimum", ContentPolicy{NameMinLength: 5, NameMaxLength: 4}},
+		{"negative description", ContentPolicy{NameMinLength: 2, NameMaxLength: 10, DescriptionMaxLength: -1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.policy.Validate(); err == nil {
+				t.Error("Expected the policy to be rejected")
+			}
+		})
+	}
+}
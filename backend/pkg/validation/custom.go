@@ -1,24 +1,16 @@
 package validation
 
 import (
+	"net/url"
 	"path/filepath"
-	"regexp"
 	"strings"
-	"unicode"
 
 	"github.com/go-playground/validator/v10"
 )
 
-var (
-	hasUpperRegex   = regexp.MustCompile(`[A-Z]`)
-	hasLowerRegex   = regexp.MustCompile(`[a-z]`)
-	hasDigitRegex   = regexp.MustCompile(`\d`)
-	hasSpecialRegex = regexp.MustCompile(`[@$!%*?&]`)
-)
-
 // RegisterDefaultCustomValidations registers all default custom validators
 func (s *Service) RegisterDefaultCustomValidations() error {
-	if err := s.RegisterCustomValidation("strongpassword", validateStrongPassword); err != nil {
+	if err := s.registerPasswordValidations(); err != nil {
 		return err
 	}
 
@@ -26,56 +18,45 @@ func (s *Service) RegisterDefaultCustomValidations() error {
 		return err
 	}
 
+	if err := s.RegisterCustomValidation("httpurl", validateHTTPURL); err != nil {
+		return err
+	}
+
 	return nil
 }
 
-// validateFilePath validates that a file path is safe (no traversal, no backslash, no absolute paths)
+// maxFilePathLength bounds paths accepted by the filepath validator
+const maxFilePathLength = 1024
+
+// validateFilePath validates that a file path is safe to resolve under a storage root:
+// bounded length, no traversal, no backslash, no NUL bytes, no drive letters and no absolute paths
 func validateFilePath(fl validator.FieldLevel) bool {
 	path := fl.Field().String()
-	if strings.Contains(path, "..") || strings.Contains(path, "\\") {
+	if len(path) > maxFilePathLength {
+		return false
+	}
+	if strings.Contains(path, "..") || strings.ContainsAny(path, "\\\x00") {
+		return false
+	}
+	// Reject Windows volume names (e.g. "C:") regardless of the host OS
+	if len(path) >= 2 && path[1] == ':' {
 		return false
 	}
 	cleaned := filepath.Clean(path)
 	return !filepath.IsAbs(cleaned)
 }
 
-// validateStrongPassword validates that a password meets strong password requirements
-// Password must contain:
-// - At least one uppercase letter
-// - At least one lowercase letter
-// - At least one digit
-// - At least one special character (@$!%*?&)
-func validateStrongPassword(fl validator.FieldLevel) bool {
-	password := fl.Field().String()
-
-	// Minimum length check (should also use min tag, but double-check here)
-	if len(password) < 8 {
-		return false
+// validateHTTPURL validates that a value is an absolute http or https URL with a host.
+// An empty value passes, so an optional *string can be set to "" to clear it;
+// presence is up to the required tag.
+func validateHTTPURL(fl validator.FieldLevel) bool {
+	value := fl.Field().String()
+	if value == "" {
+		return true
 	}
-
-	// Check for required character types
-	hasUpper := false
-	hasLower := false
-	hasDigit := false
-	hasSpecial := false
-
-	for _, char := range password {
-		switch {
-		case unicode.IsUpper(char):
-			hasUpper = true
-		case unicode.IsLower(char):
-			hasLower = true
-		case unicode.IsDigit(char):
-			hasDigit = true
-		case char == '@' || char == '$' || char == '!' || char == '%' || char == '*' || char == '?' || char == '&':
-			hasSpecial = true
-		}
-
-		// Early exit if all requirements are met
-		if hasUpper && hasLower && hasDigit && hasSpecial {
-			return true
-		}
+	u, err := url.Parse(value)
+	if err != nil {
+		return false
 	}
-
-	return hasUpper && hasLower && hasDigit && hasSpecial
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
 }
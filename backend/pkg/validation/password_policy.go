@@ -0,0 +1,117 @@
+package validation
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// DefaultPasswordMinLength is the shortest password, in characters, accepted
+// until SetPasswordPolicy is called.
+const DefaultPasswordMinLength = 8
+
+// passwordSpecialChars are the characters that satisfy the special character
+// requirement.
+const passwordSpecialChars = "@$!%*?&"
+
+// Tags for the password rules strongpassword expands to.
+const (
+	tagPasswordUpper   = "passwordupper"
+	tagPasswordLower   = "passwordlower"
+	tagPasswordDigit   = "passworddigit"
+	tagPasswordSpecial = "passwordspecial"
+)
+
+// PasswordPolicy sets the minimum password length, in characters, and which
+// character classes a password must contain. Each requirement is independent,
+// so a policy may ask for length alone.
+type PasswordPolicy struct {
+	MinLength      int
+	RequireUpper   bool
+	RequireLower   bool
+	RequireDigit   bool
+	RequireSpecial bool
+}
+
+func DefaultPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{
+		MinLength:      DefaultPasswordMinLength,
+		RequireUpper:   true,
+		RequireLower:   true,
+		RequireDigit:   true,
+		RequireSpecial: true,
+	}
+}
+
+// Validate rejects a policy that would accept an empty password.
+func (p PasswordPolicy) Validate() error {
+	if p.MinLength < 1 {
+		return fmt.Errorf("password minimum length must be at least 1, got %d", p.MinLength)
+	}
+	return nil
+}
+
+// rules returns the validation tags enforcing the policy.
+func (p PasswordPolicy) rules() string {
+	rules := []string{"min=" + strconv.Itoa(p.MinLength)}
+	if p.RequireUpper {
+		rules = append(rules, tagPasswordUpper)
+	}
+	if p.RequireLower {
+		rules = append(rules, tagPasswordLower)
+	}
+	if p.RequireDigit {
+		rules = append(rules, tagPasswordDigit)
+	}
+	if p.RequireSpecial {
+		rules = append(rules, tagPasswordSpecial)
+	}
+	return strings.Join(rules, ",")
+}
+
+var passwordPolicy = DefaultPasswordPolicy()
+
+// SetPasswordPolicy replaces the policy enforced by the strongpassword tag.
+// The tag is expanded when custom validations are registered, so this must be
+// called before RegisterDefaultCustomValidations.
+func SetPasswordPolicy(p PasswordPolicy) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	passwordPolicy = p
+	return nil
+}
+
+// CurrentPasswordPolicy returns the policy in force.
+func CurrentPasswordPolicy() PasswordPolicy {
+	return passwordPolicy
+}
+
+// registerPasswordValidations registers the character class rules and
+// expands strongpassword to the current policy's rules, so a failure reports
+// the rule that was broken and, for length, the configured minimum.
+func (s *Service) registerPasswordValidations() error {
+	classes := map[string]func(rune) bool{
+		tagPasswordUpper:   unicode.IsUpper,
+		tagPasswordLower:   unicode.IsLower,
+		tagPasswordDigit:   unicode.IsDigit,
+		tagPasswordSpecial: func(r rune) bool { return strings.ContainsRune(passwordSpecialChars, r) },
+	}
+	for tag, class := range classes {
+		if err := s.RegisterCustomValidation(tag, containsClass(class)); err != nil {
+			return err
+		}
+	}
+	s.RegisterAlias("strongpassword", CurrentPasswordPolicy().rules())
+	return nil
+}
+
+// containsClass validates that a string has at least one rune in class.
+func containsClass(class func(rune) bool) validator.Func {
+	return func(fl validator.FieldLevel) bool {
+		return strings.IndexFunc(fl.Field().String(), class) >= 0
+	}
+}
@@ -0,0 +1,99 @@
+package validation
+
+import (
+	"testing"
+
+	"backend/pkg/contracts"
+
+	"github.com/google/uuid"
+)
+
+// usePasswordPolicy sets p for the rest of the test. Validators built
+// afterwards enforce it.
+func usePasswordPolicy(t *testing.T, p PasswordPolicy) {
+	t.Helper()
+	previous := CurrentPasswordPolicy()
+	if err := SetPasswordPolicy(p); err != nil {
+		t.Fatalf("SetPasswordPolicy failed: %v", err)
+	}
+	t.Cleanup(func() { passwordPolicy = previous })
+}
+
+func passwordFieldError(t *testing.T, validator *Service, password string) string {
+	t.Helper()
+	err := validator.Validate(contracts.CreateLocalUser{
+		Name:        "Pat",
+		Email:       "pat@example.com",
+		Password:    password,
+		WorkspaceID: uuid.New(),
+	})
+	if err == nil {
+		return ""
+	}
+	return err.GetMetadata()["fields"].(map[string]string)["password"]
+}
+
+func TestPasswordPolicy_LengthOnly(t *testing.T) {
+	usePasswordPolicy(t, PasswordPolicy{MinLength: 12})
+	validator := newContentValidator(t)
+
+	tests := []struct {
+		name     string
+		password string
+		want     string
+	}{
+		{"long lowercase only", "correcthorsebattery", ""},
+		{"exactly the minimum", "aaaaaaaaaaaa", ""},
+		{"digits only", "123456789012", ""},
+		{"short despite every class", "Sh0rt!Pass", "password must be at least 12 characters"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := passwordFieldError(t, validator, tt.password); got != tt.want {
+				t.Errorf("want %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestPasswordPolicy_EachClassIsIndependent(t *testing.T) {
+	usePasswordPolicy(t, PasswordPolicy{MinLength: 8, RequireDigit: true})
+	validator := newContentValidator(t)
+
+	if got := passwordFieldError(t, validator, "lowercase1"); got != "" {
+		t.Errorf("Expected a digit-only requirement to accept a lowercase password with a digit, got %q", got)
+	}
+	if got := passwordFieldError(t, validator, "NoDigitsHere!"); got != "password must contain at least one number" {
+		t.Errorf("Unexpected message %q", got)
+	}
+}
+
+func TestPasswordPolicy_DefaultRequiresEveryClass(t *testing.T) {
+	validator := newContentValidator(t)
+
+	tests := []struct {
+		password string
+		want     string
+	}{
+		{"SecurePass123!", ""},
+		{"Sec1!", "password must be at least 8 characters"},
+		{"securepass123!", "password must contain at least one uppercase letter"},
+		{"SECUREPASS123!", "password must contain at least one lowercase letter"},
+		{"SecurePass!!", "password must contain at least one number"},
+		{"SecurePass123", "password must contain at least one special character (@$!%*?&)"},
+	}
+	for _, tt := range tests {
+		if got := passwordFieldError(t, validator, tt.password); got != tt.want {
+			t.Errorf("%q: want %q, got %q", tt.password, tt.want, got)
+		}
+	}
+}
+
+func TestPasswordPolicy_RejectsZeroMinimum(t *testing.T) {
+	if err := SetPasswordPolicy(PasswordPolicy{MinLength: 0, RequireUpper: true}); err == nil {
+		t.Fatal("Expected a zero minimum length to be rejected")
+	}
+	if CurrentPasswordPolicy() != DefaultPasswordPolicy() {
+		t.Error("Expected a rejected policy to leave the current one in place")
+	}
+}
@@ -0,0 +1,184 @@
+package validation
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// DefaultLocale is used when a request does not ask for a supported language
+const DefaultLocale = "en"
+
+// MetadataViolationsKey is the error metadata key holding the raw FieldViolations
+// for a validation error, so field messages can be re-rendered per request locale
+const MetadataViolationsKey = "violations"
+
+// FieldViolation is a locale-independent description of a failed validation rule
+type FieldViolation struct {
+	Field string       `json:"field"`
+	Tag   string       `json:"tag"`
+	Param string       `json:"param,omitempty"`
+	Kind  reflect.Kind `json:"kind"`
+}
+
+// FieldViolations are the violations of one validation error. They are
+// internal metadata: serialized and logged errors carry the rendered fields.
+type FieldViolations []FieldViolation
+
+func (FieldViolations) InternalMetadata() {}
+
+// messageTemplates maps locale -> tag -> message template.
+// Templates may reference {field} and {param}. Tags with a "_string" suffix
+// are used instead of the bare tag when the field is a string.
+var messageTemplates = map[string]map[string]string{
+	"en": {
+		"required":        "{field} is required",
+		"email":           "{field} must be a valid email address",
+		"min_string":      "{field} must be at least {param} characters",
+		"min":             "{field} must be at least {param}",
+		"max_string":      "{field} must be at most {param} characters",
+		"max":             "{field} must be at most {param}",
+		"len":             "{field} must be exactly {param} characters",
+		"uuid":            "{field} must be a valid UUID",
+		"uuid4":           "{field} must be a valid UUID v4",
+		"oneof":           "{field} must be one of: {param}",
+		"gt":              "{field} must be greater than {param}",
+		"gte":             "{field} must be greater than or equal to {param}",
+		"lt":              "{field} must be less than {param}",
+		"lte":             "{field} must be less than or equal to {param}",
+		"eq":              "{field} must equal {param}",
+		"ne":              "{field} must not equal {param}",
+		"filepath":        "{field} contains an invalid file path",
+		"httpurl":         "{field} must be a valid http or https URL",
+		"datetime":        "{field} must be a timestamp in the format {param}",
+		"passwordupper":   "{field} must contain at least one uppercase letter",
+		"passwordlower":   "{field} must contain at least one lowercase letter",
+		"passworddigit":   "{field} must contain at least one number",
+		"passwordspecial": "{field} must contain at least one special character (@$!%*?&)",
+		"authexclusive":   "{field} cannot be combined with oauth credentials",
+		"default":         "{field} failed validation: {tag}",
+	},
+	"es": {
+		"required":        "{field} es obligatorio",
+		"email":           "{field} debe ser una dirección de correo electrónico válida",
+		"min_string":      "{field} debe tener al menos {param} caracteres",
+		"min":             "{field} debe ser al menos {param}",
+		"max_string":      "{field} debe tener como máximo {param} caracteres",
+		"max":             "{field} debe ser como máximo {param}",
+		"len":             "{field} debe tener exactamente {param} caracteres",
+		"uuid":            "{field} debe ser un UUID válido",
+		"uuid4":           "{field} debe ser un UUID v4 válido",
+		"oneof":           "{field} debe ser uno de: {param}",
+		"gt":              "{field} debe ser mayor que {param}",
+		"gte":             "{field} debe ser mayor o igual que {param}",
+		"lt":              "{field} debe ser menor que {param}",
+		"lte":             "{field} debe ser menor o igual que {param}",
+		"eq":              "{field} debe ser igual a {param}",
+		"ne":              "{field} no debe ser igual a {param}",
+		"filepath":        "{field} contiene una ruta de archivo no válida",
+		"httpurl":         "{field} debe ser una URL http o https válida",
+		"datetime":        "{field} debe ser una marca de tiempo con el formato {param}",
+		"passwordupper":   "{field} debe contener al menos una letra mayúscula",
+		"passwordlower":   "{field} debe contener al menos una letra minúscula",
+		"passworddigit":   "{field} debe contener al menos un número",
+		"passwordspecial": "{field} debe contener al menos un carácter especial (@$!%*?&)",
+		"authexclusive":   "{field} no se puede combinar con credenciales oauth",
+		"default":         "{field} no superó la validación: {tag}",
+	},
+}
+
+// SupportedLocales returns the locales that have message translations
+func SupportedLocales() []string {
+	locales := make([]string, 0, len(messageTemplates))
+	for locale := range messageTemplates {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// newFieldViolation captures the parts of a validator.FieldError needed for translation
+func newFieldViolation(fe validator.FieldError) FieldViolation {
+	return FieldViolation{
+		Field: fe.Field(),
+		Tag:   fe.ActualTag(),
+		Param: fe.Param(),
+		Kind:  fe.Kind(),
+	}
+}
+
+// Translate renders a violation as a human-readable message in the given locale,
+// falling back to DefaultLocale for unknown locales
+func Translate(locale string, v FieldViolation) string {
+	templates, ok := messageTemplates[locale]
+	if !ok {
+		templates = messageTemplates[DefaultLocale]
+	}
+
+	tmpl, ok := "", false
+	if v.Kind == reflect.String {
+		tmpl, ok = templates[v.Tag+"_string"]
+	}
+	if !ok {
+		tmpl, ok = templates[v.Tag]
+	}
+	if !ok {
+		tmpl = templates["default"]
+	}
+
+	return strings.NewReplacer(
+		"{field}", v.Field,
+		"{param}", v.Param,
+		"{tag}", v.Tag,
+	).Replace(tmpl)
+}
+
+// LocalizeFields renders a field -> message map for the given locale
+func LocalizeFields(violations FieldViolations, locale string) map[string]string {
+	fields := make(map[string]string, len(violations))
+	for _, v := range violations {
+		fields[v.Field] = Translate(locale, v)
+	}
+	return fields
+}
+
+// ParseAcceptLanguage picks the best supported locale from an Accept-Language
+// header value, honouring q-weights. Returns DefaultLocale when nothing matches.
+func ParseAcceptLanguage(header string) string {
+	best := DefaultLocale
+	bestQ := -1.0
+
+	for _, part := range strings.Split(header, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		tag, q := part, 1.0
+		if idx := strings.Index(part, ";"); idx != -1 {
+			tag = strings.TrimSpace(part[:idx])
+			param := strings.TrimSpace(part[idx+1:])
+			if strings.HasPrefix(param, "q=") {
+				parsed, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64)
+				if err != nil {
+					continue
+				}
+				q = parsed
+			}
+		}
+
+		// Match on the primary subtag only (e.g. "es-MX" -> "es")
+		base := strings.ToLower(strings.SplitN(tag, "-", 2)[0])
+		if _, ok := messageTemplates[base]; !ok {
+			continue
+		}
+		if q > bestQ {
+			best, bestQ = base, q
+		}
+	}
+
+	return best
+}
@@ -0,0 +1,115 @@
+package validation
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"backend/pkg/contracts"
+
+	"github.com/google/uuid"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   string
+	}{
+		{"empty header", "", "en"},
+		{"english", "en-US", "en"},
+		{"spanish with region", "es-MX", "es"},
+		{"unsupported falls back", "fr-FR", "en"},
+		{"first supported wins", "fr, es;q=0.8, en;q=0.5", "es"},
+		{"q-weights respected", "en;q=0.4, es;q=0.9", "es"},
+		{"case insensitive", "ES", "es"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseAcceptLanguage(tt.header); got != tt.want {
+				t.Errorf("ParseAcceptLanguage(%q) = %q, want %q", tt.header, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocalizeFields_SecondLocale(t *testing.T) {
+	validator := New()
+	if err := validator.RegisterDefaultCustomValidations(); err != nil {
+		t.Fatalf("Failed to register custom validations: %v", err)
+	}
+
+	invalidRequest := contracts.CreateLocalUser{
+		Email:       "john@example.com",
+		Password:    "Pass1!",
+		WorkspaceID: uuid.New(),
+	}
+
+	err := validator.Validate(invalidRequest)
+	if err == nil {
+		t.Fatal("Expected validation error")
+	}
+
+	violations, ok := err.GetMetadata()[MetadataViolationsKey].(FieldViolations)
+	if !ok {
+		t.Fatalf("Expected violations in metadata, got: %v", err.GetMetadata())
+	}
+
+	english := LocalizeFields(violations, "en")
+	if english["name"] != "name is required" {
+		t.Errorf("Expected English required message, got: %q", english["name"])
+	}
+	if english["password"] != "password must be at least 8 characters" {
+		t.Errorf("Expected English min message, got: %q", english["password"])
+	}
+
+	spanish := LocalizeFields(violations, "es")
+	if spanish["name"] != "name es obligatorio" {
+		t.Errorf("Expected Spanish required message, got: %q", spanish["name"])
+	}
+	if spanish["password"] != "password debe tener al menos 8 caracteres" {
+		t.Errorf("Expected Spanish min message, got: %q", spanish["password"])
+	}
+}
+
+func TestValidate_ViolationsStayOutOfSerializedError(t *testing.T) {
+	validator := New()
+	if err := validator.RegisterDefaultCustomValidations(); err != nil {
+		t.Fatalf("Failed to register custom validations: %v", err)
+	}
+
+	err := validator.Validate(contracts.CreateLocalUser{Email: "john@example.com", WorkspaceID: uuid.New()})
+	if err == nil {
+		t.Fatal("Expected validation error")
+	}
+
+	data, marshalErr := json.Marshal(err)
+	if marshalErr != nil {
+		t.Fatalf("Marshal: %v", marshalErr)
+	}
+	var logs bytes.Buffer
+	slog.New(slog.NewJSONHandler(&logs, nil)).Warn("request error", "error", err)
+
+	for name, output := range map[string]string{"MarshalJSON": string(data), "LogValue": logs.String()} {
+		if strings.Contains(output, MetadataViolationsKey) {
+			t.Errorf("%s: expected no raw violations, got %s", name, output)
+		}
+		if !strings.Contains(output, "name is required") {
+			t.Errorf("%s: expected the rendered field messages, got %s", name, output)
+		}
+	}
+}
+
+func TestTranslate_UnknownLocaleAndTag(t *testing.T) {
+	v := FieldViolation{Field: "code", Tag: "hexcolor"}
+
+	if got := Translate("xx", v); got != "code failed validation: hexcolor" {
+		t.Errorf("Expected English fallback, got: %q", got)
+	}
+	if got := Translate("es", v); got != "code no superó la validación: hexcolor" {
+		t.Errorf("Expected Spanish fallback, got: %q", got)
+	}
+}
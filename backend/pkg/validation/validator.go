@@ -11,11 +11,15 @@ import (
 
 // Service wraps the go-playground validator for domain use
 type Service struct {
-	validate *validator.Validate
+	validate      *validator.Validate
+	contentPolicy ContentPolicy
 }
 
+// Option configures a Service.
+type Option func(*Service)
+
 // New creates a new validation service
-func New() *Service {
+func New(opts ...Option) *Service {
 	v := validator.New()
 
 	// Use JSON field names instead of struct field names in error messages
@@ -27,7 +31,11 @@ func New() *Service {
 		return name
 	})
 
-	return &Service{validate: v}
+	s := &Service{validate: v, contentPolicy: DefaultContentPolicy()}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // Validate validates a struct and returns a domain error if validation fails
@@ -47,11 +55,13 @@ func (s Service) Validate(data interface{}) *pkgerrors.Error {
 		).WithHTTPStatus(400)
 	}
 
-	// Convert to field error map
+	// Convert to field error map, keeping the raw violations for localization
 	fieldErrors := make(map[string]string)
+	violations := make(FieldViolations, 0, len(validationErrs))
 	for _, fieldErr := range validationErrs {
 		fieldName := fieldErr.Field()
 		fieldErrors[fieldName] = formatValidationError(fieldErr)
+		violations = append(violations, newFieldViolation(fieldErr))
 	}
 
 	// Use domain ValidationError helper
@@ -61,7 +71,10 @@ func (s Service) Validate(data interface{}) *pkgerrors.Error {
 	).
 		WithHTTPStatus(400).
 		WithSeverity(pkgerrors.SeverityWarning).
-		WithMetadata("fields", fieldErrors)
+		MergeMetadata(map[string]interface{}{
+			pkgerrors.MetadataFields: fieldErrors,
+			MetadataViolationsKey:    violations,
+		})
 }
 
 // RegisterCustomValidation registers a custom validation function
@@ -69,49 +82,19 @@ func (s *Service) RegisterCustomValidation(tag string, fn validator.Func) error
 	return s.validate.RegisterValidation(tag, fn)
 }
 
+// RegisterAlias registers tag as shorthand for the tags in alias. Errors
+// report the underlying tag and parameter, so messages show the real limit.
+func (s *Service) RegisterAlias(tag, alias string) {
+	s.validate.RegisterAlias(tag, alias)
+}
+
+// RegisterStructValidation registers a validation that inspects a whole struct,
+// for rules spanning several fields
+func (s *Service) RegisterStructValidation(fn validator.StructLevelFunc, types ...interface{}) {
+	s.validate.RegisterStructValidation(fn, types...)
+}
+
 // formatValidationError converts a validator.FieldError to a human-readable message
 func formatValidationError(fe validator.FieldError) string {
-	field := fe.Field()
-
-	switch fe.Tag() {
-	case "required":
-		return field + " is required"
-	case "email":
-		return field + " must be a valid email address"
-	case "min":
-		if fe.Kind() == reflect.String {
-			return field + " must be at least " + fe.Param() + " characters"
-		}
-		return field + " must be at least " + fe.Param()
-	case "max":
-		if fe.Kind() == reflect.String {
-			return field + " must be at most " + fe.Param() + " characters"
-		}
-		return field + " must be at most " + fe.Param()
-	case "len":
-		return field + " must be exactly " + fe.Param() + " characters"
-	case "uuid4":
-		return field + " must be a valid UUID v4"
-	case "oneof":
-		return field + " must be one of: " + fe.Param()
-	case "gt":
-		return field + " must be greater than " + fe.Param()
-	case "gte":
-		return field + " must be greater than or equal to " + fe.Param()
-	case "lt":
-		return field + " must be less than " + fe.Param()
-	case "lte":
-		return field + " must be less than or equal to " + fe.Param()
-	case "eq":
-		return field + " must equal " + fe.Param()
-	case "ne":
-		return field + " must not equal " + fe.Param()
-	case "filepath":
-		return field + " contains an invalid file path"
-	case "strongpassword":
-		return field + " must contain at least one uppercase letter, one lowercase letter, one number, and one special character"
-	default:
-		// Fallback for unknown tags
-		return field + " failed validation: " + fe.Tag()
-	}
+	return Translate(DefaultLocale, newFieldViolation(fe))
 }
@@ -1,6 +1,8 @@
 package validation
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	"backend/pkg/contracts"
@@ -147,6 +149,7 @@ func TestValidator_UUID4Validation(t *testing.T) {
 		wantError   bool
 	}{
 		{"valid UUID v4", uuid.New(), false},
+		{"valid UUID v7", uuid.Must(uuid.NewV7()), false},
 		{"nil UUID", uuid.Nil, true},
 	}
 
@@ -280,3 +283,110 @@ func TestValidator_ErrorMessages(t *testing.T) {
 		}
 	}
 }
+
+func TestValidator_FilePath(t *testing.T) {
+	validator := New()
+	if err := validator.RegisterDefaultCustomValidations(); err != nil {
+		t.Fatalf("Failed to register custom validations: %v", err)
+	}
+
+	type testStruct struct {
+		Path string `json:"path" validate:"required,filepath"`
+	}
+
+	tests := []struct {
+		name      string
+		path      string
+		wantError bool
+	}{
+		{"valid - single file", "main.tf", false},
+		{"valid - nested", "modules/network/main.tf", false},
+		{"valid - workspace template path", uuid.New().String() + "/" + uuid.New().String(), false},
+		{"invalid - parent traversal", "../etc/passwd", true},
+		{"invalid - embedded traversal", "modules/../../secret", true},
+		{"invalid - absolute", "/etc/passwd", true},
+		{"invalid - windows drive traversal", `C:\..\..`, true},
+		{"invalid - windows drive", "C:/Windows", true},
+		{"invalid - backslash", `modules\main.tf`, true},
+		{"invalid - NUL byte", "main.tf\x00.txt", true},
+		{"invalid - too long", strings.Repeat("a", maxFilePathLength+1), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.Validate(testStruct{Path: tt.path})
+			if tt.wantError && err == nil {
+				t.Errorf("Expected validation error for path: %q", tt.path)
+			}
+			if !tt.wantError && err != nil {
+				t.Errorf("Expected no error for path: %q, got: %v", tt.path, err)
+			}
+		})
+	}
+}
+
+func TestValidator_HTTPURL(t *testing.T) {
+	validator := New()
+	if err := validator.RegisterDefaultCustomValidations(); err != nil {
+		t.Fatalf("Failed to register custom validations: %v", err)
+	}
+
+	type testStruct struct {
+		RepoURL string `json:"repo_url" validate:"omitempty,httpurl"`
+	}
+
+	tests := []struct {
+		name      string
+		url       string
+		wantError bool
+	}{
+		{"valid - empty is optional", "", false},
+		{"valid - https", "https://github.com/example/repo.git", false},
+		{"valid - http with port", "http://localhost:3000/repo", false},
+		{"invalid - ssh scheme", "ssh://git@github.com/example/repo.git", true},
+		{"invalid - ftp scheme", "ftp://example.com/repo", true},
+		{"invalid - no scheme", "github.com/example/repo", true},
+		{"invalid - no host", "https://", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.Validate(testStruct{RepoURL: tt.url})
+			if tt.wantError && err == nil {
+				t.Errorf("Expected validation error for url: %q", tt.url)
+			}
+			if !tt.wantError && err != nil {
+				t.Errorf("Expected no error for url: %q, got: %v", tt.url, err)
+			}
+		})
+	}
+}
+
+func TestValidator_MaxNameLengthInMessage(t *testing.T) {
+	validator := New()
+	if err := validator.RegisterDefaultCustomValidations(); err != nil {
+		t.Fatalf("Failed to register custom validations: %v", err)
+	}
+	contracts.RegisterValidations(validator)
+
+	request := contracts.CreateLocalUser{
+		Name:        strings.Repeat("a", MaxNameLength),
+		Email:       "john@example.com",
+		Password:    "SecurePass123!",
+		WorkspaceID: uuid.New(),
+	}
+	if err := validator.Validate(request); err != nil {
+		t.Fatalf("Expected a name of exactly %d characters to pass, got: %v", MaxNameLength, err)
+	}
+
+	request.Name += "a"
+	err := validator.Validate(request)
+	if err == nil {
+		t.Fatal("Expected validation error for an over-long name")
+	}
+	fields := err.GetMetadata()["fields"].(map[string]string)
+	want := fmt.Sprintf("name must be at most %d characters", MaxNameLength)
+	if fields["name"] != want {
+		t.Errorf("Expected message %q, got %q", want, fields["name"])
+	}
+}
@@ -1,5 +1,9 @@
 import api from '@/lib/api'
-import type { Template, TemplateFileInfo } from '@/types/api'
+import type {
+  Template,
+  TemplateFileContent,
+  TemplateFileInfo,
+} from '@/types/api'
 import type { FileWithPath } from '@/components/templates/FileDropzone'
 
 export async function createTemplate(
@@ -86,10 +90,10 @@ export async function getTemplateFiles(
 export async function getTemplateFileContent(
   templateId: string,
   filePath: string,
-): Promise<string> {
-  const { data } = await api.get<string>(
+): Promise<TemplateFileContent> {
+  const { data } = await api.get<TemplateFileContent>(
     `/api/v1/templates/${templateId}/files/content`,
-    { params: { path: filePath }, responseType: 'text' },
+    { params: { path: filePath } },
   )
   return data
 }
@@ -185,8 +185,8 @@ export default function TemplateBrowserPage() {
       setContentLoading(true)
       setFileContent('')
       try {
-        const content = await getTemplateFileContent(id, filePath)
-        setFileContent(content)
+        const file = await getTemplateFileContent(id, filePath)
+        setFileContent(file.content)
       } catch (err) {
         const apiError = err as ApiError
         setFileContent(
@@ -108,6 +108,12 @@ export interface TemplateFileInfo {
   size: number
 }
 
+export interface TemplateFileContent {
+  path: string
+  language: string
+  content: string
+}
+
 export interface SystemStatus {
   initialized: boolean
 }